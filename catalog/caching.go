@@ -0,0 +1,259 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package catalog
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"golang.org/x/sync/singleflight"
+)
+
+// CachingCatalog wraps a Catalog and caches the tables returned by
+// LoadTable, reducing the number of metadata reads for read heavy
+// workloads. Concurrent loads of the same table are coalesced into a
+// single load from the wrapped catalog.
+//
+// Tables returned by the CachingCatalog commit through it, so that a
+// commit, drop or rename invalidates the cached entry, and commits which
+// are retried or validated reload the table bypassing the cache. Changes
+// made through other catalogs are otherwise only seen once the entry
+// expires.
+type CachingCatalog struct {
+	Catalog
+
+	ttl      time.Duration
+	capacity int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	// version is incremented on each invalidation, so that loads which
+	// were in flight at that time don't store a stale table.
+	version uint64
+	group   singleflight.Group
+}
+
+type cacheEntry struct {
+	key     string
+	tbl     *table.Table
+	expires time.Time
+}
+
+// NewCachingCatalog returns a CachingCatalog wrapping cat. Cached tables
+// expire after ttl, or never if ttl is not positive. If capacity is
+// positive, at most capacity tables are cached and the least recently
+// used one is evicted first.
+func NewCachingCatalog(cat Catalog, ttl time.Duration, capacity int) *CachingCatalog {
+	return &CachingCatalog{
+		Catalog:  cat,
+		ttl:      ttl,
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+func cacheKey(ident table.Identifier) string {
+	return strings.Join(ident, "\x00")
+}
+
+// LoadTable returns the cached table for identifier if there is one which
+// hasn't expired, and loads it from the wrapped catalog otherwise. The
+// props are only used when the table is loaded.
+func (c *CachingCatalog) LoadTable(ctx context.Context, identifier table.Identifier, props iceberg.Properties) (*table.Table, error) {
+	key := cacheKey(identifier)
+	if tbl, ok := c.get(key); ok {
+		return tbl, nil
+	}
+
+	ch := c.group.DoChan(key, func() (any, error) {
+		// the load is shared by all the callers waiting for it, so it
+		// isn't canceled along with the context of the first one
+		return c.load(context.WithoutCancel(ctx), identifier, props)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+
+		return res.Val.(*table.Table), nil
+	}
+}
+
+// RefreshTable loads the latest version of the table from the wrapped
+// catalog, bypassing and replacing the cached table. Tables returned by
+// the CachingCatalog refresh through it when a commit is retried or
+// validated against concurrent changes.
+func (c *CachingCatalog) RefreshTable(ctx context.Context, identifier table.Identifier) (*table.Table, error) {
+	c.Invalidate(identifier)
+
+	return c.load(ctx, identifier, nil)
+}
+
+func (c *CachingCatalog) load(ctx context.Context, identifier table.Identifier, props iceberg.Properties) (*table.Table, error) {
+	c.mu.Lock()
+	version := c.version
+	c.mu.Unlock()
+
+	tbl, err := c.Catalog.LoadTable(ctx, identifier, props)
+	if err != nil {
+		return nil, err
+	}
+
+	tbl = c.wrap(tbl)
+	c.put(cacheKey(identifier), tbl, version)
+
+	return tbl, nil
+}
+
+// CommitTable commits through the wrapped catalog, invalidating the cached
+// table whether the commit succeeds or not, as a failed commit may mean
+// the cached table is out of date.
+func (c *CachingCatalog) CommitTable(ctx context.Context, tbl *table.Table, reqs []table.Requirement, updates []table.Update) (table.Metadata, string, error) {
+	defer c.Invalidate(tbl.Identifier())
+
+	return c.Catalog.CommitTable(ctx, tbl, reqs, updates)
+}
+
+func (c *CachingCatalog) CreateTable(ctx context.Context, identifier table.Identifier, schema *iceberg.Schema, opts ...CreateTableOpt) (*table.Table, error) {
+	tbl, err := c.Catalog.CreateTable(ctx, identifier, schema, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wrap(tbl), nil
+}
+
+func (c *CachingCatalog) DropTable(ctx context.Context, identifier table.Identifier) error {
+	defer c.Invalidate(identifier)
+
+	return c.Catalog.DropTable(ctx, identifier)
+}
+
+func (c *CachingCatalog) PurgeTable(ctx context.Context, identifier table.Identifier) error {
+	defer c.Invalidate(identifier)
+
+	return c.Catalog.PurgeTable(ctx, identifier)
+}
+
+// RegisterTable registers the table through the wrapped catalog, see the
+// RegisterTable function.
+func (c *CachingCatalog) RegisterTable(ctx context.Context, identifier table.Identifier, metadataLocation string) (*table.Table, error) {
+	tbl, err := RegisterTable(ctx, c.Catalog, identifier, metadataLocation)
+	c.Invalidate(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wrap(tbl), nil
+}
+
+func (c *CachingCatalog) RenameTable(ctx context.Context, from, to table.Identifier) (*table.Table, error) {
+	tbl, err := c.Catalog.RenameTable(ctx, from, to)
+	c.Invalidate(from)
+	c.Invalidate(to)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wrap(tbl), nil
+}
+
+// Invalidate removes the cached table for identifier, if any.
+func (c *CachingCatalog) Invalidate(identifier table.Identifier) {
+	key := cacheKey(identifier)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	if elem, ok := c.entries[key]; ok {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// InvalidateAll removes all cached tables.
+func (c *CachingCatalog) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+}
+
+// wrap returns tbl committing through c rather than the wrapped catalog.
+func (c *CachingCatalog) wrap(tbl *table.Table) *table.Table {
+	return table.New(tbl.Identifier(), tbl.Metadata(), tbl.MetadataLocation(), tbl.FS, c)
+}
+
+func (c *CachingCatalog) get(key string) (*table.Table, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if c.ttl > 0 && time.Now().After(entry.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+
+	return entry.tbl, true
+}
+
+func (c *CachingCatalog) put(key string, tbl *table.Table, version uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if version != c.version {
+		return
+	}
+
+	entry := &cacheEntry{key: key, tbl: tbl, expires: time.Now().Add(c.ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	if c.capacity > 0 && c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,251 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package catalog_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingCatalog struct {
+	catalog.Catalog
+
+	meta    table.Metadata
+	loads   atomic.Int32
+	commits atomic.Int32
+	// if set, LoadTable blocks until it is closed
+	release chan struct{}
+	// the errors returned by the first commits
+	commitErrs []error
+}
+
+func (c *countingCatalog) LoadTable(_ context.Context, ident table.Identifier, _ iceberg.Properties) (*table.Table, error) {
+	c.loads.Add(1)
+	if c.release != nil {
+		<-c.release
+	}
+
+	return table.New(ident, c.meta, "s3://bucket/test/metadata/v1.metadata.json",
+		func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil }, c), nil
+}
+
+func (c *countingCatalog) CommitTable(context.Context, *table.Table, []table.Requirement, []table.Update) (table.Metadata, string, error) {
+	if n := int(c.commits.Add(1)); n <= len(c.commitErrs) {
+		return nil, "", c.commitErrs[n-1]
+	}
+
+	return c.meta, "s3://bucket/test/metadata/v2.metadata.json", nil
+}
+
+func newCountingCatalog(t *testing.T) *countingCatalog {
+	meta, err := table.NewMetadata(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64}),
+		iceberg.UnpartitionedSpec, table.UnsortedSortOrder, "s3://bucket/test", nil)
+	require.NoError(t, err)
+
+	return &countingCatalog{meta: meta}
+}
+
+func TestCachingCatalogLoadTable(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingCatalog(t)
+	cat := catalog.NewCachingCatalog(inner, time.Hour, 0)
+
+	ident := catalog.ToIdentifier("db", "tbl")
+	first, err := cat.LoadTable(ctx, ident, nil)
+	require.NoError(t, err)
+	second, err := cat.LoadTable(ctx, ident, nil)
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+	assert.EqualValues(t, 1, inner.loads.Load())
+
+	_, err = cat.LoadTable(ctx, catalog.ToIdentifier("db", "other"), nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, inner.loads.Load())
+
+	cat.Invalidate(ident)
+	_, err = cat.LoadTable(ctx, ident, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, inner.loads.Load())
+}
+
+func TestCachingCatalogCommitInvalidates(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingCatalog(t)
+	cat := catalog.NewCachingCatalog(inner, time.Hour, 0)
+
+	ident := catalog.ToIdentifier("db", "tbl")
+	tbl, err := cat.LoadTable(ctx, ident, nil)
+	require.NoError(t, err)
+
+	tx := tbl.NewTransaction()
+	require.NoError(t, tx.SetProperties(iceberg.Properties{"foo": "bar"}))
+	_, err = tx.Commit(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, inner.commits.Load())
+
+	_, err = cat.LoadTable(ctx, ident, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, inner.loads.Load())
+}
+
+func TestCachingCatalogCommitRetryBypassesCache(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingCatalog(t)
+	inner.commitErrs = []error{table.ErrCommitFailed, table.ErrCommitFailed}
+	cat := catalog.NewCachingCatalog(inner, time.Hour, 0)
+
+	ident := catalog.ToIdentifier("db", "tbl")
+	tbl, err := cat.LoadTable(ctx, ident, nil)
+	require.NoError(t, err)
+
+	// each retry reloads the table from the wrapped catalog
+	tx := tbl.NewTransaction()
+	require.NoError(t, tx.SetProperties(iceberg.Properties{"foo": "bar"}))
+	_, err = tx.Commit(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, inner.commits.Load())
+	assert.EqualValues(t, 3, inner.loads.Load())
+}
+
+func TestCachingCatalogCommitErrorInvalidates(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingCatalog(t)
+	inner.commitErrs = []error{errors.New("catalog unavailable")}
+	cat := catalog.NewCachingCatalog(inner, time.Hour, 0)
+
+	ident := catalog.ToIdentifier("db", "tbl")
+	tbl, err := cat.LoadTable(ctx, ident, nil)
+	require.NoError(t, err)
+
+	tx := tbl.NewTransaction()
+	require.NoError(t, tx.SetProperties(iceberg.Properties{"foo": "bar"}))
+	_, err = tx.Commit(ctx)
+	require.ErrorContains(t, err, "catalog unavailable")
+
+	_, err = cat.LoadTable(ctx, ident, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, inner.loads.Load())
+}
+
+func TestCachingCatalogExpiry(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingCatalog(t)
+	cat := catalog.NewCachingCatalog(inner, 10*time.Millisecond, 0)
+
+	ident := catalog.ToIdentifier("db", "tbl")
+	_, err := cat.LoadTable(ctx, ident, nil)
+	require.NoError(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+	_, err = cat.LoadTable(ctx, ident, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, inner.loads.Load())
+}
+
+func TestCachingCatalogCapacity(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingCatalog(t)
+	cat := catalog.NewCachingCatalog(inner, 0, 2)
+
+	a, b, c := catalog.ToIdentifier("db", "a"), catalog.ToIdentifier("db", "b"), catalog.ToIdentifier("db", "c")
+	for _, ident := range []table.Identifier{a, b, a, c} {
+		_, err := cat.LoadTable(ctx, ident, nil)
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 3, inner.loads.Load())
+
+	// b was the least recently used table and got evicted
+	_, err := cat.LoadTable(ctx, a, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, inner.loads.Load())
+
+	_, err = cat.LoadTable(ctx, b, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, inner.loads.Load())
+}
+
+func TestCachingCatalogCoalescesLoads(t *testing.T) {
+	ctx := context.Background()
+	inner := newCountingCatalog(t)
+	inner.release = make(chan struct{})
+	cat := catalog.NewCachingCatalog(inner, time.Hour, 0)
+
+	ident := catalog.ToIdentifier("db", "tbl")
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := cat.LoadTable(ctx, ident, nil)
+			assert.NoError(t, err)
+		}()
+	}
+
+	assert.Eventually(t, func() bool { return inner.loads.Load() == 1 },
+		time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(inner.release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, inner.loads.Load())
+}
+
+func TestCachingCatalogLoadCanceledByCaller(t *testing.T) {
+	inner := newCountingCatalog(t)
+	inner.release = make(chan struct{})
+	cat := catalog.NewCachingCatalog(inner, time.Hour, 0)
+
+	ident := catalog.ToIdentifier("db", "tbl")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := cat.LoadTable(ctx, ident, nil)
+		first <- err
+	}()
+	assert.Eventually(t, func() bool { return inner.loads.Load() == 1 },
+		time.Second, time.Millisecond)
+
+	second := make(chan error)
+	go func() {
+		_, err := cat.LoadTable(context.Background(), ident, nil)
+		second <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	// canceling the first caller doesn't fail the shared load
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+	close(inner.release)
+	assert.NoError(t, <-second)
+	assert.EqualValues(t, 1, inner.loads.Load())
+}
@@ -35,6 +35,7 @@ import (
 	"maps"
 	"strings"
 
+	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/iceberg-go"
 	iceinternal "github.com/apache/iceberg-go/internal"
 	"github.com/apache/iceberg-go/table"
@@ -60,6 +61,9 @@ var (
 	ErrNamespaceNotEmpty      = errors.New("namespace is not empty")
 	ErrNoSuchView             = errors.New("view does not exist")
 	ErrViewAlreadyExists      = errors.New("view already exists")
+	// ErrCommitFailed is returned when a table commit conflicts with a
+	// concurrent change, the table should be refreshed and the commit retried.
+	ErrCommitFailed = table.ErrCommitFailed
 )
 
 type PropertiesUpdateSummary struct {
@@ -94,6 +98,9 @@ type Catalog interface {
 	LoadTable(ctx context.Context, identifier table.Identifier, props iceberg.Properties) (*table.Table, error)
 	// DropTable tells the catalog to drop the table entirely.
 	DropTable(ctx context.Context, identifier table.Identifier) error
+	// PurgeTable drops the table like DropTable, and also deletes all of its
+	// data and metadata files.
+	PurgeTable(ctx context.Context, identifier table.Identifier) error
 	// RenameTable tells the catalog to rename a given table by the identifiers
 	// provided, and then loads and returns the destination table
 	RenameTable(ctx context.Context, from, to table.Identifier) (*table.Table, error)
@@ -166,6 +173,60 @@ func WithProperties(props iceberg.Properties) CreateTableOpt {
 	}
 }
 
+// CreateTableFromArrow creates a table in the catalog with the Iceberg
+// equivalent of the given Arrow schema, assigning fresh field IDs in
+// pre-order so that nested fields are numbered right after their parent.
+// Any field IDs in the Arrow metadata are ignored. Without options the
+// table is unpartitioned and unsorted, partition spec and sort order
+// options must refer to the assigned field IDs.
+func CreateTableFromArrow(ctx context.Context, cat Catalog, ident table.Identifier, sc *arrow.Schema, opts ...CreateTableOpt) (*table.Table, error) {
+	schema, err := table.ArrowSchemaToIcebergWithFreshIDs(sc, false)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append([]CreateTableOpt{
+		WithPartitionSpec(iceberg.UnpartitionedSpec),
+		WithSortOrder(table.UnsortedSortOrder),
+	}, opts...)
+
+	return cat.CreateTable(ctx, ident, schema, opts...)
+}
+
+// TableRegistrar is implemented by catalogs which can add an existing
+// table to the catalog from its metadata file.
+type TableRegistrar interface {
+	// RegisterTable adds a table pointing to the metadata file at
+	// metadataLocation to the catalog, without rewriting the metadata,
+	// and returns the loaded table.
+	RegisterTable(ctx context.Context, identifier table.Identifier, metadataLocation string) (*table.Table, error)
+}
+
+// RegisterTable adds a table which points to an existing metadata file to
+// the catalog, like the register_table procedure, so that tables can be
+// moved between catalogs or restored from their metadata. It fails with
+// ErrTableAlreadyExists if the identifier already exists in the catalog,
+// and with iceberg.ErrNotImplemented if the catalog doesn't implement
+// TableRegistrar.
+func RegisterTable(ctx context.Context, cat Catalog, ident table.Identifier, metadataLocation string) (*table.Table, error) {
+	r, ok := cat.(TableRegistrar)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T doesn't support registering tables",
+			iceberg.ErrNotImplemented, cat)
+	}
+
+	exists, err := cat.CheckTableExists(ctx, ident)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return nil, fmt.Errorf("%w: %s", ErrTableAlreadyExists, strings.Join(ident, "."))
+	}
+
+	return r.RegisterTable(ctx, ident, metadataLocation)
+}
+
 //lint:ignore U1000 this is linked to by catalogs via go:linkname but we don't want to export it
 func checkForOverlap(removals []string, updates iceberg.Properties) error {
 	overlap := []string{}
@@ -0,0 +1,106 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package catalog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type createCatalog struct {
+	catalog.Catalog
+
+	cfg catalog.CreateTableCfg
+}
+
+func (c *createCatalog) CreateTable(_ context.Context, ident table.Identifier, sc *iceberg.Schema, opts ...catalog.CreateTableOpt) (*table.Table, error) {
+	for _, opt := range opts {
+		opt(&c.cfg)
+	}
+
+	meta, err := table.NewMetadata(sc, c.cfg.PartitionSpec, c.cfg.SortOrder,
+		"s3://bucket/test", c.cfg.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.New(ident, meta, "s3://bucket/test/metadata/v1.metadata.json",
+		func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil }, c), nil
+}
+
+func TestCreateTableFromArrow(t *testing.T) {
+	sc := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "location", Type: arrow.StructOf(
+			arrow.Field{Name: "lat", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+			arrow.Field{Name: "long", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		), Nullable: true},
+		{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+		{Name: "data", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+
+	cat := &createCatalog{}
+	tbl, err := catalog.CreateTableFromArrow(context.Background(), cat,
+		catalog.ToIdentifier("db", "tbl"), sc)
+	require.NoError(t, err)
+
+	expected := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "location", Type: &iceberg.StructType{
+			FieldList: []iceberg.NestedField{
+				{ID: 3, Name: "lat", Type: iceberg.PrimitiveTypes.Float64},
+				{ID: 4, Name: "long", Type: iceberg.PrimitiveTypes.Float64},
+			},
+		}},
+		iceberg.NestedField{ID: 5, Name: "tags", Type: &iceberg.ListType{
+			ElementID: 6, Element: iceberg.PrimitiveTypes.String, ElementRequired: false,
+		}},
+		iceberg.NestedField{ID: 7, Name: "data", Type: iceberg.PrimitiveTypes.String},
+	)
+	assert.True(t, expected.Equals(tbl.Schema()), "expected: %s\ngot: %s", expected, tbl.Schema())
+	assert.Equal(t, 7, tbl.Metadata().LastColumnID())
+	assert.True(t, tbl.Spec().Equals(*iceberg.UnpartitionedSpec))
+	assert.True(t, tbl.SortOrder().Equals(table.UnsortedSortOrder))
+
+	// partition specs passed as options refer to the assigned field ids
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 1, FieldID: 1000, Name: "id_bucket",
+		Transform: iceberg.BucketTransform{NumBuckets: 4},
+	})
+	cat = &createCatalog{}
+	tbl, err = catalog.CreateTableFromArrow(context.Background(), cat,
+		catalog.ToIdentifier("db", "tbl"), sc, catalog.WithPartitionSpec(&spec))
+	require.NoError(t, err)
+	assert.Same(t, &spec, cat.cfg.PartitionSpec)
+	spec = tbl.Spec()
+	assert.Equal(t, 1, spec.NumFields())
+}
+
+func TestRegisterTableNotImplemented(t *testing.T) {
+	_, err := catalog.RegisterTable(context.Background(), &createCatalog{},
+		catalog.ToIdentifier("db", "tbl"), "s3://bucket/test/metadata/v1.metadata.json")
+	assert.ErrorIs(t, err, iceberg.ErrNotImplemented)
+}
@@ -52,7 +52,8 @@ const (
 	locationPropsKey = "Location"
 
 	// Table metadata location pointer.
-	metadataLocationPropsKey = "metadata_location"
+	metadataLocationPropsKey         = "metadata_location"
+	previousMetadataLocationPropsKey = "previous_metadata_location"
 
 	// The ID of the Glue Data Catalog where the tables reside. If none is provided, Glue
 	// automatically uses the caller's AWS account ID by default.
@@ -218,6 +219,17 @@ func (c *Catalog) LoadTable(ctx context.Context, identifier table.Identifier, pr
 		return nil, err
 	}
 
+	return c.loadGlueTable(ctx, identifier, glueTable, props)
+}
+
+// loadGlueTable loads the iceberg table from the metadata location stored
+// in the parameters of the given Glue table.
+func (c *Catalog) loadGlueTable(ctx context.Context, identifier table.Identifier, glueTable *types.Table, props iceberg.Properties) (*table.Table, error) {
+	database, tableName, err := identifierToGlueTable(identifier)
+	if err != nil {
+		return nil, err
+	}
+
 	location, ok := glueTable.Parameters[metadataLocationPropsKey]
 	if !ok {
 		return nil, fmt.Errorf("missing metadata location for table %s.%s", database, tableName)
@@ -361,22 +373,28 @@ func (c *Catalog) RegisterTable(ctx context.Context, identifier table.Identifier
 }
 
 func (c *Catalog) CommitTable(ctx context.Context, tbl *table.Table, requirements []table.Requirement, updates []table.Update) (table.Metadata, string, error) {
-	// Load current table
 	database, tableName, err := identifierToGlueTable(tbl.Identifier())
 	if err != nil {
 		return nil, "", err
 	}
-	current, err := c.LoadTable(ctx, tbl.Identifier(), nil)
-	if err != nil && !errors.Is(err, catalog.ErrNoSuchTable) {
+
+	// Load the current table, keeping the Glue table version so that the
+	// update below fails if another writer committed in the meantime.
+	glueTable, err := c.getTable(ctx, database, tableName)
+	if err != nil {
+		return nil, "", err
+	}
+	current, err := c.loadGlueTable(ctx, tbl.Identifier(), glueTable, nil)
+	if err != nil {
 		return nil, "", err
 	}
 
 	// Create a staging table with the updates applied
-	staged, err := internal.UpdateAndStageTable(ctx, tbl, tbl.Identifier(), requirements, updates, c)
+	staged, err := internal.UpdateAndStageTable(ctx, current, tbl.Identifier(), requirements, updates, c)
 	if err != nil {
 		return nil, "", err
 	}
-	if current != nil && staged.Metadata().Equals(current.Metadata()) {
+	if staged.Metadata().Equals(current.Metadata()) {
 		return current.Metadata(), current.MetadataLocation(), nil
 	}
 	if err := internal.WriteMetadata(ctx, staged.Metadata(), staged.MetadataLocation(), staged.Properties()); err != nil {
@@ -384,20 +402,23 @@ func (c *Catalog) CommitTable(ctx context.Context, tbl *table.Table, requirement
 	}
 
 	// Build and call Glue update request
-	tableInput, err := buildGlueTableInput(ctx, database, tableName, staged, c)
-	if err != nil {
-		return nil, "", err
-	}
 	_, err = c.glueSvc.UpdateTable(ctx, &glue.UpdateTableInput{
 		CatalogId:    c.catalogId,
 		DatabaseName: aws.String(database),
-		TableInput:   tableInput,
+		TableInput:   buildGlueTableInput(glueTable, tableName, staged),
+		VersionId:    glueTable.VersionId,
 	})
 	if err != nil {
-		return nil, "", err
+		var concurrentErr *types.ConcurrentModificationException
+		if errors.As(err, &concurrentErr) {
+			return nil, "", fmt.Errorf("%w: table %s.%s was updated concurrently: %s",
+				catalog.ErrCommitFailed, database, tableName, concurrentErr.ErrorMessage())
+		}
+
+		return nil, "", fmt.Errorf("failed to update table %s.%s: %w", database, tableName, err)
 	}
 
-	return staged.Metadata(), staged.MetadataLocation(), err
+	return staged.Metadata(), staged.MetadataLocation(), nil
 }
 
 // DropTable deletes an Iceberg table from the Glue catalog.
@@ -426,6 +447,21 @@ func (c *Catalog) DropTable(ctx context.Context, identifier table.Identifier) er
 	return nil
 }
 
+// PurgeTable deletes an Iceberg table from the Glue catalog along with
+// all of its data and metadata files.
+func (c *Catalog) PurgeTable(ctx context.Context, identifier table.Identifier) error {
+	tbl, err := c.LoadTable(ctx, identifier, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := c.DropTable(ctx, identifier); err != nil {
+		return err
+	}
+
+	return table.PurgeFiles(ctx, tbl)
+}
+
 // RenameTable renames an Iceberg table in the Glue catalog.
 func (c *Catalog) RenameTable(ctx context.Context, from, to table.Identifier) (*table.Table, error) {
 	fromDatabase, fromTable, err := identifierToGlueTable(from)
@@ -772,20 +808,18 @@ func filterDatabaseListByType(databases []types.Database, databaseType string) [
 	return filtered
 }
 
-func buildGlueTableInput(ctx context.Context, database string, tableName string, staged *table.StagedTable, cat *Catalog) (*types.TableInput, error) {
-	glueTable, err := cat.getTable(ctx, database, tableName)
-	if err != nil {
-		return nil, err
-	}
-
+func buildGlueTableInput(glueTable *types.Table, tableName string, staged *table.StagedTable) *types.TableInput {
 	glueProperties := prepareProperties(staged.Properties(), staged.MetadataLocation())
+	glueProperties[previousMetadataLocationPropsKey] = glueTable.Parameters[metadataLocationPropsKey]
 	description := staged.Properties()["comment"]
 	if description == "" {
 		description = aws.ToString(glueTable.Description)
 	}
 	existingColumnMap := map[string]string{}
-	for _, column := range glueTable.StorageDescriptor.Columns {
-		existingColumnMap[*column.Name] = *column.Comment
+	if glueTable.StorageDescriptor != nil {
+		for _, column := range glueTable.StorageDescriptor.Columns {
+			existingColumnMap[aws.ToString(column.Name)] = aws.ToString(column.Comment)
+		}
 	}
 	var glueColumns []types.Column
 	for _, column := range schemaToGlueColumns(staged.Metadata().CurrentSchema(), true) {
@@ -808,7 +842,7 @@ func buildGlueTableInput(ctx context.Context, database string, tableName string,
 			Location: aws.String(staged.Location()),
 			Columns:  glueColumns,
 		},
-	}, nil
+	}
 }
 
 func prepareProperties(icebergProperties iceberg.Properties, newMetadataLocation string) iceberg.Properties {
@@ -30,6 +30,7 @@ import (
 
 	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/internal"
 	"github.com/apache/iceberg-go/table"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -1007,8 +1008,8 @@ func TestGlueCreateTableRollbackOnInvalidMetadata(t *testing.T) {
 	_, err := glueCatalog.CreateTable(context.TODO(),
 		TableIdentifier("test_database", "test_rollback_table"),
 		schema,
-		catalog.WithLocation("s3://non-existent-test-bucket"))
-	// Should fail because LoadTable will fail to load the nonexistent metadata
+		catalog.WithLocation("file://"+t.TempDir()))
+	// Should fail because the table returned by Glue has no metadata location
 	assert.Error(err)
 	assert.Contains(err.Error(), "failed to create table")
 	mockGlueSvc.AssertCalled(t, "CreateTable", mock.Anything, mock.Anything, mock.Anything)
@@ -1278,6 +1279,83 @@ func TestGlueCheckTableNotExists(t *testing.T) {
 	assert.False(exists)
 }
 
+func setupGlueCommitTest(t *testing.T) (*Catalog, *mockGlueClient, *table.Table, string) {
+	t.Helper()
+
+	location := t.TempDir()
+	meta, err := table.NewMetadata(testSchema, &testPartitionSpec, table.UnsortedSortOrder, location, nil)
+	require.NoError(t, err)
+
+	metadataLoc := location + "/metadata/00000-abc123.metadata.json"
+	require.NoError(t, internal.WriteMetadata(context.Background(), meta, metadataLoc, nil))
+
+	mockGlueSvc := &mockGlueClient{}
+	mockGlueSvc.On("GetTable", mock.Anything, &glue.GetTableInput{
+		DatabaseName: aws.String("test_database"),
+		Name:         aws.String("test_table"),
+	}, mock.Anything).Return(&glue.GetTableOutput{
+		Table: &types.Table{
+			Name:      aws.String("test_table"),
+			VersionId: aws.String("3"),
+			Parameters: map[string]string{
+				tableTypePropsKey:        glueTypeIceberg,
+				metadataLocationPropsKey: metadataLoc,
+			},
+		},
+	}, nil)
+
+	glueCatalog := &Catalog{
+		glueSvc: mockGlueSvc,
+		awsCfg:  &aws.Config{},
+	}
+	tbl := table.New(TableIdentifier("test_database", "test_table"), meta, metadataLoc, nil, glueCatalog)
+
+	return glueCatalog, mockGlueSvc, tbl, metadataLoc
+}
+
+func TestGlueCommitTable(t *testing.T) {
+	assert := require.New(t)
+	glueCatalog, mockGlueSvc, tbl, metadataLoc := setupGlueCommitTest(t)
+
+	var input *glue.UpdateTableInput
+	mockGlueSvc.On("UpdateTable", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			input = args.Get(1).(*glue.UpdateTableInput)
+		}).
+		Return(&glue.UpdateTableOutput{}, nil).Once()
+
+	meta, newLoc, err := glueCatalog.CommitTable(context.TODO(), tbl, nil,
+		[]table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"owner": "iceberg"})})
+	assert.NoError(err)
+	assert.Equal("iceberg", meta.Properties()["owner"])
+	assert.NotEqual(metadataLoc, newLoc)
+	assert.FileExists(newLoc)
+
+	assert.NotNil(input)
+	assert.Equal("3", aws.ToString(input.VersionId))
+	assert.Equal("test_database", aws.ToString(input.DatabaseName))
+	assert.Equal("test_table", aws.ToString(input.TableInput.Name))
+	assert.Equal(newLoc, input.TableInput.Parameters[metadataLocationPropsKey])
+	assert.Equal(metadataLoc, input.TableInput.Parameters[previousMetadataLocationPropsKey])
+	assert.Equal(glueTypeIceberg, input.TableInput.Parameters[tableTypePropsKey])
+}
+
+func TestGlueCommitTableConcurrentModification(t *testing.T) {
+	assert := require.New(t)
+	glueCatalog, mockGlueSvc, tbl, _ := setupGlueCommitTest(t)
+
+	mockGlueSvc.On("UpdateTable", mock.Anything, mock.MatchedBy(func(input *glue.UpdateTableInput) bool {
+		return aws.ToString(input.VersionId) == "3"
+	}), mock.Anything).Return(&glue.UpdateTableOutput{}, &types.ConcurrentModificationException{
+		Message: aws.String("version mismatch"),
+	}).Once()
+
+	_, _, err := glueCatalog.CommitTable(context.TODO(), tbl, nil,
+		[]table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"owner": "iceberg"})})
+	assert.ErrorIs(err, catalog.ErrCommitFailed)
+	mockGlueSvc.AssertExpectations(t)
+}
+
 func cleanupTable(t *testing.T, ctlg catalog.Catalog, tbIdent table.Identifier, awsCfg aws.Config) {
 	t.Helper()
 
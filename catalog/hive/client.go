@@ -0,0 +1,71 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hive
+
+import (
+	"context"
+	"errors"
+
+	hms "github.com/beltran/gohive/hive_metastore"
+)
+
+// MetastoreClient is the subset of the ThriftHiveMetastore service used by
+// the catalog, on the structs generated from hive_metastore.thrift. It is
+// implemented by ThriftClient, and returns the exceptions raised by the
+// metastore, e.g. *hms.NoSuchObjectException, as errors.
+//
+// The catalog alters the tables it got from the metastore in place, so
+// that the fields it doesn't manage, such as the partition keys or the
+// serde parameters, are written back unchanged.
+type MetastoreClient interface {
+	GetDatabase(ctx context.Context, name string) (*hms.Database, error)
+	GetAllDatabases(ctx context.Context) ([]string, error)
+	CreateDatabase(ctx context.Context, database *hms.Database) error
+	AlterDatabase(ctx context.Context, name string, database *hms.Database) error
+	DropDatabase(ctx context.Context, name string, deleteData, cascade bool) error
+
+	GetTable(ctx context.Context, dbName, tableName string) (*hms.Table, error)
+	GetAllTables(ctx context.Context, dbName string) ([]string, error)
+	GetTableObjectsByName(ctx context.Context, dbName string, tableNames []string) ([]*hms.Table, error)
+	CreateTable(ctx context.Context, tbl *hms.Table) error
+	AlterTable(ctx context.Context, dbName, tableName string, tbl *hms.Table) error
+	DropTable(ctx context.Context, dbName, tableName string, deleteData bool) error
+
+	Lock(ctx context.Context, req *hms.LockRequest) (*hms.LockResponse, error)
+	CheckLock(ctx context.Context, lockID int64) (*hms.LockResponse, error)
+	Unlock(ctx context.Context, lockID int64) error
+}
+
+// isNoSuchObject reports whether err is an exception raised by the
+// metastore for a missing database or table.
+func isNoSuchObject(err error) bool {
+	var (
+		noSuchObject *hms.NoSuchObjectException
+		unknownDB    *hms.UnknownDBException
+	)
+
+	return errors.As(err, &noSuchObject) || errors.As(err, &unknownDB)
+}
+
+// isAlreadyExists reports whether err is an exception raised by the
+// metastore for a database or table which already exists.
+func isAlreadyExists(err error) bool {
+	var alreadyExists *hms.AlreadyExistsException
+
+	return errors.As(err, &alreadyExists)
+}
@@ -0,0 +1,790 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package hive provides a catalog backed by a Hive Metastore. Namespaces
+// are stored as metastore databases and tables as external metastore
+// tables whose metadata_location parameter points to the current Iceberg
+// metadata file. Commits swap that pointer while holding an exclusive
+// metastore lock on the table.
+package hive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+	_ "unsafe"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/internal"
+	"github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	hms "github.com/beltran/gohive/hive_metastore"
+)
+
+const (
+	// Use the same conventions as the Java and Python implementations.
+	icebergTableType   = "ICEBERG"
+	externalTableType  = "EXTERNAL_TABLE"
+	tableTypePropsKey  = "table_type"
+	externalPropsKey   = "EXTERNAL"
+	commentPropsKey    = "comment"
+	locationPropsKey   = "location"
+	storageHandlerKey  = "storage_handler"
+	storageHandlerImpl = "org.apache.iceberg.mr.hive.HiveIcebergStorageHandler"
+
+	// Table metadata location pointer.
+	metadataLocationPropsKey         = "metadata_location"
+	previousMetadataLocationPropsKey = "previous_metadata_location"
+
+	// LockCheckMinWaitTime, LockCheckMaxWaitTime and LockCheckRetries
+	// configure how often a waiting table lock is polled before the commit
+	// is given up, as durations parsed by time.ParseDuration and a count.
+	LockCheckMinWaitTime = "lock-check-min-wait-time"
+	LockCheckMaxWaitTime = "lock-check-max-wait-time"
+	LockCheckRetries     = "lock-check-retries"
+
+	defaultLockCheckMinWaitTime = 100 * time.Millisecond
+	defaultLockCheckMaxWaitTime = time.Minute
+	defaultLockCheckRetries     = 4
+)
+
+var _ catalog.Catalog = (*Catalog)(nil)
+
+func init() {
+	// the catalog is loaded either with the hive type, or from a
+	// thrift://host:port uri
+	reg := catalog.RegistrarFunc(func(_ context.Context, _ string, props iceberg.Properties) (catalog.Catalog, error) {
+		uri := props.Get("uri", "")
+		if uri == "" {
+			return nil, fmt.Errorf("%w: missing uri of the hive metastore", iceberg.ErrInvalidArgument)
+		}
+
+		return NewCatalog(NewThriftClient(strings.TrimPrefix(uri, "thrift://")), props)
+	})
+
+	catalog.Register(string(catalog.Hive), reg)
+	catalog.Register("thrift", reg)
+}
+
+type Catalog struct {
+	client MetastoreClient
+	props  iceberg.Properties
+
+	lockMinWait time.Duration
+	lockMaxWait time.Duration
+	lockRetries int
+}
+
+// NewCatalog creates a new Hive Metastore catalog using the given client,
+// usually a ThriftClient.
+func NewCatalog(client MetastoreClient, props iceberg.Properties) (*Catalog, error) {
+	if props == nil {
+		props = iceberg.Properties{}
+	}
+
+	cat := &Catalog{
+		client:      client,
+		props:       props,
+		lockMinWait: defaultLockCheckMinWaitTime,
+		lockMaxWait: defaultLockCheckMaxWaitTime,
+		lockRetries: props.GetInt(LockCheckRetries, defaultLockCheckRetries),
+	}
+
+	for key, dur := range map[string]*time.Duration{
+		LockCheckMinWaitTime: &cat.lockMinWait,
+		LockCheckMaxWaitTime: &cat.lockMaxWait,
+	} {
+		if v, ok := props[key]; ok {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+			}
+			*dur = d
+		}
+	}
+
+	return cat, nil
+}
+
+func (c *Catalog) CatalogType() catalog.Type {
+	return catalog.Hive
+}
+
+// ListTables returns the Iceberg tables in the given metastore database.
+//
+// The namespace should just contain the database name.
+func (c *Catalog) ListTables(ctx context.Context, namespace table.Identifier) iter.Seq2[table.Identifier, error] {
+	return func(yield func(table.Identifier, error) bool) {
+		database, err := identifierToDatabase(namespace)
+		if err != nil {
+			yield(table.Identifier{}, err)
+
+			return
+		}
+
+		names, err := c.client.GetAllTables(ctx, database)
+		if err != nil {
+			yield(table.Identifier{}, fmt.Errorf("failed to list tables in namespace %s: %w",
+				database, mapNotFound(err, catalog.ErrNoSuchNamespace)))
+
+			return
+		}
+
+		if len(names) == 0 {
+			return
+		}
+
+		tables, err := c.client.GetTableObjectsByName(ctx, database, names)
+		if err != nil {
+			yield(table.Identifier{}, fmt.Errorf("failed to list tables in namespace %s: %w", database, err))
+
+			return
+		}
+
+		for _, tbl := range tables {
+			if !isIcebergTable(tbl) {
+				continue
+			}
+
+			if !yield(TableIdentifier(database, tbl.TableName), nil) {
+				return
+			}
+		}
+	}
+}
+
+// LoadTable loads a table from the metadata file its metastore table
+// points to.
+//
+// The identifier should contain the database name, then the table name.
+func (c *Catalog) LoadTable(ctx context.Context, identifier table.Identifier, props iceberg.Properties) (*table.Table, error) {
+	database, tableName, err := identifierToTable(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	hiveTable, err := c.getTable(ctx, database, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.loadHiveTable(ctx, identifier, hiveTable, props)
+}
+
+// loadHiveTable loads the iceberg table from the metadata location stored
+// in the parameters of the given metastore table.
+func (c *Catalog) loadHiveTable(ctx context.Context, identifier table.Identifier, hiveTable *hms.Table, props iceberg.Properties) (*table.Table, error) {
+	location, ok := hiveTable.Parameters[metadataLocationPropsKey]
+	if !ok {
+		return nil, fmt.Errorf("missing metadata location for table %s.%s",
+			hiveTable.DbName, hiveTable.TableName)
+	}
+
+	ioProps := maps.Clone(c.props)
+	maps.Copy(ioProps, props)
+
+	icebergTable, err := table.NewFromLocation(ctx, identifier, location,
+		io.LoadFSFunc(ioProps, location), c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create table from location %s.%s: %w",
+			hiveTable.DbName, hiveTable.TableName, err)
+	}
+
+	return icebergTable, nil
+}
+
+// CreateTable writes the initial metadata file of a new table and creates
+// the metastore table pointing to it, while holding a lock on the table.
+func (c *Catalog) CreateTable(ctx context.Context, identifier table.Identifier, schema *iceberg.Schema, opts ...catalog.CreateTableOpt) (*table.Table, error) {
+	database, tableName, err := identifierToTable(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	staged, err := internal.CreateStagedTable(ctx, c.props, c.LoadNamespaceProperties, identifier, schema, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := internal.WriteMetadata(ctx, staged.Metadata(), staged.MetadataLocation(), staged.Properties()); err != nil {
+		return nil, err
+	}
+
+	hiveTable := &hms.Table{
+		DbName:     database,
+		TableName:  tableName,
+		Owner:      currentUser(),
+		TableType:  externalTableType,
+		Parameters: tableParameters(staged.Properties(), staged.MetadataLocation()),
+		Sd:         storageDescriptor(staged.Metadata()),
+	}
+
+	err = c.withLock(ctx, database, tableName, func() error {
+		if err := c.client.CreateTable(ctx, hiveTable); err != nil {
+			if isAlreadyExists(err) {
+				return fmt.Errorf("%w: %s.%s", catalog.ErrTableAlreadyExists, database, tableName)
+			}
+
+			return fmt.Errorf("failed to create table %s.%s: %w",
+				database, tableName, mapNotFound(err, catalog.ErrNoSuchNamespace))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.LoadTable(ctx, identifier, nil)
+}
+
+// RegisterTable creates a metastore table pointing to an existing metadata
+// file.
+func (c *Catalog) RegisterTable(ctx context.Context, identifier table.Identifier, metadataLocation string) (*table.Table, error) {
+	database, tableName, err := identifierToTable(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, err := table.NewFromLocation(ctx, identifier, metadataLocation,
+		io.LoadFSFunc(c.props, metadataLocation), c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table metadata from %s: %w", metadataLocation, err)
+	}
+
+	err = c.client.CreateTable(ctx, &hms.Table{
+		DbName:     database,
+		TableName:  tableName,
+		Owner:      currentUser(),
+		TableType:  externalTableType,
+		Parameters: tableParameters(tbl.Properties(), metadataLocation),
+		Sd:         storageDescriptor(tbl.Metadata()),
+	})
+	if err != nil {
+		if isAlreadyExists(err) {
+			return nil, fmt.Errorf("%w: %s.%s", catalog.ErrTableAlreadyExists, database, tableName)
+		}
+
+		return nil, fmt.Errorf("failed to register table %s.%s: %w", database, tableName, err)
+	}
+
+	return tbl, nil
+}
+
+// CommitTable applies the updates to the current metadata of the table,
+// writes the new metadata file and then points the metastore table to it.
+// The metastore table is locked while the current metadata is read and the
+// pointer swapped, failing to acquire the lock returns an error wrapping
+// [catalog.ErrCommitFailed].
+func (c *Catalog) CommitTable(ctx context.Context, tbl *table.Table, requirements []table.Requirement, updates []table.Update) (table.Metadata, string, error) {
+	database, tableName, err := identifierToTable(tbl.Identifier())
+	if err != nil {
+		return nil, "", err
+	}
+
+	var (
+		newMeta table.Metadata
+		newLoc  string
+	)
+
+	err = c.withLock(ctx, database, tableName, func() error {
+		hiveTable, err := c.getTable(ctx, database, tableName)
+		if err != nil {
+			return err
+		}
+
+		current, err := c.loadHiveTable(ctx, tbl.Identifier(), hiveTable, nil)
+		if err != nil {
+			return err
+		}
+
+		staged, err := internal.UpdateAndStageTable(ctx, current, tbl.Identifier(), requirements, updates, c)
+		if err != nil {
+			return err
+		}
+
+		if staged.Metadata().Equals(current.Metadata()) {
+			newMeta, newLoc = current.Metadata(), current.MetadataLocation()
+
+			return nil
+		}
+
+		if err := internal.WriteMetadata(ctx, staged.Metadata(), staged.MetadataLocation(), staged.Properties()); err != nil {
+			return err
+		}
+
+		// alter the table as read from the metastore, so that the fields
+		// not managed by the catalog are kept
+		updated := *hiveTable
+		updated.Parameters = commitParameters(hiveTable.Parameters, current.Properties(),
+			staged.Properties(), staged.MetadataLocation())
+		updated.Parameters[previousMetadataLocationPropsKey] = current.MetadataLocation()
+		updated.Sd = updateStorageDescriptor(hiveTable.Sd, staged.Metadata())
+
+		if err := c.client.AlterTable(ctx, database, tableName, &updated); err != nil {
+			return fmt.Errorf("failed to update table %s.%s: %w", database, tableName, err)
+		}
+
+		newMeta, newLoc = staged.Metadata(), staged.MetadataLocation()
+
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return newMeta, newLoc, nil
+}
+
+// DropTable removes an Iceberg table from the metastore, without deleting
+// its metadata or data files.
+func (c *Catalog) DropTable(ctx context.Context, identifier table.Identifier) error {
+	database, tableName, err := identifierToTable(identifier)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.getTable(ctx, database, tableName); err != nil {
+		return err
+	}
+
+	if err := c.client.DropTable(ctx, database, tableName, false); err != nil {
+		return fmt.Errorf("failed to drop table %s.%s: %w",
+			database, tableName, mapNotFound(err, catalog.ErrNoSuchTable))
+	}
+
+	return nil
+}
+
+// PurgeTable removes an Iceberg table from the metastore and deletes all
+// of its data and metadata files.
+func (c *Catalog) PurgeTable(ctx context.Context, identifier table.Identifier) error {
+	tbl, err := c.LoadTable(ctx, identifier, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := c.DropTable(ctx, identifier); err != nil {
+		return err
+	}
+
+	return table.PurgeFiles(ctx, tbl)
+}
+
+// RenameTable renames an Iceberg table by altering its metastore table.
+func (c *Catalog) RenameTable(ctx context.Context, from, to table.Identifier) (*table.Table, error) {
+	fromDatabase, fromTable, err := identifierToTable(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toDatabase, toTable, err := identifierToTable(to)
+	if err != nil {
+		return nil, err
+	}
+
+	hiveTable, err := c.getTable(ctx, fromDatabase, fromTable)
+	if err != nil {
+		return nil, err
+	}
+
+	renamed := *hiveTable
+	renamed.DbName, renamed.TableName = toDatabase, toTable
+	if err := c.client.AlterTable(ctx, fromDatabase, fromTable, &renamed); err != nil {
+		if isAlreadyExists(err) {
+			return nil, fmt.Errorf("%w: %s.%s", catalog.ErrTableAlreadyExists, toDatabase, toTable)
+		}
+
+		return nil, fmt.Errorf("failed to rename table %s.%s: %w", fromDatabase, fromTable, err)
+	}
+
+	return c.LoadTable(ctx, to, nil)
+}
+
+// CheckTableExists returns if an Iceberg table exists in the metastore.
+func (c *Catalog) CheckTableExists(ctx context.Context, identifier table.Identifier) (bool, error) {
+	database, tableName, err := identifierToTable(identifier)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := c.getTable(ctx, database, tableName); err != nil {
+		if errors.Is(err, catalog.ErrNoSuchTable) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ListNamespaces returns all databases of the metastore. Hierarchical
+// namespaces are not supported.
+func (c *Catalog) ListNamespaces(ctx context.Context, parent table.Identifier) ([]table.Identifier, error) {
+	if len(parent) > 0 {
+		return nil, errors.New("hierarchical namespace is not supported")
+	}
+
+	databases, err := c.client.GetAllDatabases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list databases: %w", err)
+	}
+
+	namespaces := make([]table.Identifier, 0, len(databases))
+	for _, db := range databases {
+		namespaces = append(namespaces, DatabaseIdentifier(db))
+	}
+
+	return namespaces, nil
+}
+
+// CreateNamespace creates a new metastore database. The comment and
+// location properties are stored as the description and location of the
+// database, any others as its parameters.
+func (c *Catalog) CreateNamespace(ctx context.Context, namespace table.Identifier, props iceberg.Properties) error {
+	database, err := identifierToDatabase(namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.CreateDatabase(ctx, propertiesToDatabase(database, props)); err != nil {
+		if isAlreadyExists(err) {
+			return fmt.Errorf("%w: %s", catalog.ErrNamespaceAlreadyExists, database)
+		}
+
+		return fmt.Errorf("failed to create database %s: %w", database, err)
+	}
+
+	return nil
+}
+
+// DropNamespace drops an empty metastore database.
+func (c *Catalog) DropNamespace(ctx context.Context, namespace table.Identifier) error {
+	database, err := identifierToDatabase(namespace)
+	if err != nil {
+		return err
+	}
+
+	tables, err := c.client.GetAllTables(ctx, database)
+	if err != nil {
+		return fmt.Errorf("failed to drop namespace %s: %w",
+			database, mapNotFound(err, catalog.ErrNoSuchNamespace))
+	}
+
+	if len(tables) > 0 {
+		return fmt.Errorf("%w: %s", catalog.ErrNamespaceNotEmpty, database)
+	}
+
+	if err := c.client.DropDatabase(ctx, database, false, false); err != nil {
+		return fmt.Errorf("failed to drop namespace %s: %w",
+			database, mapNotFound(err, catalog.ErrNoSuchNamespace))
+	}
+
+	return nil
+}
+
+func (c *Catalog) CheckNamespaceExists(ctx context.Context, namespace table.Identifier) (bool, error) {
+	database, err := identifierToDatabase(namespace)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := c.getDatabase(ctx, database); err != nil {
+		if errors.Is(err, catalog.ErrNoSuchNamespace) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// LoadNamespaceProperties returns the parameters of a metastore database,
+// along with its description and location as the comment and location
+// properties.
+func (c *Catalog) LoadNamespaceProperties(ctx context.Context, namespace table.Identifier) (iceberg.Properties, error) {
+	database, err := identifierToDatabase(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := c.getDatabase(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+
+	return databaseToProperties(db), nil
+}
+
+// avoid circular dependency while still avoiding having to export the getUpdatedPropsAndUpdateSummary function
+// so that we can re-use it in the catalog implementations without duplicating the code.
+
+//go:linkname getUpdatedPropsAndUpdateSummary github.com/apache/iceberg-go/catalog.getUpdatedPropsAndUpdateSummary
+func getUpdatedPropsAndUpdateSummary(currentProps iceberg.Properties, removals []string, updates iceberg.Properties) (iceberg.Properties, catalog.PropertiesUpdateSummary, error)
+
+// UpdateNamespaceProperties updates the properties of a metastore database.
+func (c *Catalog) UpdateNamespaceProperties(ctx context.Context, namespace table.Identifier,
+	removals []string, updates iceberg.Properties,
+) (catalog.PropertiesUpdateSummary, error) {
+	database, err := identifierToDatabase(namespace)
+	if err != nil {
+		return catalog.PropertiesUpdateSummary{}, err
+	}
+
+	db, err := c.getDatabase(ctx, database)
+	if err != nil {
+		return catalog.PropertiesUpdateSummary{}, err
+	}
+
+	updatedProps, summary, err := getUpdatedPropsAndUpdateSummary(databaseToProperties(db), removals, updates)
+	if err != nil {
+		return catalog.PropertiesUpdateSummary{}, err
+	}
+
+	// keep the fields of the database which aren't mapped to properties,
+	// e.g. its owner
+	altered, updated := *db, propertiesToDatabase(database, updatedProps)
+	altered.Description, altered.LocationUri, altered.Parameters =
+		updated.Description, updated.LocationUri, updated.Parameters
+
+	if err := c.client.AlterDatabase(ctx, database, &altered); err != nil {
+		return catalog.PropertiesUpdateSummary{}, fmt.Errorf("failed to update namespace properties %s: %w", database, err)
+	}
+
+	return summary, nil
+}
+
+// withLock runs fn while holding an exclusive lock on the given table,
+// polling the lock with an exponential backoff while it is waiting.
+func (c *Catalog) withLock(ctx context.Context, database, tableName string, fn func() error) (err error) {
+	hostname, _ := os.Hostname()
+	resp, err := c.client.Lock(ctx, &hms.LockRequest{
+		Component: []*hms.LockComponent{{
+			Type:      hms.LockType_EXCLUSIVE,
+			Level:     hms.LockLevel_TABLE,
+			Dbname:    database,
+			Tablename: &tableName,
+		}},
+		User:     currentUser(),
+		Hostname: hostname,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: failed to lock table %s.%s: %w", catalog.ErrCommitFailed, database, tableName, err)
+	}
+
+	lockID := resp.Lockid
+	defer func() {
+		if unlockErr := c.client.Unlock(ctx, lockID); unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to unlock table %s.%s: %w", database, tableName, unlockErr))
+		}
+	}()
+
+	wait := c.lockMinWait
+	for retry := 0; resp.State == hms.LockState_WAITING && retry < c.lockRetries; retry++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		wait = min(2*wait, c.lockMaxWait)
+
+		if resp, err = c.client.CheckLock(ctx, lockID); err != nil {
+			return fmt.Errorf("%w: failed to check lock on table %s.%s: %w",
+				catalog.ErrCommitFailed, database, tableName, err)
+		}
+	}
+
+	if resp.State != hms.LockState_ACQUIRED {
+		return fmt.Errorf("%w: failed to acquire lock on table %s.%s, state: %s",
+			catalog.ErrCommitFailed, database, tableName, resp.State)
+	}
+
+	return fn()
+}
+
+// getTable loads an Iceberg table from the metastore.
+func (c *Catalog) getTable(ctx context.Context, database, tableName string) (*hms.Table, error) {
+	tbl, err := c.client.GetTable(ctx, database, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table %s.%s: %w",
+			database, tableName, mapNotFound(err, catalog.ErrNoSuchTable))
+	}
+
+	if !isIcebergTable(tbl) {
+		return nil, fmt.Errorf("%w: table %s.%s is not an iceberg table",
+			catalog.ErrNoSuchTable, database, tableName)
+	}
+
+	return tbl, nil
+}
+
+// getDatabase loads a database from the metastore.
+func (c *Catalog) getDatabase(ctx context.Context, database string) (*hms.Database, error) {
+	db, err := c.client.GetDatabase(ctx, database)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w",
+			database, mapNotFound(err, catalog.ErrNoSuchNamespace))
+	}
+
+	return db, nil
+}
+
+// mapNotFound wraps the exceptions raised by the metastore for missing
+// objects with the given catalog error.
+func mapNotFound(err, notFound error) error {
+	if isNoSuchObject(err) {
+		return fmt.Errorf("%w: %w", notFound, err)
+	}
+
+	return err
+}
+
+func isIcebergTable(tbl *hms.Table) bool {
+	return tbl != nil && strings.EqualFold(tbl.Parameters[tableTypePropsKey], icebergTableType)
+}
+
+func tableParameters(props iceberg.Properties, metadataLocation string) map[string]string {
+	params := maps.Clone(props)
+	if params == nil {
+		params = make(map[string]string)
+	}
+	params[tableTypePropsKey] = icebergTableType
+	params[externalPropsKey] = "TRUE"
+	params[storageHandlerKey] = storageHandlerImpl
+	params[metadataLocationPropsKey] = metadataLocation
+
+	return params
+}
+
+// commitParameters returns the parameters of a metastore table after a
+// commit. The parameters set by other clients, e.g. Hive itself, are kept,
+// while the table properties removed by the commit are removed from them.
+func commitParameters(params map[string]string, previous, props iceberg.Properties, metadataLocation string) map[string]string {
+	merged := maps.Clone(params)
+	if merged == nil {
+		merged = make(map[string]string)
+	}
+
+	for k := range previous {
+		if _, ok := props[k]; !ok {
+			delete(merged, k)
+		}
+	}
+	maps.Copy(merged, tableParameters(props, metadataLocation))
+
+	return merged
+}
+
+func storageDescriptor(meta table.Metadata) *hms.StorageDescriptor {
+	return &hms.StorageDescriptor{
+		Cols:         schemaToHiveColumns(meta.CurrentSchema()),
+		Location:     meta.Location(),
+		InputFormat:  "org.apache.hadoop.mapred.FileInputFormat",
+		OutputFormat: "org.apache.hadoop.mapred.FileOutputFormat",
+		SerdeInfo: &hms.SerDeInfo{
+			SerializationLib: "org.apache.hadoop.hive.serde2.lazy.LazySimpleSerDe",
+			Parameters:       map[string]string{},
+		},
+	}
+}
+
+// updateStorageDescriptor returns a copy of the storage descriptor of a
+// metastore table with the columns and the location of the new metadata,
+// keeping the other fields, e.g. the serde parameters, as they are.
+func updateStorageDescriptor(sd *hms.StorageDescriptor, meta table.Metadata) *hms.StorageDescriptor {
+	if sd == nil {
+		return storageDescriptor(meta)
+	}
+
+	updated := *sd
+	updated.Cols = schemaToHiveColumns(meta.CurrentSchema())
+	updated.Location = meta.Location()
+
+	return &updated
+}
+
+func databaseToProperties(db *hms.Database) iceberg.Properties {
+	props := make(iceberg.Properties, len(db.Parameters)+2)
+	maps.Copy(props, db.Parameters)
+	if db.Description != "" {
+		props[commentPropsKey] = db.Description
+	}
+	if db.LocationUri != "" {
+		props[locationPropsKey] = db.LocationUri
+	}
+
+	return props
+}
+
+func propertiesToDatabase(name string, props iceberg.Properties) *hms.Database {
+	db := &hms.Database{Name: name, Parameters: make(map[string]string, len(props))}
+	for k, v := range props {
+		switch k {
+		case commentPropsKey:
+			db.Description = v
+		case locationPropsKey:
+			db.LocationUri = v
+		default:
+			db.Parameters[k] = v
+		}
+	}
+
+	return db
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+
+	return ""
+}
+
+func identifierToTable(identifier table.Identifier) (string, string, error) {
+	if len(identifier) != 2 {
+		return "", "", fmt.Errorf("invalid identifier, missing database name: %v", identifier)
+	}
+
+	return identifier[0], identifier[1], nil
+}
+
+func identifierToDatabase(identifier table.Identifier) (string, error) {
+	if len(identifier) != 1 {
+		return "", fmt.Errorf("invalid identifier, missing database name: %v", identifier)
+	}
+
+	return identifier[0], nil
+}
+
+// TableIdentifier returns a table identifier for an Iceberg table in the format [database, table].
+func TableIdentifier(database string, tableName string) table.Identifier {
+	return []string{database, tableName}
+}
+
+// DatabaseIdentifier returns a namespace identifier for a metastore database in the format [database].
+func DatabaseIdentifier(database string) table.Identifier {
+	return []string{database}
+}
@@ -0,0 +1,505 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/internal"
+	"github.com/apache/iceberg-go/table"
+	hms "github.com/beltran/gohive/hive_metastore"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockMetastoreClient struct {
+	mock.Mock
+}
+
+func (m *mockMetastoreClient) GetDatabase(ctx context.Context, name string) (*hms.Database, error) {
+	args := m.Called(ctx, name)
+
+	return args.Get(0).(*hms.Database), args.Error(1)
+}
+
+func (m *mockMetastoreClient) GetAllDatabases(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockMetastoreClient) CreateDatabase(ctx context.Context, database *hms.Database) error {
+	return m.Called(ctx, database).Error(0)
+}
+
+func (m *mockMetastoreClient) AlterDatabase(ctx context.Context, name string, database *hms.Database) error {
+	return m.Called(ctx, name, database).Error(0)
+}
+
+func (m *mockMetastoreClient) DropDatabase(ctx context.Context, name string, deleteData, cascade bool) error {
+	return m.Called(ctx, name, deleteData, cascade).Error(0)
+}
+
+func (m *mockMetastoreClient) GetTable(ctx context.Context, dbName, tableName string) (*hms.Table, error) {
+	args := m.Called(ctx, dbName, tableName)
+
+	return args.Get(0).(*hms.Table), args.Error(1)
+}
+
+func (m *mockMetastoreClient) GetAllTables(ctx context.Context, dbName string) ([]string, error) {
+	args := m.Called(ctx, dbName)
+
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockMetastoreClient) GetTableObjectsByName(ctx context.Context, dbName string, tableNames []string) ([]*hms.Table, error) {
+	args := m.Called(ctx, dbName, tableNames)
+
+	return args.Get(0).([]*hms.Table), args.Error(1)
+}
+
+func (m *mockMetastoreClient) CreateTable(ctx context.Context, tbl *hms.Table) error {
+	return m.Called(ctx, tbl).Error(0)
+}
+
+func (m *mockMetastoreClient) AlterTable(ctx context.Context, dbName, tableName string, tbl *hms.Table) error {
+	return m.Called(ctx, dbName, tableName, tbl).Error(0)
+}
+
+func (m *mockMetastoreClient) DropTable(ctx context.Context, dbName, tableName string, deleteData bool) error {
+	return m.Called(ctx, dbName, tableName, deleteData).Error(0)
+}
+
+func (m *mockMetastoreClient) Lock(ctx context.Context, req *hms.LockRequest) (*hms.LockResponse, error) {
+	args := m.Called(ctx, req)
+
+	return args.Get(0).(*hms.LockResponse), args.Error(1)
+}
+
+func (m *mockMetastoreClient) CheckLock(ctx context.Context, lockID int64) (*hms.LockResponse, error) {
+	args := m.Called(ctx, lockID)
+
+	return args.Get(0).(*hms.LockResponse), args.Error(1)
+}
+
+func (m *mockMetastoreClient) Unlock(ctx context.Context, lockID int64) error {
+	return m.Called(ctx, lockID).Error(0)
+}
+
+var testSchema = iceberg.NewSchemaWithIdentifiers(0, []int{},
+	iceberg.NestedField{ID: 1, Name: "foo", Type: iceberg.PrimitiveTypes.String},
+	iceberg.NestedField{ID: 2, Name: "bar", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+	iceberg.NestedField{ID: 3, Name: "baz", Type: iceberg.PrimitiveTypes.Bool})
+
+func isTableLock(req *hms.LockRequest) bool {
+	return len(req.Component) == 1 &&
+		req.Component[0].Type == hms.LockType_EXCLUSIVE &&
+		req.Component[0].Level == hms.LockLevel_TABLE &&
+		req.Component[0].Dbname == "test_database" &&
+		req.Component[0].Tablename != nil && *req.Component[0].Tablename == "test_table"
+}
+
+func setupHiveCommitTest(t *testing.T, props iceberg.Properties) (*Catalog, *mockMetastoreClient, *table.Table, string) {
+	t.Helper()
+
+	location := t.TempDir()
+	meta, err := table.NewMetadata(testSchema, iceberg.UnpartitionedSpec, table.UnsortedSortOrder, location, nil)
+	require.NoError(t, err)
+
+	metadataLoc := location + "/metadata/00000-abc123.metadata.json"
+	require.NoError(t, internal.WriteMetadata(context.Background(), meta, metadataLoc, nil))
+
+	client := &mockMetastoreClient{}
+	client.On("GetTable", mock.Anything, "test_database", "test_table").Return(&hms.Table{
+		DbName:    "test_database",
+		TableName: "test_table",
+		Owner:     "iceberg",
+		TableType: externalTableType,
+		Parameters: map[string]string{
+			tableTypePropsKey:        icebergTableType,
+			metadataLocationPropsKey: metadataLoc,
+			"transient_lastDdlTime":  "1700000000",
+		},
+	}, nil).Maybe()
+
+	cat, err := NewCatalog(client, props)
+	require.NoError(t, err)
+	tbl := table.New(TableIdentifier("test_database", "test_table"), meta, metadataLoc, nil, cat)
+
+	return cat, client, tbl, metadataLoc
+}
+
+func TestHiveCommitTable(t *testing.T) {
+	assert := require.New(t)
+	cat, client, tbl, metadataLoc := setupHiveCommitTest(t, nil)
+
+	client.On("Lock", mock.Anything, mock.MatchedBy(isTableLock)).
+		Return(&hms.LockResponse{Lockid: 42, State: hms.LockState_ACQUIRED}, nil).Once()
+	client.On("Unlock", mock.Anything, int64(42)).Return(nil).Once()
+
+	var altered *hms.Table
+	client.On("AlterTable", mock.Anything, "test_database", "test_table", mock.Anything).
+		Run(func(args mock.Arguments) {
+			altered = args.Get(3).(*hms.Table)
+		}).Return(nil).Once()
+
+	meta, newLoc, err := cat.CommitTable(context.Background(), tbl, nil,
+		[]table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"owner": "iceberg"})})
+	assert.NoError(err)
+	assert.Equal("iceberg", meta.Properties()["owner"])
+	assert.NotEqual(metadataLoc, newLoc)
+	assert.FileExists(newLoc)
+
+	assert.NotNil(altered)
+	assert.Equal("test_table", altered.TableName)
+	assert.Equal("iceberg", altered.Owner)
+	assert.Equal(newLoc, altered.Parameters[metadataLocationPropsKey])
+	assert.Equal(metadataLoc, altered.Parameters[previousMetadataLocationPropsKey])
+	assert.Equal(icebergTableType, altered.Parameters[tableTypePropsKey])
+	assert.Equal("iceberg", altered.Parameters["owner"])
+	// parameters set by the metastore are kept
+	assert.Equal("1700000000", altered.Parameters["transient_lastDdlTime"])
+	assert.Equal([]*hms.FieldSchema{
+		{Name: "foo", Type: "string"},
+		{Name: "bar", Type: "int"},
+		{Name: "baz", Type: "boolean"},
+	}, altered.Sd.Cols)
+	client.AssertExpectations(t)
+
+	// the new metadata location round trips through the table parameters
+	client.On("GetTable", mock.Anything, "test_database", "test_table").Unset()
+	client.On("GetTable", mock.Anything, "test_database", "test_table").Return(altered, nil)
+
+	loaded, err := cat.LoadTable(context.Background(), tbl.Identifier(), nil)
+	assert.NoError(err)
+	assert.Equal(newLoc, loaded.MetadataLocation())
+	assert.Equal("iceberg", loaded.Properties()["owner"])
+}
+
+func TestHiveCommitTableKeepsMetastoreFields(t *testing.T) {
+	assert := require.New(t)
+	cat, client, tbl, metadataLoc := setupHiveCommitTest(t, nil)
+
+	hiveTable := &hms.Table{
+		DbName:         "test_database",
+		TableName:      "test_table",
+		Owner:          "iceberg",
+		CreateTime:     1700000000,
+		LastAccessTime: 1700000100,
+		Retention:      7,
+		TableType:      externalTableType,
+		Parameters: map[string]string{
+			tableTypePropsKey:        icebergTableType,
+			metadataLocationPropsKey: metadataLoc,
+		},
+		PartitionKeys: []*hms.FieldSchema{{Name: "dt", Type: "string", Comment: "day"}},
+		Sd: &hms.StorageDescriptor{
+			Cols:         []*hms.FieldSchema{{Name: "old", Type: "string"}},
+			Location:     "s3://bucket/old",
+			InputFormat:  "input",
+			OutputFormat: "output",
+			Compressed:   true,
+			NumBuckets:   4,
+			SerdeInfo: &hms.SerDeInfo{
+				Name:             "serde",
+				SerializationLib: "lib",
+				Parameters:       map[string]string{"serialization.format": "1"},
+			},
+			BucketCols: []string{"old"},
+			SortCols:   []*hms.Order{{Col: "old", Order: 1}},
+			Parameters: map[string]string{"sd": "param"},
+		},
+	}
+	client.On("GetTable", mock.Anything, "test_database", "test_table").Unset()
+	client.On("GetTable", mock.Anything, "test_database", "test_table").Return(hiveTable, nil)
+	client.On("Lock", mock.Anything, mock.MatchedBy(isTableLock)).
+		Return(&hms.LockResponse{Lockid: 42, State: hms.LockState_ACQUIRED}, nil).Once()
+	client.On("Unlock", mock.Anything, int64(42)).Return(nil).Once()
+
+	var altered *hms.Table
+	client.On("AlterTable", mock.Anything, "test_database", "test_table", mock.Anything).
+		Run(func(args mock.Arguments) {
+			altered = args.Get(3).(*hms.Table)
+		}).Return(nil).Once()
+
+	meta, _, err := cat.CommitTable(context.Background(), tbl, nil,
+		[]table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"owner": "iceberg"})})
+	assert.NoError(err)
+	client.AssertExpectations(t)
+
+	// only the parameters, columns and location are changed by the commit
+	expectedSd := *hiveTable.Sd
+	expectedSd.Cols = schemaToHiveColumns(testSchema)
+	expectedSd.Location = meta.Location()
+	expected := *hiveTable
+	expected.Sd, expected.Parameters = &expectedSd, altered.Parameters
+	assert.Equal(&expected, altered)
+
+	// the table read from the metastore is left untouched
+	assert.Equal([]*hms.FieldSchema{{Name: "old", Type: "string"}}, hiveTable.Sd.Cols)
+	assert.NotContains(hiveTable.Parameters, previousMetadataLocationPropsKey)
+}
+
+func TestCommitParameters(t *testing.T) {
+	params := commitParameters(map[string]string{
+		"transient_lastDdlTime":  "1700000000",
+		metadataLocationPropsKey: "s3://bucket/tbl/metadata/v1.metadata.json",
+		"kept":                   "old",
+		"removed":                "value",
+	}, iceberg.Properties{"kept": "old", "removed": "value"},
+		iceberg.Properties{"kept": "new", "added": "value"},
+		"s3://bucket/tbl/metadata/v2.metadata.json")
+
+	require.Equal(t, map[string]string{
+		"transient_lastDdlTime":  "1700000000",
+		"kept":                   "new",
+		"added":                  "value",
+		tableTypePropsKey:        icebergTableType,
+		externalPropsKey:         "TRUE",
+		storageHandlerKey:        storageHandlerImpl,
+		metadataLocationPropsKey: "s3://bucket/tbl/metadata/v2.metadata.json",
+	}, params)
+}
+
+func TestHiveCommitTableLockConflict(t *testing.T) {
+	assert := require.New(t)
+	cat, client, tbl, _ := setupHiveCommitTest(t, nil)
+
+	client.On("Lock", mock.Anything, mock.MatchedBy(isTableLock)).
+		Return(&hms.LockResponse{Lockid: 42, State: hms.LockState_NOT_ACQUIRED}, nil).Once()
+	client.On("Unlock", mock.Anything, int64(42)).Return(nil).Once()
+
+	_, _, err := cat.CommitTable(context.Background(), tbl, nil,
+		[]table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"owner": "iceberg"})})
+	assert.ErrorIs(err, catalog.ErrCommitFailed)
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "GetTable", mock.Anything, mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "AlterTable", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHiveCommitTableLockWaiting(t *testing.T) {
+	props := iceberg.Properties{
+		LockCheckMinWaitTime: "1ms",
+		LockCheckMaxWaitTime: "2ms",
+		LockCheckRetries:     "3",
+	}
+
+	t.Run("acquired", func(t *testing.T) {
+		cat, client, tbl, _ := setupHiveCommitTest(t, props)
+
+		client.On("Lock", mock.Anything, mock.MatchedBy(isTableLock)).
+			Return(&hms.LockResponse{Lockid: 7, State: hms.LockState_WAITING}, nil).Once()
+		client.On("CheckLock", mock.Anything, int64(7)).
+			Return(&hms.LockResponse{Lockid: 7, State: hms.LockState_WAITING}, nil).Once()
+		client.On("CheckLock", mock.Anything, int64(7)).
+			Return(&hms.LockResponse{Lockid: 7, State: hms.LockState_ACQUIRED}, nil).Once()
+		client.On("AlterTable", mock.Anything, "test_database", "test_table", mock.Anything).
+			Return(nil).Once()
+		client.On("Unlock", mock.Anything, int64(7)).Return(nil).Once()
+
+		_, _, err := cat.CommitTable(context.Background(), tbl, nil,
+			[]table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"owner": "iceberg"})})
+		require.NoError(t, err)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		cat, client, tbl, _ := setupHiveCommitTest(t, props)
+
+		client.On("Lock", mock.Anything, mock.MatchedBy(isTableLock)).
+			Return(&hms.LockResponse{Lockid: 7, State: hms.LockState_WAITING}, nil).Once()
+		client.On("CheckLock", mock.Anything, int64(7)).
+			Return(&hms.LockResponse{Lockid: 7, State: hms.LockState_WAITING}, nil).Times(3)
+		client.On("Unlock", mock.Anything, int64(7)).Return(nil).Once()
+
+		_, _, err := cat.CommitTable(context.Background(), tbl, nil,
+			[]table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"owner": "iceberg"})})
+		require.ErrorIs(t, err, catalog.ErrCommitFailed)
+		client.AssertExpectations(t)
+		client.AssertNotCalled(t, "AlterTable", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHiveCreateTable(t *testing.T) {
+	assert := require.New(t)
+	client := &mockMetastoreClient{}
+	cat, err := NewCatalog(client, nil)
+	assert.NoError(err)
+
+	location := t.TempDir()
+	var created *hms.Table
+	client.On("Lock", mock.Anything, mock.MatchedBy(isTableLock)).
+		Return(&hms.LockResponse{Lockid: 1, State: hms.LockState_ACQUIRED}, nil).Once()
+	client.On("CreateTable", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*hms.Table)
+			client.On("GetTable", mock.Anything, "test_database", "test_table").Return(created, nil)
+		}).Return(nil).Once()
+	client.On("Unlock", mock.Anything, int64(1)).Return(nil).Once()
+
+	tbl, err := cat.CreateTable(context.Background(), TableIdentifier("test_database", "test_table"), testSchema,
+		catalog.WithLocation(location), catalog.WithProperties(iceberg.Properties{"key": "value"}))
+	assert.NoError(err)
+	client.AssertExpectations(t)
+
+	assert.Equal("test_database", created.DbName)
+	assert.Equal(externalTableType, created.TableType)
+	assert.Equal(location, created.Sd.Location)
+	assert.Equal(tbl.MetadataLocation(), created.Parameters[metadataLocationPropsKey])
+	assert.NotContains(created.Parameters, previousMetadataLocationPropsKey)
+	assert.Equal(icebergTableType, created.Parameters[tableTypePropsKey])
+	assert.Equal("value", created.Parameters["key"])
+	assert.FileExists(tbl.MetadataLocation())
+	assert.True(testSchema.Equals(tbl.Schema()))
+
+	client.On("Lock", mock.Anything, mock.MatchedBy(isTableLock)).
+		Return(&hms.LockResponse{Lockid: 2, State: hms.LockState_ACQUIRED}, nil).Once()
+	client.On("CreateTable", mock.Anything, mock.Anything).
+		Return(&hms.AlreadyExistsException{Message: "table test_table already exists"}).Once()
+	client.On("Unlock", mock.Anything, int64(2)).Return(nil).Once()
+
+	_, err = cat.CreateTable(context.Background(), TableIdentifier("test_database", "test_table"), testSchema,
+		catalog.WithLocation(location))
+	assert.ErrorIs(err, catalog.ErrTableAlreadyExists)
+}
+
+func TestHiveLoadTableNotIceberg(t *testing.T) {
+	client := &mockMetastoreClient{}
+	client.On("GetTable", mock.Anything, "test_database", "hive_table").Return(&hms.Table{
+		DbName: "test_database", TableName: "hive_table", Parameters: map[string]string{},
+	}, nil)
+	client.On("GetTable", mock.Anything, "test_database", "missing").
+		Return((*hms.Table)(nil), &hms.NoSuchObjectException{Message: "missing"})
+
+	cat, err := NewCatalog(client, nil)
+	require.NoError(t, err)
+
+	_, err = cat.LoadTable(context.Background(), TableIdentifier("test_database", "hive_table"), nil)
+	require.ErrorIs(t, err, catalog.ErrNoSuchTable)
+
+	exists, err := cat.CheckTableExists(context.Background(), TableIdentifier("test_database", "missing"))
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func TestHiveListTables(t *testing.T) {
+	client := &mockMetastoreClient{}
+	client.On("GetAllTables", mock.Anything, "test_database").
+		Return([]string{"a", "b", "c"}, nil)
+	client.On("GetTableObjectsByName", mock.Anything, "test_database", []string{"a", "b", "c"}).
+		Return([]*hms.Table{
+			{TableName: "a", Parameters: map[string]string{tableTypePropsKey: "iceberg"}},
+			{TableName: "b", Parameters: map[string]string{}},
+			{TableName: "c", Parameters: map[string]string{tableTypePropsKey: icebergTableType}},
+		}, nil)
+
+	cat, err := NewCatalog(client, nil)
+	require.NoError(t, err)
+
+	var idents []table.Identifier
+	for ident, err := range cat.ListTables(context.Background(), DatabaseIdentifier("test_database")) {
+		require.NoError(t, err)
+		idents = append(idents, ident)
+	}
+
+	require.Equal(t, []table.Identifier{
+		TableIdentifier("test_database", "a"),
+		TableIdentifier("test_database", "c"),
+	}, idents)
+}
+
+func TestHiveNamespaceProperties(t *testing.T) {
+	assert := require.New(t)
+	client := &mockMetastoreClient{}
+	cat, err := NewCatalog(client, nil)
+	assert.NoError(err)
+
+	var db *hms.Database
+	client.On("CreateDatabase", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { db = args.Get(1).(*hms.Database) }).
+		Return(nil).Once()
+
+	props := iceberg.Properties{"comment": "my database", "location": "s3://bucket/db", "owner": "iceberg"}
+	assert.NoError(cat.CreateNamespace(context.Background(), DatabaseIdentifier("test_database"), props))
+	assert.Equal(&hms.Database{
+		Name:        "test_database",
+		Description: "my database",
+		LocationUri: "s3://bucket/db",
+		Parameters:  map[string]string{"owner": "iceberg"},
+	}, db)
+
+	client.On("GetDatabase", mock.Anything, "test_database").Return(db, nil)
+	loaded, err := cat.LoadNamespaceProperties(context.Background(), DatabaseIdentifier("test_database"))
+	assert.NoError(err)
+	assert.Equal(props, loaded)
+
+	var altered *hms.Database
+	client.On("AlterDatabase", mock.Anything, "test_database", mock.Anything).
+		Run(func(args mock.Arguments) { altered = args.Get(2).(*hms.Database) }).
+		Return(nil).Once()
+
+	summary, err := cat.UpdateNamespaceProperties(context.Background(), DatabaseIdentifier("test_database"),
+		[]string{"owner", "missing"}, iceberg.Properties{"comment": "updated"})
+	assert.NoError(err)
+	assert.Equal([]string{"owner"}, summary.Removed)
+	assert.Equal([]string{"comment"}, summary.Updated)
+	assert.Equal([]string{"missing"}, summary.Missing)
+	assert.Equal("updated", altered.Description)
+	assert.Equal("s3://bucket/db", altered.LocationUri)
+	assert.Empty(altered.Parameters)
+
+	client.On("CreateDatabase", mock.Anything, mock.Anything).
+		Return(&hms.AlreadyExistsException{Message: "test_database already exists"}).Once()
+	err = cat.CreateNamespace(context.Background(), DatabaseIdentifier("test_database"), nil)
+	assert.ErrorIs(err, catalog.ErrNamespaceAlreadyExists)
+}
+
+func TestHiveDropNamespaceNotEmpty(t *testing.T) {
+	client := &mockMetastoreClient{}
+	client.On("GetAllTables", mock.Anything, "test_database").Return([]string{"a"}, nil).Once()
+	client.On("GetAllTables", mock.Anything, "empty").Return([]string{}, nil).Once()
+	client.On("DropDatabase", mock.Anything, "empty", false, false).Return(nil).Once()
+
+	cat, err := NewCatalog(client, nil)
+	require.NoError(t, err)
+
+	err = cat.DropNamespace(context.Background(), DatabaseIdentifier("test_database"))
+	require.ErrorIs(t, err, catalog.ErrNamespaceNotEmpty)
+	require.NoError(t, cat.DropNamespace(context.Background(), DatabaseIdentifier("empty")))
+	client.AssertExpectations(t)
+}
+
+func TestIcebergTypeToHiveType(t *testing.T) {
+	typ := &iceberg.StructType{FieldList: []iceberg.NestedField{
+		{ID: 1, Name: "a", Type: iceberg.DecimalTypeOf(10, 2)},
+		{ID: 2, Name: "b", Type: &iceberg.ListType{ElementID: 3, Element: iceberg.PrimitiveTypes.TimestampTz}},
+		{ID: 4, Name: "c", Type: &iceberg.MapType{
+			KeyID: 5, KeyType: iceberg.PrimitiveTypes.String,
+			ValueID: 6, ValueType: iceberg.PrimitiveTypes.Int64,
+		}},
+	}}
+
+	require.Equal(t,
+		"struct<a:decimal(10,2),b:array<timestamp with local time zone>,c:map<string,bigint>>",
+		icebergTypeToHiveType(typ))
+	require.Equal(t, []*hms.FieldSchema{{Name: "foo", Type: "string"}},
+		schemaToHiveColumns(iceberg.NewSchema(0, testSchema.Field(0))))
+}
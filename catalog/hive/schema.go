@@ -0,0 +1,84 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hive
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+	hms "github.com/beltran/gohive/hive_metastore"
+)
+
+// schemaToHiveColumns converts an Iceberg schema to a list of metastore columns.
+func schemaToHiveColumns(schema *iceberg.Schema) []*hms.FieldSchema {
+	columns := make([]*hms.FieldSchema, 0, len(schema.Fields()))
+	for _, field := range schema.Fields() {
+		columns = append(columns, &hms.FieldSchema{
+			Name:    field.Name,
+			Type:    icebergTypeToHiveType(field.Type),
+			Comment: field.Doc,
+		})
+	}
+
+	return columns
+}
+
+// icebergTypeToHiveType converts an Iceberg type to its Hive type string
+// representation, see https://cwiki.apache.org/confluence/display/hive/languagemanual+types
+func icebergTypeToHiveType(typ iceberg.Type) string {
+	switch t := typ.(type) {
+	case iceberg.BooleanType:
+		return "boolean"
+	case iceberg.Int32Type:
+		return "int"
+	case iceberg.Int64Type:
+		return "bigint"
+	case iceberg.Float32Type:
+		return "float"
+	case iceberg.Float64Type:
+		return "double"
+	case iceberg.DateType:
+		return "date"
+	case iceberg.TimeType:
+		return "string"
+	case iceberg.TimestampType:
+		return "timestamp"
+	case iceberg.TimestampTzType:
+		return "timestamp with local time zone"
+	case iceberg.StringType, iceberg.UUIDType:
+		return "string"
+	case iceberg.BinaryType, iceberg.FixedType:
+		return "binary"
+	case iceberg.DecimalType:
+		return fmt.Sprintf("decimal(%d,%d)", t.Precision(), t.Scale())
+	case *iceberg.StructType:
+		fields := make([]string, 0, len(t.FieldList))
+		for _, field := range t.FieldList {
+			fields = append(fields, field.Name+":"+icebergTypeToHiveType(field.Type))
+		}
+
+		return "struct<" + strings.Join(fields, ",") + ">"
+	case *iceberg.ListType:
+		return "array<" + icebergTypeToHiveType(t.Element) + ">"
+	case *iceberg.MapType:
+		return "map<" + icebergTypeToHiveType(t.KeyType) + "," + icebergTypeToHiveType(t.ValueType) + ">"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,269 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	hms "github.com/beltran/gohive/hive_metastore"
+)
+
+const bufferSize = 4096
+
+// ThriftClient is a MetastoreClient talking to a Hive Metastore over
+// Thrift, using the binary protocol over a buffered or framed socket. Calls
+// are serialized over a single connection, which is opened on the first
+// call and reopened after a transport error.
+type ThriftClient struct {
+	addr        string
+	framed      bool
+	dialTimeout time.Duration
+	conf        *thrift.TConfiguration
+
+	mu     sync.Mutex
+	conn   net.Conn
+	client *hms.ThriftHiveMetastoreClient
+}
+
+type ThriftClientOption func(*ThriftClient)
+
+// WithFramedTransport frames the messages, for metastores configured with
+// hive.metastore.thrift.framed.transport.enabled.
+func WithFramedTransport() ThriftClientOption {
+	return func(c *ThriftClient) { c.framed = true }
+}
+
+// WithDialTimeout bounds the time to connect to the metastore, in addition
+// to the deadline of the context of the call.
+func WithDialTimeout(timeout time.Duration) ThriftClientOption {
+	return func(c *ThriftClient) { c.dialTimeout = timeout }
+}
+
+// NewThriftClient returns a client for the metastore listening at addr,
+// given as host:port.
+func NewThriftClient(addr string, opts ...ThriftClientOption) *ThriftClient {
+	c := &ThriftClient{addr: addr, conf: &thrift.TConfiguration{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Close closes the connection to the metastore, if any.
+func (c *ThriftClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+
+	err := c.conn.Close()
+	c.conn, c.client = nil, nil
+
+	return err
+}
+
+// call runs fn with the client of the current connection, connecting to
+// the metastore first if needed. The connection is closed when the context
+// is done during the call, and dropped after any error other than an
+// exception declared by the method, which leaves it in a consistent state.
+func (c *ThriftClient) call(ctx context.Context, method string, fn func(*hms.ThriftHiveMetastoreClient) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.connect(ctx); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		return err
+	}
+
+	// unblock reads and writes when the context is done
+	conn := c.conn
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	err := fn(c.client)
+	if err == nil || isDeclaredException(err) {
+		return err
+	}
+
+	c.conn.Close()
+	c.conn, c.client = nil, nil
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return fmt.Errorf("metastore call %s failed: %w", method, err)
+}
+
+func (c *ThriftClient) connect(ctx context.Context) error {
+	if c.conn != nil {
+		return nil
+	}
+
+	dialer := net.Dialer{Timeout: c.dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to metastore %s: %w", c.addr, err)
+	}
+
+	var trans thrift.TTransport = thrift.NewTSocketFromConnConf(conn, c.conf)
+	if c.framed {
+		trans = thrift.NewTFramedTransportConf(trans, c.conf)
+	} else {
+		trans = thrift.NewTBufferedTransport(trans, bufferSize)
+	}
+
+	proto := thrift.NewTBinaryProtocolConf(trans, c.conf)
+	c.conn = conn
+	c.client = hms.NewThriftHiveMetastoreClient(thrift.NewTStandardClient(proto, proto))
+
+	return nil
+}
+
+// isDeclaredException reports whether err is one of the exceptions
+// declared in hive_metastore.thrift, e.g. NoSuchObjectException, as
+// opposed to a transport or protocol error.
+func isDeclaredException(err error) bool {
+	var exc thrift.TException
+
+	return errors.As(err, &exc) && exc.TExceptionType() == thrift.TExceptionTypeCompiled
+}
+
+func (c *ThriftClient) GetDatabase(ctx context.Context, name string) (db *hms.Database, err error) {
+	err = c.call(ctx, "get_database", func(client *hms.ThriftHiveMetastoreClient) (err error) {
+		db, err = client.GetDatabase(ctx, name)
+
+		return err
+	})
+
+	return db, err
+}
+
+func (c *ThriftClient) GetAllDatabases(ctx context.Context) (names []string, err error) {
+	err = c.call(ctx, "get_all_databases", func(client *hms.ThriftHiveMetastoreClient) (err error) {
+		names, err = client.GetAllDatabases(ctx)
+
+		return err
+	})
+
+	return names, err
+}
+
+func (c *ThriftClient) CreateDatabase(ctx context.Context, database *hms.Database) error {
+	return c.call(ctx, "create_database", func(client *hms.ThriftHiveMetastoreClient) error {
+		return client.CreateDatabase(ctx, database)
+	})
+}
+
+func (c *ThriftClient) AlterDatabase(ctx context.Context, name string, database *hms.Database) error {
+	return c.call(ctx, "alter_database", func(client *hms.ThriftHiveMetastoreClient) error {
+		return client.AlterDatabase(ctx, name, database)
+	})
+}
+
+func (c *ThriftClient) DropDatabase(ctx context.Context, name string, deleteData, cascade bool) error {
+	return c.call(ctx, "drop_database", func(client *hms.ThriftHiveMetastoreClient) error {
+		return client.DropDatabase(ctx, name, deleteData, cascade)
+	})
+}
+
+func (c *ThriftClient) GetTable(ctx context.Context, dbName, tableName string) (tbl *hms.Table, err error) {
+	err = c.call(ctx, "get_table", func(client *hms.ThriftHiveMetastoreClient) (err error) {
+		tbl, err = client.GetTable(ctx, dbName, tableName)
+
+		return err
+	})
+
+	return tbl, err
+}
+
+func (c *ThriftClient) GetAllTables(ctx context.Context, dbName string) (names []string, err error) {
+	err = c.call(ctx, "get_all_tables", func(client *hms.ThriftHiveMetastoreClient) (err error) {
+		names, err = client.GetAllTables(ctx, dbName)
+
+		return err
+	})
+
+	return names, err
+}
+
+func (c *ThriftClient) GetTableObjectsByName(ctx context.Context, dbName string, tableNames []string) (tables []*hms.Table, err error) {
+	err = c.call(ctx, "get_table_objects_by_name", func(client *hms.ThriftHiveMetastoreClient) (err error) {
+		tables, err = client.GetTableObjectsByName(ctx, dbName, tableNames)
+
+		return err
+	})
+
+	return tables, err
+}
+
+func (c *ThriftClient) CreateTable(ctx context.Context, tbl *hms.Table) error {
+	return c.call(ctx, "create_table", func(client *hms.ThriftHiveMetastoreClient) error {
+		return client.CreateTable(ctx, tbl)
+	})
+}
+
+func (c *ThriftClient) AlterTable(ctx context.Context, dbName, tableName string, tbl *hms.Table) error {
+	return c.call(ctx, "alter_table", func(client *hms.ThriftHiveMetastoreClient) error {
+		return client.AlterTable(ctx, dbName, tableName, tbl)
+	})
+}
+
+func (c *ThriftClient) DropTable(ctx context.Context, dbName, tableName string, deleteData bool) error {
+	return c.call(ctx, "drop_table", func(client *hms.ThriftHiveMetastoreClient) error {
+		return client.DropTable(ctx, dbName, tableName, deleteData)
+	})
+}
+
+func (c *ThriftClient) Lock(ctx context.Context, req *hms.LockRequest) (resp *hms.LockResponse, err error) {
+	err = c.call(ctx, "lock", func(client *hms.ThriftHiveMetastoreClient) (err error) {
+		resp, err = client.Lock(ctx, req)
+
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *ThriftClient) CheckLock(ctx context.Context, lockID int64) (resp *hms.LockResponse, err error) {
+	err = c.call(ctx, "check_lock", func(client *hms.ThriftHiveMetastoreClient) (err error) {
+		resp, err = client.CheckLock(ctx, &hms.CheckLockRequest{Lockid: lockID})
+
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *ThriftClient) Unlock(ctx context.Context, lockID int64) error {
+	return c.call(ctx, "unlock", func(client *hms.ThriftHiveMetastoreClient) error {
+		return client.Unlock(ctx, &hms.UnlockRequest{Lockid: lockID})
+	})
+}
@@ -0,0 +1,188 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package hive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	hms "github.com/beltran/gohive/hive_metastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMetastore implements the metastore calls used by the tests, the
+// others panic.
+type fakeMetastore struct {
+	hms.ThriftHiveMetastore
+
+	tables  map[string]*hms.Table
+	altered *hms.Table
+	lock    *hms.LockRequest
+	block   chan struct{}
+}
+
+func (f *fakeMetastore) GetTable(_ context.Context, dbName, tableName string) (*hms.Table, error) {
+	tbl, ok := f.tables[dbName+"."+tableName]
+	if !ok {
+		return nil, &hms.NoSuchObjectException{Message: "table " + tableName + " not found"}
+	}
+
+	return tbl, nil
+}
+
+func (f *fakeMetastore) AlterTable(_ context.Context, _, _ string, tbl *hms.Table) error {
+	f.altered = tbl
+
+	return nil
+}
+
+func (f *fakeMetastore) GetAllDatabases(context.Context) ([]string, error) {
+	<-f.block
+
+	return nil, nil
+}
+
+func (f *fakeMetastore) Lock(_ context.Context, req *hms.LockRequest) (*hms.LockResponse, error) {
+	f.lock = req
+
+	return &hms.LockResponse{Lockid: 42, State: hms.LockState_WAITING}, nil
+}
+
+func (f *fakeMetastore) CheckLock(_ context.Context, req *hms.CheckLockRequest) (*hms.LockResponse, error) {
+	return nil, &hms.NoSuchLockException{Message: "lock not found"}
+}
+
+// serveMetastore serves the calls made to the returned address with the
+// handler.
+func serveMetastore(t *testing.T, framed bool, handler hms.ThriftHiveMetastore) string {
+	t.Helper()
+
+	sock, err := thrift.NewTServerSocket("127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, sock.Listen())
+
+	conf := &thrift.TConfiguration{}
+	var transports thrift.TTransportFactory = thrift.NewTBufferedTransportFactory(bufferSize)
+	if framed {
+		transports = thrift.NewTFramedTransportFactoryConf(thrift.NewTTransportFactory(), conf)
+	}
+
+	server := thrift.NewTSimpleServer4(hms.NewThriftHiveMetastoreProcessor(handler),
+		sock, transports, thrift.NewTBinaryProtocolFactoryConf(conf))
+	go server.Serve()
+	t.Cleanup(func() { server.Stop() })
+
+	return sock.Addr().String()
+}
+
+func TestThriftClientTableRoundTrip(t *testing.T) {
+	tbl := &hms.Table{
+		DbName:         "db",
+		TableName:      "tbl",
+		Owner:          "iceberg",
+		CreateTime:     1700000000,
+		LastAccessTime: 1700000100,
+		Retention:      7,
+		TableType:      externalTableType,
+		Parameters:     map[string]string{metadataLocationPropsKey: "s3://bucket/v1.metadata.json"},
+		PartitionKeys:  []*hms.FieldSchema{{Name: "dt", Type: "string", Comment: "day"}},
+		Sd: &hms.StorageDescriptor{
+			Cols:         []*hms.FieldSchema{{Name: "id", Type: "bigint", Comment: "key"}},
+			Location:     "s3://bucket/tbl",
+			InputFormat:  "input",
+			OutputFormat: "output",
+			NumBuckets:   4,
+			SerdeInfo: &hms.SerDeInfo{
+				Name:             "serde",
+				SerializationLib: "lib",
+				Parameters:       map[string]string{"serialization.format": "1"},
+			},
+			BucketCols: []string{"id"},
+			SortCols:   []*hms.Order{{Col: "id", Order: 1}},
+			Parameters: map[string]string{"sd": "param"},
+		},
+	}
+
+	for _, framed := range []bool{false, true} {
+		metastore := &fakeMetastore{tables: map[string]*hms.Table{"db.tbl": tbl}}
+		addr := serveMetastore(t, framed, metastore)
+
+		var opts []ThriftClientOption
+		if framed {
+			opts = append(opts, WithFramedTransport())
+		}
+		client := NewThriftClient(addr, opts...)
+
+		got, err := client.GetTable(context.Background(), "db", "tbl")
+		require.NoError(t, err)
+		assert.Equal(t, tbl, got)
+
+		// exceptions are returned and keep the connection usable
+		_, err = client.GetTable(context.Background(), "db", "missing")
+		var noSuchObject *hms.NoSuchObjectException
+		require.True(t, errors.As(err, &noSuchObject))
+		assert.Equal(t, "table missing not found", noSuchObject.Message)
+		assert.True(t, isNoSuchObject(err))
+
+		got.Parameters[metadataLocationPropsKey] = "s3://bucket/v2.metadata.json"
+		require.NoError(t, client.AlterTable(context.Background(), "db", "tbl", got))
+		assert.Equal(t, got, metastore.altered)
+
+		require.NoError(t, client.Close())
+	}
+}
+
+func TestThriftClientLock(t *testing.T) {
+	metastore := &fakeMetastore{}
+	client := NewThriftClient(serveMetastore(t, false, metastore))
+	defer client.Close()
+
+	tableName := "tbl"
+	lock := &hms.LockRequest{
+		Component: []*hms.LockComponent{{
+			Type: hms.LockType_EXCLUSIVE, Level: hms.LockLevel_TABLE, Dbname: "db", Tablename: &tableName,
+		}},
+		User:     "user",
+		Hostname: "host",
+	}
+	resp, err := client.Lock(context.Background(), lock)
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, resp.Lockid)
+	assert.Equal(t, hms.LockState_WAITING, resp.State)
+	assert.Equal(t, lock.Component, metastore.lock.Component)
+
+	_, err = client.CheckLock(context.Background(), 42)
+	var noSuchLock *hms.NoSuchLockException
+	require.True(t, errors.As(err, &noSuchLock))
+	assert.Equal(t, "lock not found", noSuchLock.Message)
+}
+
+func TestThriftClientContextCanceled(t *testing.T) {
+	metastore := &fakeMetastore{block: make(chan struct{})}
+	client := NewThriftClient(serveMetastore(t, false, metastore))
+	defer client.Close()
+	defer close(metastore.block)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+	_, err := client.GetAllDatabases(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
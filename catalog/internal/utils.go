@@ -42,14 +42,16 @@ func GetMetadataLoc(location string, newVersion uint) string {
 		location, newVersion, uuid.New().String())
 }
 
+// WriteTableMetadata writes metadata as JSON to loc. The content is written
+// with a single WriteFile call, so that the file is created atomically and
+// a partially written metadata file is never committed.
 func WriteTableMetadata(metadata table.Metadata, fs io.WriteFileIO, loc string) error {
-	out, err := fs.Create(loc)
+	out, err := json.Marshal(metadata)
 	if err != nil {
-		return nil
+		return err
 	}
-	defer out.Close()
 
-	return json.NewEncoder(out).Encode(metadata)
+	return fs.WriteFile(loc, out)
 }
 
 func WriteMetadata(ctx context.Context, metadata table.Metadata, loc string, props iceberg.Properties) error {
@@ -63,14 +65,7 @@ func WriteMetadata(ctx context.Context, metadata table.Metadata, loc string, pro
 		return errors.New("filesystem IO does not support writing")
 	}
 
-	out, err := wfs.Create(loc)
-	if err != nil {
-		return nil
-	}
-
-	defer out.Close()
-
-	return json.NewEncoder(out).Encode(metadata)
+	return WriteTableMetadata(metadata, wfs, loc)
 }
 
 func UpdateTableMetadata(base table.Metadata, updates []table.Update, metadataLoc string) (table.Metadata, error) {
@@ -349,9 +344,14 @@ func CreateViewMetadata(
 	if err != nil {
 		return "", fmt.Errorf("failed to create view metadata file: %w", err)
 	}
-	defer out.Close()
 
 	if _, err := out.Write(viewMetadataBytes); err != nil {
+		io.AbortWrite(out)
+
+		return "", fmt.Errorf("failed to write view metadata: %w", err)
+	}
+
+	if err := out.Close(); err != nil {
 		return "", fmt.Errorf("failed to write view metadata: %w", err)
 	}
 
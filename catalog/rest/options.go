@@ -20,6 +20,7 @@ package rest
 import (
 	"crypto/tls"
 	"net/url"
+	"time"
 
 	"github.com/apache/iceberg-go"
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -39,6 +40,15 @@ func WithOAuthToken(token string) Option {
 	}
 }
 
+// WithTokenRefreshSkew sets how long before the expiry of an OAuth2 token,
+// obtained using WithCredential, it should be refreshed. Defaults to one
+// minute.
+func WithTokenRefreshSkew(skew time.Duration) Option {
+	return func(o *options) {
+		o.tokenRefreshSkew = &skew
+	}
+}
+
 func WithTLSConfig(config *tls.Config) Option {
 	return func(o *options) {
 		o.tlsConfig = config
@@ -114,6 +124,7 @@ type options struct {
 	tlsConfig         *tls.Config
 	credential        string
 	oauthToken        string
+	tokenRefreshSkew  *time.Duration
 	warehouseLocation string
 	metadataLocation  string
 	enableSigv4       bool
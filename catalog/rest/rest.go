@@ -34,6 +34,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/apache/iceberg-go"
@@ -43,15 +44,21 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"golang.org/x/sync/singleflight"
 )
 
 var _ catalog.Catalog = (*Catalog)(nil)
 
 const (
 	pageSizeKey contextKey = "page_size"
+	// skipAuthKey marks requests, such as token refreshes, which must not
+	// go through the session's token manager.
+	skipAuthKey contextKey = "skip_auth"
 
 	defaultPageSize = 20
 
+	defaultTokenRefreshSkew = time.Minute
+
 	keyOauthToken        = "token"
 	keyWarehouseLocation = "warehouse"
 	keyMetadataLocation  = "metadata_location"
@@ -77,10 +84,11 @@ var (
 	ErrBadRequest           = fmt.Errorf("%w: bad request", ErrRESTError)
 	ErrForbidden            = fmt.Errorf("%w: forbidden", ErrRESTError)
 	ErrUnauthorized         = fmt.Errorf("%w: unauthorized", ErrRESTError)
+	ErrUnprocessableEntity  = fmt.Errorf("%w: unprocessable entity", ErrRESTError)
 	ErrAuthorizationExpired = fmt.Errorf("%w: authorization expired", ErrRESTError)
 	ErrServiceUnavailable   = fmt.Errorf("%w: service unavailable", ErrRESTError)
 	ErrServerError          = fmt.Errorf("%w: server error", ErrRESTError)
-	ErrCommitFailed         = fmt.Errorf("%w: commit failed, refresh and try again", ErrRESTError)
+	ErrCommitFailed         = fmt.Errorf("%w: %w", ErrRESTError, catalog.ErrCommitFailed)
 	ErrCommitStateUnknown   = fmt.Errorf("%w: commit failed due to unknown reason", ErrRESTError)
 	ErrOAuthError           = fmt.Errorf("%w: oauth error", ErrRESTError)
 )
@@ -193,6 +201,77 @@ type configResponse struct {
 	Overrides iceberg.Properties `json:"overrides"`
 }
 
+// oauthTokenManager keeps track of the expiry of an OAuth2 access token
+// obtained using client credentials, and refreshes it once it is within
+// the configured skew of expiring. Concurrent callers share a single
+// in-flight refresh.
+type oauthTokenManager struct {
+	fetch func(context.Context) (oauthTokenResponse, error)
+	skew  time.Duration
+	group singleflight.Group
+
+	mu          sync.RWMutex
+	accessToken string
+	// zero if the token response did not include expires_in
+	expiresAt time.Time
+}
+
+func newOAuthTokenManager(tok oauthTokenResponse, skew time.Duration, fetch func(context.Context) (oauthTokenResponse, error)) *oauthTokenManager {
+	m := &oauthTokenManager{fetch: fetch, skew: skew}
+	m.set(tok)
+
+	return m
+}
+
+func (m *oauthTokenManager) set(tok oauthTokenResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.accessToken = tok.AccessToken
+	if tok.ExpiresIn > 0 {
+		m.expiresAt = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
+	} else {
+		m.expiresAt = time.Time{}
+	}
+}
+
+// current returns the current access token and whether it is still
+// valid for use, i.e. it is not within the skew of expiring.
+func (m *oauthTokenManager) current() (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.accessToken, m.expiresAt.IsZero() || time.Now().Add(m.skew).Before(m.expiresAt)
+}
+
+func (m *oauthTokenManager) token(ctx context.Context) (string, error) {
+	if tok, valid := m.current(); valid {
+		return tok, nil
+	}
+
+	tok, err, _ := m.group.Do("token", func() (any, error) {
+		// another caller may have completed a refresh in the meantime
+		if tok, valid := m.current(); valid {
+			return tok, nil
+		}
+
+		// the refresh is shared with other callers, so it should not be
+		// cancelled just because the request that triggered it was
+		rsp, err := m.fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return "", fmt.Errorf("failed to refresh oauth token: %w", err)
+		}
+		m.set(rsp)
+
+		return rsp.AccessToken, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return tok.(string), nil
+}
+
 type sessionTransport struct {
 	http.Transport
 
@@ -201,6 +280,10 @@ type sessionTransport struct {
 	cfg            aws.Config
 	service        string
 	newHash        func() hash.Hash
+
+	// if non-nil, provides the current bearer token for each request,
+	// taking precedence over any Authorization default header.
+	auth *oauthTokenManager
 }
 
 // from https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/aws/signer/v4#Signer.SignHTTP
@@ -213,6 +296,14 @@ func (s *sessionTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 		}
 	}
 
+	if s.auth != nil && r.Context().Value(skipAuthKey) == nil {
+		token, err := s.auth.token(r.Context())
+		if err != nil {
+			return nil, err
+		}
+		r.Header.Set(authorizationHeader, bearerPrefix+" "+token)
+	}
+
 	if s.signer != nil {
 		var payloadHash string
 		if r.Body == nil {
@@ -359,7 +450,7 @@ func handleNon200(rsp *http.Response, override map[int]error) error {
 	case http.StatusForbidden:
 		e.wrapping = ErrForbidden
 	case http.StatusUnprocessableEntity:
-		e.wrapping = ErrRESTError
+		e.wrapping = ErrUnprocessableEntity
 	case 419:
 		e.wrapping = ErrAuthorizationExpired
 	case http.StatusNotImplemented:
@@ -506,7 +597,7 @@ func (r *Catalog) init(ctx context.Context, ops *options, uri string) error {
 	return nil
 }
 
-func (r *Catalog) fetchAccessToken(cl *http.Client, creds string, opts *options) (string, error) {
+func (r *Catalog) fetchAccessToken(ctx context.Context, cl *http.Client, creds string, opts *options) (oauthTokenResponse, error) {
 	clientID, clientSecret, hasID := strings.Cut(creds, ":")
 	if !hasID {
 		clientID, clientSecret = "", clientID
@@ -528,9 +619,16 @@ func (r *Catalog) fetchAccessToken(cl *http.Client, creds string, opts *options)
 		uri = r.baseURI.JoinPath("oauth/tokens")
 	}
 
-	rsp, err := cl.PostForm(uri.String(), data)
+	req, err := http.NewRequestWithContext(context.WithValue(ctx, skipAuthKey, true),
+		http.MethodPost, uri.String(), strings.NewReader(data.Encode()))
 	if err != nil {
-		return "", err
+		return oauthTokenResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rsp, err := cl.Do(req)
+	if err != nil {
+		return oauthTokenResponse{}, err
 	}
 
 	if rsp.StatusCode == http.StatusOK {
@@ -538,10 +636,10 @@ func (r *Catalog) fetchAccessToken(cl *http.Client, creds string, opts *options)
 		dec := json.NewDecoder(rsp.Body)
 		var tok oauthTokenResponse
 		if err := dec.Decode(&tok); err != nil {
-			return "", fmt.Errorf("failed to decode oauth token response: %w", err)
+			return oauthTokenResponse{}, fmt.Errorf("failed to decode oauth token response: %w", err)
 		}
 
-		return tok.AccessToken, nil
+		return tok, nil
 	}
 
 	switch rsp.StatusCode {
@@ -550,12 +648,12 @@ func (r *Catalog) fetchAccessToken(cl *http.Client, creds string, opts *options)
 		dec := json.NewDecoder(rsp.Body)
 		var oauthErr oauthErrorResponse
 		if err := dec.Decode(&oauthErr); err != nil {
-			return "", fmt.Errorf("failed to decode oauth error: %w", err)
+			return oauthTokenResponse{}, fmt.Errorf("failed to decode oauth error: %w", err)
 		}
 
-		return "", oauthErr
+		return oauthTokenResponse{}, oauthErr
 	default:
-		return "", handleNon200(rsp, nil)
+		return oauthTokenResponse{}, handleNon200(rsp, nil)
 	}
 }
 
@@ -568,10 +666,22 @@ func (r *Catalog) createSession(ctx context.Context, opts *options) (*http.Clien
 
 	token := opts.oauthToken
 	if token == "" && opts.credential != "" {
-		var err error
-		if token, err = r.fetchAccessToken(cl, opts.credential, opts); err != nil {
+		tok, err := r.fetchAccessToken(ctx, cl, opts.credential, opts)
+		if err != nil {
 			return nil, fmt.Errorf("auth error: %w", err)
 		}
+
+		token = tok.AccessToken
+		if tok.ExpiresIn > 0 {
+			skew := defaultTokenRefreshSkew
+			if opts.tokenRefreshSkew != nil {
+				skew = *opts.tokenRefreshSkew
+			}
+
+			session.auth = newOAuthTokenManager(tok, skew, func(ctx context.Context) (oauthTokenResponse, error) {
+				return r.fetchAccessToken(ctx, cl, opts.credential, opts)
+			})
+		}
 	}
 
 	if token != "" {
@@ -812,6 +922,8 @@ func (r *Catalog) CommitTable(ctx context.Context, tbl *table.Table, requirement
 	return ret.Metadata, ret.MetadataLoc, nil
 }
 
+// RegisterTable adds a table pointing to an existing metadata file to the
+// catalog using the register endpoint.
 func (r *Catalog) RegisterTable(ctx context.Context, identifier table.Identifier, metadataLoc string) (*table.Table, error) {
 	ns, tbl, err := splitIdentForPath(identifier)
 	if err != nil {
@@ -823,7 +935,7 @@ func (r *Catalog) RegisterTable(ctx context.Context, identifier table.Identifier
 		MetadataLoc string `json:"metadata-location"`
 	}
 
-	ret, err := doPost[payload, loadTableResponse](ctx, r.baseURI, []string{"namespaces", ns, "tables", tbl},
+	ret, err := doPost[payload, loadTableResponse](ctx, r.baseURI, []string{"namespaces", ns, "register"},
 		payload{Name: tbl, MetadataLoc: metadataLoc}, r.cl, map[int]error{
 			http.StatusNotFound: catalog.ErrNoSuchNamespace, http.StatusConflict: catalog.ErrTableAlreadyExists,
 		})
@@ -26,10 +26,12 @@ import (
 	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -133,6 +135,99 @@ func TestAuthHeader(t *testing.T) {
 	}, cat.cl.Transport.(*sessionTransport).defaultHeaders)
 }
 
+func TestOAuthTokenRefresh(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var tokenRequests atomic.Int32
+	mux.HandleFunc("/v1/oauth/tokens", func(w http.ResponseWriter, req *http.Request) {
+		n := tokenRequests.Add(1)
+		// the first token is within the default refresh skew of expiring
+		expiresIn := 3600
+		if n == 1 {
+			expiresIn = 30
+		}
+
+		// give concurrent requests a chance to pile up behind the refresh
+		time.Sleep(50 * time.Millisecond)
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("token-%d", n),
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn,
+		})
+	})
+
+	mux.HandleFunc("/v1/config", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"Bearer token-2"}, r.Header.Values("Authorization"))
+		json.NewEncoder(w).Encode(map[string]any{
+			"defaults": map[string]any{}, "overrides": map[string]any{},
+		})
+	})
+
+	var authHeaders sync.Map
+	mux.HandleFunc("/v1/namespaces", func(w http.ResponseWriter, r *http.Request) {
+		authHeaders.Store(r.Header.Get("Authorization"), struct{}{})
+		json.NewEncoder(w).Encode(map[string]any{"namespaces": []any{}})
+	})
+
+	cat, err := NewCatalog(context.Background(), "rest", srv.URL,
+		WithCredential("client:secret"))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, tokenRequests.Load())
+
+	mgr := cat.cl.Transport.(*sessionTransport).auth
+	require.NotNil(t, mgr)
+
+	// simulate the current token expiring
+	mgr.mu.Lock()
+	mgr.expiresAt = time.Now().Add(-time.Second)
+	mgr.mu.Unlock()
+
+	var grp errgroup.Group
+	for range 10 {
+		grp.Go(func() error {
+			_, err := cat.ListNamespaces(context.Background(), nil)
+
+			return err
+		})
+	}
+	require.NoError(t, grp.Wait())
+
+	assert.EqualValues(t, 3, tokenRequests.Load(), "expected exactly one refresh")
+	authHeaders.Range(func(k, _ any) bool {
+		assert.Equal(t, "Bearer token-3", k)
+
+		return true
+	})
+}
+
+func TestOAuthTokenWithoutExpiryIsNotRefreshed(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/v1/oauth/tokens", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "some_jwt_token",
+			"token_type":   "Bearer",
+		})
+	})
+
+	mux.HandleFunc("/v1/config", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"defaults": map[string]any{}, "overrides": map[string]any{},
+		})
+	})
+
+	cat, err := NewCatalog(context.Background(), "rest", srv.URL,
+		WithCredential("client:secret"))
+	require.NoError(t, err)
+	assert.Nil(t, cat.cl.Transport.(*sessionTransport).auth)
+}
+
 func TestAuthUriHeader(t *testing.T) {
 	t.Parallel()
 	mux := http.NewServeMux()
@@ -914,6 +914,64 @@ func (r *RestCatalogSuite) TestUpdateNamespaceProps404() {
 	r.ErrorContains(err, "Namespace does not exist: does_not_exist in warehouse")
 }
 
+func (r *RestCatalogSuite) TestUpdateNamespacePropsMultiLevel() {
+	r.mux.HandleFunc("/v1/namespaces/{ns}/properties", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodPost, req.Method)
+		r.Equal("accounting\x1ftax", req.PathValue("ns"))
+		r.Equal("/v1/namespaces/accounting%1Ftax/properties", req.URL.EscapedPath())
+
+		var body map[string]any
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&body))
+		r.Equal(map[string]any{
+			"removals": []any{"owner"},
+			"updates":  map[string]any{"retention": "30d"},
+		}, body)
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"removed": []string{"owner"},
+			"updated": []string{"retention"},
+			"missing": []string{},
+		})
+	})
+
+	cat, err := rest.NewCatalog(context.Background(), "rest", r.srv.URL, rest.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	summary, err := cat.UpdateNamespaceProperties(context.Background(), table.Identifier{"accounting", "tax"},
+		[]string{"owner"}, iceberg.Properties{"retention": "30d"})
+	r.Require().NoError(err)
+
+	r.Equal(catalog.PropertiesUpdateSummary{
+		Removed: []string{"owner"},
+		Updated: []string{"retention"},
+		Missing: []string{},
+	}, summary)
+}
+
+func (r *RestCatalogSuite) TestUpdateNamespaceProps422() {
+	r.mux.HandleFunc("/v1/namespaces/fokko/properties", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodPost, req.Method)
+
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{
+				"message": "Duplicate key to update and remove: prop",
+				"type":    "UnprocessableEntityException",
+				"code":    422,
+			},
+		})
+	})
+
+	cat, err := rest.NewCatalog(context.Background(), "rest", r.srv.URL, rest.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	_, err = cat.UpdateNamespaceProperties(context.Background(),
+		table.Identifier{"fokko"}, []string{"prop"}, iceberg.Properties{"prop": "yes"})
+	r.ErrorIs(err, rest.ErrUnprocessableEntity)
+	r.ErrorIs(err, rest.ErrRESTError)
+	r.ErrorContains(err, "Duplicate key to update and remove: prop")
+}
+
 var (
 	exampleTableMetadataNoSnapshotV1 = `{
 	"format-version": 1,
@@ -1292,6 +1350,25 @@ func (r *RestCatalogSuite) TestDropTable204() {
 	r.NoError(err)
 }
 
+func (r *RestCatalogSuite) TestPurgeTable204() {
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables/table", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodDelete, req.Method)
+		r.Equal("true", req.URL.Query().Get("purgeRequested"))
+
+		for k, v := range TestHeaders {
+			r.Equal(v, req.Header.Values(k))
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	cat, err := rest.NewCatalog(context.Background(), "rest", r.srv.URL, rest.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	err = cat.PurgeTable(context.Background(), catalog.ToIdentifier("fokko", "table"))
+	r.NoError(err)
+}
+
 func (r *RestCatalogSuite) TestDropTable404() {
 	// Mock the drop table endpoint with 404 response
 	r.mux.HandleFunc("/v1/namespaces/fokko/tables/table", func(w http.ResponseWriter, req *http.Request) {
@@ -1322,7 +1399,7 @@ func (r *RestCatalogSuite) TestDropTable404() {
 }
 
 func (r *RestCatalogSuite) TestRegisterTable200() {
-	r.mux.HandleFunc("/v1/namespaces/fokko/tables/fokko2", func(w http.ResponseWriter, req *http.Request) {
+	r.mux.HandleFunc("/v1/namespaces/fokko/register", func(w http.ResponseWriter, req *http.Request) {
 		r.Require().Equal(http.MethodPost, req.Method)
 
 		for k, v := range TestHeaders {
@@ -1470,7 +1547,7 @@ func (r *RestCatalogSuite) TestRegisterTable200() {
 }
 
 func (r *RestCatalogSuite) TestRegisterTable404() {
-	r.mux.HandleFunc("/v1/namespaces/nonexistent/tables/fokko2", func(w http.ResponseWriter, req *http.Request) {
+	r.mux.HandleFunc("/v1/namespaces/nonexistent/register", func(w http.ResponseWriter, req *http.Request) {
 		r.Require().Equal(http.MethodPost, req.Method)
 
 		for k, v := range TestHeaders {
@@ -1503,7 +1580,7 @@ func (r *RestCatalogSuite) TestRegisterTable404() {
 }
 
 func (r *RestCatalogSuite) TestRegisterTable409() {
-	r.mux.HandleFunc("/v1/namespaces/fokko/tables/alreadyexist", func(w http.ResponseWriter, req *http.Request) {
+	r.mux.HandleFunc("/v1/namespaces/fokko/register", func(w http.ResponseWriter, req *http.Request) {
 		r.Require().Equal(http.MethodPost, req.Method)
 
 		for k, v := range TestHeaders {
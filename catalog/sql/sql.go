@@ -323,6 +323,61 @@ func (c *Catalog) CreateTable(ctx context.Context, ident table.Identifier, sc *i
 	return c.LoadTable(ctx, ident, staged.Properties())
 }
 
+// RegisterTable adds a table to the catalog which points to an existing
+// metadata file, without rewriting it. The metadata file is read to make
+// sure it is valid before the table is added.
+func (c *Catalog) RegisterTable(ctx context.Context, ident table.Identifier, metadataLoc string) (*table.Table, error) {
+	ns := strings.Join(catalog.NamespaceFromIdent(ident), ".")
+	tblIdent := catalog.TableNameFromIdent(ident)
+
+	exists, err := c.namespaceExists(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", catalog.ErrNoSuchNamespace, ns)
+	}
+
+	if _, err := table.NewFromLocation(ctx, ident, metadataLoc,
+		io.LoadFSFunc(c.props, metadataLoc), c); err != nil {
+		return nil, fmt.Errorf("failed to read table metadata from %s: %w", metadataLoc, err)
+	}
+
+	err = withWriteTx(ctx, c.db, func(ctx context.Context, tx bun.Tx) error {
+		exists, err := tx.NewSelect().Model(&sqlIcebergTable{
+			CatalogName:    c.name,
+			TableNamespace: ns,
+			TableName:      tblIdent,
+		}).WherePK().Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("error encountered checking existence of table '%s': %w", ident, err)
+		}
+
+		if exists {
+			return fmt.Errorf("%w: %s", catalog.ErrTableAlreadyExists, ident)
+		}
+
+		_, err = tx.NewInsert().Model(&sqlIcebergTable{
+			CatalogName:      c.name,
+			TableNamespace:   ns,
+			TableName:        tblIdent,
+			MetadataLocation: sql.NullString{String: metadataLoc, Valid: true},
+			IcebergType:      TableType,
+		}).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to register table: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.LoadTable(ctx, ident, nil)
+}
+
 func (c *Catalog) CommitTable(ctx context.Context, tbl *table.Table, reqs []table.Requirement, updates []table.Update) (table.Metadata, string, error) {
 	ns := catalog.NamespaceFromIdent(tbl.Identifier())
 	tblName := catalog.TableNameFromIdent(tbl.Identifier())
@@ -348,30 +403,8 @@ func (c *Catalog) CommitTable(ctx context.Context, tbl *table.Table, reqs []tabl
 
 	err = withWriteTx(ctx, c.db, func(ctx context.Context, tx bun.Tx) error {
 		if current != nil {
-			res, err := tx.NewUpdate().Model(&sqlIcebergTable{
-				CatalogName:              c.name,
-				TableNamespace:           strings.Join(ns, "."),
-				TableName:                tblName,
-				IcebergType:              TableType,
-				MetadataLocation:         sql.NullString{Valid: true, String: staged.MetadataLocation()},
-				PreviousMetadataLocation: sql.NullString{Valid: true, String: current.MetadataLocation()},
-			}).WherePK().Where("metadata_location = ?", current.MetadataLocation()).
-				Where("iceberg_type = ?", TableType).
-				Exec(ctx)
-			if err != nil {
-				return fmt.Errorf("error updating table information: %w", err)
-			}
-
-			n, err := res.RowsAffected()
-			if err != nil {
-				return fmt.Errorf("error updating table information: %w", err)
-			}
-
-			if n == 0 {
-				return fmt.Errorf("table has been updated by another process: %s.%s", strings.Join(ns, "."), tblName)
-			}
-
-			return nil
+			return c.swapMetadataLocation(ctx, tx, ns, tblName,
+				current.MetadataLocation(), staged.MetadataLocation())
 		}
 
 		_, err := tx.NewInsert().Model(&sqlIcebergTable{
@@ -394,6 +427,37 @@ func (c *Catalog) CommitTable(ctx context.Context, tbl *table.Table, reqs []tabl
 	return staged.Metadata(), staged.MetadataLocation(), nil
 }
 
+// swapMetadataLocation atomically points the table at a new metadata file,
+// using the previous location as an optimistic lock so that a concurrent
+// commit by another writer is detected rather than overwritten.
+func (c *Catalog) swapMetadataLocation(ctx context.Context, tx bun.Tx, ns table.Identifier, tblName, prevLoc, newLoc string) error {
+	res, err := tx.NewUpdate().Model(&sqlIcebergTable{
+		CatalogName:              c.name,
+		TableNamespace:           strings.Join(ns, "."),
+		TableName:                tblName,
+		IcebergType:              TableType,
+		MetadataLocation:         sql.NullString{Valid: true, String: newLoc},
+		PreviousMetadataLocation: sql.NullString{Valid: true, String: prevLoc},
+	}).WherePK().Where("metadata_location = ?", prevLoc).
+		Where("iceberg_type = ?", TableType).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("error updating table information: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error updating table information: %w", err)
+	}
+
+	if n == 0 {
+		return fmt.Errorf("%w: table has been updated by another process: %s.%s",
+			catalog.ErrCommitFailed, strings.Join(ns, "."), tblName)
+	}
+
+	return nil
+}
+
 func (c *Catalog) LoadTable(ctx context.Context, identifier table.Identifier, props iceberg.Properties) (*table.Table, error) {
 	ns := catalog.NamespaceFromIdent(identifier)
 	tbl := catalog.TableNameFromIdent(identifier)
@@ -466,6 +530,21 @@ func (c *Catalog) DropTable(ctx context.Context, identifier table.Identifier) er
 	})
 }
 
+// PurgeTable drops the table and deletes all of its data and metadata
+// files, which are found through the metadata of the dropped table.
+func (c *Catalog) PurgeTable(ctx context.Context, identifier table.Identifier) error {
+	tbl, err := c.LoadTable(ctx, identifier, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := c.DropTable(ctx, identifier); err != nil {
+		return err
+	}
+
+	return table.PurgeFiles(ctx, tbl)
+}
+
 func (c *Catalog) RenameTable(ctx context.Context, from, to table.Identifier) (*table.Table, error) {
 	fromNs := strings.Join(catalog.NamespaceFromIdent(from), ".")
 	fromTbl := catalog.TableNameFromIdent(from)
@@ -525,17 +604,20 @@ func (c *Catalog) RenameTable(ctx context.Context, from, to table.Identifier) (*
 	return c.LoadTable(ctx, to, nil)
 }
 
+// CheckTableExists looks the table up in the catalog's table, without
+// reading its metadata file.
 func (c *Catalog) CheckTableExists(ctx context.Context, identifier table.Identifier) (bool, error) {
-	_, err := c.LoadTable(ctx, identifier, nil)
-	if err != nil {
-		if errors.Is(err, catalog.ErrNoSuchTable) {
-			return false, nil
-		}
-
-		return false, err
-	}
+	ns := strings.Join(catalog.NamespaceFromIdent(identifier), ".")
+	tbl := catalog.TableNameFromIdent(identifier)
 
-	return true, nil
+	return withReadTx(ctx, c.db, func(ctx context.Context, tx bun.Tx) (bool, error) {
+		return tx.NewSelect().Model((*sqlIcebergTable)(nil)).
+			Where("catalog_name = ?", c.name).
+			Where("table_namespace = ?", ns).
+			Where("table_name = ?", tbl).
+			Where("iceberg_type = ?", TableType).
+			Limit(1).Exists(ctx)
+	})
 }
 
 func (c *Catalog) CreateNamespace(ctx context.Context, namespace table.Identifier, props iceberg.Properties) error {
@@ -0,0 +1,73 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/sqliteshim"
+)
+
+func TestSwapMetadataLocationConflict(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	db, err := sql.Open(sqliteshim.ShimName, "file://"+filepath.Join(dir, "sql-catalog.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	cat, err := NewCatalog("default", db, SQLite, iceberg.Properties{"warehouse": "file://" + dir})
+	require.NoError(t, err)
+
+	ns, tblName := table.Identifier{"db"}, "tbl"
+	require.NoError(t, cat.CreateNamespace(ctx, ns, nil))
+	tbl, err := cat.CreateTable(ctx, append(ns, tblName), iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true}))
+	require.NoError(t, err)
+
+	swap := func(prevLoc, newLoc string) error {
+		return withWriteTx(ctx, cat.db, func(ctx context.Context, tx bun.Tx) error {
+			return cat.swapMetadataLocation(ctx, tx, ns, tblName, prevLoc, newLoc)
+		})
+	}
+
+	// another writer has already committed on top of the location we loaded
+	require.NoError(t, swap(tbl.MetadataLocation(), "concurrent-location"))
+
+	err = swap(tbl.MetadataLocation(), "stale-location")
+	assert.ErrorIs(t, err, catalog.ErrCommitFailed)
+	assert.ErrorContains(t, err, "table has been updated by another process: db.tbl")
+
+	var row sqlIcebergTable
+	require.NoError(t, cat.db.NewSelect().Model(&row).
+		Where("catalog_name = ?", "default").
+		Where("table_namespace = ?", "db").
+		Where("table_name = ?", tblName).
+		Scan(ctx))
+	assert.Equal(t, "concurrent-location", row.MetadataLocation.String)
+	assert.Equal(t, tbl.MetadataLocation(), row.PreviousMetadataLocation.String)
+}
@@ -677,6 +677,121 @@ func (s *SqliteCatalogTestSuite) TestDropTableNotExist() {
 	}
 }
 
+func (s *SqliteCatalogTestSuite) TestCheckTableExists() {
+	cat := s.getCatalogSqlite()
+	tblID := s.randomTableIdentifier()
+	s.Require().NoError(cat.CreateNamespace(context.Background(), catalog.NamespaceFromIdent(tblID), nil))
+
+	exists, err := cat.CheckTableExists(context.Background(), tblID)
+	s.Require().NoError(err)
+	s.False(exists)
+
+	_, err = cat.CreateTable(context.Background(), tblID, tableSchemaNested)
+	s.Require().NoError(err)
+
+	exists, err = cat.CheckTableExists(context.Background(), tblID)
+	s.Require().NoError(err)
+	s.True(exists)
+
+	// views aren't tables
+	viewID := table.Identifier{tblID[0], tableName()}
+	s.Require().NoError(cat.CreateView(context.Background(), viewID, tableSchemaNested, "SELECT 1", nil))
+	exists, err = cat.CheckTableExists(context.Background(), viewID)
+	s.Require().NoError(err)
+	s.False(exists)
+}
+
+func (s *SqliteCatalogTestSuite) TestRegisterTable() {
+	ctx := context.Background()
+	cat := s.getCatalogSqlite()
+	tblID := s.randomTableIdentifier()
+	s.Require().NoError(cat.CreateNamespace(ctx, catalog.NamespaceFromIdent(tblID), nil))
+
+	tbl, err := cat.CreateTable(ctx, tblID, tableSchemaNested)
+	s.Require().NoError(err)
+	s.Require().NoError(cat.DropTable(ctx, tblID))
+
+	registered, err := catalog.RegisterTable(ctx, cat, tblID, tbl.MetadataLocation())
+	s.Require().NoError(err)
+	s.Equal(tblID, registered.Identifier())
+	s.Equal(tbl.MetadataLocation(), registered.MetadataLocation())
+
+	loaded, err := cat.LoadTable(ctx, tblID, nil)
+	s.Require().NoError(err)
+	s.Equal(tbl.MetadataLocation(), loaded.MetadataLocation())
+	s.True(tbl.Metadata().Equals(loaded.Metadata()))
+
+	// the same metadata can be registered under another name
+	otherID := table.Identifier{tblID[0], tableName()}
+	_, err = cat.RegisterTable(ctx, otherID, tbl.MetadataLocation())
+	s.Require().NoError(err)
+
+	_, err = catalog.RegisterTable(ctx, cat, tblID, tbl.MetadataLocation())
+	s.ErrorIs(err, catalog.ErrTableAlreadyExists)
+	_, err = cat.RegisterTable(ctx, otherID, tbl.MetadataLocation())
+	s.ErrorIs(err, catalog.ErrTableAlreadyExists)
+
+	_, err = cat.RegisterTable(ctx, table.Identifier{"missing_ns", tableName()}, tbl.MetadataLocation())
+	s.ErrorIs(err, catalog.ErrNoSuchNamespace)
+}
+
+func (s *SqliteCatalogTestSuite) TestPurgeTable() {
+	ctx := context.Background()
+	cat := s.getCatalogSqlite()
+	tblID := s.randomTableIdentifier()
+	s.Require().NoError(cat.CreateNamespace(ctx, catalog.NamespaceFromIdent(tblID), nil))
+
+	sc := iceberg.NewSchema(0, iceberg.NestedField{
+		ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true,
+	})
+	tbl, err := cat.CreateTable(ctx, tblID, sc)
+	s.Require().NoError(err)
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	s.Require().NoError(err)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema,
+		[]string{`[{"id": 1}, {"id": 2}]`})
+	s.Require().NoError(err)
+	defer arrTbl.Release()
+
+	for range 2 {
+		tx := tbl.NewTransaction()
+		s.Require().NoError(tx.AppendTable(ctx, arrTbl, 1, nil))
+		tbl, err = tx.Commit(ctx)
+		s.Require().NoError(err)
+	}
+
+	files := []string{tbl.MetadataLocation()}
+	for entry := range tbl.Metadata().PreviousFiles() {
+		files = append(files, entry.MetadataFile)
+	}
+	for _, snap := range tbl.Metadata().Snapshots() {
+		files = append(files, snap.ManifestList)
+	}
+	tasks, err := tbl.Scan().PlanFiles(ctx)
+	s.Require().NoError(err)
+	s.NotEmpty(tasks)
+	for _, task := range tasks {
+		files = append(files, task.File.FilePath())
+	}
+
+	localPath := func(path string) string { return strings.TrimPrefix(path, "file://") }
+	for _, f := range files {
+		s.FileExists(localPath(f))
+	}
+
+	s.Require().NoError(cat.PurgeTable(ctx, tblID))
+
+	exists, err := cat.CheckTableExists(ctx, tblID)
+	s.Require().NoError(err)
+	s.False(exists)
+	for _, f := range files {
+		s.NoFileExists(localPath(f))
+	}
+
+	s.ErrorIs(cat.PurgeTable(ctx, tblID), catalog.ErrNoSuchTable)
+}
+
 func (s *SqliteCatalogTestSuite) TestRenameTable() {
 	tests := []struct {
 		cat       *sqlcat.Catalog
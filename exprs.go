@@ -20,6 +20,7 @@ package iceberg
 import (
 	"fmt"
 	"reflect"
+	"slices"
 
 	"github.com/google/uuid"
 )
@@ -394,6 +395,11 @@ func (r Reference) Bind(s *Schema, caseSensitive bool) (BoundTerm, error) {
 	if caseSensitive {
 		field, found = s.FindFieldByName(string(r))
 	} else {
+		if ids := s.caseInsensitiveFieldIDs(string(r)); len(ids) > 1 {
+			return nil, fmt.Errorf("%w: ambiguous reference '%s' matches fields %v, caseSensitive=false",
+				ErrInvalidSchema, string(r), ids)
+		}
+
 		field, found = s.FindFieldByNameCaseInsensitive(string(r))
 	}
 	if !found {
@@ -421,10 +427,10 @@ type BoundReference interface {
 
 type boundRef[T LiteralType] struct {
 	field NestedField
-	acc   accessor
+	acc   Accessor
 }
 
-func createBoundRef(field NestedField, acc accessor) BoundReference {
+func createBoundRef(field NestedField, acc Accessor) BoundReference {
 	switch field.Type.(type) {
 	case BooleanType:
 		return &boundRef[bool]{field: field, acc: acc}
@@ -896,12 +902,16 @@ func (usp *unboundSetPredicate) Negate() BooleanExpression {
 
 func (usp *unboundSetPredicate) Term() UnboundTerm { return usp.term }
 func (usp *unboundSetPredicate) Bind(schema *Schema, caseSensitive bool) (BooleanExpression, error) {
+	return usp.bind(schema, caseSensitive, DefaultInPredicateThreshold)
+}
+
+func (usp *unboundSetPredicate) bind(schema *Schema, caseSensitive bool, threshold int) (BooleanExpression, error) {
 	bound, err := usp.term.Bind(schema, caseSensitive)
 	if err != nil {
 		return nil, err
 	}
 
-	return createBoundSetPredicate(usp.op, bound, usp.lits)
+	return createBoundSetPredicate(usp.op, bound, usp.lits, threshold)
 }
 
 // BoundSetPredicate is a bound expression that utilizes a set of literals such as In or NotIn
@@ -912,66 +922,82 @@ type BoundSetPredicate interface {
 	AsUnbound(Reference, []Literal) UnboundPredicate
 }
 
-func createBoundSetPredicate(op Operation, term BoundTerm, lits Set[Literal]) (BooleanExpression, error) {
+func createBoundSetPredicate(op Operation, term BoundTerm, lits Set[Literal], threshold int) (BooleanExpression, error) {
 	boundType := term.Type()
 
-	typedSet := newLiteralSet()
+	casted := make([]Literal, 0, lits.Len())
 	for _, v := range lits.Members() {
-		casted, err := v.To(boundType)
+		c, err := v.To(boundType)
 		if err != nil {
 			return nil, err
 		}
-		typedSet.Add(casted)
-	}
 
-	switch typedSet.Len() {
-	case 0:
-		if op == OpIn {
-			return AlwaysFalse{}, nil
-		} else if op == OpNotIn {
-			return AlwaysTrue{}, nil
-		}
-	case 1:
-		if op == OpIn {
-			return createBoundLiteralPredicate(OpEQ, term, typedSet.Members()[0])
-		} else if op == OpNotIn {
-			return createBoundLiteralPredicate(OpNEQ, term, typedSet.Members()[0])
+		switch c.(type) {
+		case AboveMaxLiteral, BelowMinLiteral:
+			// values out of the range of the type can never match
+			continue
 		}
+		casted = append(casted, c)
 	}
 
 	switch term.Type().(type) {
 	case BooleanType:
-		return newBoundSetPredicate[bool](op, term, typedSet), nil
+		return newBoundSetPredicate[bool](op, term, casted, threshold)
 	case Int32Type:
-		return newBoundSetPredicate[int32](op, term, typedSet), nil
+		return newBoundSetPredicate[int32](op, term, casted, threshold)
 	case Int64Type:
-		return newBoundSetPredicate[int64](op, term, typedSet), nil
+		return newBoundSetPredicate[int64](op, term, casted, threshold)
 	case Float32Type:
-		return newBoundSetPredicate[float32](op, term, typedSet), nil
+		return newBoundSetPredicate[float32](op, term, casted, threshold)
 	case Float64Type:
-		return newBoundSetPredicate[float64](op, term, typedSet), nil
+		return newBoundSetPredicate[float64](op, term, casted, threshold)
 	case DateType:
-		return newBoundSetPredicate[Date](op, term, typedSet), nil
+		return newBoundSetPredicate[Date](op, term, casted, threshold)
 	case TimeType:
-		return newBoundSetPredicate[Time](op, term, typedSet), nil
+		return newBoundSetPredicate[Time](op, term, casted, threshold)
 	case TimestampType, TimestampTzType:
-		return newBoundSetPredicate[Timestamp](op, term, typedSet), nil
+		return newBoundSetPredicate[Timestamp](op, term, casted, threshold)
 	case StringType:
-		return newBoundSetPredicate[string](op, term, typedSet), nil
+		return newBoundSetPredicate[string](op, term, casted, threshold)
 	case BinaryType, FixedType:
-		return newBoundSetPredicate[[]byte](op, term, typedSet), nil
+		return newBoundSetPredicate[[]byte](op, term, casted, threshold)
 	case DecimalType:
-		return newBoundSetPredicate[Decimal](op, term, typedSet), nil
+		return newBoundSetPredicate[Decimal](op, term, casted, threshold)
 	case UUIDType:
-		return newBoundSetPredicate[uuid.UUID](op, term, typedSet), nil
+		return newBoundSetPredicate[uuid.UUID](op, term, casted, threshold)
 	}
 
 	return nil, fmt.Errorf("%w: invalid bound type for set predicate - %s",
 		ErrType, term.Type())
 }
 
-func newBoundSetPredicate[T LiteralType](op Operation, term BoundTerm, lits Set[Literal]) *boundSetPredicate[T] {
-	return &boundSetPredicate[T]{op: op, term: term.(bound[T]), lits: lits}
+// newBoundSetPredicate builds the literal set for a bound set predicate.
+// Up to threshold literals are kept in a hash set, larger lists are kept
+// sorted and searched with a binary search instead.
+func newBoundSetPredicate[T LiteralType](op Operation, term BoundTerm, lits []Literal, threshold int) (BooleanExpression, error) {
+	var typedSet Set[Literal]
+	if len(lits) > threshold {
+		typedSet = newSortedLiteralSet[T](lits...)
+	} else {
+		typedSet = newLiteralSet(lits...)
+	}
+
+	switch typedSet.Len() {
+	case 0:
+		if op == OpIn {
+			return AlwaysFalse{}, nil
+		} else if op == OpNotIn {
+			return AlwaysTrue{}, nil
+		}
+	case 1:
+		if op == OpIn {
+			return createBoundLiteralPredicate(OpEQ, term, typedSet.Members()[0])
+		} else if op == OpNotIn {
+			return createBoundLiteralPredicate(OpNEQ, term, typedSet.Members()[0])
+		}
+	}
+
+	return &boundSetPredicate[T]{op: op, term: term.(bound[T]), lits: typedSet}, nil
 }
 
 type boundSetPredicate[T LiteralType] struct {
@@ -1051,3 +1077,83 @@ func (b *BoundTransform) evalToLiteral(st structLike) Optional[Literal] {
 func (b *BoundTransform) evalIsNull(st structLike) bool {
 	return !b.evalToLiteral(st).Valid
 }
+
+// Simplify returns an equivalent, normalized form of the given expression
+// in order to reduce the work performed by evaluators. Nested AndExpr and
+// OrExpr nodes are flattened, AlwaysTrue and AlwaysFalse are folded away,
+// double negations are removed and duplicate operands of an And or Or are
+// dropped, as determined by the Equals method of each expression.
+func Simplify(expr BooleanExpression) BooleanExpression {
+	switch e := expr.(type) {
+	case NotExpr:
+		return NewNot(Simplify(e.child))
+	case AndExpr:
+		var operands []BooleanExpression
+		for _, op := range flattenExpr(e, OpAnd, nil) {
+			switch op := Simplify(op).(type) {
+			case AlwaysFalse:
+				return AlwaysFalse{}
+			case AlwaysTrue:
+			default:
+				operands = appendUniqueExpr(operands, flattenExpr(op, OpAnd, nil)...)
+			}
+		}
+
+		return foldExprs(operands, AlwaysTrue{}, newAnd)
+	case OrExpr:
+		var operands []BooleanExpression
+		for _, op := range flattenExpr(e, OpOr, nil) {
+			switch op := Simplify(op).(type) {
+			case AlwaysTrue:
+				return AlwaysTrue{}
+			case AlwaysFalse:
+			default:
+				operands = appendUniqueExpr(operands, flattenExpr(op, OpOr, nil)...)
+			}
+		}
+
+		return foldExprs(operands, AlwaysFalse{}, newOr)
+	}
+
+	return expr
+}
+
+// flattenExpr collects the operands of a tree of nested AndExpr (for OpAnd)
+// or OrExpr (for OpOr) nodes in left to right order.
+func flattenExpr(expr BooleanExpression, op Operation, out []BooleanExpression) []BooleanExpression {
+	switch e := expr.(type) {
+	case AndExpr:
+		if op == OpAnd {
+			return flattenExpr(e.right, op, flattenExpr(e.left, op, out))
+		}
+	case OrExpr:
+		if op == OpOr {
+			return flattenExpr(e.right, op, flattenExpr(e.left, op, out))
+		}
+	}
+
+	return append(out, expr)
+}
+
+func appendUniqueExpr(out []BooleanExpression, exprs ...BooleanExpression) []BooleanExpression {
+	for _, e := range exprs {
+		if !slices.ContainsFunc(out, e.Equals) {
+			out = append(out, e)
+		}
+	}
+
+	return out
+}
+
+func foldExprs(exprs []BooleanExpression, empty BooleanExpression, fn func(l, r BooleanExpression) BooleanExpression) BooleanExpression {
+	if len(exprs) == 0 {
+		return empty
+	}
+
+	result := exprs[0]
+	for _, e := range exprs[1:] {
+		result = fn(result, e)
+	}
+
+	return result
+}
@@ -0,0 +1,422 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// opJSONNames are the names of the operations in the JSON representation
+// of expressions used by the REST catalog specification.
+var opJSONNames = map[Operation]string{
+	OpTrue:          "true",
+	OpFalse:         "false",
+	OpIsNull:        "is-null",
+	OpNotNull:       "not-null",
+	OpIsNan:         "is-nan",
+	OpNotNan:        "not-nan",
+	OpLT:            "lt",
+	OpLTEQ:          "lt-eq",
+	OpGT:            "gt",
+	OpGTEQ:          "gt-eq",
+	OpEQ:            "eq",
+	OpNEQ:           "not-eq",
+	OpStartsWith:    "starts-with",
+	OpNotStartsWith: "not-starts-with",
+	OpIn:            "in",
+	OpNotIn:         "not-in",
+	OpNot:           "not",
+	OpAnd:           "and",
+	OpOr:            "or",
+}
+
+func opFromJSONName(name string) (Operation, bool) {
+	for op, n := range opJSONNames {
+		if n == name {
+			return op, true
+		}
+	}
+
+	return 0, false
+}
+
+// ParseExpressionJSON parses an expression from the JSON representation
+// produced by marshaling a BooleanExpression, which is the one used by the
+// REST catalog specification, e.g.
+//
+//	{"type": "and", "left": {"type": "gt", "term": "a", "value": 1},
+//	 "right": {"type": "in", "term": "b", "values": ["x", "y"]}}
+//
+// The result is unbound. Since the types of the terms aren't known yet,
+// integral values are parsed as int64 literals, other numbers as float64
+// literals and strings as string literals, which are converted to the
+// type of their field when the expression is bound. Binary and fixed
+// values are written as hex strings, use ParseExpressionJSONWithSchema to
+// decode them.
+func ParseExpressionJSON(data []byte) (BooleanExpression, error) {
+	return exprParser{}.parse(data)
+}
+
+// ParseExpressionJSONWithSchema parses an expression like
+// ParseExpressionJSON, decoding the hex strings compared to binary and
+// fixed fields of the schema into binary and fixed literals. The result
+// is still unbound.
+func ParseExpressionJSONWithSchema(data []byte, sc *Schema, caseSensitive bool) (BooleanExpression, error) {
+	return exprParser{schema: sc, caseSensitive: caseSensitive}.parse(data)
+}
+
+func (AlwaysTrue) MarshalJSON() ([]byte, error)  { return []byte("true"), nil }
+func (AlwaysFalse) MarshalJSON() ([]byte, error) { return []byte("false"), nil }
+
+func (n NotExpr) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type  string            `json:"type"`
+		Child BooleanExpression `json:"child"`
+	}{opJSONNames[OpNot], n.child})
+}
+
+func (a AndExpr) MarshalJSON() ([]byte, error) {
+	return marshalBinaryExpr(OpAnd, a.left, a.right)
+}
+
+func (o OrExpr) MarshalJSON() ([]byte, error) {
+	return marshalBinaryExpr(OpOr, o.left, o.right)
+}
+
+func marshalBinaryExpr(op Operation, left, right BooleanExpression) ([]byte, error) {
+	return json.Marshal(struct {
+		Type  string            `json:"type"`
+		Left  BooleanExpression `json:"left"`
+		Right BooleanExpression `json:"right"`
+	}{opJSONNames[op], left, right})
+}
+
+func (up *unboundUnaryPredicate) MarshalJSON() ([]byte, error) {
+	return marshalPredicate(up.op, up.term, nil, nil)
+}
+
+func (ul *unboundLiteralPredicate) MarshalJSON() ([]byte, error) {
+	return marshalPredicate(ul.op, ul.term, ul.lit.Type(), []Literal{ul.lit})
+}
+
+func (usp *unboundSetPredicate) MarshalJSON() ([]byte, error) {
+	lits := usp.lits.Members()
+	if len(lits) == 0 {
+		return marshalPredicate(usp.op, usp.term, nil, lits)
+	}
+
+	return marshalPredicate(usp.op, usp.term, lits[0].Type(), lits)
+}
+
+// Bound predicates are written like unbound ones, with the field id of
+// the bound reference in addition to its name.
+
+func (bp *boundUnaryPredicate[T]) MarshalJSON() ([]byte, error) {
+	return marshalPredicate(bp.op, bp.term, nil, nil)
+}
+
+func (blp *boundLiteralPredicate[T]) MarshalJSON() ([]byte, error) {
+	return marshalPredicate(blp.op, blp.term, blp.term.Type(), []Literal{blp.lit})
+}
+
+func (bsp *boundSetPredicate[T]) MarshalJSON() ([]byte, error) {
+	return marshalPredicate(bsp.op, bsp.term, bsp.term.Type(), bsp.lits.Members())
+}
+
+func (b *boundRef[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type    string `json:"type"`
+		FieldID int    `json:"field-id"`
+		Name    string `json:"name"`
+	}{"reference", b.field.ID, b.field.Name})
+}
+
+func (b *BoundTransform) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type      string    `json:"type"`
+		Transform Transform `json:"transform"`
+		Term      BoundTerm `json:"term"`
+	}{"transform", b.transform, b.term})
+}
+
+// marshalPredicate writes a predicate on term, with a "value" if op is a
+// literal operation and "values" if it is a set operation. The values are
+// written as typ, and sorted so that the output is stable.
+func marshalPredicate(op Operation, term Term, typ Type, lits []Literal) ([]byte, error) {
+	out := struct {
+		Type   string            `json:"type"`
+		Term   Term              `json:"term"`
+		Value  json.RawMessage   `json:"value,omitempty"`
+		Values []json.RawMessage `json:"values,omitempty"`
+	}{Type: opJSONNames[op], Term: term}
+
+	values := make([]json.RawMessage, len(lits))
+	for i, lit := range lits {
+		v, err := marshalLiteralValue(typ, lit)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	switch {
+	case op >= OpLT && op <= OpNotStartsWith:
+		out.Value = values[0]
+	case op == OpIn || op == OpNotIn:
+		slices.SortFunc(values, func(a, b json.RawMessage) int { return bytes.Compare(a, b) })
+		out.Values = values
+	}
+
+	return json.Marshal(out)
+}
+
+// marshalLiteralValue writes numbers and booleans as JSON values, binary
+// values as hex strings and the other values as their human readable
+// string form, e.g. dates as 2017-12-01.
+func marshalLiteralValue(typ Type, lit Literal) (json.RawMessage, error) {
+	switch v := lit.Any().(type) {
+	case bool, int32, int64:
+		return json.Marshal(v)
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return nil, fmt.Errorf("%w: cannot write %v as a JSON value", ErrInvalidArgument, v)
+		}
+
+		return json.Marshal(v)
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("%w: cannot write %v as a JSON value", ErrInvalidArgument, v)
+		}
+
+		return json.Marshal(v)
+	case []byte:
+		return json.Marshal(strings.ToUpper(hex.EncodeToString(v)))
+	}
+
+	return json.Marshal(ToHumanString(typ, lit))
+}
+
+// exprParser parses expressions from JSON, with the schema, if any, used
+// to find the type of the values of predicates.
+type exprParser struct {
+	schema        *Schema
+	caseSensitive bool
+}
+
+func (p exprParser) parse(data []byte) (BooleanExpression, error) {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		if b {
+			return AlwaysTrue{}, nil
+		}
+
+		return AlwaysFalse{}, nil
+	}
+
+	var raw struct {
+		Type   string            `json:"type"`
+		Left   json.RawMessage   `json:"left"`
+		Right  json.RawMessage   `json:"right"`
+		Child  json.RawMessage   `json:"child"`
+		Term   json.RawMessage   `json:"term"`
+		Value  json.RawMessage   `json:"value"`
+		Values []json.RawMessage `json:"values"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: invalid expression JSON: %w", ErrInvalidArgument, err)
+	}
+
+	op, ok := opFromJSONName(raw.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown expression type '%s'", ErrInvalidArgument, raw.Type)
+	}
+
+	switch op {
+	case OpTrue:
+		return AlwaysTrue{}, nil
+	case OpFalse:
+		return AlwaysFalse{}, nil
+	case OpNot:
+		child, err := p.parse(raw.Child)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewNot(child), nil
+	case OpAnd, OpOr:
+		left, err := p.parse(raw.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		right, err := p.parse(raw.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		if op == OpAnd {
+			return NewAnd(left, right), nil
+		}
+
+		return NewOr(left, right), nil
+	}
+
+	term, err := parseTermJSON(raw.Term)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case op >= OpIsNull && op <= OpNotNan:
+		return UnaryPredicate(op, term), nil
+	case op >= OpLT && op <= OpNotStartsWith:
+		lit, err := p.parseValue(term, raw.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		return LiteralPredicate(op, term, lit), nil
+	default:
+		lits := make([]Literal, len(raw.Values))
+		for i, v := range raw.Values {
+			if lits[i], err = p.parseValue(term, v); err != nil {
+				return nil, err
+			}
+		}
+
+		return SetPredicate(op, term, lits), nil
+	}
+}
+
+// parseValue parses the value of a predicate on term, hex decoding it if
+// term is a binary or fixed field of the schema.
+func (p exprParser) parseValue(term UnboundTerm, data json.RawMessage) (Literal, error) {
+	lit, err := parseLiteralJSON(data)
+	if err != nil || p.schema == nil {
+		return lit, err
+	}
+
+	ref, ok := term.(Reference)
+	if !ok {
+		return lit, nil
+	}
+
+	var field NestedField
+	if p.caseSensitive {
+		field, ok = p.schema.FindFieldByName(string(ref))
+	} else {
+		field, ok = p.schema.FindFieldByNameCaseInsensitive(string(ref))
+	}
+	if !ok {
+		// left for binding to report
+		return lit, nil
+	}
+
+	switch field.Type.(type) {
+	case BinaryType, FixedType:
+		return parseHexLiteral(field.Type, lit, data)
+	}
+
+	return lit, nil
+}
+
+// parseHexLiteral decodes the hex string lit, parsed from data, into a
+// literal of the binary or fixed type typ.
+func parseHexLiteral(typ Type, lit Literal, data json.RawMessage) (Literal, error) {
+	str, ok := lit.(StringLiteral)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected a hex string for %s, got %s",
+			ErrInvalidArgument, typ, data)
+	}
+
+	b, err := hex.DecodeString(string(str))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid hex string for %s: %w", ErrInvalidArgument, typ, err)
+	}
+
+	if fixed, ok := typ.(FixedType); ok {
+		if fixed.len != len(b) {
+			return nil, fmt.Errorf("%w: expected %d bytes for %s, got %d",
+				ErrInvalidArgument, fixed.len, typ, len(b))
+		}
+
+		return FixedLiteral(b), nil
+	}
+
+	return BinaryLiteral(b), nil
+}
+
+func parseTermJSON(data json.RawMessage) (UnboundTerm, error) {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return Reference(name), nil
+	}
+
+	var raw struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: invalid term JSON: %w", ErrInvalidArgument, err)
+	}
+
+	switch raw.Type {
+	case "reference":
+		return Reference(raw.Name), nil
+	case "transform":
+		return nil, fmt.Errorf("%w: unbound transform terms", ErrNotImplemented)
+	}
+
+	return nil, fmt.Errorf("%w: unknown term type '%s'", ErrInvalidArgument, raw.Type)
+}
+
+func parseLiteralJSON(data json.RawMessage) (Literal, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("%w: invalid literal JSON: %w", ErrInvalidArgument, err)
+	}
+
+	switch v := v.(type) {
+	case bool:
+		return BoolLiteral(v), nil
+	case string:
+		return StringLiteral(v), nil
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return Int64Literal(n), nil
+		}
+
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid number %s: %w", ErrInvalidArgument, v, err)
+		}
+
+		return Float64Literal(f), nil
+	}
+
+	return nil, fmt.Errorf("%w: invalid literal value %s", ErrInvalidArgument, data)
+}
@@ -18,6 +18,7 @@
 package iceberg_test
 
 import (
+	"encoding/json"
 	"math"
 	"strconv"
 	"testing"
@@ -201,6 +202,42 @@ func TestRefBindingCaseSensitive(t *testing.T) {
 	assert.ErrorContains(t, err, "could not bind reference 'foot', caseSensitive=false")
 }
 
+func TestBindCaseInsensitiveCoercion(t *testing.T) {
+	eq, err := iceberg.EqualTo(iceberg.Reference("FOO"), "hello").Bind(tableSchemaSimple, false)
+	require.NoError(t, err)
+	require.Implements(t, (*iceberg.BoundLiteralPredicate)(nil), eq)
+	assert.Equal(t, 1, eq.(iceberg.BoundPredicate).Term().Ref().Field().ID)
+	assert.Equal(t, iceberg.StringLiteral("hello"), eq.(iceberg.BoundLiteralPredicate).Literal())
+
+	_, err = iceberg.EqualTo(iceberg.Reference("FOO"), "hello").Bind(tableSchemaSimple, true)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+	assert.ErrorContains(t, err, "could not bind reference 'FOO'")
+
+	in, err := iceberg.BindExpr(tableSchemaSimple,
+		iceberg.IsIn(iceberg.Reference("Bar"), int64(1), int64(2), int64(3)), false)
+	require.NoError(t, err)
+	require.Implements(t, (*iceberg.BoundSetPredicate)(nil), in)
+	assert.Equal(t, 2, in.(iceberg.BoundPredicate).Term().Ref().Field().ID)
+	lits := in.(iceberg.BoundSetPredicate).Literals()
+	assert.Equal(t, 3, lits.Len())
+	for _, v := range []int32{1, 2, 3} {
+		assert.True(t, lits.Contains(iceberg.Int32Literal(v)))
+	}
+
+	ambiguous := iceberg.NewSchema(1,
+		iceberg.NestedField{ID: 1, Name: "foo", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 2, Name: "FOO", Type: iceberg.PrimitiveTypes.String},
+	)
+
+	_, err = iceberg.EqualTo(iceberg.Reference("Foo"), "hello").Bind(ambiguous, false)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+	assert.ErrorContains(t, err, "ambiguous reference 'Foo' matches fields [1 2]")
+
+	bound, err := iceberg.EqualTo(iceberg.Reference("FOO"), "hello").Bind(ambiguous, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, bound.(iceberg.BoundPredicate).Term().Ref().Field().ID)
+}
+
 func TestRefTypes(t *testing.T) {
 	sc := iceberg.NewSchema(1,
 		iceberg.NestedField{ID: 1, Name: "a", Type: iceberg.PrimitiveTypes.Bool},
@@ -818,3 +855,334 @@ func TestBindAboveBelowIntMax(t *testing.T) {
 		})
 	}
 }
+
+func TestBindInPredicateThreshold(t *testing.T) {
+	sc := iceberg.NewSchema(1,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64},
+		iceberg.NestedField{ID: 2, Name: "s", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "f", Type: iceberg.PrimitiveTypes.Float64})
+
+	bindBoth := func(t *testing.T, expr iceberg.BooleanExpression) (sorted, hashed iceberg.BoundSetPredicate) {
+		b, err := iceberg.BindExpr(sc, expr, true, iceberg.WithInPredicateThreshold(0))
+		require.NoError(t, err)
+		sorted = b.(iceberg.BoundSetPredicate)
+
+		b, err = iceberg.BindExpr(sc, expr, true, iceberg.WithInPredicateThreshold(math.MaxInt))
+		require.NoError(t, err)
+		hashed = b.(iceberg.BoundSetPredicate)
+
+		return sorted, hashed
+	}
+
+	t.Run("int64", func(t *testing.T) {
+		vals := make([]int64, 10_000)
+		for i := range vals {
+			vals[i] = int64(len(vals)-i) * 2
+		}
+
+		for _, expr := range []iceberg.BooleanExpression{
+			iceberg.IsIn(iceberg.Reference("id"), vals...),
+			iceberg.NotIn(iceberg.Reference("id"), vals...),
+		} {
+			sorted, hashed := bindBoth(t, expr)
+			assert.Equal(t, hashed.Op(), sorted.Op())
+			assert.Equal(t, hashed.Literals().Len(), sorted.Literals().Len())
+			assert.True(t, sorted.Equals(hashed))
+			assert.True(t, hashed.Equals(sorted))
+
+			for v := int64(-10); v < 20_010; v++ {
+				lit := iceberg.NewLiteral(v)
+				require.Equal(t, hashed.Literals().Contains(lit), sorted.Literals().Contains(lit), v)
+			}
+			assert.False(t, sorted.Literals().Contains(iceberg.NewLiteral(int32(2))))
+		}
+	})
+
+	t.Run("string with duplicates", func(t *testing.T) {
+		sorted, hashed := bindBoth(t,
+			iceberg.IsIn(iceberg.Reference("s"), "c", "a", "b", "a", "c"))
+		assert.Equal(t, 3, sorted.Literals().Len())
+		assert.True(t, sorted.Equals(hashed))
+
+		for _, v := range []string{"", "a", "aa", "b", "c", "d"} {
+			lit := iceberg.NewLiteral(v)
+			assert.Equal(t, hashed.Literals().Contains(lit), sorted.Literals().Contains(lit), v)
+		}
+	})
+
+	t.Run("float NaN", func(t *testing.T) {
+		sorted, hashed := bindBoth(t,
+			iceberg.IsIn(iceberg.Reference("f"), math.NaN(), 1.5, -2.5))
+
+		for _, v := range []float64{math.NaN(), 1.5, -2.5, 0} {
+			lit := iceberg.NewLiteral(v)
+			assert.Equal(t, hashed.Literals().Contains(lit), sorted.Literals().Contains(lit), v)
+		}
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		sc := iceberg.NewSchema(1,
+			iceberg.NestedField{ID: 1, Name: "i", Type: iceberg.PrimitiveTypes.Int32})
+
+		expr := iceberg.IsIn(iceberg.Reference("i"), int64(math.MaxInt32)+1,
+			int64(math.MaxInt32), int64(math.MinInt32)-1, 5)
+		for _, threshold := range []int{0, math.MaxInt} {
+			b, err := iceberg.BindExpr(sc, expr, true, iceberg.WithInPredicateThreshold(threshold))
+			require.NoError(t, err)
+
+			lits := b.(iceberg.BoundSetPredicate).Literals()
+			assert.Equal(t, 2, lits.Len())
+			assert.True(t, lits.Contains(iceberg.NewLiteral(int32(math.MaxInt32))))
+			assert.True(t, lits.Contains(iceberg.NewLiteral(int32(5))))
+		}
+
+		b, err := iceberg.BindExpr(sc, iceberg.IsIn(iceberg.Reference("i"),
+			int64(math.MaxInt32)+1, int64(math.MinInt32)-1), true, iceberg.WithInPredicateThreshold(0))
+		require.NoError(t, err)
+		assert.Equal(t, iceberg.AlwaysFalse{}, b)
+	})
+
+	t.Run("default threshold", func(t *testing.T) {
+		vals := make([]int64, iceberg.DefaultInPredicateThreshold+1)
+		for i := range vals {
+			vals[i] = int64(i)
+		}
+
+		b, err := iceberg.BindExpr(sc, iceberg.IsIn(iceberg.Reference("id"), vals...), true)
+		require.NoError(t, err)
+		_, hashed := bindBoth(t, iceberg.IsIn(iceberg.Reference("id"), vals...))
+		assert.True(t, b.Equals(hashed))
+	})
+}
+
+func BenchmarkInPredicateContains(b *testing.B) {
+	sc := iceberg.NewSchema(1,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64})
+
+	vals := make([]int64, 10_000)
+	for i := range vals {
+		vals[i] = int64(i) * 2
+	}
+	expr := iceberg.IsIn(iceberg.Reference("id"), vals...)
+
+	probes := make([]iceberg.Literal, 1024)
+	for i := range probes {
+		probes[i] = iceberg.NewLiteral(int64(i * 19))
+	}
+
+	for _, bm := range []struct {
+		name      string
+		threshold int
+	}{
+		{"hash set", math.MaxInt},
+		{"sorted slice", 0},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			bound, err := iceberg.BindExpr(sc, expr, true,
+				iceberg.WithInPredicateThreshold(bm.threshold))
+			require.NoError(b, err)
+			set := bound.(iceberg.BoundSetPredicate).Literals()
+
+			var i int
+			for b.Loop() {
+				set.Contains(probes[i%len(probes)])
+				i++
+			}
+		})
+	}
+}
+
+func TestExpressionJSONRoundTrip(t *testing.T) {
+	expr := iceberg.NewOr(
+		iceberg.NewAnd(
+			iceberg.GreaterThan(iceberg.Reference("a"), int64(1)),
+			iceberg.IsIn(iceberg.Reference("b"), "x", "y")),
+		iceberg.NewNot(iceberg.EqualTo(iceberg.Reference("c"), true)))
+
+	data, err := json.Marshal(expr)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "or",
+		"left": {
+			"type": "and",
+			"left": {"type": "gt", "term": "a", "value": 1},
+			"right": {"type": "in", "term": "b", "values": ["x", "y"]}
+		},
+		"right": {"type": "not", "child": {"type": "eq", "term": "c", "value": true}}
+	}`, string(data))
+
+	parsed, err := iceberg.ParseExpressionJSON(data)
+	require.NoError(t, err)
+	assert.True(t, expr.Equals(parsed), "expected %s, got %s", expr, parsed)
+}
+
+func TestExpressionJSONPredicates(t *testing.T) {
+	tests := []struct {
+		expr     iceberg.BooleanExpression
+		expected string
+	}{
+		{iceberg.AlwaysTrue{}, `true`},
+		{iceberg.AlwaysFalse{}, `false`},
+		{iceberg.IsNull(iceberg.Reference("a")), `{"type": "is-null", "term": "a"}`},
+		{iceberg.NotNaN(iceberg.Reference("f")), `{"type": "not-nan", "term": "f"}`},
+		{iceberg.LessThanEqual(iceberg.Reference("f"), 1.5), `{"type": "lt-eq", "term": "f", "value": 1.5}`},
+		{iceberg.NotStartsWith(iceberg.Reference("s"), "abc"), `{"type": "not-starts-with", "term": "s", "value": "abc"}`},
+		{iceberg.NotIn(iceberg.Reference("a"), int64(3), int64(1), int64(2)), `{"type": "not-in", "term": "a", "values": [1, 2, 3]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			data, err := json.Marshal(tt.expr)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(data))
+
+			parsed, err := iceberg.ParseExpressionJSON(data)
+			require.NoError(t, err)
+			assert.True(t, tt.expr.Equals(parsed), "expected %s, got %s", tt.expr, parsed)
+		})
+	}
+
+	// typed literals are written in their human readable form and
+	// converted back when the parsed expression is bound
+	data, err := json.Marshal(iceberg.EqualTo(iceberg.Reference("d"), iceberg.Date(17501)))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type": "eq", "term": "d", "value": "2017-12-01"}`, string(data))
+
+	_, err = iceberg.ParseExpressionJSON([]byte(`{"type": "between", "term": "a"}`))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	_, err = json.Marshal(iceberg.EqualTo(iceberg.Reference("f"), math.NaN()))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
+
+func TestBoundExpressionJSON(t *testing.T) {
+	schema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "a", Type: iceberg.PrimitiveTypes.Int32},
+		iceberg.NestedField{ID: 2, Name: "d", Type: iceberg.PrimitiveTypes.Date},
+		iceberg.NestedField{ID: 3, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz})
+
+	expr := iceberg.NewAnd(
+		iceberg.IsIn(iceberg.Reference("a"), int32(1), int32(2)),
+		iceberg.EqualTo(iceberg.Reference("d"), "2017-12-01"),
+		iceberg.GreaterThan(iceberg.Reference("ts"), iceberg.Timestamp(1512151975038194)))
+
+	bound, err := iceberg.BindExpr(schema, expr, true)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(bound)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "and",
+		"left": {
+			"type": "and",
+			"left": {"type": "in", "term": {"type": "reference", "field-id": 1, "name": "a"}, "values": [1, 2]},
+			"right": {"type": "eq", "term": {"type": "reference", "field-id": 2, "name": "d"}, "value": "2017-12-01"}
+		},
+		"right": {"type": "gt", "term": {"type": "reference", "field-id": 3, "name": "ts"}, "value": "2017-12-01T18:12:55.038194+00:00"}
+	}`, string(data))
+
+	parsed, err := iceberg.ParseExpressionJSON(data)
+	require.NoError(t, err)
+
+	rebound, err := iceberg.BindExpr(schema, parsed, true)
+	require.NoError(t, err)
+	assert.True(t, bound.Equals(rebound), "expected %s, got %s", bound, rebound)
+}
+
+func TestExpressionJSONBinaryRoundTrip(t *testing.T) {
+	schema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "b", Type: iceberg.PrimitiveTypes.Binary},
+		iceberg.NestedField{ID: 2, Name: "f", Type: iceberg.FixedTypeOf(3)},
+		iceberg.NestedField{ID: 3, Name: "s", Type: iceberg.PrimitiveTypes.String})
+
+	expr := iceberg.NewAnd(
+		iceberg.LiteralPredicate(iceberg.OpEQ, iceberg.Reference("b"), iceberg.BinaryLiteral{0xde, 0xad, 0xbe, 0xef}),
+		iceberg.SetPredicate(iceberg.OpIn, iceberg.Reference("f"), []iceberg.Literal{
+			iceberg.FixedLiteral{0x01, 0x02, 0x03}, iceberg.FixedLiteral{0x0a, 0x0b, 0x0c},
+		}),
+		iceberg.EqualTo(iceberg.Reference("s"), "abc"))
+
+	bound, err := iceberg.BindExpr(schema, expr, true)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(bound)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "and",
+		"left": {
+			"type": "and",
+			"left": {"type": "eq", "term": {"type": "reference", "field-id": 1, "name": "b"}, "value": "DEADBEEF"},
+			"right": {"type": "in", "term": {"type": "reference", "field-id": 2, "name": "f"}, "values": ["010203", "0A0B0C"]}
+		},
+		"right": {"type": "eq", "term": {"type": "reference", "field-id": 3, "name": "s"}, "value": "abc"}
+	}`, string(data))
+
+	parsed, err := iceberg.ParseExpressionJSONWithSchema(data, schema, true)
+	require.NoError(t, err)
+
+	rebound, err := iceberg.BindExpr(schema, parsed, true)
+	require.NoError(t, err)
+	assert.True(t, bound.Equals(rebound), "expected %s, got %s", bound, rebound)
+
+	// unbound expressions are written and parsed the same way
+	data, err = json.Marshal(expr)
+	require.NoError(t, err)
+
+	parsed, err = iceberg.ParseExpressionJSONWithSchema(data, schema, true)
+	require.NoError(t, err)
+	assert.True(t, expr.Equals(parsed), "expected %s, got %s", expr, parsed)
+
+	_, err = iceberg.ParseExpressionJSONWithSchema(
+		[]byte(`{"type": "eq", "term": "b", "value": "not hex"}`), schema, true)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	_, err = iceberg.ParseExpressionJSONWithSchema(
+		[]byte(`{"type": "eq", "term": "f", "value": "0102"}`), schema, true)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
+
+func TestSimplify(t *testing.T) {
+	sc := iceberg.NewSchema(1,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64},
+		iceberg.NestedField{ID: 2, Name: "s", Type: iceberg.PrimitiveTypes.String})
+
+	bind := func(expr iceberg.BooleanExpression) iceberg.BooleanExpression {
+		b, err := iceberg.BindExpr(sc, expr, true)
+		require.NoError(t, err)
+
+		return b
+	}
+
+	x := bind(iceberg.LessThan(iceberg.Reference("id"), int64(10)))
+	y := bind(iceberg.EqualTo(iceberg.Reference("s"), "foo"))
+	z := bind(iceberg.IsIn(iceberg.Reference("id"), int64(1), int64(2), int64(3)))
+
+	tests := []struct {
+		name     string
+		input    iceberg.BooleanExpression
+		expected iceberg.BooleanExpression
+	}{
+		{"and true", iceberg.NewAnd(iceberg.AlwaysTrue{}, x), x},
+		{"or false", iceberg.NewOr(iceberg.AlwaysFalse{}, x), x},
+		{"double negation", iceberg.NewNot(iceberg.NewNot(x)), x},
+		{"and false", iceberg.NewAnd(x, iceberg.AlwaysFalse{}), iceberg.AlwaysFalse{}},
+		{"nested not", iceberg.NewAnd(y, iceberg.NewNot(iceberg.NewNot(x))), iceberg.NewAnd(y, x)},
+		{"dedupe and", iceberg.NewAnd(x, y, x), iceberg.NewAnd(x, y)},
+		{"dedupe or", iceberg.NewOr(x, iceberg.NewOr(y, x)), iceberg.NewOr(x, y)},
+		{"dedupe set", iceberg.NewAnd(z,
+			bind(iceberg.IsIn(iceberg.Reference("id"), int64(3), int64(2), int64(1)))), z},
+		{"collapse to single", iceberg.NewOr(x, x, x), x},
+		{"flatten", iceberg.NewAnd(iceberg.NewAnd(x, y), iceberg.NewAnd(z, x)),
+			iceberg.NewAnd(x, y, z)},
+		{"mixed", iceberg.NewOr(iceberg.NewAnd(x, x), iceberg.NewAnd(y, y)),
+			iceberg.NewOr(x, y)},
+		{"untouched", ExprA{}, ExprA{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := iceberg.Simplify(tt.input)
+			assert.Truef(t, tt.expected.Equals(result), "expected: %s\ngot: %s", tt.expected, result)
+		})
+	}
+}
@@ -22,7 +22,9 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
 	"gocloud.dev/blob"
@@ -43,32 +45,56 @@ const (
 	// AdlsWriteBlockSize         = "adls.write.block-size-bytes"
 )
 
+// parseAzureLocation returns the container and, if present, the storage
+// account of a location, which is either of the form
+// abfs[s]://<container>@<account>.dfs.core.windows.net/<path> or
+// wasb[s]://<container>/<path>.
+func parseAzureLocation(parsed *url.URL) (containerName, accountName string) {
+	if parsed.User == nil {
+		return parsed.Host, ""
+	}
+
+	accountName, _, _ = strings.Cut(parsed.Hostname(), ".")
+
+	return parsed.User.Username(), accountName
+}
+
 // Construct a Azure bucket from a URL
+//
+// The client is authenticated with, in order of precedence, the account's
+// shared key, a SAS token or connection string configured for the account,
+// or else the default Azure credential chain, which covers managed
+// identities as well as credentials from the environment.
 func createAzureBucket(ctx context.Context, parsed *url.URL, props map[string]string) (*blob.Bucket, error) {
 	adlsSasTokens := propertiesWithPrefix(props, AdlsSasTokenPrefix)
 	adlsConnectionStrings := propertiesWithPrefix(props, AdlsConnectionStringPrefix)
 
-	// Construct the client
-	accountName := props[AdlsSharedKeyAccountName]
-	endpoint := props[AdlsEndpoint]
-	protocol := props[AdlsProtocol]
-
-	var client *container.Client
+	containerName, accountName := parseAzureLocation(parsed)
+	if name := props[AdlsSharedKeyAccountName]; name != "" {
+		accountName = name
+	}
 
 	if accountName == "" {
 		return nil, errors.New("account name is required for azure bucket")
 	}
 
-	if accountKey, ok := props[AdlsSharedKeyAccountKey]; ok {
+	newContainerURL := func(sasToken string) (string, error) {
 		svcURL, err := azureblob.NewServiceURL(&azureblob.ServiceURLOptions{
 			AccountName:   accountName,
-			Protocol:      protocol,
-			StorageDomain: endpoint,
+			SASToken:      sasToken,
+			Protocol:      props[AdlsProtocol],
+			StorageDomain: props[AdlsEndpoint],
 		})
 		if err != nil {
-			return nil, err
+			return "", err
 		}
-		containerURL, err := url.JoinPath(string(svcURL), parsed.Host)
+
+		return url.JoinPath(string(svcURL), containerName)
+	}
+
+	var client *container.Client
+	if accountKey, ok := props[AdlsSharedKeyAccountKey]; ok {
+		containerURL, err := newContainerURL("")
 		if err != nil {
 			return nil, err
 		}
@@ -82,17 +108,7 @@ func createAzureBucket(ctx context.Context, parsed *url.URL, props map[string]st
 			return nil, fmt.Errorf("failed container.NewClientWithSharedKeyCredential: %w", err)
 		}
 	} else if sasToken, ok := adlsSasTokens[accountName]; ok {
-		svcURL, err := azureblob.NewServiceURL(&azureblob.ServiceURLOptions{
-			AccountName:   accountName,
-			SASToken:      sasToken,
-			Protocol:      protocol,
-			StorageDomain: endpoint,
-		})
-		if err != nil {
-			return nil, err
-		}
-
-		containerURL, err := url.JoinPath(string(svcURL), parsed.Host)
+		containerURL, err := newContainerURL(sasToken)
 		if err != nil {
 			return nil, err
 		}
@@ -103,10 +119,24 @@ func createAzureBucket(ctx context.Context, parsed *url.URL, props map[string]st
 		}
 	} else if connectionString, ok := adlsConnectionStrings[accountName]; ok {
 		var err error
-		client, err = container.NewClientFromConnectionString(connectionString, parsed.Host, nil)
+		client, err = container.NewClientFromConnectionString(connectionString, containerName, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed container.NewClientFromConnectionString: %w", err)
 		}
+	} else {
+		containerURL, err := newContainerURL("")
+		if err != nil {
+			return nil, err
+		}
+		cred, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed azidentity.NewDefaultAzureCredential: %w", err)
+		}
+
+		client, err = container.NewClient(containerURL, cred, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed container.NewClient: %w", err)
+		}
 	}
 
 	return azureblob.OpenBucket(ctx, client, nil)
@@ -22,6 +22,7 @@ package io_test
 import (
 	"context"
 	"fmt"
+	goio "io"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
@@ -127,6 +128,71 @@ func (s *AzureBlobIOTestSuite) TestAzuriteWarehouseConnectionString() {
 	s.Require().NotNil(tbl)
 }
 
+func (s *AzureBlobIOTestSuite) loadFS(location string) io.IO {
+	fs, err := io.LoadFS(s.ctx, map[string]string{
+		io.AdlsSharedKeyAccountKey: accountKey,
+		io.AdlsEndpoint:            endpoint,
+		io.AdlsProtocol:            protocol,
+	}, location)
+	s.Require().NoError(err)
+
+	return fs
+}
+
+func (s *AzureBlobIOTestSuite) TestAzureBlobRangedRead() {
+	// the account name is taken from the location
+	loc := fmt.Sprintf("abfss://%s@%s.dfs.core.windows.net/data/file.bin", containerName, accountName)
+	fs := s.loadFS(loc)
+	s.Require().NoError(fs.(io.WriteFileIO).WriteFile(loc, []byte("0123456789")))
+
+	f, err := fs.Open(loc)
+	s.Require().NoError(err)
+	defer f.Close()
+
+	buf := make([]byte, 4)
+	n, err := f.ReadAt(buf, 3)
+	s.Require().NoError(err)
+	s.Equal("3456", string(buf[:n]))
+
+	n, err = f.ReadAt(buf, 8)
+	s.ErrorIs(err, goio.EOF)
+	s.Equal("89", string(buf[:n]))
+}
+
+func (s *AzureBlobIOTestSuite) TestAzureBlobMetadataOverwrite() {
+	loc := fmt.Sprintf("wasb://%s/metadata/version-hint.text", containerName)
+	fs := s.loadFS(loc)
+	wfs := fs.(io.WriteFileIO)
+	s.Require().NoError(wfs.WriteFile(loc, []byte("1")))
+
+	w, err := wfs.Create(loc)
+	s.Require().NoError(err)
+	_, err = w.Write([]byte("2"))
+	s.Require().NoError(err)
+
+	// the block list isn't committed until the writer is closed, so the
+	// previous content is still visible
+	f, err := fs.Open(loc)
+	s.Require().NoError(err)
+	content, err := goio.ReadAll(f)
+	s.Require().NoError(err)
+	s.Require().NoError(f.Close())
+	s.Equal("1", string(content))
+
+	s.Require().NoError(w.Close())
+
+	f, err = fs.Open(loc)
+	s.Require().NoError(err)
+	content, err = goio.ReadAll(f)
+	s.Require().NoError(err)
+	s.Require().NoError(f.Close())
+	s.Equal("2", string(content))
+
+	s.Require().NoError(fs.Remove(loc))
+	// deleting a blob which doesn't exist is a no-op
+	s.NoError(fs.Remove(loc))
+}
+
 func (s *AzureBlobIOTestSuite) createContainerIfNotExist(containerName string) error {
 	svcURL, err := azureblob.NewServiceURL(&azureblob.ServiceURLOptions{
 		AccountName:   accountName,
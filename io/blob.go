@@ -25,7 +25,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
 )
 
 // blobOpenFile describes a single open blob as a File.
@@ -41,26 +43,35 @@ type blobOpenFile struct {
 	ctx       context.Context
 }
 
+// ReadAt reads len(p) bytes at offset off with a single ranged read of the
+// blob, so that e.g. Parquet footers can be read without fetching the whole
+// object. The range is clamped to the size of the blob.
 func (f *blobOpenFile) ReadAt(p []byte, off int64) (int, error) {
-	rdr, err := f.b.Bucket.NewRangeReader(f.ctx, f.key, off, int64(len(p)), nil)
+	if off < 0 {
+		return 0, &fs.PathError{Op: "readat", Path: f.name, Err: fs.ErrInvalid}
+	}
+
+	length := min(int64(len(p)), f.Size()-off)
+	if length <= 0 {
+		return 0, io.EOF
+	}
+
+	rdr, err := f.b.Bucket.NewRangeReader(f.ctx, f.key, off, length, nil)
 	if err != nil {
 		return 0, err
 	}
+	defer rdr.Close()
 
-	// ensure the buffer is read, or EOF is reached for this read of this "chunk"
-	// given we are using offsets to read this block, it is constrained by size of 'p'
-	size, err := io.ReadFull(rdr, p)
+	n, err := io.ReadFull(rdr, p[:length])
 	if err != nil {
-		if errors.Is(err, io.EOF) {
-			return size, err
-		}
-		// check we are at the end of the underlying file
-		if off+int64(size) > f.Size() {
-			return size, err
-		}
+		return n, err
+	}
+
+	if n < len(p) {
+		return n, io.EOF
 	}
 
-	return size, rdr.Close()
+	return n, nil
 }
 
 // Functions to implement the `Stat()` function in the `io/fs.File` interface
@@ -103,14 +114,46 @@ func (bfs *blobFileIO) Open(path string) (File, error) {
 	return &blobOpenFile{Reader: r, name: name, key: key, b: bfs, ctx: bfs.ctx}, nil
 }
 
+// Remove deletes the named blob. Removing a blob which doesn't exist is not
+// an error, so that cleaning up after a failed commit is idempotent.
 func (bfs *blobFileIO) Remove(name string) error {
 	name = bfs.preprocess(name)
 
-	return bfs.Bucket.Delete(bfs.ctx, name)
+	err := bfs.Bucket.Delete(bfs.ctx, name)
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return nil
+	}
+
+	return err
+}
+
+// DeleteBatch deletes the named blobs. Blobs of an S3 bucket are deleted
+// with as few DeleteObjects requests as possible, while blobs of other
+// buckets are deleted one at a time.
+func (bfs *blobFileIO) DeleteBatch(ctx context.Context, paths []string) error {
+	var client *s3.Client
+	if bfs.Bucket.As(&client) {
+		return s3DeleteBatch(ctx, client, bfs.bucketName, paths, bfs.preprocess)
+	}
+
+	var errs []error
+	for _, path := range paths {
+		err := bfs.Bucket.Delete(ctx, bfs.preprocess(path))
+		if err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+			errs = append(errs, deleteError(path, err))
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 func (bfs *blobFileIO) Create(name string) (FileWriter, error) {
-	return bfs.NewWriter(bfs.ctx, name, true, nil)
+	w, err := bfs.NewWriter(bfs.ctx, name, true, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
 }
 
 func (bfs *blobFileIO) WriteFile(name string, content []byte) error {
@@ -125,8 +168,9 @@ func (bfs *blobFileIO) WriteFile(name string, content []byte) error {
 // If overwrite is disabled and a blob with this path already exists,
 // an error will be returned.
 //
-// The caller must call Close on the returned Writer, even if the write is
-// aborted.
+// The caller must call Close, or Abort to abandon the write, on the
+// returned Writer. The blob is only created once Close succeeds, and not at
+// all if a write failed, so readers never observe partially written content.
 func (io *blobFileIO) NewWriter(ctx context.Context, path string, overwrite bool, opts *blob.WriterOptions) (w *blobWriteFile, err error) {
 	path = io.preprocess(path)
 	if !fs.ValidPath(path) {
@@ -142,14 +186,20 @@ func (io *blobFileIO) NewWriter(ctx context.Context, path string, overwrite bool
 			return nil, &fs.PathError{Op: "new writer", Path: path, Err: fs.ErrInvalid}
 		}
 	}
+	// canceling the context before Close aborts the upload
+	ctx, cancel := context.WithCancel(ctx)
 	bw, err := io.Bucket.NewWriter(ctx, path, opts)
 	if err != nil {
+		cancel()
+
 		return nil, err
 	}
 
 	return &blobWriteFile{
 			Writer: bw,
 			name:   path,
+			b:      io,
+			cancel: cancel,
 		},
 		nil
 }
@@ -160,11 +210,44 @@ func createBlobFS(ctx context.Context, bucket *blob.Bucket, bucketName string) I
 
 type blobWriteFile struct {
 	*blob.Writer
-	name string
-	b    *blobFileIO
+	name   string
+	b      *blobFileIO
+	cancel context.CancelFunc
+}
+
+func (f *blobWriteFile) Name() string     { return f.name }
+func (f *blobWriteFile) Sys() interface{} { return f.b }
+
+func (f *blobWriteFile) Close() error {
+	defer f.cancel()
+
+	return f.Writer.Close()
+}
+
+// Abort cancels the upload, so that the blob isn't created.
+func (f *blobWriteFile) Abort() error {
+	f.cancel()
+	// closing a writer whose context is canceled only reports the
+	// cancellation
+	f.Writer.Close()
+
+	return nil
 }
 
-func (f *blobWriteFile) Name() string                { return f.name }
-func (f *blobWriteFile) Sys() interface{}            { return f.b }
-func (f *blobWriteFile) Close() error                { return f.Writer.Close() }
-func (f *blobWriteFile) Write(p []byte) (int, error) { return f.Writer.Write(p) }
+func (f *blobWriteFile) Write(p []byte) (int, error) {
+	n, err := f.Writer.Write(p)
+	if err != nil {
+		f.cancel()
+	}
+
+	return n, err
+}
+
+func (f *blobWriteFile) ReadFrom(r io.Reader) (int64, error) {
+	n, err := f.Writer.ReadFrom(r)
+	if err != nil {
+		f.cancel()
+	}
+
+	return n, err
+}
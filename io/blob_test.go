@@ -0,0 +1,103 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	icebergio "github.com/apache/iceberg-go/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobReadAt(t *testing.T) {
+	bfs, err := icebergio.LoadFS(context.Background(), nil, "mem://bucket/")
+	require.NoError(t, err)
+
+	wfs := bfs.(icebergio.WriteFileIO)
+	require.NoError(t, wfs.WriteFile("mem://bucket/data/file.bin", []byte("0123456789")))
+
+	f, err := bfs.Open("mem://bucket/data/file.bin")
+	require.NoError(t, err)
+	defer f.Close()
+
+	buf := make([]byte, 4)
+	n, err := f.ReadAt(buf, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "3456", string(buf[:n]))
+
+	// a read past the end of the blob is clamped to its size
+	n, err = f.ReadAt(buf, 8)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, "89", string(buf[:n]))
+
+	n, err = f.ReadAt(buf, 10)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Zero(t, n)
+
+	_, err = f.ReadAt(buf, -1)
+	assert.Error(t, err)
+
+	// ranged reads don't affect sequential reads of the file
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(content))
+}
+
+func TestBlobWriteAndRemove(t *testing.T) {
+	bfs, err := icebergio.LoadFS(context.Background(), nil, "mem://bucket/")
+	require.NoError(t, err)
+
+	wfs := bfs.(icebergio.WriteFileIO)
+	w, err := wfs.Create("mem://bucket/metadata/00001.metadata.json")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(`{"format-version": 2}`))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	f, err := bfs.Open("mem://bucket/metadata/00001.metadata.json")
+	require.NoError(t, err)
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.JSONEq(t, `{"format-version": 2}`, string(content))
+
+	require.NoError(t, bfs.Remove("mem://bucket/metadata/00001.metadata.json"))
+	_, err = bfs.Open("mem://bucket/metadata/00001.metadata.json")
+	assert.Error(t, err)
+
+	// removing a blob which doesn't exist succeeds
+	assert.NoError(t, bfs.Remove("mem://bucket/metadata/00001.metadata.json"))
+}
+
+func TestBlobAbort(t *testing.T) {
+	bfs, err := icebergio.LoadFS(context.Background(), nil, "mem://bucket/")
+	require.NoError(t, err)
+
+	wfs := bfs.(icebergio.WriteFileIO)
+	w, err := wfs.Create("mem://bucket/data/file.parquet")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("partial"))
+	require.NoError(t, err)
+	require.NoError(t, icebergio.AbortWrite(w))
+
+	_, err = bfs.Open("mem://bucket/data/file.parquet")
+	assert.Error(t, err)
+}
@@ -19,13 +19,16 @@ package io
 
 import (
 	"context"
+	"net/http"
 	"net/url"
+	"os"
 
 	"cloud.google.com/go/storage"
 
 	"gocloud.dev/blob"
 	"gocloud.dev/blob/gcsblob"
 	"gocloud.dev/gcp"
+	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 )
 
@@ -34,6 +37,7 @@ const (
 	GCSEndpoint   = "gcs.endpoint"
 	GCSKeyPath    = "gcs.keypath"
 	GCSJSONKey    = "gcs.jsonkey"
+	GCSProjectID  = "gcs.project-id" // project billed for requests
 	GCSUseJsonAPI = "gcs.usejsonapi" // set to anything to enable
 )
 
@@ -58,15 +62,54 @@ func ParseGCSConfig(props map[string]string) *gcsblob.Options {
 	}
 }
 
+// gcsCredentials returns the credentials configured by the GCSJSONKey or
+// GCSKeyPath properties, falling back to the application default
+// credentials. It returns nil if there are none, in which case requests
+// are made anonymously.
+func gcsCredentials(ctx context.Context, props map[string]string) (*google.Credentials, error) {
+	key := []byte(props[GCSJSONKey])
+	if path := props[GCSKeyPath]; len(key) == 0 && path != "" {
+		var err error
+		if key, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(key) > 0 {
+		return google.CredentialsFromJSON(ctx, key, storage.ScopeReadWrite)
+	}
+
+	creds, _ := gcp.DefaultCredentials(ctx)
+
+	return creds, nil
+}
+
+// quotaProjectTransport sets the project which is billed for the requests,
+// e.g. for requester pays buckets.
+type quotaProjectTransport struct {
+	base    http.RoundTripper
+	project string
+}
+
+func (t *quotaProjectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Goog-User-Project", t.project)
+
+	return t.base.RoundTrip(req)
+}
+
 // Construct a GCS bucket from a URL
 func createGCSBucket(ctx context.Context, parsed *url.URL, props map[string]string) (*blob.Bucket, error) {
 	gcscfg := ParseGCSConfig(props)
-	creds, _ := gcp.DefaultCredentials(ctx)
+	creds, err := gcsCredentials(ctx, props)
+	if err != nil {
+		return nil, err
+	}
+
 	var client *gcp.HTTPClient
 	if creds == nil {
 		client = gcp.NewAnonymousHTTPClient(gcp.DefaultTransport())
 	} else {
-		var err error
 		client, err = gcp.NewHTTPClient(
 			gcp.DefaultTransport(),
 			gcp.CredentialsTokenSource(creds))
@@ -75,6 +118,14 @@ func createGCSBucket(ctx context.Context, parsed *url.URL, props map[string]stri
 		}
 	}
 
+	if project := props[GCSProjectID]; project != "" {
+		base := client.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		client.Transport = &quotaProjectTransport{base: base, project: project}
+	}
+
 	bucket, err := gcsblob.OpenBucket(ctx, client, parsed.Host, gcscfg)
 	if err != nil {
 		return nil, err
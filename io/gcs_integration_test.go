@@ -24,6 +24,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	goio "io"
 	"net/http"
 	"net/url"
 	"testing"
@@ -139,6 +140,65 @@ func (s *GCSIOTestSuite) TestGCSWarehouse() {
 	s.Require().NotNil(tbl)
 }
 
+func (s *GCSIOTestSuite) loadFS() io.IO {
+	fs, err := io.LoadFS(s.ctx, map[string]string{
+		io.GCSEndpoint:   fmt.Sprintf("http://%s/", gcsEndpoint),
+		io.GCSUseJsonAPI: "true",
+	}, fmt.Sprintf("gs://%s/", gcsBucketName))
+	s.Require().NoError(err)
+
+	return fs
+}
+
+func (s *GCSIOTestSuite) TestGCSRangedReads() {
+	fs := s.loadFS()
+	loc := fmt.Sprintf("gs://%s/data/file.bin", gcsBucketName)
+	s.Require().NoError(fs.(io.WriteFileIO).WriteFile(loc, []byte("0123456789")))
+
+	f, err := fs.Open(loc)
+	s.Require().NoError(err)
+	defer f.Close()
+
+	buf := make([]byte, 4)
+	n, err := f.ReadAt(buf, 3)
+	s.Require().NoError(err)
+	s.Equal("3456", string(buf[:n]))
+
+	// e.g. reading the last bytes of a Parquet footer past the end
+	n, err = f.ReadAt(buf, 8)
+	s.ErrorIs(err, goio.EOF)
+	s.Equal("89", string(buf[:n]))
+}
+
+func (s *GCSIOTestSuite) TestGCSMetadataUpload() {
+	fs := s.loadFS()
+	loc := fmt.Sprintf("gs://%s/metadata/00001.metadata.json", gcsBucketName)
+
+	w, err := fs.(io.WriteFileIO).Create(loc)
+	s.Require().NoError(err)
+	_, err = w.Write([]byte(`{"format-version": 2,`))
+	s.Require().NoError(err)
+
+	// the object isn't visible until the upload is completed
+	_, err = fs.Open(loc)
+	s.Error(err)
+
+	_, err = w.Write([]byte(` "table-uuid": "x"}`))
+	s.Require().NoError(err)
+	s.Require().NoError(w.Close())
+
+	f, err := fs.Open(loc)
+	s.Require().NoError(err)
+	content, err := goio.ReadAll(f)
+	s.Require().NoError(err)
+	s.Require().NoError(f.Close())
+	s.JSONEq(`{"format-version": 2, "table-uuid": "x"}`, string(content))
+
+	s.Require().NoError(fs.Remove(loc))
+	// deleting an object which doesn't exist succeeds
+	s.NoError(fs.Remove(loc))
+}
+
 func TestGCSIOIntegration(t *testing.T) {
 	suite.Run(t, new(GCSIOTestSuite))
 }
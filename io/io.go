@@ -82,6 +82,51 @@ type WriteFileIO interface {
 	WriteFile(name string, p []byte) error
 }
 
+// DeleteBatchIO is the interface implemented by a file system that can
+// delete many files with fewer requests than removing them one by one.
+type DeleteBatchIO interface {
+	IO
+
+	// DeleteBatch deletes the named files. Files which don't exist are
+	// not an error. If some of the files couldn't be deleted, the error
+	// joins a *PathError naming each of them.
+	DeleteBatch(ctx context.Context, paths []string) error
+}
+
+// DeleteBatch deletes the named files, using fsys.DeleteBatch if fsys
+// implements DeleteBatchIO and removing the files one at a time
+// otherwise. Files which don't exist are not an error, and deleting
+// continues past failures, which are returned joined together.
+func DeleteBatch(ctx context.Context, fsys IO, paths []string) error {
+	if dfs, ok := fsys.(DeleteBatchIO); ok {
+		return dfs.DeleteBatch(ctx, paths)
+	}
+
+	var errs []error
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		if err := fsys.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, deleteError(path, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deleteError wraps err in a *PathError for path, unless it already is
+// one.
+func deleteError(path string, err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return err
+	}
+
+	return &fs.PathError{Op: "delete", Path: path, Err: err}
+}
+
 // A File provides access to a single file. The File interface is the
 // minimum implementation required for Iceberg to interact with a file.
 // Directory files should also implement
@@ -97,6 +142,26 @@ type FileWriter interface {
 	io.ReaderFrom
 }
 
+// AbortFileWriter is the interface implemented by a FileWriter which
+// can abandon its write, so that nothing is published under its name.
+type AbortFileWriter interface {
+	FileWriter
+
+	// Abort discards what was written and releases the writer, which
+	// must not be used or closed afterwards.
+	Abort() error
+}
+
+// AbortWrite abandons the write of w if it implements AbortFileWriter,
+// and otherwise closes it.
+func AbortWrite(w FileWriter) error {
+	if aw, ok := w.(AbortFileWriter); ok {
+		return aw.Abort()
+	}
+
+	return w.Close()
+}
+
 // A ReadDirFile is a directory file whose entries can be read with the
 // ReadDir method. Every directory file should implement this interface.
 // (It is permissible for any file to implement this interface, but
@@ -267,7 +332,14 @@ func inferFileIOFromSchema(ctx context.Context, path string, props map[string]st
 		return nil, fmt.Errorf("IO for file '%s' not implemented", path)
 	}
 
-	return createBlobFS(ctx, bucket, parsed.Host), nil
+	// locations such as abfss://container@account.dfs.core.windows.net/path
+	// identify the bucket by their whole authority
+	bucketName := parsed.Host
+	if parsed.User != nil {
+		bucketName = parsed.User.String() + "@" + parsed.Host
+	}
+
+	return createBlobFS(ctx, bucket, bucketName), nil
 }
 
 // LoadFS takes a map of properties and an optional URI location
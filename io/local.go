@@ -18,6 +18,8 @@
 package io
 
 import (
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -25,25 +27,93 @@ import (
 
 // LocalFS is an implementation of IO that implements interaction with
 // the local file system.
+//
+// Files are written atomically: content is written to a temporary file in
+// the same directory which is only renamed to the target name once the
+// write completes, so that readers never observe a partially written file,
+// such as a table metadata file being committed.
 type LocalFS struct{}
 
 func (LocalFS) Open(name string) (File, error) {
 	return os.Open(strings.TrimPrefix(name, "file://"))
 }
 
+// Create returns a writer for the named file. The file only becomes
+// visible under its name once the writer is closed successfully.
 func (LocalFS) Create(name string) (FileWriter, error) {
 	filename := strings.TrimPrefix(name, "file://")
 	if err := os.MkdirAll(filepath.Dir(filename), 0o777); err != nil {
 		return nil, err
 	}
 
-	return os.Create(filename)
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+
+	f := &atomicFile{File: tmp, name: filename}
+	// CreateTemp restricts the file to the current user
+	if err := tmp.Chmod(0o644); err != nil {
+		f.Abort()
+
+		return nil, err
+	}
+
+	return f, nil
 }
 
-func (LocalFS) WriteFile(name string, content []byte) error {
-	return os.WriteFile(strings.TrimPrefix(name, "file://"), content, 0o777)
+func (l LocalFS) WriteFile(name string, content []byte) error {
+	f, err := l.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(content); err != nil {
+		f.(*atomicFile).Abort()
+
+		return err
+	}
+
+	return f.Close()
 }
 
+// Remove removes the named file. Removing a file which doesn't exist is
+// not an error, so that cleaning up after a failed commit is idempotent.
 func (LocalFS) Remove(name string) error {
-	return os.Remove(strings.TrimPrefix(name, "file://"))
+	err := os.Remove(strings.TrimPrefix(name, "file://"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// atomicFile is a temporary file which is renamed to name when closed.
+type atomicFile struct {
+	*os.File
+
+	name string
+}
+
+func (f *atomicFile) Close() error {
+	if err := f.File.Close(); err != nil {
+		os.Remove(f.File.Name())
+
+		return err
+	}
+
+	if err := os.Rename(f.File.Name(), f.name); err != nil {
+		os.Remove(f.File.Name())
+
+		return err
+	}
+
+	return nil
+}
+
+// Abort closes and removes the temporary file without renaming it.
+func (f *atomicFile) Abort() error {
+	f.File.Close()
+
+	return os.Remove(f.File.Name())
 }
@@ -0,0 +1,140 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	icebergio "github.com/apache/iceberg-go/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalFSAtomicCreate(t *testing.T) {
+	dir := t.TempDir()
+	loc := "file://" + filepath.Join(dir, "metadata", "00001-abc.metadata.json")
+	lfs := icebergio.LocalFS{}
+
+	w, err := lfs.Create(loc)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(`{"format-version": 2,`))
+	require.NoError(t, err)
+
+	// simulate a crash before the write completes: the partially written
+	// temporary file is left behind but is never visible under its name
+	_, err = lfs.Open(loc)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	entries, err := os.ReadDir(filepath.Join(dir, "metadata"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.NotEqual(t, "00001-abc.metadata.json", entries[0].Name())
+
+	_, err = w.Write([]byte(` "table-uuid": "x"}`))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	f, err := lfs.Open(loc)
+	require.NoError(t, err)
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"format-version": 2, "table-uuid": "x"}`, string(content))
+
+	buf := make([]byte, 16)
+	_, err = f.ReadAt(buf, 1)
+	require.NoError(t, err)
+	assert.Equal(t, `"format-version"`, string(buf))
+
+	entries, err = os.ReadDir(filepath.Join(dir, "metadata"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestLocalFSAtomicOverwrite(t *testing.T) {
+	loc := filepath.Join(t.TempDir(), "data.bin")
+	lfs := icebergio.LocalFS{}
+	require.NoError(t, lfs.WriteFile(loc, []byte("old")))
+
+	w, err := lfs.Create(loc)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("new content"))
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(loc)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(content))
+
+	require.NoError(t, w.Close())
+	content, err = os.ReadFile(loc)
+	require.NoError(t, err)
+	assert.Equal(t, "new content", string(content))
+}
+
+func TestLocalFSAbort(t *testing.T) {
+	dir := t.TempDir()
+	loc := filepath.Join(dir, "data.parquet")
+	lfs := icebergio.LocalFS{}
+
+	w, err := lfs.Create(loc)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("partial"))
+	require.NoError(t, err)
+	require.NoError(t, icebergio.AbortWrite(w))
+
+	// neither the file nor its temporary file is left behind
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLocalFSRemoveMissing(t *testing.T) {
+	lfs := icebergio.LocalFS{}
+	loc := filepath.Join(t.TempDir(), "missing.avro")
+	assert.NoError(t, lfs.Remove(loc))
+
+	require.NoError(t, lfs.WriteFile(loc, []byte("data")))
+	assert.NoError(t, lfs.Remove(loc))
+	_, err := os.Stat(loc)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestLocalFSDeleteBatch(t *testing.T) {
+	dir := t.TempDir()
+	lfs := icebergio.LocalFS{}
+
+	paths := make([]string, 3)
+	for i := range paths {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("file-%d", i))
+		require.NoError(t, os.WriteFile(paths[i], []byte("data"), 0o644))
+	}
+
+	// LocalFS removes the files one at a time, skipping missing files
+	require.NoError(t, icebergio.DeleteBatch(context.Background(), lfs,
+		append(paths, filepath.Join(dir, "missing"))))
+	for _, p := range paths {
+		assert.NoFileExists(t, p)
+	}
+}
@@ -0,0 +1,102 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// DefaultMaxRangeGap is the default maximum number of unrequested bytes
+// between two ranges for ReadRanges to fetch them with a single read.
+const DefaultMaxRangeGap = 8 << 10
+
+// ByteRange is a contiguous range of bytes within a file.
+type ByteRange struct {
+	Offset int64
+	Length int64
+}
+
+func (r ByteRange) end() int64 { return r.Offset + r.Length }
+
+// ReadRanges reads the given byte ranges from r, returning the contents of
+// each range in the same order as ranges.
+//
+// Ranges which overlap or are separated by at most maxGap bytes are
+// coalesced and fetched with a single ReadAt call. For object stores, where
+// every ReadAt is a ranged GET request, this trades reading a few unneeded
+// bytes for fewer requests, e.g. when reading the column chunks of a
+// Parquet row group. The returned slices may share memory.
+func ReadRanges(r io.ReaderAt, ranges []ByteRange, maxGap int64) ([][]byte, error) {
+	for _, rng := range ranges {
+		if rng.Offset < 0 || rng.Length < 0 {
+			return nil, fmt.Errorf("invalid byte range: offset %d, length %d", rng.Offset, rng.Length)
+		}
+	}
+
+	out := make([][]byte, len(ranges))
+	for i := range out {
+		out[i] = []byte{}
+	}
+
+	for _, merged := range coalesceRanges(ranges, maxGap) {
+		buf := make([]byte, merged.Length)
+		n, err := r.ReadAt(buf, merged.Offset)
+		if err != nil && (!errors.Is(err, io.EOF) || n < len(buf)) {
+			return nil, err
+		}
+
+		for i, rng := range ranges {
+			if rng.Offset >= merged.Offset && rng.end() <= merged.end() {
+				start := rng.Offset - merged.Offset
+				out[i] = buf[start : start+rng.Length : start+rng.Length]
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// coalesceRanges returns the sorted ranges covering all of the given
+// non-empty ranges, merging those which overlap or are at most maxGap
+// bytes apart.
+func coalesceRanges(ranges []ByteRange, maxGap int64) []ByteRange {
+	sorted := slices.SortedFunc(slices.Values(ranges), func(a, b ByteRange) int {
+		return cmp.Compare(a.Offset, b.Offset)
+	})
+
+	var merged []ByteRange
+	for _, rng := range sorted {
+		if rng.Length == 0 {
+			continue
+		}
+
+		if n := len(merged); n > 0 && rng.Offset <= merged[n-1].end()+maxGap {
+			last := &merged[n-1]
+			last.Length = max(last.end(), rng.end()) - last.Offset
+
+			continue
+		}
+		merged = append(merged, rng)
+	}
+
+	return merged
+}
@@ -0,0 +1,124 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io_test
+
+import (
+	"io"
+	"testing"
+
+	icebergio "github.com/apache/iceberg-go/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingReaderAt struct {
+	io.ReaderAt
+	reads []icebergio.ByteRange
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	c.reads = append(c.reads, icebergio.ByteRange{Offset: off, Length: int64(len(p))})
+
+	return c.ReaderAt.ReadAt(p, off)
+}
+
+// openS3File opens content as an object of the mock S3 bucket, and
+// returns the mock with the requests done to open it cleared.
+func openS3File(t *testing.T, content []byte) (*mockS3, icebergio.File) {
+	t.Helper()
+
+	mock, fsys := newMockS3FS(t)
+	mock.objects["data.bin"] = content
+
+	f, err := fsys.Open("s3://bucket/data.bin")
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	mock.ranges = nil
+
+	return mock, f
+}
+
+func TestS3RangedRead(t *testing.T) {
+	content := make([]byte, 1000)
+	for i := range content {
+		content[i] = byte(i % 251)
+	}
+
+	mock, f := openS3File(t, content)
+
+	// footer-style read of the last bytes of the file
+	footer := make([]byte, 8)
+	n, err := f.ReadAt(footer, int64(len(content)-8))
+	require.NoError(t, err)
+	assert.Equal(t, 8, n)
+	assert.Equal(t, content[len(content)-8:], footer)
+
+	mid := make([]byte, 100)
+	n, err = f.ReadAt(mid, 300)
+	require.NoError(t, err)
+	assert.Equal(t, 100, n)
+	assert.Equal(t, content[300:400], mid)
+
+	// reads past the end of the object are clamped to it
+	tail := make([]byte, 10)
+	n, err = f.ReadAt(tail, 995)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, content[995:], tail[:n])
+
+	assert.Equal(t, []string{"bytes=992-999", "bytes=300-399", "bytes=995-999"}, mock.ranges)
+}
+
+func TestReadRangesCoalesces(t *testing.T) {
+	content := make([]byte, 1000)
+	for i := range content {
+		content[i] = byte(i % 251)
+	}
+
+	mock, f := openS3File(t, content)
+	rdr := &countingReaderAt{ReaderAt: f}
+	ranges := []icebergio.ByteRange{
+		{Offset: 900, Length: 100},
+		{Offset: 10, Length: 20},
+		{Offset: 40, Length: 10},
+		{Offset: 45, Length: 30},
+		{Offset: 500, Length: 0},
+	}
+
+	out, err := icebergio.ReadRanges(rdr, ranges, 16)
+	require.NoError(t, err)
+	require.Len(t, out, len(ranges))
+	for i, rng := range ranges {
+		assert.Equal(t, content[rng.Offset:rng.Offset+rng.Length], out[i], "range %d", i)
+	}
+
+	assert.Equal(t, []icebergio.ByteRange{
+		{Offset: 10, Length: 65},
+		{Offset: 900, Length: 100},
+	}, rdr.reads)
+	// each coalesced read is a single ranged GET
+	assert.Equal(t, []string{"bytes=10-74", "bytes=900-999"}, mock.ranges)
+
+	rdr.reads = nil
+	_, err = icebergio.ReadRanges(rdr, ranges[1:3], 0)
+	require.NoError(t, err)
+	assert.Len(t, rdr.reads, 2)
+
+	_, err = icebergio.ReadRanges(rdr, []icebergio.ByteRange{{Offset: -1, Length: 1}}, 0)
+	assert.Error(t, err)
+}
@@ -19,7 +19,9 @@ package io
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"net/url"
 	"os"
@@ -32,6 +34,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go/auth/bearer"
 	"gocloud.dev/blob"
 	"gocloud.dev/blob/s3blob"
@@ -149,3 +152,53 @@ func createS3Bucket(ctx context.Context, parsed *url.URL, props map[string]strin
 
 	return bucket, nil
 }
+
+// s3DeleteBatchSize is the maximum number of keys of a DeleteObjects
+// request.
+const s3DeleteBatchSize = 1000
+
+// s3DeleteBatch deletes the objects at paths from bucket with
+// DeleteObjects requests of up to s3DeleteBatchSize keys, toKey converting
+// each path to its object key. Keys which the response reports as failed
+// are returned as a *fs.PathError for their path, as are all the paths of
+// a request which failed as a whole.
+func s3DeleteBatch(ctx context.Context, client *s3.Client, bucket string, paths []string, toKey func(string) string) error {
+	var errs []error
+	for batch := range slices.Chunk(paths, s3DeleteBatchSize) {
+		keyToPath := make(map[string]string, len(batch))
+		objects := make([]types.ObjectIdentifier, len(batch))
+		for i, path := range batch {
+			key := toKey(path)
+			keyToPath[key] = path
+			objects[i] = types.ObjectIdentifier{Key: aws.String(key)}
+		}
+
+		out, err := client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			for _, path := range batch {
+				errs = append(errs, &fs.PathError{Op: "delete", Path: path, Err: err})
+			}
+
+			continue
+		}
+
+		for _, e := range out.Errors {
+			key := aws.ToString(e.Key)
+			path, ok := keyToPath[key]
+			if !ok {
+				path = key
+			}
+
+			errs = append(errs, &fs.PathError{
+				Op:   "delete",
+				Path: path,
+				Err:  fmt.Errorf("%s: %s", aws.ToString(e.Code), aws.ToString(e.Message)),
+			})
+		}
+	}
+
+	return errors.Join(errs...)
+}
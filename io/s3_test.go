@@ -0,0 +1,173 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	icebergio "github.com/apache/iceberg-go/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockS3 serves the objects of a single bucket to GET requests, which
+// may be ranged, and HEAD requests, recording the Range header of each
+// GET. It also serves DeleteObjects requests, recording the keys of each
+// request and failing the deletion of any key containing "denied".
+type mockS3 struct {
+	mx       sync.Mutex
+	requests [][]string
+	objects  map[string][]byte
+	ranges   []string
+}
+
+func (m *mockS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		m.serveObject(w, r)
+
+		return
+	}
+
+	if r.Method != http.MethodPost || r.URL.Path != "/bucket" || !r.URL.Query().Has("delete") {
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+
+		return
+	}
+
+	var req struct {
+		Objects []struct {
+			Key string `xml:"Key"`
+		} `xml:"Object"`
+	}
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	keys := make([]string, len(req.Objects))
+	var result strings.Builder
+	result.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	result.WriteString(`<DeleteResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">`)
+	for i, obj := range req.Objects {
+		keys[i] = obj.Key
+		if strings.Contains(obj.Key, "denied") {
+			fmt.Fprintf(&result, "<Error><Key>%s</Key><Code>AccessDenied</Code><Message>Access Denied</Message></Error>", obj.Key)
+		}
+	}
+	result.WriteString(`</DeleteResult>`)
+
+	m.mx.Lock()
+	m.requests = append(m.requests, keys)
+	m.mx.Unlock()
+
+	w.Header().Set("Content-Type", "application/xml")
+	fmt.Fprint(w, result.String())
+}
+
+func (m *mockS3) serveObject(w http.ResponseWriter, r *http.Request) {
+	key, _ := strings.CutPrefix(r.URL.Path, "/bucket/")
+
+	m.mx.Lock()
+	data, ok := m.objects[key]
+	if r.Method == http.MethodGet {
+		m.ranges = append(m.ranges, r.Header.Get("Range"))
+	}
+	m.mx.Unlock()
+
+	if !ok {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>`+
+			`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+
+		return
+	}
+
+	// ServeContent answers ranged GETs with the requested bytes
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("ETag", `"mock"`)
+	http.ServeContent(w, r, key, time.Unix(0, 0), bytes.NewReader(data))
+}
+
+func newMockS3FS(t *testing.T) (*mockS3, icebergio.IO) {
+	mock := &mockS3{objects: make(map[string][]byte)}
+	srv := httptest.NewServer(mock)
+	t.Cleanup(srv.Close)
+
+	fsys, err := icebergio.LoadFS(context.Background(), map[string]string{
+		icebergio.S3Region:          "us-east-1",
+		icebergio.S3AccessKeyID:     "access-key",
+		icebergio.S3SecretAccessKey: "secret-key",
+		icebergio.S3EndpointURL:     srv.URL,
+	}, "s3://bucket/")
+	require.NoError(t, err)
+
+	return mock, fsys
+}
+
+func TestS3DeleteBatch(t *testing.T) {
+	mock, fsys := newMockS3FS(t)
+	require.Implements(t, (*icebergio.DeleteBatchIO)(nil), fsys)
+
+	paths := make([]string, 1001)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("s3://bucket/data/file-%04d.parquet", i)
+	}
+
+	require.NoError(t, icebergio.DeleteBatch(context.Background(), fsys, paths))
+
+	// S3 deletes at most 1000 keys per request
+	require.Len(t, mock.requests, 2)
+	assert.Len(t, mock.requests[0], 1000)
+	assert.Len(t, mock.requests[1], 1)
+	assert.Equal(t, "data/file-0000.parquet", mock.requests[0][0])
+	assert.Equal(t, "data/file-1000.parquet", mock.requests[1][0])
+}
+
+func TestS3DeleteBatchPartialFailure(t *testing.T) {
+	_, fsys := newMockS3FS(t)
+
+	err := icebergio.DeleteBatch(context.Background(), fsys, []string{
+		"s3://bucket/data/a.parquet",
+		"s3://bucket/data/denied-1.parquet",
+		"s3://bucket/data/b.parquet",
+		"s3://bucket/data/denied-2.parquet",
+	})
+	require.Error(t, err)
+
+	var failed []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var pathErr *fs.PathError
+		require.True(t, errors.As(e, &pathErr))
+		failed = append(failed, pathErr.Path)
+	}
+	assert.Equal(t, []string{"s3://bucket/data/denied-1.parquet", "s3://bucket/data/denied-2.parquet"}, failed)
+	assert.ErrorContains(t, err, "AccessDenied")
+}
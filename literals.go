@@ -28,7 +28,9 @@ import (
 	"math/big"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 	"unsafe"
 
 	"github.com/apache/arrow-go/v18/arrow"
@@ -109,6 +111,196 @@ func NewLiteral[T LiteralType](val T) Literal {
 	panic("can't happen due to literal type constraint")
 }
 
+// literalOf returns the literal for a value of one of the Go types used
+// for literal values, e.g. int32 or Date.
+func literalOf(val any) (Literal, error) {
+	switch v := val.(type) {
+	case bool:
+		return NewLiteral(v), nil
+	case int32:
+		return NewLiteral(v), nil
+	case int64:
+		return NewLiteral(v), nil
+	case float32:
+		return NewLiteral(v), nil
+	case float64:
+		return NewLiteral(v), nil
+	case Date:
+		return NewLiteral(v), nil
+	case Time:
+		return NewLiteral(v), nil
+	case Timestamp:
+		return NewLiteral(v), nil
+	case string:
+		return NewLiteral(v), nil
+	case []byte:
+		return NewLiteral(v), nil
+	case uuid.UUID:
+		return NewLiteral(v), nil
+	case Decimal:
+		return NewLiteral(v), nil
+	}
+
+	return nil, fmt.Errorf("%w: unsupported literal value %v (%T)", ErrInvalidArgument, val, val)
+}
+
+// TruncateLowerBound truncates a string or binary lower bound to at most
+// length unicode code points, respectively bytes. A prefix is never
+// greater than the value, so the result is still a valid lower bound.
+// Literals of other types are returned unchanged.
+func TruncateLowerBound(lit Literal, length int) Literal {
+	switch v := lit.(type) {
+	case StringLiteral:
+		return StringLiteral(truncateString(string(v), length))
+	case BinaryLiteral:
+		if len(v) > length {
+			return BinaryLiteral(bytes.Clone(v[:length]))
+		}
+	}
+
+	return lit
+}
+
+// TruncateUpperBound truncates a string or binary upper bound to at most
+// length unicode code points, respectively bytes. If the value is longer
+// than length, the last code point or byte of the prefix which can be
+// incremented is incremented, so that the result remains greater than the
+// value. The returned bool is false if there is no such truncated upper
+// bound, e.g. when the first length bytes are all 0xFF, in which case the
+// bound should be omitted. Literals of other types are returned unchanged.
+func TruncateUpperBound(lit Literal, length int) (Literal, bool) {
+	switch v := lit.(type) {
+	case StringLiteral:
+		if utf8.RuneCountInString(string(v)) <= length {
+			return lit, true
+		}
+
+		result := []rune(string(v))[:length]
+		for i := len(result) - 1; i >= 0; i-- {
+			next := result[i] + 1
+			if next >= 0xD800 && next <= 0xDFFF {
+				// skip the surrogates, which aren't valid code points
+				next = 0xE000
+			}
+
+			if next <= utf8.MaxRune {
+				result[i] = next
+
+				return StringLiteral(result), true
+			}
+		}
+
+		return nil, false
+	case BinaryLiteral:
+		if len(v) <= length {
+			return lit, true
+		}
+
+		result := bytes.Clone(v[:length])
+		for i := len(result) - 1; i >= 0; i-- {
+			if result[i] < math.MaxUint8 {
+				result[i]++
+
+				return BinaryLiteral(result), true
+			}
+		}
+
+		return nil, false
+	}
+
+	return lit, true
+}
+
+// compareFloats orders floating point values as defined by the spec for
+// sorting: -NaN < -Infinity < -value < -0 < 0 < value < Infinity < NaN
+func compareFloats[T float32 | float64](v1, v2 T) int {
+	nanRank := func(v T) int {
+		switch {
+		case !math.IsNaN(float64(v)):
+			return 0
+		case math.Signbit(float64(v)):
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	if r1, r2 := nanRank(v1), nanRank(v2); r1 != 0 || r2 != 0 {
+		return cmp.Compare(r1, r2)
+	}
+
+	switch {
+	case v1 < v2:
+		return -1
+	case v1 > v2:
+		return 1
+	}
+
+	// distinguish -0 and 0, which compare as equal
+	return cmp.Compare(signValue(v2), signValue(v1))
+}
+
+func signValue[T float32 | float64](v T) int {
+	if math.Signbit(float64(v)) {
+		return 1
+	}
+
+	return 0
+}
+
+// CompareLiterals compares two literals of the same primitive type,
+// returning 0 if they are equal, a negative value if lhs sorts before rhs
+// and a positive value otherwise. If rhs is of a different type it is
+// first converted to the type of lhs, an error is returned if that isn't
+// possible.
+func CompareLiterals(lhs, rhs Literal) (int, error) {
+	switch lhs := lhs.(type) {
+	case TypedLiteral[bool]:
+		return compareTyped(lhs, rhs)
+	case TypedLiteral[int32]:
+		return compareTyped(lhs, rhs)
+	case TypedLiteral[int64]:
+		return compareTyped(lhs, rhs)
+	case TypedLiteral[float32]:
+		return compareTyped(lhs, rhs)
+	case TypedLiteral[float64]:
+		return compareTyped(lhs, rhs)
+	case TypedLiteral[Date]:
+		return compareTyped(lhs, rhs)
+	case TypedLiteral[Time]:
+		return compareTyped(lhs, rhs)
+	case TypedLiteral[Timestamp]:
+		return compareTyped(lhs, rhs)
+	case TypedLiteral[string]:
+		return compareTyped(lhs, rhs)
+	case TypedLiteral[[]byte]:
+		return compareTyped(lhs, rhs)
+	case TypedLiteral[uuid.UUID]:
+		return compareTyped(lhs, rhs)
+	case TypedLiteral[Decimal]:
+		return compareTyped(lhs, rhs)
+	}
+
+	return 0, fmt.Errorf("%w: cannot compare literal %s", ErrInvalidArgument, lhs)
+}
+
+func compareTyped[T LiteralType](lhs TypedLiteral[T], rhs Literal) (int, error) {
+	typed, ok := rhs.(TypedLiteral[T])
+	if !ok {
+		conv, err := rhs.To(lhs.Type())
+		if err != nil {
+			return 0, err
+		}
+
+		if typed, ok = conv.(TypedLiteral[T]); !ok {
+			return 0, fmt.Errorf("%w: cannot compare %s with %s",
+				ErrInvalidArgument, lhs.Type(), rhs.Type())
+		}
+	}
+
+	return lhs.Comparator()(lhs.Value(), typed.Value()), nil
+}
+
 func getComparator[T LiteralType]() Comparator[T] {
 	var z T
 
@@ -359,15 +551,14 @@ type BoolLiteral bool
 
 func (BoolLiteral) Comparator() Comparator[bool] {
 	return func(v1, v2 bool) int {
-		if v1 {
-			if v2 {
-				return 0
-			}
-
+		switch {
+		case v1 == v2:
+			return 0
+		case v1:
 			return 1
+		default:
+			return -1
 		}
-
-		return -1
 	}
 }
 
@@ -578,7 +769,7 @@ func (i *Int64Literal) UnmarshalBinary(data []byte) error {
 
 type Float32Literal float32
 
-func (Float32Literal) Comparator() Comparator[float32] { return cmp.Compare[float32] }
+func (Float32Literal) Comparator() Comparator[float32] { return compareFloats[float32] }
 func (f Float32Literal) Type() Type                    { return PrimitiveTypes.Float32 }
 func (f Float32Literal) Value() float32                { return float32(f) }
 func (f Float32Literal) Any() any                      { return f.Value() }
@@ -626,7 +817,7 @@ func (f *Float32Literal) UnmarshalBinary(data []byte) error {
 
 type Float64Literal float64
 
-func (Float64Literal) Comparator() Comparator[float64] { return cmp.Compare[float64] }
+func (Float64Literal) Comparator() Comparator[float64] { return compareFloats[float64] }
 func (f Float64Literal) Type() Type                    { return PrimitiveTypes.Float64 }
 func (f Float64Literal) Value() float64                { return float64(f) }
 func (f Float64Literal) Any() any                      { return f.Value() }
@@ -769,6 +960,67 @@ func (t *TimeLiteral) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// NewTimestampLiteralFromString parses an ISO-8601 timestamp without an
+// offset, such as "2017-08-18T14:21:01.919234", as a timestamp literal.
+// Fractional seconds beyond microseconds are truncated and years outside
+// of 0000-9999 are written with a sign, e.g. "-0044-03-15T12:00:00".
+func NewTimestampLiteralFromString(s string) (Literal, error) {
+	// requires RFC3339 with no time zone
+	tm, err := parseTimestamp("2006-01-02T15:04:05", s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid Timestamp format for casting from string '%s': %s",
+			ErrBadCast, s, err.Error())
+	}
+
+	return TimestampLiteral(tm.UnixMicro()), nil
+}
+
+// NewTimestampTzLiteralFromString parses an RFC3339 timestamp with an
+// offset, such as "2017-08-18T14:21:01.919234-07:00", as a timestamptz
+// literal holding the microseconds since the epoch in UTC.
+func NewTimestampTzLiteralFromString(s string) (Literal, error) {
+	// requires RFC3339 format WITH time zone
+	tm, err := parseTimestamp(time.RFC3339, s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid TimestampTz format for casting from string '%s': %s",
+			ErrBadCast, s, err.Error())
+	}
+
+	return TimestampLiteral(tm.UnixMicro()), nil
+}
+
+// parseTimestamp parses s with layout, additionally accepting signed years
+// which time.Parse doesn't support.
+func parseTimestamp(layout, s string) (time.Time, error) {
+	if len(s) == 0 || (s[0] != '-' && s[0] != '+') {
+		return time.Parse(layout, s)
+	}
+
+	end := strings.IndexByte(s[1:], '-') + 1
+	if end < 5 {
+		return time.Parse(layout, s)
+	}
+
+	year, err := strconv.Atoi(s[:end])
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// parse the rest with a placeholder year which agrees on February 29th
+	placeholder := "2001"
+	if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		placeholder = "2000"
+	}
+
+	tm, err := time.Parse(layout, placeholder+s[end:])
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(year, tm.Month(), tm.Day(), tm.Hour(), tm.Minute(),
+		tm.Second(), tm.Nanosecond(), tm.Location()), nil
+}
+
 type TimestampLiteral Timestamp
 
 func (TimestampLiteral) Comparator() Comparator[Timestamp] { return cmp.Compare[Timestamp] }
@@ -886,23 +1138,9 @@ func (s StringLiteral) To(typ Type) (Literal, error) {
 
 		return TimeLiteral(val), nil
 	case TimestampType:
-		// requires RFC3339 with no time zone
-		tm, err := time.Parse("2006-01-02T15:04:05", string(s))
-		if err != nil {
-			return nil, fmt.Errorf("%w: invalid Timestamp format for casting from string '%s': %s",
-				ErrBadCast, s, err.Error())
-		}
-
-		return TimestampLiteral(Timestamp(tm.UTC().UnixMicro())), nil
+		return NewTimestampLiteralFromString(string(s))
 	case TimestampTzType:
-		// requires RFC3339 format WITH time zone
-		tm, err := time.Parse(time.RFC3339, string(s))
-		if err != nil {
-			return nil, fmt.Errorf("%w: invalid TimestampTz format for casting from string '%s': %s",
-				ErrBadCast, s, err.Error())
-		}
-
-		return TimestampLiteral(Timestamp(tm.UTC().UnixMicro())), nil
+		return NewTimestampTzLiteralFromString(string(s))
 	case UUIDType:
 		val, err := uuid.Parse(string(s))
 		if err != nil {
@@ -1139,12 +1377,16 @@ func (DecimalLiteral) Comparator() Comparator[Decimal] {
 			return v1.Val.Cmp(v2.Val)
 		}
 
-		rescaled, err := v2.Val.Rescale(int32(v2.Scale), int32(v1.Scale))
-		if err != nil {
-			return -1
+		// scale both values up to the larger scale, using big ints so
+		// that neither side can overflow or lose precision
+		lhs, rhs := v1.Val.BigInt(), v2.Val.BigInt()
+		if v1.Scale < v2.Scale {
+			lhs.Mul(lhs, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(v2.Scale-v1.Scale)), nil))
+		} else {
+			rhs.Mul(rhs, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(v1.Scale-v2.Scale)), nil))
 		}
 
-		return v1.Val.Cmp(rescaled)
+		return lhs.Cmp(rhs)
 	}
 }
 func (d DecimalLiteral) Type() Type     { return DecimalTypeOf(9, d.Scale) }
@@ -1161,8 +1403,15 @@ func (d DecimalLiteral) To(t Type) (Literal, error) {
 			return d, nil
 		}
 
-		return nil, fmt.Errorf("%w: could not convert %v to %s",
-			ErrBadCast, d, t)
+		// rescaling is only permitted when it is lossless and the
+		// result still fits within the target precision
+		v, err := d.Val.Rescale(int32(d.Scale), int32(t.scale))
+		if err != nil || !v.FitsInPrecision(int32(t.precision)) {
+			return nil, fmt.Errorf("%w: could not convert %v to %s",
+				ErrBadCast, d, t)
+		}
+
+		return DecimalLiteral{Val: v, Scale: t.scale}, nil
 	case Int32Type:
 		v := d.Val.BigInt().Int64()
 		if v > math.MaxInt32 {
@@ -1226,48 +1475,78 @@ func (d DecimalLiteral) Decrement() Literal {
 }
 
 func (d DecimalLiteral) MarshalBinary() (data []byte, err error) {
-	// stored as unscaled value in two's compliment big-endian values
-	// using the minimum number of bytes for the values
-	n := decimal128.Num(d.Val).BigInt()
-	minBytes := (n.BitLen() + 8) / 8
-	// bytes gives absolute value as big-endian bytes
-	data = n.FillBytes(make([]byte, minBytes))
-	if n.Sign() < 0 {
-		// convert to 2's complement for negative value
-		for i, v := range data {
-			data[i] = ^v
+	return decimalToBytes(d.Val), nil
+}
+
+func (d *DecimalLiteral) UnmarshalBinary(data []byte) error {
+	val, err := decimalFromBytes(data)
+	if err != nil {
+		return err
+	}
+	d.Val = val
+
+	return nil
+}
+
+// EncodeDecimalBound encodes a decimal as stored in lower and upper bounds:
+// the unscaled value at the given scale as a two's complement big-endian
+// number, using the minimum number of bytes like Java's
+// BigInteger.toByteArray. An error is returned if the decimal can't be
+// rescaled without losing precision.
+func EncodeDecimalBound(d Decimal, scale int) ([]byte, error) {
+	val := d.Val
+	if d.Scale != scale {
+		var err error
+		if val, err = val.Rescale(int32(d.Scale), int32(scale)); err != nil {
+			return nil, fmt.Errorf("%w: cannot rescale %s to scale %d: %w",
+				ErrInvalidArgument, d, scale, err)
 		}
-		data[len(data)-1] += 1
 	}
 
-	return
+	return decimalToBytes(val), nil
 }
 
-func (d *DecimalLiteral) UnmarshalBinary(data []byte) error {
-	// stored as unscaled value in two's complement
-	// big-endian values using the minimum number of bytes
-	if len(data) == 0 {
-		d.Val = decimal128.Num{}
+// DecodeDecimalBound decodes a decimal bound written by EncodeDecimalBound
+// for a decimal type with the given scale.
+func DecodeDecimalBound(b []byte, scale int) (Decimal, error) {
+	val, err := decimalFromBytes(b)
+	if err != nil {
+		return Decimal{}, err
+	}
 
-		return nil
+	return Decimal{Val: val, Scale: scale}, nil
+}
+
+func decimalToBytes(val decimal128.Num) []byte {
+	n := val.BigInt()
+	if n.Sign() >= 0 {
+		// leave room for the sign bit
+		return n.FillBytes(make([]byte, n.BitLen()/8+1))
 	}
 
-	if int8(data[0]) >= 0 {
-		// not negative
-		d.Val = decimal128.FromBigInt((&big.Int{}).SetBytes(data))
+	// the two's complement of a negative n in size bytes is 2^(8*size) + n,
+	// where size is the smallest for which -2^(8*size-1) <= n
+	size := new(big.Int).Not(n).BitLen()/8 + 1
+	twos := new(big.Int).Lsh(big.NewInt(1), uint(size*8))
+
+	return twos.Add(twos, n).FillBytes(make([]byte, size))
+}
 
-		return nil
+func decimalFromBytes(data []byte) (decimal128.Num, error) {
+	if len(data) == 0 {
+		return decimal128.Num{}, nil
 	}
 
-	// convert two's complement and remember it's negative
-	out := make([]byte, len(data))
-	for i, b := range data {
-		out[i] = ^b
+	if len(data) > 16 {
+		return decimal128.Num{}, fmt.Errorf("%w: invalid length for decimal: %d bytes, must be at most 16",
+			ErrInvalidArgument, len(data))
 	}
-	out[len(out)-1] += 1
 
-	value := (&big.Int{}).SetBytes(out)
-	d.Val = decimal128.FromBigInt(value.Neg(value))
+	n := new(big.Int).SetBytes(data)
+	if data[0]&0x80 != 0 {
+		// negative value, undo the two's complement
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(data)*8)))
+	}
 
-	return nil
+	return decimal128.FromBigInt(n), nil
 }
@@ -19,7 +19,9 @@ package iceberg_test
 
 import (
 	"math"
+	"slices"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -45,6 +47,86 @@ func TestNumericLiteralCompare(t *testing.T) {
 	assert.True(t, smallLit.Type().Equals(iceberg.PrimitiveTypes.Int32))
 }
 
+func TestFloatLiteralOrdering(t *testing.T) {
+	values := []float64{math.NaN(), 1.5, math.Inf(1), 0, math.Copysign(0, -1),
+		math.Inf(-1), -2.5, math.Copysign(math.NaN(), -1)}
+
+	cmp := iceberg.Float64Literal(0).Comparator()
+	slices.SortFunc(values, cmp)
+
+	// -NaN < -Infinity < -value < -0 < 0 < value < Infinity < NaN
+	require.Len(t, values, 8)
+	assert.True(t, math.IsNaN(values[0]) && math.Signbit(values[0]))
+	assert.Equal(t, []float64{math.Inf(-1), -2.5}, values[1:3])
+	assert.True(t, values[3] == 0 && math.Signbit(values[3]))
+	assert.True(t, values[4] == 0 && !math.Signbit(values[4]))
+	assert.Equal(t, []float64{1.5, math.Inf(1)}, values[5:7])
+	assert.True(t, math.IsNaN(values[7]) && !math.Signbit(values[7]))
+
+	nan := math.NaN()
+	for _, v := range []float64{math.Inf(1), math.MaxFloat64, 0, -1} {
+		assert.Equal(t, 1, cmp(nan, v))
+		assert.Equal(t, -1, cmp(v, nan))
+	}
+	assert.Equal(t, 0, cmp(nan, nan))
+
+	cmp32 := iceberg.Float32Literal(0).Comparator()
+	assert.Equal(t, 1, cmp32(float32(math.NaN()), float32(math.Inf(1))))
+	assert.Equal(t, -1, cmp32(float32(-1), float32(math.NaN())))
+}
+
+func TestDecimalLiteralCompare(t *testing.T) {
+	dec := func(v int64, scale int) iceberg.Decimal {
+		return iceberg.Decimal{Val: decimal128.FromI64(v), Scale: scale}
+	}
+
+	cmp := iceberg.DecimalLiteral{}.Comparator()
+	// 1.25 > 1.2, even though 125 can't be rescaled to a single digit scale
+	assert.Equal(t, 1, cmp(dec(125, 2), dec(12, 1)))
+	assert.Equal(t, -1, cmp(dec(12, 1), dec(125, 2)))
+	// 3.40 == 3.4
+	assert.Equal(t, 0, cmp(dec(340, 2), dec(34, 1)))
+	// 10.0 > 9.99
+	assert.Equal(t, 1, cmp(dec(100, 1), dec(999, 2)))
+	assert.Equal(t, -1, cmp(dec(-100, 1), dec(-999, 2)))
+	// large values don't overflow when scaled up
+	assert.Equal(t, 1, cmp(dec(math.MaxInt64, 0), dec(math.MaxInt64, 38)))
+}
+
+func TestCompareLiterals(t *testing.T) {
+	tests := []struct {
+		lhs, rhs iceberg.Literal
+		expected int
+	}{
+		{iceberg.NewLiteral(int32(-5)), iceberg.NewLiteral(int32(3)), -1},
+		{iceberg.NewLiteral(int64(7)), iceberg.NewLiteral(int32(7)), 0},
+		{iceberg.NewLiteral(math.NaN()), iceberg.NewLiteral(math.Inf(1)), 1},
+		{iceberg.NewLiteral(iceberg.Date(19000)), iceberg.NewLiteral(iceberg.Date(18999)), 1},
+		{iceberg.NewLiteral(iceberg.Timestamp(1)), iceberg.NewLiteral(iceberg.Timestamp(2)), -1},
+		{iceberg.NewLiteral("a"), iceberg.NewLiteral("B"), 1},
+		{iceberg.NewLiteral("é"), iceberg.NewLiteral("z"), 1},
+		{iceberg.NewLiteral([]byte{0x01, 0xff}), iceberg.NewLiteral([]byte{0x02}), -1},
+		{iceberg.FixedLiteral{0x00, 0x01}, iceberg.NewLiteral([]byte{0x00, 0x01}), 0},
+		{iceberg.NewLiteral(true), iceberg.NewLiteral(false), 1},
+		{iceberg.NewLiteral(false), iceberg.NewLiteral(false), 0},
+		{
+			iceberg.NewLiteral(iceberg.Decimal{Val: decimal128.FromI64(125), Scale: 2}),
+			iceberg.NewLiteral(iceberg.Decimal{Val: decimal128.FromI64(12), Scale: 1}), 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lhs.String()+" "+tt.rhs.String(), func(t *testing.T) {
+			got, err := iceberg.CompareLiterals(tt.lhs, tt.rhs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+
+	_, err := iceberg.CompareLiterals(iceberg.NewLiteral("abc"), iceberg.NewLiteral(true))
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
+}
+
 func TestIntConversion(t *testing.T) {
 	lit := iceberg.NewLiteral(int32(34))
 
@@ -270,9 +352,43 @@ func TestDecimalToDecimalConversion(t *testing.T) {
 	assert.ErrorIs(t, err, iceberg.ErrBadCast)
 	assert.ErrorContains(t, err, "could not convert 34.11 to decimal(9, 1)")
 
-	_, err = lit.To(iceberg.DecimalTypeOf(9, 3))
+	v, err = lit.To(iceberg.DecimalTypeOf(9, 3))
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.NewLiteral(iceberg.Decimal{Val: decimal128.FromI64(34110), Scale: 3}), v)
+
+	_, err = lit.To(iceberg.DecimalTypeOf(4, 3))
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
+	assert.ErrorContains(t, err, "could not convert 34.11 to decimal(4, 3)")
+}
+
+func TestLiteralCrossTypeConversions(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     iceberg.Literal
+		to       iceberg.Type
+		expected iceberg.Literal
+	}{
+		{"int32 to int64", iceberg.NewLiteral(int32(34)),
+			iceberg.PrimitiveTypes.Int64, iceberg.NewLiteral(int64(34))},
+		{"string to date", iceberg.NewLiteral("2017-08-18"),
+			iceberg.PrimitiveTypes.Date, iceberg.NewLiteral(iceberg.Date(17396))},
+		{"decimal(10, 2) to decimal(10, 4)",
+			iceberg.NewLiteral(iceberg.Decimal{Val: decimal128.FromI64(1234), Scale: 2}),
+			iceberg.DecimalTypeOf(10, 4),
+			iceberg.NewLiteral(iceberg.Decimal{Val: decimal128.FromI64(123400), Scale: 4})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.from.To(tt.to)
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equals(got), "expected %s, got %s", tt.expected, got)
+		})
+	}
+
+	_, err := iceberg.NewLiteral(iceberg.Decimal{Val: decimal128.FromI64(12345), Scale: 4}).
+		To(iceberg.DecimalTypeOf(10, 2))
 	assert.ErrorIs(t, err, iceberg.ErrBadCast)
-	assert.ErrorContains(t, err, "could not convert 34.11 to decimal(9, 3)")
 }
 
 func TestDecimalLiteralConversions(t *testing.T) {
@@ -1195,3 +1311,216 @@ func TestDecimalMaxMinRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestUUIDLiteralConstruction(t *testing.T) {
+	id := uuid.MustParse("f79c3e09-677c-4bbd-a479-3f349cb785e7")
+
+	fromStr, err := iceberg.NewLiteral(id.String()).To(iceberg.PrimitiveTypes.UUID)
+	require.NoError(t, err)
+	fromBytes, err := iceberg.NewLiteral(id[:]).To(iceberg.PrimitiveTypes.UUID)
+	require.NoError(t, err)
+	fromFixed, err := iceberg.FixedLiteral(id[:]).To(iceberg.PrimitiveTypes.UUID)
+	require.NoError(t, err)
+
+	lit := iceberg.NewLiteral(id)
+	assert.IsType(t, iceberg.UUIDLiteral{}, lit)
+	for _, other := range []iceberg.Literal{fromStr, fromBytes, fromFixed} {
+		assert.True(t, lit.Equals(other))
+		assert.True(t, other.Equals(lit))
+	}
+	assert.Equal(t, id.String(), lit.String())
+	// a uuid never equals the fixed or binary literal of its bytes
+	assert.False(t, lit.Equals(iceberg.FixedLiteral(id[:])))
+	assert.False(t, lit.Equals(iceberg.BinaryLiteral(id[:])))
+
+	_, err = iceberg.NewLiteral("not-a-uuid").To(iceberg.PrimitiveTypes.UUID)
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
+	_, err = iceberg.NewLiteral([]byte{1, 2, 3}).To(iceberg.PrimitiveTypes.UUID)
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
+}
+
+func TestUUIDLiteralCompare(t *testing.T) {
+	low := iceberg.UUIDLiteral(uuid.MustParse("00000000-0000-0000-0000-0000000000ff"))
+	mid := iceberg.UUIDLiteral(uuid.MustParse("0000000f-0000-0000-0000-000000000000"))
+	high := iceberg.UUIDLiteral(uuid.MustParse("f0000000-0000-0000-0000-000000000000"))
+
+	// uuids compare as unsigned bytes, most significant byte first
+	cmp := low.Comparator()
+	assert.Equal(t, -1, cmp(low.Value(), mid.Value()))
+	assert.Equal(t, -1, cmp(mid.Value(), high.Value()))
+	assert.Equal(t, 1, cmp(high.Value(), low.Value()))
+	assert.Equal(t, 0, cmp(mid.Value(), mid.Value()))
+
+	result, err := iceberg.CompareLiterals(mid, iceberg.NewLiteral("f0000000-0000-0000-0000-000000000000"))
+	require.NoError(t, err)
+	assert.Equal(t, -1, result)
+
+	result, err = iceberg.CompareLiterals(mid, iceberg.NewLiteral(uuid.UUID(low).String()))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result)
+}
+
+func TestTimestampLiteralFromString(t *testing.T) {
+	tests := []struct {
+		str      string
+		expected int64
+	}{
+		{"2017-08-18T14:21:01", 1503066061000000},
+		{"2017-08-18T14:21:01.919234", 1503066061919234},
+		// digits beyond microseconds are truncated
+		{"2017-08-18T14:21:01.919234567", 1503066061919234},
+		{"1969-12-31T23:59:59.9999999", -1},
+		{"-0001-01-01T00:00:00", time.Date(-1, 1, 1, 0, 0, 0, 0, time.UTC).UnixMicro()},
+		{"-0004-02-29T12:30:00.5", time.Date(-4, 2, 29, 12, 30, 0, 500000000, time.UTC).UnixMicro()},
+		{"+10000-01-01T00:00:00", time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC).UnixMicro()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			lit, err := iceberg.NewTimestampLiteralFromString(tt.str)
+			require.NoError(t, err)
+			assert.Equal(t, iceberg.TimestampLiteral(tt.expected), lit)
+
+			casted, err := iceberg.StringLiteral(tt.str).To(iceberg.PrimitiveTypes.Timestamp)
+			require.NoError(t, err)
+			assert.True(t, lit.Equals(casted))
+		})
+	}
+
+	for _, invalid := range []string{
+		"2017-08-18T14:21:01Z", "2017-08-18T14:21:01+02:00", "2017-08-18",
+		"-001-01-01T00:00:00", "-0001-02-29T00:00:00",
+	} {
+		_, err := iceberg.NewTimestampLiteralFromString(invalid)
+		assert.ErrorIs(t, err, iceberg.ErrBadCast, invalid)
+	}
+}
+
+func TestTimestampTzLiteralFromString(t *testing.T) {
+	const expected = iceberg.TimestampLiteral(1503091261919234)
+
+	// offsets are normalized to UTC
+	for _, str := range []string{
+		"2017-08-18T14:21:01.919234-07:00",
+		"2017-08-18T21:21:01.919234Z",
+		"2017-08-18T21:21:01.919234+00:00",
+		"2017-08-19T02:51:01.919234999+05:30",
+	} {
+		lit, err := iceberg.NewTimestampTzLiteralFromString(str)
+		require.NoError(t, err, str)
+		assert.Equal(t, expected, lit, str)
+	}
+
+	lit, err := iceberg.NewTimestampTzLiteralFromString("-0044-03-15T12:00:00+01:00")
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.TimestampLiteral(
+		time.Date(-44, 3, 15, 11, 0, 0, 0, time.UTC).UnixMicro()), lit)
+
+	_, err = iceberg.NewTimestampTzLiteralFromString("2017-08-18T14:21:01.919234")
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
+}
+
+func TestDecimalBoundEncoding(t *testing.T) {
+	tests := []struct {
+		unscaled int64
+		scale    int
+		expected []byte
+	}{
+		{0, 0, []byte{0x00}},
+		{0, 5, []byte{0x00}},
+		{1, 2, []byte{0x01}},
+		{127, 0, []byte{0x7f}},
+		{128, 0, []byte{0x00, 0x80}},
+		{255, 3, []byte{0x00, 0xff}},
+		{256, 0, []byte{0x01, 0x00}},
+		{12345, 2, []byte{0x30, 0x39}},
+		{1234567, 4, []byte{0x12, 0xd6, 0x87}},
+		{-1, 0, []byte{0xff}},
+		{-128, 1, []byte{0x80}},
+		{-129, 0, []byte{0xff, 0x7f}},
+		{-256, 2, []byte{0xff, 0x00}},
+		{-32768, 0, []byte{0x80, 0x00}},
+		{-32769, 0, []byte{0xff, 0x7f, 0xff}},
+		{-1234567, 4, []byte{0xed, 0x29, 0x79}},
+	}
+
+	for _, tt := range tests {
+		dec := iceberg.Decimal{Val: decimal128.FromI64(tt.unscaled), Scale: tt.scale}
+		t.Run(dec.String(), func(t *testing.T) {
+			encoded, err := iceberg.EncodeDecimalBound(dec, tt.scale)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, encoded)
+
+			lit, err := iceberg.DecimalLiteral(dec).MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, lit)
+
+			decoded, err := iceberg.DecodeDecimalBound(encoded, tt.scale)
+			require.NoError(t, err)
+			assert.Equal(t, dec, decoded)
+		})
+	}
+
+	// the largest values of decimal(38, x) take all 16 bytes
+	maxVal, err := decimal128.FromString(strings.Repeat("9", 38), 38, 0)
+	require.NoError(t, err)
+	for _, val := range []decimal128.Num{maxVal, maxVal.Negate()} {
+		encoded, err := iceberg.EncodeDecimalBound(iceberg.Decimal{Val: val, Scale: 10}, 10)
+		require.NoError(t, err)
+		assert.Len(t, encoded, 16)
+
+		decoded, err := iceberg.DecodeDecimalBound(encoded, 10)
+		require.NoError(t, err)
+		assert.Equal(t, val, decoded.Val)
+	}
+
+	// bounds are written at the scale of the column
+	encoded, err := iceberg.EncodeDecimalBound(iceberg.Decimal{Val: decimal128.FromI64(12345), Scale: 2}, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x12, 0xd6, 0x44}, encoded)
+
+	_, err = iceberg.EncodeDecimalBound(iceberg.Decimal{Val: decimal128.FromI64(12345), Scale: 2}, 1)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	_, err = iceberg.DecodeDecimalBound(make([]byte, 17), 2)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
+
+func TestTruncateBounds(t *testing.T) {
+	tests := []struct {
+		lit          iceberg.Literal
+		length       int
+		lower, upper iceberg.Literal
+	}{
+		{iceberg.StringLiteral("abc"), 4, iceberg.StringLiteral("abc"), iceberg.StringLiteral("abc")},
+		{iceberg.StringLiteral("abcdef"), 3, iceberg.StringLiteral("abc"), iceberg.StringLiteral("abd")},
+		// truncation counts code points, not bytes
+		{iceberg.StringLiteral("ßäöü"), 2, iceberg.StringLiteral("ßä"), iceberg.StringLiteral("ßå")},
+		{iceberg.StringLiteral("日本語です"), 3, iceberg.StringLiteral("日本語"), iceberg.StringLiteral("日本\u8a9f")},
+		// the last code point can't be incremented, so the one before it is
+		{iceberg.StringLiteral("a\U0010FFFFb"), 2, iceberg.StringLiteral("a\U0010FFFF"), iceberg.StringLiteral("b\U0010FFFF")},
+		// incrementing skips over the surrogates
+		{iceberg.StringLiteral("\ud7ffa"), 1, iceberg.StringLiteral("\ud7ff"), iceberg.StringLiteral("\ue000")},
+		{iceberg.BinaryLiteral{0x01, 0x02, 0x03}, 2, iceberg.BinaryLiteral{0x01, 0x02}, iceberg.BinaryLiteral{0x01, 0x03}},
+		{iceberg.BinaryLiteral{0x01, 0xff, 0x03}, 2, iceberg.BinaryLiteral{0x01, 0xff}, iceberg.BinaryLiteral{0x02, 0xff}},
+		{iceberg.BinaryLiteral{0xff, 0xff}, 2, iceberg.BinaryLiteral{0xff, 0xff}, iceberg.BinaryLiteral{0xff, 0xff}},
+		// other types are never truncated
+		{iceberg.Int64Literal(12345), 1, iceberg.Int64Literal(12345), iceberg.Int64Literal(12345)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lit.String(), func(t *testing.T) {
+			assert.Equal(t, tt.lower, iceberg.TruncateLowerBound(tt.lit, tt.length))
+
+			upper, ok := iceberg.TruncateUpperBound(tt.lit, tt.length)
+			require.True(t, ok)
+			assert.Equal(t, tt.upper, upper)
+		})
+	}
+
+	// there is no truncated upper bound if all the kept bytes are 0xff
+	_, ok := iceberg.TruncateUpperBound(iceberg.BinaryLiteral{0xff, 0xff, 0x00}, 2)
+	assert.False(t, ok)
+	_, ok = iceberg.TruncateUpperBound(iceberg.StringLiteral("\U0010FFFF\U0010FFFFa"), 2)
+	assert.False(t, ok)
+}
@@ -23,12 +23,14 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/big"
 	"reflect"
 	"slices"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
 	"github.com/apache/iceberg-go/internal"
 	iceio "github.com/apache/iceberg-go/io"
 	"github.com/google/uuid"
@@ -403,7 +405,7 @@ func (m *manifestFile) FetchEntries(fs iceio.IO, discardDeleted bool) ([]Manifes
 	return fetchManifestEntries(m, fs, discardDeleted)
 }
 
-func getFieldIDMap(sc avro.Schema) (map[string]int, map[int]avro.LogicalType) {
+func getFieldIDMap(sc avro.Schema) (map[string]int, map[int]avro.LogicalSchema) {
 	getField := func(rs *avro.RecordSchema, name string) *avro.Field {
 		for _, f := range rs.Fields() {
 			if f.Name() == name {
@@ -415,7 +417,7 @@ func getFieldIDMap(sc avro.Schema) (map[string]int, map[int]avro.LogicalType) {
 	}
 
 	result := make(map[string]int)
-	logicalTypes := make(map[int]avro.LogicalType)
+	logicalTypes := make(map[int]avro.LogicalSchema)
 	entryField := getField(sc.(*avro.RecordSchema), "data_file")
 	partitionField := getField(entryField.Type().(*avro.RecordSchema), "partition")
 
@@ -428,8 +430,8 @@ func getFieldIDMap(sc avro.Schema) (map[string]int, map[int]avro.LogicalType) {
 					avroTyp = t
 				}
 			}
-			if ps, ok := avroTyp.(*avro.PrimitiveSchema); ok && ps.Logical() != nil {
-				logicalTypes[int(fid)] = ps.Logical().Type()
+			if ls, ok := avroTyp.(avro.LogicalTypeSchema); ok && ls.Logical() != nil {
+				logicalTypes[int(fid)] = ls.Logical()
 			}
 		}
 	}
@@ -439,7 +441,7 @@ func getFieldIDMap(sc avro.Schema) (map[string]int, map[int]avro.LogicalType) {
 
 type hasFieldToIDMap interface {
 	setFieldNameToIDMap(map[string]int)
-	setFieldIDToLogicalTypeMap(map[int]avro.LogicalType)
+	setFieldIDToLogicalTypeMap(map[int]avro.LogicalSchema)
 }
 
 func fetchManifestEntries(m ManifestFile, fs iceio.IO, discardDeleted bool) ([]ManifestEntry, error) {
@@ -568,7 +570,7 @@ type ManifestReader struct {
 	isFallback    bool
 	content       ManifestContent
 	fieldNameToID map[string]int
-	fieldIDToType map[int]avro.LogicalType
+	fieldIDToType map[int]avro.LogicalSchema
 
 	// The rest are lazily populated, on demand. Most readers
 	// will likely only try to load the entries.
@@ -744,6 +746,71 @@ func (c *ManifestReader) ReadEntry() (ManifestEntry, error) {
 // of manifest entries or an error if one is encountered. If discardDeleted
 // is true, the returned slice omits entries whose status is "deleted".
 func ReadManifest(m ManifestFile, f io.Reader, discardDeleted bool) ([]ManifestEntry, error) {
+	filter := EntryFilterAll
+	if discardDeleted {
+		filter = EntryFilterLive
+	}
+
+	return readManifestEntries(m, f, filter)
+}
+
+// ManifestEntryFilter selects the entries of a manifest by their status,
+// see ReadManifestEntries.
+type ManifestEntryFilter int8
+
+const (
+	// EntryFilterAll selects all entries.
+	EntryFilterAll ManifestEntryFilter = iota
+	// EntryFilterLive selects the added and existing entries, which are
+	// the files that are part of the table in the snapshot that wrote
+	// the manifest.
+	EntryFilterLive
+	// EntryFilterAdded selects the entries with status EntryStatusADDED.
+	EntryFilterAdded
+	// EntryFilterExisting selects the entries with status EntryStatusEXISTING.
+	EntryFilterExisting
+	// EntryFilterDeleted selects the entries with status EntryStatusDELETED.
+	EntryFilterDeleted
+)
+
+// Matches returns true if entries with the given status are selected by
+// the filter.
+func (f ManifestEntryFilter) Matches(status ManifestEntryStatus) bool {
+	switch f {
+	case EntryFilterAll:
+		return true
+	case EntryFilterLive:
+		return status != EntryStatusDELETED
+	case EntryFilterAdded:
+		return status == EntryStatusADDED
+	case EntryFilterExisting:
+		return status == EntryStatusEXISTING
+	case EntryFilterDeleted:
+		return status == EntryStatusDELETED
+	default:
+		return false
+	}
+}
+
+// ReadManifestEntries reads the manifest file using fs and returns its
+// entries which are selected by filter. The snapshot ID and sequence
+// numbers which entries inherit from the manifest are filled in, the
+// same as for FetchEntries.
+func ReadManifestEntries(fs iceio.IO, m ManifestFile, filter ManifestEntryFilter) ([]ManifestEntry, error) {
+	if filter < EntryFilterAll || filter > EntryFilterDeleted {
+		return nil, fmt.Errorf("%w: invalid manifest entry filter %d", ErrInvalidArgument, filter)
+	}
+
+	f, err := fs.Open(m.FilePath())
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readManifestEntries(m, f, filter)
+}
+
+func readManifestEntries(m ManifestFile, f io.Reader, filter ManifestEntryFilter) ([]ManifestEntry, error) {
 	manifestReader, err := NewManifestReader(m, f)
 	if err != nil {
 		return nil, err
@@ -758,7 +825,7 @@ func ReadManifest(m ManifestFile, f io.Reader, discardDeleted bool) ([]ManifestE
 
 			return results, err
 		}
-		if discardDeleted && entry.Status() == EntryStatusDELETED {
+		if !filter.Matches(entry.Status()) {
 			continue
 		}
 		results = append(results, entry)
@@ -778,9 +845,13 @@ func ReadManifestList(in io.Reader) ([]ManifestFile, error) {
 		return nil, err
 	}
 
-	version, err := strconv.Atoi(string(dec.Metadata()["format-version"]))
-	if err != nil {
-		return nil, fmt.Errorf("invalid format-version: %w", err)
+	// manifest lists written by older v1 writers may not include
+	// the format-version key, in which case we treat them as v1
+	version := 1
+	if v, ok := dec.Metadata()["format-version"]; ok {
+		if version, err = strconv.Atoi(string(v)); err != nil {
+			return nil, fmt.Errorf("invalid format-version: %w", err)
+		}
 	}
 
 	if version == 1 {
@@ -825,9 +896,11 @@ func (v1writerImpl) prepareEntry(entry *manifestEntry, sn int64) (ManifestEntry,
 	}, nil
 }
 
-type v2writerImpl struct{}
+type v2writerImpl struct {
+	contentType ManifestContent
+}
 
-func (v2writerImpl) content() ManifestContent { return ManifestContentData }
+func (v v2writerImpl) content() ManifestContent { return v.contentType }
 func (v2writerImpl) prepareEntry(entry *manifestEntry, snapshotID int64) (ManifestEntry, error) {
 	if entry.SeqNum == nil {
 		if entry.Snapshot != nil && *entry.Snapshot != snapshotID {
@@ -858,6 +931,8 @@ type partitionFieldStats[T LiteralType] struct {
 
 func newPartitionFieldStat(typ PrimitiveType) (fieldStats, error) {
 	switch typ.(type) {
+	case BooleanType:
+		return &partitionFieldStats[bool]{cmp: getComparator[bool]()}, nil
 	case Int32Type:
 		return &partitionFieldStats[int32]{cmp: getComparator[int32]()}, nil
 	case Int64Type:
@@ -872,7 +947,7 @@ func newPartitionFieldStat(typ PrimitiveType) (fieldStats, error) {
 		return &partitionFieldStats[Date]{cmp: getComparator[Date]()}, nil
 	case TimeType:
 		return &partitionFieldStats[Time]{cmp: getComparator[Time]()}, nil
-	case TimestampType:
+	case TimestampType, TimestampTzType:
 		return &partitionFieldStats[Timestamp]{cmp: getComparator[Timestamp]()}, nil
 	case UUIDType:
 		return &partitionFieldStats[uuid.UUID]{cmp: getComparator[uuid.UUID]()}, nil
@@ -906,9 +981,12 @@ func (p *partitionFieldStats[T]) toSummary() FieldSummary {
 		upperBound = &ub
 	}
 
+	// copy the flag so the summary isn't affected by later updates
+	containsNaN := p.containsNan
+
 	return FieldSummary{
 		ContainsNull: p.containsNull,
-		ContainsNaN:  &p.containsNan,
+		ContainsNaN:  &containsNaN,
 		LowerBound:   lowerBound,
 		UpperBound:   upperBound,
 	}
@@ -923,12 +1001,20 @@ func (p *partitionFieldStats[T]) update(value any) (err error) {
 
 	var actualVal T
 	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 {
+		// fixed values are decoded from avro as byte arrays
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		v = reflect.ValueOf(b)
+	}
+
 	if !v.CanConvert(reflect.TypeOf(actualVal)) {
 		return fmt.Errorf("expected type %T, got %T", actualVal, value)
 	}
 
 	actualVal = v.Convert(reflect.TypeOf(actualVal)).Interface().(T)
 
+	// NaN is tracked with contains_nan and never used as a bound
 	switch f := any(actualVal).(type) {
 	case float32:
 		if math.IsNaN(float64(f)) {
@@ -960,9 +1046,21 @@ func (p *partitionFieldStats[T]) update(value any) (err error) {
 	return nil
 }
 
-func constructPartitionSummaries(spec PartitionSpec, schema *Schema, partitions []map[int]any) ([]FieldSummary, error) {
+// partitionSummaries accumulates the field summaries of a manifest's
+// partitions as entries are written, so that the partition values of the
+// entries don't need to be kept around until the manifest is complete.
+type partitionSummaries struct {
+	fields []NestedField
+	stats  []fieldStats
+}
+
+func newPartitionSummaries(spec PartitionSpec, schema *Schema) (*partitionSummaries, error) {
 	partType := spec.PartitionType(schema)
-	fieldStats := make([]fieldStats, len(partType.FieldList))
+	p := &partitionSummaries{
+		fields: partType.FieldList,
+		stats:  make([]fieldStats, len(partType.FieldList)),
+	}
+
 	var err error
 	for i, field := range partType.FieldList {
 		pt, ok := field.Type.(PrimitiveType)
@@ -970,24 +1068,52 @@ func constructPartitionSummaries(spec PartitionSpec, schema *Schema, partitions
 			return nil, fmt.Errorf("expected primitive type for partition field, got %s", field.Type)
 		}
 
-		fieldStats[i], err = newPartitionFieldStat(pt)
+		p.stats[i], err = newPartitionFieldStat(pt)
 		if err != nil {
 			return nil, fmt.Errorf("error constructing field stats for partition %d: %s: %s", i, field.Name, err)
 		}
 	}
 
-	for _, part := range partitions {
-		for i, field := range partType.FieldList {
-			fieldStats[i].update(part[field.ID])
+	return p, nil
+}
+
+func (p *partitionSummaries) update(partition map[int]any) error {
+	for i, field := range p.fields {
+		if err := p.stats[i].update(partition[field.ID]); err != nil {
+			return fmt.Errorf("invalid value for partition field %s: %w", field.Name, err)
 		}
 	}
 
-	summaries := make([]FieldSummary, len(fieldStats))
-	for i, stat := range fieldStats {
+	return nil
+}
+
+func (p *partitionSummaries) summaries() []FieldSummary {
+	summaries := make([]FieldSummary, len(p.stats))
+	for i, stat := range p.stats {
 		summaries[i] = stat.toSummary()
 	}
 
-	return summaries, nil
+	return summaries
+}
+
+// RecomputePartitionSummaries rebuilds the partition field summaries of a
+// manifest from its entries, as the manifest writer computes them, for
+// example to repair the summaries of a manifest list entry. The entries
+// of all statuses are included and the bounds are compared with the
+// comparator for the result type of each partition field.
+func RecomputePartitionSummaries(entries []ManifestEntry, spec *PartitionSpec, schema *Schema) ([]FieldSummary, error) {
+	partitions, err := newPartitionSummaries(*spec, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if err := partitions.update(entry.DataFile().Partition()); err != nil {
+			return nil, err
+		}
+	}
+
+	return partitions.summaries(), nil
 }
 
 type ManifestWriter struct {
@@ -1009,24 +1135,80 @@ type ManifestWriter struct {
 	deletedFiles  int32
 	deletedRows   int64
 
-	partitions  []map[int]any
+	partitions  *partitionSummaries
 	minSeqNum   int64
 	reusedEntry manifestEntry
 }
 
-func NewManifestWriter(version int, out io.Writer, spec PartitionSpec, schema *Schema, snapshotID int64) (*ManifestWriter, error) {
-	var impl writerImpl
+// WriterOption configures the writers for manifest and manifest list files.
+type WriterOption func(*writerOptions)
+
+type writerOptions struct {
+	codec   ocf.CodecName
+	content ManifestContent
+}
+
+func newWriterOptions(opts []WriterOption) writerOptions {
+	o := writerOptions{codec: ocf.Deflate}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithAvroCodec sets the compression codec used for the avro data blocks
+// of manifest and manifest list files. Supported codecs are ocf.Null,
+// ocf.Deflate, ocf.Snappy and ocf.ZStandard. Deflate is used by default
+// for compatibility with other implementations.
+func WithAvroCodec(codec ocf.CodecName) WriterOption {
+	return func(o *writerOptions) {
+		o.codec = codec
+	}
+}
+
+// WithManifestContent sets the type of files tracked by a manifest written
+// with NewManifestWriter. Manifests track data files by default, delete
+// files can only be tracked by manifests of format version 2 or later.
+func WithManifestContent(content ManifestContent) WriterOption {
+	return func(o *writerOptions) {
+		o.content = content
+	}
+}
+
+// newAvroEncoder creates an OCF encoder which embeds the full avro schema
+// along with the provided metadata in the file header, compressing data
+// blocks with the given codec.
+func newAvroEncoder(sch avro.Schema, out io.Writer, meta map[string][]byte, codec ocf.CodecName) (*ocf.Encoder, error) {
+	return ocf.NewEncoderWithSchema(sch, out,
+		ocf.WithSchemaMarshaler(ocf.FullSchemaMarshaler),
+		ocf.WithEncoderSchemaCache(&avro.SchemaCache{}),
+		ocf.WithMetadata(meta),
+		ocf.WithCodec(codec))
+}
+
+// NewManifestWriter creates a writer which streams manifest entries to out
+// as they are added, only keeping the running counts and partition field
+// summaries needed for the resulting ManifestFile in memory.
+func NewManifestWriter(version int, out io.Writer, spec PartitionSpec, schema *Schema, snapshotID int64, opts ...WriterOption) (*ManifestWriter, error) {
+	var (
+		impl    writerImpl
+		options = newWriterOptions(opts)
+	)
 
 	switch version {
 	case 1:
+		if options.content != ManifestContentData {
+			return nil, errors.New("v1 manifests can only track data files")
+		}
 		impl = v1writerImpl{}
 	case 2:
-		impl = v2writerImpl{}
+		impl = v2writerImpl{contentType: options.content}
 	default:
 		return nil, fmt.Errorf("unsupported manifest version: %d", version)
 	}
 
-	sc, err := partitionTypeToAvroSchema(spec.PartitionType(schema))
+	sc, err := partitionTypeToAvroSchema(manifestPartitionType(spec, schema))
 	if err != nil {
 		return nil, err
 	}
@@ -1036,6 +1218,11 @@ func NewManifestWriter(version int, out io.Writer, spec PartitionSpec, schema *S
 		return nil, err
 	}
 
+	partitions, err := newPartitionSummaries(spec, schema)
+	if err != nil {
+		return nil, err
+	}
+
 	w := &ManifestWriter{
 		impl:       impl,
 		version:    version,
@@ -1044,7 +1231,7 @@ func NewManifestWriter(version int, out io.Writer, spec PartitionSpec, schema *S
 		schema:     schema,
 		snapshotID: snapshotID,
 		minSeqNum:  -1,
-		partitions: make([]map[int]any, 0),
+		partitions: partitions,
 	}
 
 	md, err := w.meta()
@@ -1052,11 +1239,7 @@ func NewManifestWriter(version int, out io.Writer, spec PartitionSpec, schema *S
 		return nil, err
 	}
 
-	enc, err := ocf.NewEncoderWithSchema(fileSchema, out,
-		ocf.WithSchemaMarshaler(ocf.FullSchemaMarshaler),
-		ocf.WithEncoderSchemaCache(&avro.SchemaCache{}),
-		ocf.WithMetadata(md),
-		ocf.WithCodec(ocf.Deflate))
+	enc, err := newAvroEncoder(fileSchema, out, md, options.codec)
 
 	w.writer = enc
 
@@ -1086,17 +1269,14 @@ func (w *ManifestWriter) ToManifestFile(location string, length int64) (Manifest
 		w.minSeqNum = -1
 	}
 
-	partitions, err := constructPartitionSummaries(w.spec, w.schema, w.partitions)
-	if err != nil {
-		return nil, err
-	}
+	partitions := w.partitions.summaries()
 
 	return &manifestFile{
 		version:            w.version,
 		Path:               location,
 		Len:                length,
 		SpecID:             int32(w.spec.id),
-		Content:            ManifestContentData,
+		Content:            w.impl.content(),
 		SeqNumber:          -1,
 		MinSeqNumber:       w.minSeqNum,
 		AddedSnapshotID:    w.snapshotID,
@@ -1143,6 +1323,13 @@ func (w *ManifestWriter) addEntry(entry *manifestEntry) error {
 		return errors.New("cannot add entry to closed manifest writer")
 	}
 
+	// v1 data files don't have a content type, they can only be data
+	isData := entry.DataFile().ContentType() == EntryContentData
+	if w.version > 1 && isData != (w.impl.content() == ManifestContentData) {
+		return fmt.Errorf("cannot add %s file to a %s manifest",
+			entry.DataFile().ContentType(), w.impl.content())
+	}
+
 	switch entry.Status() {
 	case EntryStatusADDED:
 		w.addedFiles++
@@ -1157,7 +1344,9 @@ func (w *ManifestWriter) addEntry(entry *manifestEntry) error {
 		return fmt.Errorf("unknown entry status: %v", entry.Status())
 	}
 
-	w.partitions = append(w.partitions, entry.DataFile().Partition())
+	if err := w.partitions.update(entry.DataFile().Partition()); err != nil {
+		return err
+	}
 	if (entry.Status() == EntryStatusADDED || entry.Status() == EntryStatusEXISTING) &&
 		entry.SequenceNum() > 0 && (w.minSeqNum < 0 || entry.SequenceNum() < w.minSeqNum) {
 		w.minSeqNum = entry.SequenceNum()
@@ -1195,15 +1384,17 @@ type ManifestListWriter struct {
 	out              io.Writer
 	commitSnapshotID int64
 	sequenceNumber   int64
+	codec            ocf.CodecName
 	writer           *ocf.Encoder
 }
 
-func NewManifestListWriterV1(out io.Writer, snapshotID int64, parentSnapshot *int64) (*ManifestListWriter, error) {
+func NewManifestListWriterV1(out io.Writer, snapshotID int64, parentSnapshot *int64, opts ...WriterOption) (*ManifestListWriter, error) {
 	m := &ManifestListWriter{
 		version:          1,
 		out:              out,
 		commitSnapshotID: snapshotID,
 		sequenceNumber:   -1,
+		codec:            newWriterOptions(opts).codec,
 	}
 
 	parentSnapshotStr := "null"
@@ -1218,12 +1409,13 @@ func NewManifestListWriterV1(out io.Writer, snapshotID int64, parentSnapshot *in
 	})
 }
 
-func NewManifestListWriterV2(out io.Writer, snapshotID, sequenceNumber int64, parentSnapshot *int64) (*ManifestListWriter, error) {
+func NewManifestListWriterV2(out io.Writer, snapshotID, sequenceNumber int64, parentSnapshot *int64, opts ...WriterOption) (*ManifestListWriter, error) {
 	m := &ManifestListWriter{
 		version:          2,
 		out:              out,
 		commitSnapshotID: snapshotID,
 		sequenceNumber:   sequenceNumber,
+		codec:            newWriterOptions(opts).codec,
 	}
 
 	parentSnapshotStr := "null"
@@ -1245,11 +1437,7 @@ func (m *ManifestListWriter) init(meta map[string][]byte) error {
 		return err
 	}
 
-	enc, err := ocf.NewEncoderWithSchema(fileSchema, m.out,
-		ocf.WithSchemaMarshaler(ocf.FullSchemaMarshaler),
-		ocf.WithEncoderSchemaCache(&avro.SchemaCache{}),
-		ocf.WithMetadata(meta),
-		ocf.WithCodec(ocf.Deflate))
+	enc, err := newAvroEncoder(fileSchema, m.out, meta, m.codec)
 	if err != nil {
 		return err
 	}
@@ -1328,7 +1516,7 @@ func (m *ManifestListWriter) AddManifests(files []ManifestFile) error {
 }
 
 // WriteManifestList writes a list of manifest files to an avro file.
-func WriteManifestList(version int, out io.Writer, snapshotID int64, parentSnapshotID, sequenceNumber *int64, files []ManifestFile) error {
+func WriteManifestList(version int, out io.Writer, snapshotID int64, parentSnapshotID, sequenceNumber *int64, files []ManifestFile, opts ...WriterOption) error {
 	var (
 		writer *ManifestListWriter
 		err    error
@@ -1336,12 +1524,12 @@ func WriteManifestList(version int, out io.Writer, snapshotID int64, parentSnaps
 
 	switch version {
 	case 1:
-		writer, err = NewManifestListWriterV1(out, snapshotID, parentSnapshotID)
+		writer, err = NewManifestListWriterV1(out, snapshotID, parentSnapshotID, opts...)
 	case 2:
 		if sequenceNumber == nil {
 			return errors.New("sequence number is required for V2 tables")
 		}
-		writer, err = NewManifestListWriterV2(out, snapshotID, *sequenceNumber, parentSnapshotID)
+		writer, err = NewManifestListWriterV2(out, snapshotID, *sequenceNumber, parentSnapshotID, opts...)
 	default:
 		return fmt.Errorf("unsupported manifest version: %d", version)
 	}
@@ -1357,6 +1545,52 @@ func WriteManifestList(version int, out io.Writer, snapshotID int64, parentSnaps
 	return writer.Close()
 }
 
+// ManifestFileWriter streams the entries of a single manifest file to its
+// output, tracking the number of bytes written so that closing the writer
+// produces the complete ManifestFile.
+//
+// Entries are encoded to the output as they are written: only the avro
+// block being filled, the running counts and the partition field summaries
+// are held in memory, so the output is where the manifest spills to. When
+// out is the manifest file itself, e.g. created with io.WriteFileIO, a
+// manifest with millions of entries is written with a constant memory
+// footprint and nothing needs to be spilled to a temporary file first.
+type ManifestFileWriter struct {
+	wr       *ManifestWriter
+	location string
+	counter  *internal.CountingWriter
+}
+
+// NewManifestFileWriter creates a ManifestFileWriter for the manifest
+// which will be stored at location, writing its content to out.
+func NewManifestFileWriter(location string, out io.Writer, version int, spec PartitionSpec, schema *Schema, snapshotID int64, opts ...WriterOption) (*ManifestFileWriter, error) {
+	cnt := &internal.CountingWriter{W: out}
+	w, err := NewManifestWriter(version, cnt, spec, schema, snapshotID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ManifestFileWriter{wr: w, location: location, counter: cnt}, nil
+}
+
+// Write adds an entry to the manifest as is, keeping its status, snapshot
+// ID and sequence numbers.
+func (w *ManifestFileWriter) Write(entry ManifestEntry) error {
+	return w.wr.addEntry(entry.(*manifestEntry))
+}
+
+// Close flushes the remaining entries to the output and returns the
+// resulting ManifestFile, with its length, entry counts and partition
+// field summaries. It does not close the underlying output.
+func (w *ManifestFileWriter) Close() (ManifestFile, error) {
+	// flush the writer to ensure the count is accurate
+	if err := w.wr.Close(); err != nil {
+		return nil, err
+	}
+
+	return w.wr.ToManifestFile(w.location, w.counter.Count)
+}
+
 func WriteManifest(
 	filename string,
 	out io.Writer,
@@ -1365,26 +1599,20 @@ func WriteManifest(
 	schema *Schema,
 	snapshotID int64,
 	entries []ManifestEntry,
+	opts ...WriterOption,
 ) (ManifestFile, error) {
-	cnt := &internal.CountingWriter{W: out}
-
-	w, err := NewManifestWriter(version, cnt, spec, schema, snapshotID)
+	w, err := NewManifestFileWriter(filename, out, version, spec, schema, snapshotID, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, entry := range entries {
-		if err := w.addEntry(entry.(*manifestEntry)); err != nil {
+		if err := w.Write(entry); err != nil {
 			return nil, err
 		}
 	}
 
-	// flush the writer to ensure cnt.Count is accurate
-	if err := w.Close(); err != nil {
-		return nil, err
-	}
-
-	return w.ToManifestFile(filename, cnt.Count)
+	return w.Close()
 }
 
 // ManifestEntryStatus defines constants for the entry status of
@@ -1461,11 +1689,59 @@ func mapToAvroColMap[K comparable, V any](m map[K]V) *[]colMap[K, V] {
 	return &out
 }
 
-func avroPartitionData(input map[int]any, logicalTypes map[int]avro.LogicalType) map[int]any {
+// decimalToRat converts a decimal value to the *big.Rat representation
+// expected by the avro codec for decimal logical types.
+func decimalToRat(d Decimal) *big.Rat {
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Scale)), nil)
+
+	return new(big.Rat).SetFrac(d.Val.BigInt(), denom)
+}
+
+// ratToDecimal converts a *big.Rat decoded by the avro codec for a decimal
+// logical type back into a Decimal with the provided scale.
+func ratToDecimal(r *big.Rat, scale int) Decimal {
+	unscaled := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	unscaled.Mul(unscaled, r.Num())
+	unscaled.Quo(unscaled, r.Denom())
+
+	return Decimal{Val: decimal128.FromBigInt(unscaled), Scale: scale}
+}
+
+// manifestPartitionType returns the partition type written to manifests
+// for spec. Void transforms always produce null partition values and the
+// other transforms produce null for null source values, so a field is only
+// required when it isn't void and its source field is required.
+func manifestPartitionType(spec PartitionSpec, schema *Schema) *StructType {
+	partType := spec.PartitionType(schema)
+	for i, f := range partType.FieldList {
+		for field := range spec.Fields() {
+			if field.FieldID == f.ID {
+				_, void := field.Transform.(VoidTransform)
+				source, ok := schema.FindFieldByID(field.SourceID)
+				partType.FieldList[i].Required = !void && ok && source.Required
+			}
+		}
+	}
+
+	return partType
+}
+
+// avroPartitionValue converts a partition value into the representation
+// required by the avro encoder for its partition field.
+func avroPartitionValue(v any) any {
+	switch v := v.(type) {
+	case Decimal:
+		return decimalToRat(v)
+	default:
+		return v
+	}
+}
+
+func avroPartitionData(input map[int]any, logicalTypes map[int]avro.LogicalSchema) map[int]any {
 	out := make(map[int]any)
 	for k, v := range input {
-		if logical, ok := logicalTypes[k]; ok {
-			switch logical {
+		if logical, ok := logicalTypes[k]; ok && v != nil {
+			switch logical.Type() {
 			case avro.Date:
 				out[k] = Date(v.(time.Time).Truncate(24*time.Hour).Unix() / int64((time.Hour * 24).Seconds()))
 			case avro.TimeMillis:
@@ -1476,6 +1752,12 @@ func avroPartitionData(input map[int]any, logicalTypes map[int]avro.LogicalType)
 				out[k] = Timestamp(v.(time.Time).UTC().UnixMilli())
 			case avro.TimestampMicros:
 				out[k] = Timestamp(v.(time.Time).UTC().UnixMicro())
+			case avro.Decimal:
+				if r, ok := v.(*big.Rat); ok {
+					out[k] = ratToDecimal(r, logical.(*avro.DecimalLogicalSchema).Scale())
+				} else {
+					out[k] = v
+				}
 			default:
 				out[k] = v
 			}
@@ -1518,7 +1800,7 @@ type dataFile struct {
 
 	// used for partition retrieval
 	fieldNameToID          map[string]int
-	fieldIDToLogicalType   map[int]avro.LogicalType
+	fieldIDToLogicalType   map[int]avro.LogicalSchema
 	fieldIDToPartitionData map[int]any
 
 	specID   int32
@@ -1548,7 +1830,7 @@ func (d *dataFile) initializeMapData() {
 }
 
 func (d *dataFile) setFieldNameToIDMap(m map[string]int) { d.fieldNameToID = m }
-func (d *dataFile) setFieldIDToLogicalTypeMap(m map[int]avro.LogicalType) {
+func (d *dataFile) setFieldIDToLogicalTypeMap(m map[int]avro.LogicalSchema) {
 	d.fieldIDToLogicalType = m
 }
 
@@ -1798,7 +2080,7 @@ func NewDataFileBuilder(
 	fieldNameToID := make(map[string]int)
 	for _, p := range spec.fields {
 		if pData, ok := fieldIDToPartitionData[p.FieldID]; ok {
-			partitionData[p.Name] = pData
+			partitionData[p.Name] = avroPartitionValue(pData)
 			fieldNameToID[p.Name] = p.FieldID
 		}
 	}
@@ -19,11 +19,19 @@ package iceberg
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
 	"github.com/apache/iceberg-go/internal"
+	iceio "github.com/apache/iceberg-go/io"
 	"github.com/hamba/avro/v2"
 	"github.com/hamba/avro/v2/ocf"
 	"github.com/stretchr/testify/suite"
@@ -795,6 +803,96 @@ func (m *ManifestTestSuite) TestReadManifestListIncompleteSchema() {
 	m.ErrorContains(err, "unknown type: field_summary")
 }
 
+func (m *ManifestTestSuite) TestManifestListRoundTrip() {
+	lower, upper := []byte{0x01, 0x00, 0x00, 0x00}, []byte{0x02, 0x00, 0x00, 0x00}
+	partitions := []FieldSummary{{ContainsNull: true, LowerBound: &lower, UpperBound: &upper}}
+
+	for _, version := range []int{1, 2} {
+		m.Run(fmt.Sprintf("v%d", version), func() {
+			files := []ManifestFile{
+				NewManifestFile(version, "s3://bucket/table/metadata/m0.avro", 1024, 1, 1234).
+					SequenceNum(5, 4).
+					AddedFiles(3).
+					ExistingFiles(2).
+					DeletedFiles(1).
+					AddedRows(300).
+					ExistingRows(200).
+					DeletedRows(100).
+					Partitions(partitions).
+					Build(),
+				NewManifestFile(version, "s3://bucket/table/metadata/m1.avro", 2048, 0, 1234).
+					SequenceNum(5, 5).
+					Build(),
+			}
+
+			var buf bytes.Buffer
+			seqNum := int64(5)
+			m.Require().NoError(WriteManifestList(version, &buf, 1234, nil, &seqNum, files))
+
+			list, err := ReadManifestList(&buf)
+			m.Require().NoError(err)
+			m.Require().Len(list, len(files))
+
+			for i, expected := range files {
+				actual := list[i]
+				m.Equal(version, actual.Version())
+				m.Equal(expected.FilePath(), actual.FilePath())
+				m.Equal(expected.Length(), actual.Length())
+				m.Equal(expected.PartitionSpecID(), actual.PartitionSpecID())
+				m.Equal(expected.SnapshotID(), actual.SnapshotID())
+				m.Equal(expected.AddedDataFiles(), actual.AddedDataFiles())
+				m.Equal(expected.ExistingDataFiles(), actual.ExistingDataFiles())
+				m.Equal(expected.DeletedDataFiles(), actual.DeletedDataFiles())
+				m.Equal(expected.AddedRows(), actual.AddedRows())
+				m.Equal(expected.ExistingRows(), actual.ExistingRows())
+				m.Equal(expected.DeletedRows(), actual.DeletedRows())
+				if version == 1 {
+					// v1 manifest lists have no sequence numbers
+					m.Zero(actual.SequenceNum())
+					m.Zero(actual.MinSequenceNum())
+				} else {
+					m.Equal(expected.SequenceNum(), actual.SequenceNum())
+					m.Equal(expected.MinSequenceNum(), actual.MinSequenceNum())
+				}
+			}
+
+			m.Require().Len(list[0].Partitions(), 1)
+			m.True(list[0].Partitions()[0].ContainsNull)
+			m.Equal(lower, *list[0].Partitions()[0].LowerBound)
+			m.Equal(upper, *list[0].Partitions()[0].UpperBound)
+		})
+	}
+}
+
+func (m *ManifestTestSuite) TestReadManifestListMissingFormatVersion() {
+	sch, err := internal.NewManifestFileSchema(1)
+	m.Require().NoError(err)
+
+	var buf bytes.Buffer
+	enc, err := ocf.NewEncoderWithSchema(sch, &buf,
+		ocf.WithEncoderSchemaCache(&avro.SchemaCache{}),
+		ocf.WithMetadata(map[string][]byte{
+			"snapshot-id":        []byte("1234"),
+			"parent-snapshot-id": []byte("null"),
+		}),
+	)
+	m.Require().NoError(err)
+
+	var tmp manifestFileV1
+	NewManifestFile(1, "s3://bucket/table/metadata/m0.avro", 1024, 0, 1234).
+		AddedFiles(3).Build().(*manifestFile).toV1(&tmp)
+	m.Require().NoError(enc.Encode(&tmp))
+	m.Require().NoError(enc.Close())
+
+	list, err := ReadManifestList(&buf)
+	m.Require().NoError(err)
+	m.Require().Len(list, 1)
+	m.Equal(1, list[0].Version())
+	m.Equal("s3://bucket/table/metadata/m0.avro", list[0].FilePath())
+	m.EqualValues(1234, list[0].SnapshotID())
+	m.EqualValues(3, list[0].AddedDataFiles())
+}
+
 func (m *ManifestTestSuite) TestReadManifestIncompleteSchema() {
 	// This prevents a regression that could be caused by using a schema cache
 	// across multiple read/write operations of an avro file. While it may sound
@@ -1167,6 +1265,620 @@ func (m *ManifestTestSuite) TestManifestWriterMeta() {
 	m.Equal("[]", string(md["partition-spec"]))
 }
 
+func (m *ManifestTestSuite) TestManifestDecimalPartitionRoundTrip() {
+	sch := NewSchema(0,
+		NestedField{ID: 1, Name: "amount", Type: DecimalTypeOf(9, 2), Required: true})
+	spec := NewPartitionSpecID(1,
+		PartitionField{FieldID: 1000, SourceID: 1, Name: "amount_trunc", Transform: TruncateTransform{Width: 10}})
+
+	partValue := Decimal{Val: decimal128.FromI64(-12340), Scale: 2}
+	bldr, err := NewDataFileBuilder(spec, EntryContentData, "s3://bucket/data/file.parquet",
+		ParquetFile, map[int]any{1000: partValue}, 10, 1024)
+	m.Require().NoError(err)
+
+	snapID := int64(1)
+	entries := []ManifestEntry{NewManifestEntry(EntryStatusADDED, &snapID, nil, nil, bldr.Build())}
+
+	var buf bytes.Buffer
+	mf, err := WriteManifest("s3://bucket/metadata/manifest.avro", &buf, 2, spec, sch, snapID, entries)
+	m.Require().NoError(err)
+
+	expectedBound, err := DecimalLiteral(partValue).MarshalBinary()
+	m.Require().NoError(err)
+	m.Require().Len(mf.Partitions(), 1)
+	m.Equal(expectedBound, *mf.Partitions()[0].LowerBound)
+	m.Equal(expectedBound, *mf.Partitions()[0].UpperBound)
+
+	read, err := ReadManifest(mf, bytes.NewReader(buf.Bytes()), false)
+	m.Require().NoError(err)
+	m.Require().Len(read, 1)
+
+	got := read[0].DataFile().Partition()[1000]
+	m.Require().IsType(Decimal{}, got)
+	m.Equal(2, got.(Decimal).Scale)
+	m.True(DecimalLiteral(partValue).Equals(DecimalLiteral(got.(Decimal))))
+}
+
+func (m *ManifestTestSuite) TestBucketPartitionAvroSchema() {
+	sch := NewSchema(0,
+		NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.String, Required: true})
+	spec := NewPartitionSpecID(1,
+		PartitionField{FieldID: 1000, SourceID: 1, Name: "id_bucket", Transform: BucketTransform{NumBuckets: 16}})
+
+	partType := spec.PartitionType(sch)
+	m.Require().Len(partType.FieldList, 1)
+	m.Equal(PrimitiveTypes.Int32, partType.FieldList[0].Type)
+	m.Equal(PrimitiveTypes.Int32, BucketTransform{NumBuckets: 16}.ResultType(PrimitiveTypes.String))
+
+	sc, err := partitionTypeToAvroSchema(manifestPartitionType(spec, sch))
+	m.Require().NoError(err)
+
+	fields := sc.(*avro.RecordSchema).Fields()
+	m.Require().Len(fields, 1)
+	m.Equal("id_bucket", fields[0].Name())
+	m.Equal(avro.Int, fields[0].Type().Type())
+	m.Equal(1000, fields[0].Prop("field-id"))
+
+	bucket := BucketTransform{NumBuckets: 16}.Apply(Optional[Literal]{Val: NewLiteral("iceberg"), Valid: true})
+	m.Require().True(bucket.Valid)
+
+	bldr, err := NewDataFileBuilder(spec, EntryContentData, "s3://bucket/data/file.parquet",
+		ParquetFile, map[int]any{1000: bucket.Val.Any()}, 1, 1)
+	m.Require().NoError(err)
+
+	snapID := int64(1)
+	var buf bytes.Buffer
+	mf, err := WriteManifest("s3://bucket/metadata/manifest.avro", &buf, 2, spec, sch, snapID,
+		[]ManifestEntry{NewManifestEntry(EntryStatusADDED, &snapID, nil, nil, bldr.Build())})
+	m.Require().NoError(err)
+
+	entries, err := ReadManifest(mf, bytes.NewReader(buf.Bytes()), false)
+	m.Require().NoError(err)
+	m.Require().Len(entries, 1)
+	m.EqualValues(bucket.Val.Any(), entries[0].DataFile().Partition()[1000])
+}
+
+func (m *ManifestTestSuite) TestVoidPartitionNullable() {
+	sch := NewSchema(0,
+		NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int32, Required: true},
+		NestedField{ID: 2, Name: "data", Type: PrimitiveTypes.String, Required: true})
+	spec := NewPartitionSpecID(1,
+		PartitionField{FieldID: 1000, SourceID: 1, Name: "id", Transform: IdentityTransform{}},
+		PartitionField{FieldID: 1001, SourceID: 2, Name: "data_void", Transform: VoidTransform{}})
+
+	sc, err := partitionTypeToAvroSchema(manifestPartitionType(spec, sch))
+	m.Require().NoError(err)
+
+	fields := sc.(*avro.RecordSchema).Fields()
+	m.Require().Len(fields, 2)
+	m.Equal(avro.Int, fields[0].Type().Type())
+	m.Require().Equal(avro.Union, fields[1].Type().Type())
+	m.True(fields[1].Type().(*avro.UnionSchema).Nullable())
+
+	bldr, err := NewDataFileBuilder(spec, EntryContentData, "s3://bucket/data/file.parquet",
+		ParquetFile, map[int]any{1000: int32(1), 1001: nil}, 1, 1)
+	m.Require().NoError(err)
+
+	snapID := int64(1)
+	var buf bytes.Buffer
+	mf, err := WriteManifest("s3://bucket/metadata/manifest.avro", &buf, 2, spec, sch, snapID,
+		[]ManifestEntry{NewManifestEntry(EntryStatusADDED, &snapID, nil, nil, bldr.Build())})
+	m.Require().NoError(err)
+
+	entries, err := ReadManifest(mf, bytes.NewReader(buf.Bytes()), false)
+	m.Require().NoError(err)
+	m.Require().Len(entries, 1)
+	m.EqualValues(1, entries[0].DataFile().Partition()[1000])
+	m.Nil(entries[0].DataFile().Partition()[1001])
+}
+
+func (m *ManifestTestSuite) TestMixedTransformPartitionAvroSchema() {
+	sch := NewSchema(0,
+		NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int64, Required: true},
+		NestedField{ID: 2, Name: "ts", Type: PrimitiveTypes.TimestampTz, Required: true},
+		NestedField{ID: 3, Name: "category", Type: PrimitiveTypes.String, Required: true})
+	spec := NewPartitionSpecID(1,
+		PartitionField{FieldID: 1000, SourceID: 3, Name: "category", Transform: IdentityTransform{}},
+		PartitionField{FieldID: 1001, SourceID: 1, Name: "id_bucket", Transform: BucketTransform{NumBuckets: 8}},
+		PartitionField{FieldID: 1002, SourceID: 2, Name: "ts_day", Transform: DayTransform{}})
+
+	sc, err := partitionTypeToAvroSchema(manifestPartitionType(spec, sch))
+	m.Require().NoError(err)
+
+	fields := sc.(*avro.RecordSchema).Fields()
+	m.Require().Len(fields, 3)
+	for i, expected := range []struct {
+		name string
+		typ  avro.Type
+		id   int
+	}{
+		{"category", avro.String, 1000},
+		{"id_bucket", avro.Int, 1001},
+		{"ts_day", avro.Int, 1002},
+	} {
+		m.Equal(expected.name, fields[i].Name())
+		m.Equal(expected.typ, fields[i].Type().Type())
+		m.Equal(expected.id, fields[i].Prop("field-id"))
+	}
+
+	bldr, err := NewDataFileBuilder(spec, EntryContentData, "s3://bucket/data/file.parquet",
+		ParquetFile, map[int]any{1000: "books", 1001: int32(5), 1002: int32(19000)}, 1, 1)
+	m.Require().NoError(err)
+
+	snapID := int64(1)
+	var buf bytes.Buffer
+	mf, err := WriteManifest("s3://bucket/metadata/manifest.avro", &buf, 2, spec, sch, snapID,
+		[]ManifestEntry{NewManifestEntry(EntryStatusADDED, &snapID, nil, nil, bldr.Build())})
+	m.Require().NoError(err)
+
+	entries, err := ReadManifest(mf, bytes.NewReader(buf.Bytes()), false)
+	m.Require().NoError(err)
+	m.Require().Len(entries, 1)
+	part := entries[0].DataFile().Partition()
+	m.Equal("books", part[1000])
+	m.EqualValues(5, part[1001])
+	m.EqualValues(19000, part[1002])
+}
+
+func (m *ManifestTestSuite) TestManifestFileWriterSummaries() {
+	sch := NewSchema(0,
+		NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int32},
+		NestedField{ID: 2, Name: "category", Type: PrimitiveTypes.String})
+	spec := NewPartitionSpecID(1,
+		PartitionField{FieldID: 1000, SourceID: 1, Name: "id", Transform: IdentityTransform{}},
+		PartitionField{FieldID: 1001, SourceID: 2, Name: "category", Transform: IdentityTransform{}},
+		PartitionField{FieldID: 1002, SourceID: 1, Name: "id_void", Transform: VoidTransform{}})
+
+	f, err := os.CreateTemp(m.T().TempDir(), "manifest-*.avro")
+	m.Require().NoError(err)
+	defer f.Close()
+
+	snapID, seqNum := int64(1), int64(1)
+	w, err := NewManifestFileWriter(f.Name(), f, 2, spec, sch, snapID)
+	m.Require().NoError(err)
+
+	const numEntries = 5000
+	var (
+		rng        = rand.New(rand.NewSource(42))
+		partitions = make([]map[int]any, 0, numEntries)
+		added      int32
+		deleted    int32
+	)
+	for i := range numEntries {
+		part := map[int]any{
+			1000: int32(rng.Intn(100000) - 50000),
+			1001: fmt.Sprintf("category-%d", rng.Intn(1000)),
+			1002: nil,
+		}
+		partitions = append(partitions, part)
+
+		bldr, err := NewDataFileBuilder(spec, EntryContentData, fmt.Sprintf("s3://bucket/data/%d.parquet", i),
+			ParquetFile, part, 1, 1)
+		m.Require().NoError(err)
+
+		if i%7 == 0 {
+			m.Require().NoError(w.Write(NewManifestEntry(EntryStatusDELETED, &snapID, &seqNum, &seqNum, bldr.Build())))
+			deleted++
+		} else {
+			m.Require().NoError(w.Write(NewManifestEntry(EntryStatusADDED, &snapID, nil, nil, bldr.Build())))
+			added++
+		}
+	}
+
+	mf, err := w.Close()
+	m.Require().NoError(err)
+
+	info, err := f.Stat()
+	m.Require().NoError(err)
+	m.Equal(info.Size(), mf.Length())
+	m.Equal(f.Name(), mf.FilePath())
+	m.Equal(ManifestContentData, mf.ManifestContent())
+	m.Equal(added, mf.AddedDataFiles())
+	m.Equal(deleted, mf.DeletedDataFiles())
+
+	// compute the expected summaries over all the partitions at once
+	bruteForce := func(id int, lit func(any) Literal, less func(a, b any) bool) FieldSummary {
+		var (
+			summary      = FieldSummary{ContainsNaN: &falseBool}
+			lower, upper any
+		)
+		for _, part := range partitions {
+			v := part[id]
+			if v == nil {
+				summary.ContainsNull = true
+
+				continue
+			}
+
+			if lower == nil || less(v, lower) {
+				lower = v
+			}
+			if upper == nil || less(upper, v) {
+				upper = v
+			}
+		}
+
+		if lower != nil {
+			lb, err := lit(lower).MarshalBinary()
+			m.Require().NoError(err)
+			ub, err := lit(upper).MarshalBinary()
+			m.Require().NoError(err)
+			summary.LowerBound, summary.UpperBound = &lb, &ub
+		}
+
+		return summary
+	}
+
+	expected := []FieldSummary{
+		bruteForce(1000, func(v any) Literal { return NewLiteral(v.(int32)) },
+			func(a, b any) bool { return a.(int32) < b.(int32) }),
+		bruteForce(1001, func(v any) Literal { return NewLiteral(v.(string)) },
+			func(a, b any) bool { return a.(string) < b.(string) }),
+		bruteForce(1002, func(v any) Literal { return NewLiteral(v.(int32)) },
+			func(a, b any) bool { return a.(int32) < b.(int32) }),
+	}
+	m.Equal(expected, mf.Partitions())
+
+	_, err = f.Seek(0, io.SeekStart)
+	m.Require().NoError(err)
+	entries, err := ReadManifest(mf, f, false)
+	m.Require().NoError(err)
+	m.Len(entries, numEntries)
+}
+
+func (m *ManifestTestSuite) TestPartitionSummaryNaN() {
+	sch := NewSchema(0,
+		NestedField{ID: 1, Name: "score", Type: PrimitiveTypes.Float64, Required: true},
+		NestedField{ID: 2, Name: "ratio", Type: PrimitiveTypes.Float32, Required: true},
+		NestedField{ID: 3, Name: "weight", Type: PrimitiveTypes.Float64, Required: true})
+	spec := NewPartitionSpecID(1,
+		PartitionField{FieldID: 1000, SourceID: 1, Name: "score", Transform: IdentityTransform{}},
+		PartitionField{FieldID: 1001, SourceID: 2, Name: "ratio", Transform: IdentityTransform{}},
+		PartitionField{FieldID: 1002, SourceID: 3, Name: "weight", Transform: IdentityTransform{}})
+
+	nan32 := float32(math.NaN())
+	snapID := int64(1)
+	var entries []ManifestEntry
+	for i, part := range []map[int]any{
+		{1000: 1.5, 1001: nan32, 1002: 1.0},
+		{1000: math.NaN(), 1001: float32(0.25), 1002: 2.0},
+		{1000: -2.0, 1001: nan32, 1002: 3.0},
+	} {
+		bldr, err := NewDataFileBuilder(spec, EntryContentData, fmt.Sprintf("s3://bucket/data/%d.parquet", i),
+			ParquetFile, part, 1, 1)
+		m.Require().NoError(err)
+		entries = append(entries, NewManifestEntry(EntryStatusADDED, &snapID, nil, nil, bldr.Build()))
+	}
+
+	var buf bytes.Buffer
+	mf, err := WriteManifest("s3://bucket/metadata/manifest.avro", &buf, 2, spec, sch, snapID, entries)
+	m.Require().NoError(err)
+
+	bound := func(lit Literal) *[]byte {
+		b, err := lit.MarshalBinary()
+		m.Require().NoError(err)
+
+		return &b
+	}
+
+	trueBool := true
+	expected := []FieldSummary{
+		{ContainsNaN: &trueBool, LowerBound: bound(Float64Literal(-2.0)), UpperBound: bound(Float64Literal(1.5))},
+		{ContainsNaN: &trueBool, LowerBound: bound(Float32Literal(0.25)), UpperBound: bound(Float32Literal(0.25))},
+		{ContainsNaN: &falseBool, LowerBound: bound(Float64Literal(1.0)), UpperBound: bound(Float64Literal(3.0))},
+	}
+	m.Equal(expected, mf.Partitions())
+
+	// contains_nan survives a round trip through the manifest list
+	seqNum := int64(1)
+	var listBuf bytes.Buffer
+	m.Require().NoError(WriteManifestList(2, &listBuf, snapID, nil, &seqNum, []ManifestFile{mf}))
+	list, err := ReadManifestList(&listBuf)
+	m.Require().NoError(err)
+	m.Require().Len(list, 1)
+	m.Equal(expected, list[0].Partitions())
+
+	// a partition with only NaN values has no bounds
+	bldr, err := NewDataFileBuilder(spec, EntryContentData, "s3://bucket/data/nan.parquet",
+		ParquetFile, map[int]any{1000: math.NaN(), 1001: nan32, 1002: math.NaN()}, 1, 1)
+	m.Require().NoError(err)
+
+	buf.Reset()
+	mf, err = WriteManifest("s3://bucket/metadata/manifest.avro", &buf, 2, spec, sch, snapID,
+		[]ManifestEntry{NewManifestEntry(EntryStatusADDED, &snapID, nil, nil, bldr.Build())})
+	m.Require().NoError(err)
+	for _, summary := range mf.Partitions() {
+		m.True(*summary.ContainsNaN)
+		m.False(summary.ContainsNull)
+		m.Nil(summary.LowerBound)
+		m.Nil(summary.UpperBound)
+	}
+}
+
+func (m *ManifestTestSuite) TestReadManifestEntries() {
+	sch := NewSchema(0, NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int32, Required: true})
+
+	dataFile := func(path string) DataFile {
+		bldr, err := NewDataFileBuilder(*UnpartitionedSpec, EntryContentData, path, ParquetFile, nil, 1, 1)
+		m.Require().NoError(err)
+
+		return bldr.Build()
+	}
+
+	oldSnap, parentSnap, snapID := int64(1), int64(2), int64(3)
+	oldSeq, delSeq := int64(1), int64(2)
+	entries := []ManifestEntry{
+		NewManifestEntry(EntryStatusADDED, nil, nil, nil, dataFile("s3://bucket/data/added.parquet")),
+		NewManifestEntry(EntryStatusEXISTING, &oldSnap, &oldSeq, &oldSeq, dataFile("s3://bucket/data/existing.parquet")),
+		NewManifestEntry(EntryStatusDELETED, &snapID, &delSeq, &delSeq, dataFile("s3://bucket/data/deleted.parquet")),
+	}
+
+	path := filepath.Join(m.T().TempDir(), "manifest.avro")
+	f, err := os.Create(path)
+	m.Require().NoError(err)
+	written, err := WriteManifest(path, f, 2, *UnpartitionedSpec, sch, snapID, entries)
+	m.Require().NoError(err)
+	m.Require().NoError(f.Close())
+
+	// the sequence number of the manifest is assigned by the manifest list
+	var list bytes.Buffer
+	seqNum := int64(3)
+	m.Require().NoError(WriteManifestList(2, &list, snapID, &parentSnap, &seqNum, []ManifestFile{written}))
+	manifests, err := ReadManifestList(&list)
+	m.Require().NoError(err)
+	m.Require().Len(manifests, 1)
+	mf := manifests[0]
+
+	paths := func(entries []ManifestEntry) []string {
+		out := make([]string, len(entries))
+		for i, e := range entries {
+			out[i] = e.DataFile().FilePath()
+		}
+
+		return out
+	}
+
+	tests := []struct {
+		filter   ManifestEntryFilter
+		expected []string
+	}{
+		{EntryFilterAll, []string{"s3://bucket/data/added.parquet", "s3://bucket/data/existing.parquet", "s3://bucket/data/deleted.parquet"}},
+		{EntryFilterLive, []string{"s3://bucket/data/added.parquet", "s3://bucket/data/existing.parquet"}},
+		{EntryFilterAdded, []string{"s3://bucket/data/added.parquet"}},
+		{EntryFilterExisting, []string{"s3://bucket/data/existing.parquet"}},
+		{EntryFilterDeleted, []string{"s3://bucket/data/deleted.parquet"}},
+	}
+
+	for _, tt := range tests {
+		read, err := ReadManifestEntries(iceio.LocalFS{}, mf, tt.filter)
+		m.Require().NoError(err)
+		m.Equal(tt.expected, paths(read))
+	}
+
+	// added entries inherit the snapshot and sequence numbers of the
+	// manifest, while existing and deleted entries keep their own
+	read, err := ReadManifestEntries(iceio.LocalFS{}, mf, EntryFilterAll)
+	m.Require().NoError(err)
+	m.Require().Len(read, 3)
+
+	m.EqualValues(snapID, read[0].SnapshotID())
+	m.EqualValues(3, read[0].SequenceNum())
+	m.EqualValues(3, *read[0].FileSequenceNum())
+
+	m.EqualValues(oldSnap, read[1].SnapshotID())
+	m.EqualValues(oldSeq, read[1].SequenceNum())
+	m.EqualValues(oldSeq, *read[1].FileSequenceNum())
+
+	m.EqualValues(snapID, read[2].SnapshotID())
+	m.EqualValues(delSeq, read[2].SequenceNum())
+
+	_, err = ReadManifestEntries(iceio.LocalFS{}, mf, ManifestEntryFilter(10))
+	m.ErrorIs(err, ErrInvalidArgument)
+}
+
+func (m *ManifestTestSuite) TestRecomputePartitionSummaries() {
+	sch := NewSchema(0,
+		NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int64, Required: true},
+		NestedField{ID: 2, Name: "price", Type: PrimitiveTypes.Float64})
+	spec := NewPartitionSpecID(1,
+		PartitionField{FieldID: 1000, SourceID: 1, Name: "id_bucket", Transform: BucketTransform{NumBuckets: 8}},
+		PartitionField{FieldID: 1001, SourceID: 2, Name: "price", Transform: IdentityTransform{}})
+
+	snapID, seqNum := int64(1), int64(1)
+	entry := func(status ManifestEntryStatus, path string, bucket int32, price any) ManifestEntry {
+		bldr, err := NewDataFileBuilder(spec, EntryContentData, path, ParquetFile,
+			map[int]any{1000: bucket, 1001: price}, 1, 1)
+		m.Require().NoError(err)
+
+		return NewManifestEntry(status, &snapID, &seqNum, &seqNum, bldr.Build())
+	}
+
+	entries := []ManifestEntry{
+		entry(EntryStatusADDED, "s3://bucket/data/1.parquet", 5, 1.5),
+		entry(EntryStatusADDED, "s3://bucket/data/2.parquet", 2, math.NaN()),
+		entry(EntryStatusEXISTING, "s3://bucket/data/3.parquet", 7, nil),
+		entry(EntryStatusDELETED, "s3://bucket/data/4.parquet", 0, -3.0),
+	}
+
+	summaries, err := RecomputePartitionSummaries(entries, &spec, sch)
+	m.Require().NoError(err)
+	m.Require().Len(summaries, 2)
+
+	bound := func(lit Literal) *[]byte {
+		b, err := lit.MarshalBinary()
+		m.Require().NoError(err)
+
+		return &b
+	}
+	falseVal, trueVal := false, true
+
+	m.Equal(FieldSummary{
+		ContainsNull: false, ContainsNaN: &falseVal,
+		LowerBound: bound(Int32Literal(0)), UpperBound: bound(Int32Literal(7)),
+	}, summaries[0])
+	// the float bounds are compared as floats, not as their encoded bytes
+	m.Equal(FieldSummary{
+		ContainsNull: true, ContainsNaN: &trueVal,
+		LowerBound: bound(Float64Literal(-3.0)), UpperBound: bound(Float64Literal(1.5)),
+	}, summaries[1])
+
+	// the summaries match the ones computed when writing the manifest
+	mf, err := WriteManifest("s3://bucket/metadata/manifest.avro", io.Discard, 2, spec, sch, snapID, entries)
+	m.Require().NoError(err)
+	m.Equal(mf.Partitions(), summaries)
+}
+
+func (m *ManifestTestSuite) TestManifestWriterContent() {
+	sch := NewSchema(0, NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int32})
+	snapID := int64(1)
+
+	_, err := NewManifestWriter(1, io.Discard, *UnpartitionedSpec, sch, snapID,
+		WithManifestContent(ManifestContentDeletes))
+	m.Error(err)
+
+	var buf bytes.Buffer
+	w, err := NewManifestFileWriter("s3://bucket/metadata/deletes.avro", &buf, 2, *UnpartitionedSpec, sch, snapID,
+		WithManifestContent(ManifestContentDeletes))
+	m.Require().NoError(err)
+
+	dataFile, err := NewDataFileBuilder(*UnpartitionedSpec, EntryContentData, "s3://bucket/data/1.parquet",
+		ParquetFile, nil, 1, 1)
+	m.Require().NoError(err)
+	m.ErrorContains(w.Write(NewManifestEntry(EntryStatusADDED, &snapID, nil, nil, dataFile.Build())),
+		"cannot add Data file to a deletes manifest")
+
+	deleteFile, err := NewDataFileBuilder(*UnpartitionedSpec, EntryContentPosDeletes, "s3://bucket/data/1-deletes.parquet",
+		ParquetFile, nil, 3, 1)
+	m.Require().NoError(err)
+	m.Require().NoError(w.Write(NewManifestEntry(EntryStatusADDED, &snapID, nil, nil, deleteFile.Build())))
+
+	mf, err := w.Close()
+	m.Require().NoError(err)
+	m.Equal(ManifestContentDeletes, mf.ManifestContent())
+	m.EqualValues(3, mf.AddedRows())
+	m.EqualValues(buf.Len(), mf.Length())
+}
+
+func (m *ManifestTestSuite) TestManifestOCFHeaderMetadata() {
+	sch := NewSchema(3,
+		NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int32, Required: true})
+	spec := NewPartitionSpecID(1,
+		PartitionField{FieldID: 1000, SourceID: 1, Name: "id", Transform: IdentityTransform{}})
+
+	bldr, err := NewDataFileBuilder(spec, EntryContentData, "s3://bucket/data/file.parquet",
+		ParquetFile, map[int]any{1000: int32(1)}, 10, 1024)
+	m.Require().NoError(err)
+
+	snapID := int64(1)
+	entries := []ManifestEntry{NewManifestEntry(EntryStatusADDED, &snapID, nil, nil, bldr.Build())}
+
+	var buf bytes.Buffer
+	_, err = WriteManifest("s3://bucket/metadata/manifest.avro", &buf, 1, spec, sch, snapID, entries)
+	m.Require().NoError(err)
+
+	dec, err := ocf.NewDecoder(&buf)
+	m.Require().NoError(err)
+
+	meta := dec.Metadata()
+	m.Equal("1", string(meta["format-version"]))
+	m.Equal("3", string(meta["schema-id"]))
+	m.Equal("1", string(meta["partition-spec-id"]))
+	m.Equal("data", string(meta["content"]))
+	m.Equal(string(ocf.Deflate), string(meta["avro.codec"]))
+	m.JSONEq(`[{"source-id": 1, "field-id": 1000, "name": "id", "transform": "identity"}]`,
+		string(meta["partition-spec"]))
+
+	var schema Schema
+	m.Require().NoError(json.Unmarshal(meta["schema"], &schema))
+	m.True(sch.Equals(&schema))
+
+	buf.Reset()
+	m.Require().NoError(WriteManifestList(1, &buf, snapID, nil, nil, nil))
+
+	dec, err = ocf.NewDecoder(&buf)
+	m.Require().NoError(err)
+
+	meta = dec.Metadata()
+	m.Equal("1", string(meta["format-version"]))
+	m.Equal("1", string(meta["snapshot-id"]))
+	m.Equal("null", string(meta["parent-snapshot-id"]))
+	m.Equal(string(ocf.Deflate), string(meta["avro.codec"]))
+}
+
+func (m *ManifestTestSuite) TestManifestWriterCodecs() {
+	sch := NewSchema(0,
+		NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int32, Required: true})
+	spec := NewPartitionSpecID(1,
+		PartitionField{FieldID: 1000, SourceID: 1, Name: "id", Transform: IdentityTransform{}})
+
+	bldr, err := NewDataFileBuilder(spec, EntryContentData, "s3://bucket/data/file.parquet",
+		ParquetFile, map[int]any{1000: int32(1)}, 10, 1024)
+	m.Require().NoError(err)
+
+	snapID, seqNum := int64(1), int64(1)
+	entries := []ManifestEntry{NewManifestEntry(EntryStatusADDED, &snapID, nil, nil, bldr.Build())}
+
+	for _, codec := range []ocf.CodecName{ocf.Null, ocf.Deflate, ocf.Snappy, ocf.ZStandard} {
+		m.Run(string(codec), func() {
+			var buf bytes.Buffer
+			mf, err := WriteManifest("s3://bucket/metadata/manifest.avro", &buf, 2,
+				spec, sch, snapID, entries, WithAvroCodec(codec))
+			m.Require().NoError(err)
+
+			dec, err := ocf.NewDecoder(bytes.NewReader(buf.Bytes()))
+			m.Require().NoError(err)
+			m.Equal(string(codec), string(dec.Metadata()["avro.codec"]))
+
+			read, err := ReadManifest(mf, &buf, false)
+			m.Require().NoError(err)
+			m.Require().Len(read, 1)
+			m.Equal("s3://bucket/data/file.parquet", read[0].DataFile().FilePath())
+
+			buf.Reset()
+			m.Require().NoError(WriteManifestList(2, &buf, snapID, nil, &seqNum,
+				[]ManifestFile{mf}, WithAvroCodec(codec)))
+
+			list, err := ReadManifestList(&buf)
+			m.Require().NoError(err)
+			m.Require().Len(list, 1)
+			m.Equal(mf.FilePath(), list[0].FilePath())
+		})
+	}
+
+	var buf bytes.Buffer
+	_, err = NewManifestListWriterV2(&buf, snapID, seqNum, nil, WithAvroCodec("lz4"))
+	m.Error(err)
+}
+
 func TestManifests(t *testing.T) {
 	suite.Run(t, new(ManifestTestSuite))
 }
+
+func BenchmarkWriteManifestListCodecs(b *testing.B) {
+	const numFiles = 10_000
+
+	lower, upper := []byte{0x01, 0x00, 0x00, 0x00}, []byte{0x02, 0x00, 0x00, 0x00}
+	files := make([]ManifestFile, numFiles)
+	for i := range files {
+		files[i] = NewManifestFile(2, fmt.Sprintf("s3://bucket/table/metadata/%d-m0.avro", i), 7989, 0, 1234).
+			SequenceNum(1, 1).
+			AddedFiles(3).
+			AddedRows(int64(i)).
+			Partitions([]FieldSummary{{ContainsNull: true, LowerBound: &lower, UpperBound: &upper}}).
+			Build()
+	}
+
+	seqNum := int64(1)
+	for _, codec := range []ocf.CodecName{ocf.Null, ocf.Deflate, ocf.Snappy, ocf.ZStandard} {
+		b.Run(string(codec), func(b *testing.B) {
+			var buf bytes.Buffer
+			for b.Loop() {
+				buf.Reset()
+				if err := WriteManifestList(2, &buf, 1234, nil, &seqNum, files, WithAvroCodec(codec)); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(buf.Len()), "bytes/op")
+		})
+	}
+}
@@ -18,6 +18,7 @@
 package iceberg
 
 import (
+	"encoding/json"
 	"fmt"
 	"slices"
 	"strconv"
@@ -82,8 +83,67 @@ func (m *MappedField) String() string {
 	return bldr.String()
 }
 
+// NameMapping maps the column names of data files which were written
+// without field ids, such as imported Parquet files, to Iceberg field ids.
+// It is stored as JSON in the schema.name-mapping.default table property.
 type NameMapping []MappedField
 
+// ParseNameMapping parses the JSON representation of a name mapping.
+func ParseNameMapping(data string) (NameMapping, error) {
+	var nm NameMapping
+	if err := json.Unmarshal([]byte(data), &nm); err != nil {
+		return nil, fmt.Errorf("%w: invalid name mapping: %w", ErrInvalidArgument, err)
+	}
+
+	return nm, nil
+}
+
+// Find returns the mapped field for a path of column names, such as
+// "location", "lat" for the nested field location.lat, or nil if there is
+// no mapping for it. Each name may match any of the names of the field.
+func (nm NameMapping) Find(names ...string) *MappedField {
+	if len(names) == 0 {
+		return nil
+	}
+
+	field := &MappedField{Fields: nm}
+	for _, name := range names {
+		if field = field.GetField(name); field == nil {
+			return nil
+		}
+	}
+
+	return field
+}
+
+// FindByName returns the mapped field for a dotted column name such as
+// "location.lat", or nil if there is no mapping for it. Unlike Find, it
+// also matches names which contain a dot themselves.
+func (nm NameMapping) FindByName(name string) *MappedField {
+	return nm.IndexByName()[name]
+}
+
+// IndexByName returns the mapped fields indexed by their full dotted name,
+// fields with multiple names are indexed under every combination of them.
+func (nm NameMapping) IndexByName() map[string]*MappedField {
+	index := make(map[string]*MappedField)
+
+	var visit func(prefix string, fields []MappedField)
+	visit = func(prefix string, fields []MappedField) {
+		for i := range fields {
+			f := &fields[i]
+			for _, name := range f.Names {
+				fullName := prefix + name
+				index[fullName] = f
+				visit(fullName+".", f.Fields)
+			}
+		}
+	}
+	visit("", nm)
+
+	return index
+}
+
 func (nm NameMapping) String() string {
 	var bldr strings.Builder
 	bldr.WriteString("[\n")
@@ -150,3 +150,32 @@ func TestNameMappingToString(t *testing.T) {
 		}},
 	}.String())
 }
+
+func TestNameMappingFind(t *testing.T) {
+	nm, err := iceberg.ParseNameMapping(`[
+		{"field-id": 1, "names": ["id", "record_id"]},
+		{"field-id": 2, "names": ["data.raw"]},
+		{"field-id": 3, "names": ["location"], "fields": [
+			{"field-id": 4, "names": ["latitude", "lat"]},
+			{"field-id": 5, "names": ["longitude", "long"]}
+		]}
+	]`)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, nm.Find("record_id").ID())
+	assert.Equal(t, 3, nm.Find("location").ID())
+	assert.Equal(t, 4, nm.Find("location", "lat").ID())
+	assert.Equal(t, 5, nm.Find("location", "longitude").ID())
+	assert.Nil(t, nm.Find("location", "altitude"))
+	assert.Nil(t, nm.Find("lat"))
+	assert.Nil(t, nm.Find())
+
+	assert.Equal(t, 4, nm.FindByName("location.latitude").ID())
+	assert.Equal(t, 5, nm.FindByName("location.long").ID())
+	assert.Equal(t, 2, nm.FindByName("data.raw").ID())
+	assert.Nil(t, nm.FindByName("location.altitude"))
+	assert.Len(t, nm.IndexByName(), 8)
+
+	_, err = iceberg.ParseNameMapping(`{"names": "id"}`)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
@@ -18,6 +18,7 @@
 package iceberg
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"iter"
@@ -212,7 +213,12 @@ func (ps *PartitionSpec) LastAssignedFieldID() int {
 	return id
 }
 
-// PartitionType produces a struct of the partition spec.
+// PartitionType produces a struct of the partition spec. Each field of the
+// struct uses the partition field ID and name, and the result type of its
+// transform applied to the source column type in the given schema, e.g. int
+// for a bucket transform. Void transforms keep the source type, as they only
+// ever produce null. Fields whose source column isn't in the schema are
+// omitted.
 //
 // The partition fields should be optional:
 //   - All partition transforms are required to produce null if the input value
@@ -221,7 +227,7 @@ func (ps *PartitionSpec) LastAssignedFieldID() int {
 //     have the result field and it may be null.
 //
 // There is a case where we can guarantee that a partition field in the first
-// and only parittion spec that uses a required source column will never be
+// and only partition spec that uses a required source column will never be
 // null, but it doesn't seem worth tracking this case.
 func (ps *PartitionSpec) PartitionType(schema *Schema) *StructType {
 	nestedFields := []NestedField{}
@@ -263,6 +269,107 @@ func (ps *PartitionSpec) PartitionToPath(data structLike, sc *Schema) string {
 	return path.Join(segments...)
 }
 
+// PartitionFromPath parses a partition path in the form produced by
+// [PartitionSpec.PartitionToPath] back into the partition values, in the
+// order of the partition fields. Null values are returned as nil.
+func (ps *PartitionSpec) PartitionFromPath(p string, sc *Schema) ([]Literal, error) {
+	partType := ps.PartitionType(sc)
+
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	if p == "" {
+		segments = nil
+	}
+
+	if len(segments) != len(partType.FieldList) {
+		return nil, fmt.Errorf("%w: partition path '%s' has %d segments, expected %d",
+			ErrInvalidArgument, p, len(segments), len(partType.FieldList))
+	}
+
+	values := make([]Literal, len(segments))
+	for i, seg := range segments {
+		name, value, ok := strings.Cut(seg, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid partition path segment '%s'",
+				ErrInvalidArgument, seg)
+		}
+
+		name, err := url.QueryUnescape(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid partition path segment '%s': %s",
+				ErrInvalidArgument, seg, err)
+		}
+
+		field := partType.FieldList[i]
+		if name != field.Name {
+			return nil, fmt.Errorf("%w: expected partition field '%s', got '%s'",
+				ErrInvalidArgument, field.Name, name)
+		}
+
+		if value, err = url.QueryUnescape(value); err != nil {
+			return nil, fmt.Errorf("%w: invalid partition path segment '%s': %s",
+				ErrInvalidArgument, seg, err)
+		}
+
+		if values[i], err = FromHumanString(field.Type, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// ToHumanString returns the human readable form of a partition value of
+// type t as used in partition paths, e.g. dates as 2017-12-01, binary
+// values base64 encoded and nil as "null". The result isn't URL escaped.
+func ToHumanString(t Type, lit Literal) string {
+	if lit == nil {
+		return "null"
+	}
+
+	if _, ok := t.(TimestampTzType); ok {
+		if ts, ok := lit.Any().(Timestamp); ok {
+			return ts.ToTime().Format("2006-01-02T15:04:05.999999") + "+00:00"
+		}
+	}
+
+	return IdentityTransform{}.ToHumanStr(lit.Any())
+}
+
+// FromHumanString parses the human readable form of a partition value, as
+// produced by [ToHumanString], into a literal of type t. "null" is parsed
+// as a nil literal.
+func FromHumanString(t Type, s string) (Literal, error) {
+	if s == "null" {
+		return nil, nil
+	}
+
+	switch t := t.(type) {
+	case BinaryType:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: casting '%s' to %s - %s",
+				ErrBadCast, s, t, err.Error())
+		}
+
+		return BinaryLiteral(b), nil
+	case FixedType:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: casting '%s' to %s - %s",
+				ErrBadCast, s, t, err.Error())
+		}
+
+		if len(b) != t.len {
+			return nil, fmt.Errorf("%w: cast '%s' to %s - wrong length",
+				ErrBadCast, s, t)
+		}
+
+		return FixedLiteral(b), nil
+	}
+
+	return StringLiteral(s).To(t)
+}
+
 // AssignFreshPartitionSpecIDs creates a new PartitionSpec by reassigning the field IDs
 // from the old schema to the corresponding fields in the fresh schema, while re-assigning
 // the actual Spec IDs to 1000 + the position of the field in the partition spec.
@@ -124,6 +124,29 @@ func TestSerializePartitionSpec(t *testing.T) {
 	assert.True(t, spec.Equals(outspec))
 }
 
+func TestSerializeVoidPartitionSpec(t *testing.T) {
+	const specJSON = `{
+		"spec-id": 1,
+		"fields": [
+			{
+				"source-id": 1,
+				"field-id": 1000,
+				"transform": "void",
+				"name": "str_void"
+			}
+		]
+	}`
+
+	var spec iceberg.PartitionSpec
+	require.NoError(t, json.Unmarshal([]byte(specJSON), &spec))
+	require.Equal(t, 1, spec.NumFields())
+	assert.Equal(t, iceberg.VoidTransform{}, spec.Field(0).Transform)
+
+	data, err := json.Marshal(spec)
+	require.NoError(t, err)
+	assert.JSONEq(t, specJSON, string(data))
+}
+
 func TestPartitionType(t *testing.T) {
 	spec := iceberg.NewPartitionSpecID(3,
 		iceberg.PartitionField{
@@ -156,6 +179,37 @@ func TestPartitionType(t *testing.T) {
 	assert.Truef(t, expected.Equals(actual), "expected: %s, got: %s", expected, actual)
 }
 
+func TestPartitionTypeMixedTransforms(t *testing.T) {
+	schema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz, Required: true},
+		iceberg.NestedField{ID: 4, Name: "category", Type: iceberg.PrimitiveTypes.String, Required: true})
+
+	spec := iceberg.NewPartitionSpecID(1,
+		iceberg.PartitionField{SourceID: 4, FieldID: 1000, Name: "category", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 1, FieldID: 1001, Name: "id_bucket", Transform: iceberg.BucketTransform{NumBuckets: 16}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1002, Name: "ts_day", Transform: iceberg.DayTransform{}},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1003, Name: "data_void", Transform: iceberg.VoidTransform{}},
+	)
+
+	expected := &iceberg.StructType{
+		FieldList: []iceberg.NestedField{
+			{ID: 1000, Name: "category", Type: iceberg.PrimitiveTypes.String},
+			{ID: 1001, Name: "id_bucket", Type: iceberg.PrimitiveTypes.Int32},
+			{ID: 1002, Name: "ts_day", Type: iceberg.PrimitiveTypes.Int32},
+			{ID: 1003, Name: "data_void", Type: iceberg.PrimitiveTypes.String},
+		},
+	}
+
+	actual := spec.PartitionType(schema)
+	assert.Truef(t, expected.Equals(actual), "expected: %s, got: %s", expected, actual)
+	// partition fields are optional even when the source column is required
+	for _, f := range actual.FieldList {
+		assert.Falsef(t, f.Required, "expected %s to be optional", f.Name)
+	}
+}
+
 type partitionRecord []any
 
 func (p partitionRecord) Size() int            { return len(p) }
@@ -246,3 +300,83 @@ func TestGetPartitionFieldName(t *testing.T) {
 		})
 	}
 }
+
+func TestHumanStringRoundTrip(t *testing.T) {
+	dec, err := iceberg.StringLiteral("-1.50").To(iceberg.DecimalTypeOf(9, 2))
+	require.NoError(t, err)
+
+	tests := []struct {
+		typ      iceberg.Type
+		lit      iceberg.Literal
+		expected string
+	}{
+		{iceberg.PrimitiveTypes.Date, iceberg.NewLiteral(iceberg.Date(17501)), "2017-12-01"},
+		{iceberg.PrimitiveTypes.Date, iceberg.NewLiteral(iceberg.Date(-1)), "1969-12-31"},
+		{iceberg.DecimalTypeOf(9, 2), dec, "-1.50"},
+		{iceberg.PrimitiveTypes.String, iceberg.NewLiteral("a/b/c=d"), "a/b/c=d"},
+		{iceberg.PrimitiveTypes.String, nil, "null"},
+		{iceberg.PrimitiveTypes.Int32, nil, "null"},
+		{iceberg.PrimitiveTypes.Bool, iceberg.NewLiteral(true), "true"},
+		{iceberg.PrimitiveTypes.Int64, iceberg.NewLiteral(int64(-1234567890000)), "-1234567890000"},
+		{iceberg.PrimitiveTypes.Binary, iceberg.NewLiteral([]byte("foo")), "Zm9v"},
+		{iceberg.PrimitiveTypes.Timestamp, iceberg.NewLiteral(iceberg.Timestamp(1512151975038194)),
+			"2017-12-01T18:12:55.038194"},
+		{iceberg.PrimitiveTypes.TimestampTz, iceberg.NewLiteral(iceberg.Timestamp(1512151975038194)),
+			"2017-12-01T18:12:55.038194+00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ.String()+"/"+tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, iceberg.ToHumanString(tt.typ, tt.lit))
+
+			lit, err := iceberg.FromHumanString(tt.typ, tt.expected)
+			require.NoError(t, err)
+			if tt.lit == nil {
+				assert.Nil(t, lit)
+
+				return
+			}
+			assert.True(t, tt.lit.Equals(lit), "expected %s, got %s", tt.lit, lit)
+		})
+	}
+
+	_, err = iceberg.FromHumanString(iceberg.PrimitiveTypes.Date, "2017-13-01")
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
+}
+
+func TestPartitionSpecFromPath(t *testing.T) {
+	schema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "str", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 2, Name: "dt", Type: iceberg.PrimitiveTypes.Date},
+		iceberg.NestedField{ID: 3, Name: "int", Type: iceberg.PrimitiveTypes.Int32, Required: true})
+
+	spec := iceberg.NewPartitionSpecID(3,
+		iceberg.PartitionField{
+			SourceID: 1, FieldID: 1000,
+			Transform: iceberg.IdentityTransform{}, Name: "my/str",
+		},
+		iceberg.PartitionField{
+			SourceID: 2, FieldID: 1001,
+			Transform: iceberg.IdentityTransform{}, Name: "dt",
+		},
+		iceberg.PartitionField{
+			SourceID: 3, FieldID: 1002,
+			Transform: iceberg.BucketTransform{NumBuckets: 25}, Name: "int_bucket",
+		})
+
+	record := partitionRecord{"a/b c+d", nil, int32(10)}
+	path := spec.PartitionToPath(record, schema)
+	assert.Equal(t, "my%2Fstr=a%2Fb+c%2Bd/dt=null/int_bucket=10", path)
+
+	values, err := spec.PartitionFromPath(path, schema)
+	require.NoError(t, err)
+	assert.Equal(t, []iceberg.Literal{
+		iceberg.NewLiteral("a/b c+d"), nil, iceberg.NewLiteral(int32(10)),
+	}, values)
+
+	_, err = spec.PartitionFromPath("my%2Fstr=a/dt=null", schema)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	_, err = spec.PartitionFromPath("str=a/dt=null/int_bucket=10", schema)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
@@ -0,0 +1,237 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// GenericRecord is a row of a struct type, for building the rows of a
+// table in plain Go values rather than Arrow arrays. It implements the
+// Size, Get and Set methods which accessors and the expression
+// evaluators operate on.
+//
+// The value of each field must be nil or of the Go type used for the
+// field's type: bool, int32, int64, float32, float64, [Date], [Time],
+// [Timestamp] for both timestamp types, string, []byte for binary and
+// fixed, [uuid.UUID] and [Decimal] with the scale of the type. Nested
+// structs are *GenericRecord of the nested struct type, lists are []any
+// and maps are map[any]any.
+type GenericRecord struct {
+	typ    *StructType
+	values []any
+}
+
+// NewGenericRecord returns a record of the given struct type with all
+// of its fields set to nil.
+func NewGenericRecord(typ *StructType) *GenericRecord {
+	return &GenericRecord{typ: typ, values: make([]any, len(typ.FieldList))}
+}
+
+// Struct returns the type of the record.
+func (r *GenericRecord) Struct() *StructType { return r.typ }
+
+func (r *GenericRecord) Size() int { return len(r.values) }
+
+// Get returns the value of the field at pos, and panics if pos is out
+// of bounds.
+func (r *GenericRecord) Get(pos int) any {
+	r.checkPos(pos)
+
+	return r.values[pos]
+}
+
+// Set changes the value of the field at pos. It panics with an
+// ErrInvalidArgument error if pos is out of bounds, if the value isn't of
+// the Go type for the field's type or if it is nil for a required field.
+// Use SetField to get the error instead.
+func (r *GenericRecord) Set(pos int, val any) {
+	r.checkPos(pos)
+	if err := checkRecordValue(r.typ.FieldList[pos], val); err != nil {
+		panic(err)
+	}
+
+	r.values[pos] = val
+}
+
+// GetField returns the value of the field with the given name, and
+// false if the struct doesn't have such a field.
+func (r *GenericRecord) GetField(name string) (any, bool) {
+	for i, f := range r.typ.FieldList {
+		if f.Name == name {
+			return r.values[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// SetField changes the value of the field with the given name, returning
+// an error if there is no such field or the value doesn't match its type.
+func (r *GenericRecord) SetField(name string, val any) error {
+	for i, f := range r.typ.FieldList {
+		if f.Name != name {
+			continue
+		}
+
+		if err := checkRecordValue(f, val); err != nil {
+			return err
+		}
+		r.values[i] = val
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: field '%s' not found in %s", ErrInvalidArgument, name, r.typ)
+}
+
+func (r *GenericRecord) checkPos(pos int) {
+	if pos < 0 || pos >= len(r.values) {
+		panic(fmt.Errorf("%w: position %d out of bounds for %s",
+			ErrInvalidArgument, pos, r.typ))
+	}
+}
+
+func checkRecordValue(field NestedField, val any) error {
+	if val == nil {
+		if field.Required {
+			return fmt.Errorf("%w: required field '%s' cannot be null",
+				ErrInvalidArgument, field.Name)
+		}
+
+		return nil
+	}
+
+	if !isRecordValue(field.Type, val) {
+		return fmt.Errorf("%w: invalid value %v of type %T for field '%s' of type %s",
+			ErrInvalidArgument, val, val, field.Name, field.Type)
+	}
+
+	return nil
+}
+
+func isRecordValue(typ Type, val any) bool {
+	switch t := typ.(type) {
+	case BooleanType:
+		_, ok := val.(bool)
+
+		return ok
+	case Int32Type:
+		_, ok := val.(int32)
+
+		return ok
+	case Int64Type:
+		_, ok := val.(int64)
+
+		return ok
+	case Float32Type:
+		_, ok := val.(float32)
+
+		return ok
+	case Float64Type:
+		_, ok := val.(float64)
+
+		return ok
+	case DateType:
+		_, ok := val.(Date)
+
+		return ok
+	case TimeType:
+		_, ok := val.(Time)
+
+		return ok
+	case TimestampType, TimestampTzType:
+		_, ok := val.(Timestamp)
+
+		return ok
+	case StringType:
+		_, ok := val.(string)
+
+		return ok
+	case BinaryType:
+		_, ok := val.([]byte)
+
+		return ok
+	case FixedType:
+		v, ok := val.([]byte)
+
+		return ok && len(v) == t.Len()
+	case UUIDType:
+		_, ok := val.(uuid.UUID)
+
+		return ok
+	case DecimalType:
+		v, ok := val.(Decimal)
+
+		return ok && v.Scale == t.Scale()
+	case *StructType:
+		v, ok := val.(*GenericRecord)
+
+		return ok && v.typ.Equals(t)
+	case *ListType:
+		v, ok := val.([]any)
+		if !ok {
+			return false
+		}
+
+		for _, elem := range v {
+			if elem == nil {
+				if t.ElementRequired {
+					return false
+				}
+
+				continue
+			}
+
+			if !isRecordValue(t.Element, elem) {
+				return false
+			}
+		}
+
+		return true
+	case *MapType:
+		v, ok := val.(map[any]any)
+		if !ok {
+			return false
+		}
+
+		for k, item := range v {
+			if k == nil || !isRecordValue(t.KeyType, k) {
+				return false
+			}
+
+			if item == nil {
+				if t.ValueRequired {
+					return false
+				}
+
+				continue
+			}
+
+			if !isRecordValue(t.ValueType, item) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenericRecord(t *testing.T) {
+	location := &iceberg.StructType{FieldList: []iceberg.NestedField{
+		{ID: 4, Name: "lat", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+		{ID: 5, Name: "long", Type: iceberg.PrimitiveTypes.Float64},
+	}}
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "tags", Type: &iceberg.ListType{
+			ElementID: 6, Element: iceberg.PrimitiveTypes.String, ElementRequired: true,
+		}},
+		iceberg.NestedField{ID: 3, Name: "location", Type: location},
+	)
+
+	st := sc.AsStruct()
+	rec := iceberg.NewGenericRecord(&st)
+	assert.Equal(t, 3, rec.Size())
+	assert.Nil(t, rec.Get(2))
+
+	rec.Set(0, int64(1))
+	require.NoError(t, rec.SetField("tags", []any{"a", "b"}))
+
+	loc := iceberg.NewGenericRecord(location)
+	loc.Set(0, 52.1)
+	require.NoError(t, loc.SetField("long", 4.3))
+	rec.Set(2, loc)
+
+	// values set on the nested record after it was set are visible
+	loc.Set(1, 4.4)
+
+	acc, err := iceberg.BuildAccessor(sc, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 4.4, acc.Get(rec))
+
+	acc, err = iceberg.BuildAccessor(sc, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), acc.Get(rec))
+
+	v, ok := rec.GetField("tags")
+	assert.True(t, ok)
+	assert.Equal(t, []any{"a", "b"}, v)
+	_, ok = rec.GetField("missing")
+	assert.False(t, ok)
+
+	// an unset struct makes its nested fields null
+	rec.Set(2, nil)
+	acc, err = iceberg.BuildAccessor(sc, 5)
+	require.NoError(t, err)
+	assert.Nil(t, acc.Get(rec))
+
+	// Set panics on invalid positions and values, SetField returns an error
+	assert.PanicsWithError(t, "invalid argument: invalid value 1 of type int for field 'id' of type long",
+		func() { rec.Set(0, 1) })
+	assert.Panics(t, func() { rec.Set(0, nil) })
+	assert.Panics(t, func() { rec.Set(3, int64(1)) })
+	assert.Panics(t, func() { rec.Get(-1) })
+	assert.Panics(t, func() { rec.Set(2, iceberg.NewGenericRecord(&st)) })
+	assert.Equal(t, int64(1), rec.Get(0))
+
+	assert.ErrorIs(t, rec.SetField("tags", []any{"a", nil}), iceberg.ErrInvalidArgument)
+	assert.ErrorIs(t, rec.SetField("tags", []string{"a"}), iceberg.ErrInvalidArgument)
+	assert.ErrorIs(t, loc.SetField("lat", float32(1)), iceberg.ErrInvalidArgument)
+	assert.ErrorIs(t, rec.SetField("missing", int64(1)), iceberg.ErrInvalidArgument)
+}
@@ -0,0 +1,278 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import "fmt"
+
+// ResidualEvaluator computes the residual of a row filter for the rows of a
+// single partition. The residual is the part of the filter that is not
+// already decided by the partition values: predicates the partition is
+// guaranteed to satisfy are replaced with AlwaysTrue, and predicates it can
+// never satisfy are replaced with AlwaysFalse, so that partition columns
+// don't need to be re-evaluated for every row.
+type ResidualEvaluator struct {
+	residual residualFunc
+}
+
+// residualFunc returns the residual of an expression for a partition.
+type residualFunc func(partition structLike) (BooleanExpression, error)
+
+// NewResidualEvaluator binds expr to the given schema and returns an
+// evaluator producing residuals for partitions of the provided spec. The
+// projections of the predicates of expr onto the partition fields are
+// computed and bound once here, so that computing a residual only has to
+// evaluate them against the partition values.
+func NewResidualEvaluator(spec PartitionSpec, schema *Schema, expr BooleanExpression, caseSensitive bool) (*ResidualEvaluator, error) {
+	expr, err := RewriteNotExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	bound, err := BindExpr(schema, expr, caseSensitive)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.NumFields() == 0 {
+		return &ResidualEvaluator{residual: func(structLike) (BooleanExpression, error) {
+			return bound, nil
+		}}, nil
+	}
+
+	residual, err := VisitExpr(bound, &residualBuilder{
+		spec:       spec,
+		partSchema: NewSchema(0, spec.PartitionType(schema).FieldList...),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResidualEvaluator{residual: residual}, nil
+}
+
+// Residual returns the bound residual expression for the partition whose
+// values are provided in partition data, ordered as the fields of the
+// partition spec.
+func (r *ResidualEvaluator) Residual(partition structLike) (BooleanExpression, error) {
+	return r.residual(partition)
+}
+
+// residualBuilder builds the residualFunc of a bound expression.
+type residualBuilder struct {
+	spec       PartitionSpec
+	partSchema *Schema
+}
+
+// partitionProjection holds the strict and inclusive projections of a
+// predicate onto a partition field, bound to the partition schema. Either
+// is nil if the transform of the field has no such projection.
+type partitionProjection struct {
+	strict, inclusive BooleanExpression
+}
+
+func (*residualBuilder) VisitTrue() residualFunc {
+	return func(structLike) (BooleanExpression, error) { return AlwaysTrue{}, nil }
+}
+
+func (*residualBuilder) VisitFalse() residualFunc {
+	return func(structLike) (BooleanExpression, error) { return AlwaysFalse{}, nil }
+}
+
+func (*residualBuilder) VisitNot(child residualFunc) residualFunc {
+	return func(partition structLike) (BooleanExpression, error) {
+		c, err := child(partition)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewNot(c), nil
+	}
+}
+
+func (*residualBuilder) VisitAnd(left, right residualFunc) residualFunc {
+	return func(partition structLike) (BooleanExpression, error) {
+		l, err := left(partition)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := right(partition)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewAnd(l, r), nil
+	}
+}
+
+func (*residualBuilder) VisitOr(left, right residualFunc) residualFunc {
+	return func(partition structLike) (BooleanExpression, error) {
+		l, err := left(partition)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := right(partition)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewOr(l, r), nil
+	}
+}
+
+func (*residualBuilder) VisitUnbound(pred UnboundPredicate) residualFunc {
+	panic(fmt.Errorf("%w: cannot compute residual of unbound predicate: %s",
+		ErrInvalidArgument, pred))
+}
+
+func (b *residualBuilder) VisitBound(pred BoundPredicate) residualFunc {
+	parts := b.spec.FieldsBySourceID(pred.Term().Ref().Field().ID)
+	projections := make([]partitionProjection, 0, len(parts))
+	for _, part := range parts {
+		strict, err := strictProject(part.Transform, part.Name, pred)
+		if err != nil {
+			panic(err)
+		}
+
+		incl, err := part.Transform.Project(part.Name, pred)
+		if err != nil {
+			panic(err)
+		}
+
+		projections = append(projections, partitionProjection{
+			strict:    b.bind(strict),
+			inclusive: b.bind(incl),
+		})
+	}
+
+	return func(partition structLike) (BooleanExpression, error) {
+		for _, proj := range projections {
+			if proj.strict != nil {
+				match, err := (&exprEvaluator{bound: proj.strict}).Eval(partition)
+				if err != nil {
+					return nil, err
+				}
+
+				if match {
+					return AlwaysTrue{}, nil
+				}
+			}
+
+			if proj.inclusive != nil {
+				match, err := (&exprEvaluator{bound: proj.inclusive}).Eval(partition)
+				if err != nil {
+					return nil, err
+				}
+
+				if !match {
+					return AlwaysFalse{}, nil
+				}
+			}
+		}
+
+		return pred, nil
+	}
+}
+
+// bind binds a projected predicate to the partition schema, returning nil
+// if there is no projection.
+func (b *residualBuilder) bind(pred UnboundPredicate) BooleanExpression {
+	if pred == nil {
+		return nil
+	}
+
+	bound, err := BindExpr(b.partSchema, pred, true)
+	if err != nil {
+		panic(err)
+	}
+
+	return bound
+}
+
+// strictProject returns a predicate on the partition field which, when it
+// holds for a partition, guarantees that pred holds for every row in that
+// partition. It returns nil if no such projection is known for the transform.
+func strictProject(t Transform, name string, pred BoundPredicate) (UnboundPredicate, error) {
+	if _, ok := pred.Term().(*BoundTransform); ok {
+		return nil, nil
+	}
+
+	switch t := t.(type) {
+	case IdentityTransform:
+		return t.Project(name, pred)
+	case TimeTransform:
+		transformer, err := t.Transformer(pred.Term().Ref().Type())
+		if err != nil {
+			return nil, err
+		}
+
+		switch p := pred.(type) {
+		case BoundUnaryPredicate:
+			return p.AsUnbound(Reference(name)), nil
+		case BoundLiteralPredicate:
+			return truncateNumberStrict(name, p, transformer)
+		case BoundSetPredicate:
+			if p.Op() != OpNotIn {
+				break
+			}
+
+			return setApplyTransform(name, p, transformer), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func truncateNumberStrict[T LiteralType](name string, pred BoundLiteralPredicate, fn func(any) Optional[T]) (UnboundPredicate, error) {
+	boundary, ok := pred.Literal().(NumericLiteral)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected numeric literal, got %s",
+			ErrInvalidArgument, pred.Literal().Type())
+	}
+
+	switch pred.Op() {
+	case OpLT:
+		return LiteralPredicate(OpLT, Reference(name),
+			transformLiteral(fn, boundary)), nil
+	case OpLTEQ:
+		next := boundary.Increment()
+		if _, ok := next.(AboveMaxLiteral); ok {
+			return nil, nil
+		}
+
+		return LiteralPredicate(OpLT, Reference(name),
+			transformLiteral(fn, next)), nil
+	case OpGT:
+		return LiteralPredicate(OpGT, Reference(name),
+			transformLiteral(fn, boundary)), nil
+	case OpGTEQ:
+		prev := boundary.Decrement()
+		if _, ok := prev.(BelowMinLiteral); ok {
+			return nil, nil
+		}
+
+		return LiteralPredicate(OpGT, Reference(name),
+			transformLiteral(fn, prev)), nil
+	case OpNEQ:
+		return LiteralPredicate(OpNEQ, Reference(name),
+			transformLiteral(fn, boundary)), nil
+	}
+
+	return nil, nil
+}
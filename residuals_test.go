@@ -0,0 +1,114 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResidualDayPartitionedTimestampRange(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "ts", Type: iceberg.PrimitiveTypes.Timestamp, Required: true},
+		iceberg.NestedField{ID: 2, Name: "id", Type: iceberg.PrimitiveTypes.Int32, Required: true})
+
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 1, FieldID: 1000, Name: "ts_day", Transform: iceberg.DayTransform{},
+	})
+
+	ts := func(s string) iceberg.Timestamp {
+		tm, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+
+		return iceberg.Timestamp(tm.UnixMicro())
+	}
+
+	day := func(s string) int32 {
+		return int32(time.Duration(ts(s)) * time.Microsecond / (24 * time.Hour))
+	}
+
+	idFilter := iceberg.GreaterThan(iceberg.Reference("id"), int32(5))
+	boundIDFilter, err := iceberg.BindExpr(sc, idFilter, true)
+	require.NoError(t, err)
+
+	rangeFilter := iceberg.NewAnd(
+		iceberg.GreaterThanEqual(iceberg.Reference("ts"), ts("2023-01-02T00:00:00Z")),
+		iceberg.LessThan(iceberg.Reference("ts"), ts("2023-01-04T00:00:00Z")))
+
+	t.Run("fully covered partitions", func(t *testing.T) {
+		eval, err := iceberg.NewResidualEvaluator(spec, sc, rangeFilter, true)
+		require.NoError(t, err)
+
+		for _, d := range []string{"2023-01-02T00:00:00Z", "2023-01-03T00:00:00Z"} {
+			residual, err := eval.Residual(rowOf(day(d)))
+			require.NoError(t, err)
+			assert.Equal(t, iceberg.AlwaysTrue{}, residual, d)
+		}
+	})
+
+	t.Run("non-matching partitions", func(t *testing.T) {
+		eval, err := iceberg.NewResidualEvaluator(spec, sc, rangeFilter, true)
+		require.NoError(t, err)
+
+		for _, d := range []string{"2023-01-01T00:00:00Z", "2023-01-04T00:00:00Z"} {
+			residual, err := eval.Residual(rowOf(day(d)))
+			require.NoError(t, err)
+			assert.Equal(t, iceberg.AlwaysFalse{}, residual, d)
+		}
+	})
+
+	t.Run("keeps non-partition predicates", func(t *testing.T) {
+		eval, err := iceberg.NewResidualEvaluator(spec, sc,
+			iceberg.NewAnd(rangeFilter, idFilter), true)
+		require.NoError(t, err)
+
+		residual, err := eval.Residual(rowOf(day("2023-01-03T00:00:00Z")))
+		require.NoError(t, err)
+		assert.True(t, boundIDFilter.Equals(residual), "got %s", residual)
+	})
+
+	t.Run("partially covered partition", func(t *testing.T) {
+		lower := iceberg.GreaterThanEqual(iceberg.Reference("ts"), ts("2023-01-02T12:00:00Z"))
+		boundLower, err := iceberg.BindExpr(sc, lower, true)
+		require.NoError(t, err)
+
+		eval, err := iceberg.NewResidualEvaluator(spec, sc, lower, true)
+		require.NoError(t, err)
+
+		residual, err := eval.Residual(rowOf(day("2023-01-02T00:00:00Z")))
+		require.NoError(t, err)
+		assert.True(t, boundLower.Equals(residual), "got %s", residual)
+
+		residual, err = eval.Residual(rowOf(day("2023-01-03T00:00:00Z")))
+		require.NoError(t, err)
+		assert.Equal(t, iceberg.AlwaysTrue{}, residual)
+	})
+
+	t.Run("unpartitioned", func(t *testing.T) {
+		eval, err := iceberg.NewResidualEvaluator(*iceberg.UnpartitionedSpec, sc, idFilter, true)
+		require.NoError(t, err)
+
+		residual, err := eval.Residual(rowOf())
+		require.NoError(t, err)
+		assert.True(t, boundIDFilter.Equals(residual), "got %s", residual)
+	})
+}
@@ -0,0 +1,147 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import "fmt"
+
+// triBool is a value of three-valued logic, ordered so that AND is the
+// minimum and OR the maximum of two values.
+type triBool int8
+
+const (
+	triFalse triBool = iota
+	triUnknown
+	triTrue
+)
+
+func triOf(b bool) triBool {
+	if b {
+		return triTrue
+	}
+
+	return triFalse
+}
+
+// Evaluate evaluates a bound expression against a single row, fetching
+// the values of the referenced fields with their accessors.
+//
+// Nulls follow SQL's three-valued logic: comparing a null value, or
+// testing it for set membership, a prefix or NaN, is unknown rather than
+// true or false, and unknown stays unknown under NOT. The row matches
+// only if the whole expression is true, so for example neither
+// `x > 1` nor `NOT (x > 1)` match a row where x is null, while
+// `x IS NULL` does.
+func Evaluate(expr BooleanExpression, row structLike) (bool, error) {
+	res, err := VisitExpr(expr, &rowEvaluator{ev: exprEvaluator{bound: expr, st: row}})
+	if err != nil {
+		return false, err
+	}
+
+	return res == triTrue, nil
+}
+
+// rowEvaluator wraps exprEvaluator, which treats nulls as smaller than
+// any other value, to make predicates on null values unknown.
+type rowEvaluator struct {
+	ev exprEvaluator
+}
+
+func (*rowEvaluator) VisitTrue() triBool  { return triTrue }
+func (*rowEvaluator) VisitFalse() triBool { return triFalse }
+
+func (*rowEvaluator) VisitNot(child triBool) triBool {
+	return triTrue - child
+}
+
+func (*rowEvaluator) VisitAnd(left, right triBool) triBool { return min(left, right) }
+func (*rowEvaluator) VisitOr(left, right triBool) triBool  { return max(left, right) }
+
+func (*rowEvaluator) VisitUnbound(pred UnboundPredicate) triBool {
+	panic(fmt.Errorf("%w: found unbound predicate when evaluating expression: %s",
+		ErrInvalidArgument, pred))
+}
+
+func (r *rowEvaluator) VisitBound(pred BoundPredicate) triBool {
+	return VisitBoundPredicate(pred, r)
+}
+
+// ifNotNull returns unknown if the term is null for the row, and the
+// result of fn otherwise.
+func (r *rowEvaluator) ifNotNull(term BoundTerm, fn func() bool) triBool {
+	if term.evalIsNull(r.ev.st) {
+		return triUnknown
+	}
+
+	return triOf(fn())
+}
+
+func (r *rowEvaluator) VisitIn(term BoundTerm, lits Set[Literal]) triBool {
+	return r.ifNotNull(term, func() bool { return r.ev.VisitIn(term, lits) })
+}
+
+func (r *rowEvaluator) VisitNotIn(term BoundTerm, lits Set[Literal]) triBool {
+	return r.ifNotNull(term, func() bool { return r.ev.VisitNotIn(term, lits) })
+}
+
+func (r *rowEvaluator) VisitIsNan(term BoundTerm) triBool {
+	return r.ifNotNull(term, func() bool { return r.ev.VisitIsNan(term) })
+}
+
+func (r *rowEvaluator) VisitNotNan(term BoundTerm) triBool {
+	return r.ifNotNull(term, func() bool { return r.ev.VisitNotNan(term) })
+}
+
+func (r *rowEvaluator) VisitIsNull(term BoundTerm) triBool {
+	return triOf(r.ev.VisitIsNull(term))
+}
+
+func (r *rowEvaluator) VisitNotNull(term BoundTerm) triBool {
+	return triOf(r.ev.VisitNotNull(term))
+}
+
+func (r *rowEvaluator) VisitEqual(term BoundTerm, lit Literal) triBool {
+	return r.ifNotNull(term, func() bool { return r.ev.VisitEqual(term, lit) })
+}
+
+func (r *rowEvaluator) VisitNotEqual(term BoundTerm, lit Literal) triBool {
+	return r.ifNotNull(term, func() bool { return r.ev.VisitNotEqual(term, lit) })
+}
+
+func (r *rowEvaluator) VisitGreaterEqual(term BoundTerm, lit Literal) triBool {
+	return r.ifNotNull(term, func() bool { return r.ev.VisitGreaterEqual(term, lit) })
+}
+
+func (r *rowEvaluator) VisitGreater(term BoundTerm, lit Literal) triBool {
+	return r.ifNotNull(term, func() bool { return r.ev.VisitGreater(term, lit) })
+}
+
+func (r *rowEvaluator) VisitLessEqual(term BoundTerm, lit Literal) triBool {
+	return r.ifNotNull(term, func() bool { return r.ev.VisitLessEqual(term, lit) })
+}
+
+func (r *rowEvaluator) VisitLess(term BoundTerm, lit Literal) triBool {
+	return r.ifNotNull(term, func() bool { return r.ev.VisitLess(term, lit) })
+}
+
+func (r *rowEvaluator) VisitStartsWith(term BoundTerm, lit Literal) triBool {
+	return r.ifNotNull(term, func() bool { return r.ev.VisitStartsWith(term, lit) })
+}
+
+func (r *rowEvaluator) VisitNotStartsWith(term BoundTerm, lit Literal) triBool {
+	return r.ifNotNull(term, func() bool { return r.ev.VisitNotStartsWith(term, lit) })
+}
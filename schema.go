@@ -19,6 +19,7 @@ package iceberg
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"slices"
@@ -45,7 +46,7 @@ type Schema struct {
 	idToField     atomic.Pointer[map[int]NestedField]
 	nameToID      atomic.Pointer[map[string]int]
 	nameToIDLower atomic.Pointer[map[string]int]
-	idToAccessor  atomic.Pointer[map[int]accessor]
+	idToAccessor  atomic.Pointer[map[int]Accessor]
 
 	lazyIDToParent  func() (map[int]int, error)
 	lazyNameMapping func() NameMapping
@@ -168,7 +169,7 @@ func (s *Schema) lazyNameToIDLower() (map[string]int, error) {
 	return out, nil
 }
 
-func (s *Schema) lazyIdToAccessor() (map[int]accessor, error) {
+func (s *Schema) lazyIdToAccessor() (map[int]Accessor, error) {
 	index := s.idToAccessor.Load()
 	if index != nil {
 		return *index, nil
@@ -275,6 +276,22 @@ func (s *Schema) FindFieldByNameCaseInsensitive(name string) (NestedField, bool)
 	return s.FindFieldByID(id)
 }
 
+// caseInsensitiveFieldIDs returns the sorted, distinct IDs of every
+// field whose name matches the provided name ignoring case.
+func (s *Schema) caseInsensitiveFieldIDs(name string) []int {
+	idx, _ := s.lazyNameToID()
+
+	ids := make([]int, 0, 1)
+	for k, id := range idx {
+		if strings.EqualFold(k, name) && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
 // FindFieldByID is like [*Schema.FindColumnName], but returns the whole
 // field rather than just the field name.
 func (s *Schema) FindFieldByID(id int) (NestedField, bool) {
@@ -317,10 +334,10 @@ func (s *Schema) FindTypeByNameCaseInsensitive(name string) (Type, bool) {
 	return f.Type, true
 }
 
-func (s *Schema) accessorForField(id int) (accessor, bool) {
+func (s *Schema) accessorForField(id int) (Accessor, bool) {
 	idx, err := s.lazyIdToAccessor()
 	if err != nil {
-		return accessor{}, false
+		return Accessor{}, false
 	}
 
 	acc, ok := idx[id]
@@ -328,6 +345,25 @@ func (s *Schema) accessorForField(id int) (accessor, bool) {
 	return acc, ok
 }
 
+// BuildAccessor returns an Accessor for the field with the given ID in
+// the provided schema. An error is returned if the field does not exist
+// or is nested within a list or map, as accessors can only traverse
+// structs by position.
+func BuildAccessor(schema *Schema, fieldID int) (*Accessor, error) {
+	if _, ok := schema.FindFieldByID(fieldID); !ok {
+		return nil, fmt.Errorf("%w: could not find field with id %d",
+			ErrInvalidSchema, fieldID)
+	}
+
+	acc, ok := schema.accessorForField(fieldID)
+	if !ok {
+		return nil, fmt.Errorf("%w: field %d is nested within a list or map and cannot be accessed by position",
+			ErrInvalidArgument, fieldID)
+	}
+
+	return &acc, nil
+}
+
 // Equals compares the fields and identifierIDs, but does not compare
 // the schema ID itself.
 func (s *Schema) Equals(other *Schema) bool {
@@ -394,6 +430,24 @@ func (s *Schema) Select(caseSensitive bool, names ...string) (*Schema, error) {
 	return PruneColumns(s, ids, true)
 }
 
+// Project creates a new schema containing only the fields identified by
+// the provided field IDs. Any struct, list or map containers required to
+// reach a selected nested field are retained, and all field IDs, names and
+// required flags are preserved from the original schema.
+//
+// An error is returned if a requested field ID does not exist in the schema.
+func (s *Schema) Project(ids ...int) (*Schema, error) {
+	selected := make(map[int]Void, len(ids))
+	for _, id := range ids {
+		if _, ok := s.FindFieldByID(id); !ok {
+			return nil, fmt.Errorf("%w: could not find field with id %d", ErrInvalidSchema, id)
+		}
+		selected[id] = void
+	}
+
+	return PruneColumns(s, selected, true)
+}
+
 func (s *Schema) FieldHasOptionalParent(id int) bool {
 	idToParent, _ := s.lazyIDToParent()
 	idToField, _ := s.lazyIDToField()
@@ -898,11 +952,7 @@ func PruneColumns(schema *Schema, selected map[int]Void, selectFullTypes bool) (
 		}
 	}
 
-	return &Schema{
-		fields:             n.Fields(),
-		ID:                 schema.ID,
-		IdentifierFieldIDs: newIdentifierIDs,
-	}, nil
+	return NewSchemaWithIdentifiers(schema.ID, newIdentifierIDs, n.Fields()...), nil
 }
 
 type pruneColVisitor struct {
@@ -926,11 +976,13 @@ func (p *pruneColVisitor) Struct(st StructType, fieldResults []Type) Type {
 			sameType = false
 			// type has changed, create a new field with the projected type
 			selected = append(selected, NestedField{
-				ID:       field.ID,
-				Name:     field.Name,
-				Type:     t,
-				Doc:      field.Doc,
-				Required: field.Required,
+				ID:             field.ID,
+				Name:           field.Name,
+				Type:           t,
+				Doc:            field.Doc,
+				Required:       field.Required,
+				InitialDefault: field.InitialDefault,
+				WriteDefault:   field.WriteDefault,
 			})
 		}
 	}
@@ -1162,43 +1214,43 @@ func (i *indexParents) Primitive(PrimitiveType) map[int]int {
 
 type buildPosAccessors struct{}
 
-func (buildPosAccessors) Schema(_ *Schema, structResult map[int]accessor) map[int]accessor {
+func (buildPosAccessors) Schema(_ *Schema, structResult map[int]Accessor) map[int]Accessor {
 	return structResult
 }
 
-func (buildPosAccessors) Struct(st StructType, fieldResults []map[int]accessor) map[int]accessor {
-	result := map[int]accessor{}
+func (buildPosAccessors) Struct(st StructType, fieldResults []map[int]Accessor) map[int]Accessor {
+	result := map[int]Accessor{}
 	for pos, f := range st.FieldList {
 		if innerMap := fieldResults[pos]; len(innerMap) != 0 {
 			for inner, acc := range innerMap {
 				acc := acc
-				result[inner] = accessor{pos: pos, inner: &acc}
+				result[inner] = Accessor{pos: pos, inner: &acc}
 			}
 		} else {
-			result[f.ID] = accessor{pos: pos}
+			result[f.ID] = Accessor{pos: pos}
 		}
 	}
 
 	return result
 }
 
-func (buildPosAccessors) Field(_ NestedField, fieldResult map[int]accessor) map[int]accessor {
+func (buildPosAccessors) Field(_ NestedField, fieldResult map[int]Accessor) map[int]Accessor {
 	return fieldResult
 }
 
-func (buildPosAccessors) List(ListType, map[int]accessor) map[int]accessor {
-	return map[int]accessor{}
+func (buildPosAccessors) List(ListType, map[int]Accessor) map[int]Accessor {
+	return map[int]Accessor{}
 }
 
-func (buildPosAccessors) Map(_ MapType, _, _ map[int]accessor) map[int]accessor {
-	return map[int]accessor{}
+func (buildPosAccessors) Map(_ MapType, _, _ map[int]Accessor) map[int]Accessor {
+	return map[int]Accessor{}
 }
 
-func (buildPosAccessors) Primitive(PrimitiveType) map[int]accessor {
-	return map[int]accessor{}
+func (buildPosAccessors) Primitive(PrimitiveType) map[int]Accessor {
+	return map[int]Accessor{}
 }
 
-func buildAccessors(schema *Schema) (map[int]accessor, error) {
+func buildAccessors(schema *Schema) (map[int]Accessor, error) {
 	return Visit(schema, buildPosAccessors{})
 }
 
@@ -1542,3 +1594,117 @@ func (sanitizeColumnNameVisitor) Map(mapType MapType, keyResult, valueResult Nes
 func (sanitizeColumnNameVisitor) Primitive(p PrimitiveType) NestedField {
 	return NestedField{Type: p}
 }
+
+// CheckWriteCompatible returns an error if data with the write schema
+// can't be written to a table with the table schema. Fields are matched
+// by ID, so that names may differ. Each write field's type must be the
+// table field's type or widened to it, i.e. int to long, float to double
+// or a decimal of the same scale to a larger precision, optional
+// write fields can't be written to required table fields, required table
+// fields can't be missing from the write schema and the write schema
+// can't have fields which the table doesn't have. Missing optional
+// fields are written as nulls.
+//
+// All incompatibilities are reported, each as an error wrapping
+// ErrInvalidSchema with the ID of the offending field.
+func CheckWriteCompatible(tableSchema, writeSchema *Schema) error {
+	writeFields, err := IndexByID(writeSchema)
+	if err != nil {
+		return err
+	}
+
+	tableFields, err := IndexByID(tableSchema)
+	if err != nil {
+		return err
+	}
+
+	errs := checkWriteFields(tableSchema.Fields(), writeFields, nil)
+	errs = checkUnknownWriteFields(writeSchema.Fields(), tableFields, errs)
+
+	return errors.Join(errs...)
+}
+
+func checkUnknownWriteFields(writeFields []NestedField, tableFields map[int]NestedField, errs []error) []error {
+	for _, wf := range writeFields {
+		if _, ok := tableFields[wf.ID]; !ok {
+			errs = append(errs, fmt.Errorf("%w: field %d (%s): not found in table schema",
+				ErrInvalidSchema, wf.ID, wf.Name))
+
+			continue
+		}
+
+		if nested, ok := wf.Type.(NestedType); ok {
+			errs = checkUnknownWriteFields(nested.Fields(), tableFields, errs)
+		}
+	}
+
+	return errs
+}
+
+func checkWriteFields(tableFields []NestedField, writeFields map[int]NestedField, errs []error) []error {
+	for _, tf := range tableFields {
+		wf, ok := writeFields[tf.ID]
+		if !ok {
+			// the nested fields of a missing field are missing too and
+			// don't need to be reported on their own
+			if tf.Required {
+				errs = append(errs, fmt.Errorf("%w: field %d (%s): required field is missing",
+					ErrInvalidSchema, tf.ID, tf.Name))
+			}
+
+			continue
+		}
+
+		if tf.Required && !wf.Required {
+			errs = append(errs, fmt.Errorf("%w: field %d (%s): cannot write optional field to required field",
+				ErrInvalidSchema, tf.ID, tf.Name))
+		}
+
+		switch tt := tf.Type.(type) {
+		case NestedType:
+			if tt.Type() != wf.Type.Type() {
+				errs = append(errs, fmt.Errorf("%w: field %d (%s): cannot write %s to %s",
+					ErrInvalidSchema, tf.ID, tf.Name, wf.Type, tf.Type))
+
+				continue
+			}
+
+			errs = checkWriteFields(tt.Fields(), writeFields, errs)
+		default:
+			if tf.Type.Equals(wf.Type) {
+				continue
+			}
+
+			if !canPromoteForWrite(wf.Type, tf.Type) {
+				errs = append(errs, fmt.Errorf("%w: field %d (%s): cannot write %s to %s",
+					ErrInvalidSchema, tf.ID, tf.Name, wf.Type, tf.Type))
+			}
+		}
+	}
+
+	return errs
+}
+
+// canPromoteForWrite reports whether values of the write type can be
+// written to a field of the table type. Unlike PromoteType, which also
+// allows reading e.g. string files as binary, only the widenings which
+// keep the values unchanged are allowed: int to long, float to double and
+// a decimal to a decimal of the same scale with a larger precision.
+func canPromoteForWrite(writeType, tableType Type) bool {
+	switch wt := writeType.(type) {
+	case Int32Type:
+		_, ok := tableType.(Int64Type)
+
+		return ok
+	case Float32Type:
+		_, ok := tableType.(Float64Type)
+
+		return ok
+	case DecimalType:
+		tt, ok := tableType.(DecimalType)
+
+		return ok && wt.scale == tt.scale && wt.precision <= tt.precision
+	}
+
+	return false
+}
@@ -61,6 +61,10 @@ func partitionTypeToAvroSchema(t *StructType) (avro.Schema, error) {
 			return nil, fmt.Errorf("unsupported partition type: %s", f.Type.String())
 		}
 
+		if !f.Required {
+			sc = internal.NullableSchema(sc)
+		}
+
 		fields[i], _ = avro.NewField(f.Name, sc, internal.WithFieldID(f.ID))
 	}
 
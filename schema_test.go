@@ -361,6 +361,75 @@ func TestSchemaFindField(t *testing.T) {
 	}
 }
 
+func TestSchemaFindFieldByPath(t *testing.T) {
+	tests := []struct {
+		path string
+		id   int
+	}{
+		{"foo", 1},
+		{"person.name", 16},
+		{"person.age", 17},
+		{"quux.value.key", 9},
+		{"location.element.latitude", 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			f, ok := tableSchemaNested.FindFieldByName(tt.path)
+			require.True(t, ok)
+			assert.Equal(t, tt.id, f.ID)
+
+			_, ok = tableSchemaNested.FindFieldByName(strings.ToUpper(tt.path))
+			assert.False(t, ok)
+
+			f, ok = tableSchemaNested.FindFieldByNameCaseInsensitive(strings.ToUpper(tt.path))
+			require.True(t, ok)
+			assert.Equal(t, tt.id, f.ID)
+		})
+	}
+
+	// list elements are addressed through the "element" field, not by
+	// position, and primitive fields have no children to traverse into
+	for _, path := range []string{"location.0.latitude", "location.element.altitude", "person.name.first", "person.zip"} {
+		_, ok := tableSchemaNested.FindFieldByName(path)
+		assert.False(t, ok, path)
+		_, ok = tableSchemaNested.FindFieldByNameCaseInsensitive(path)
+		assert.False(t, ok, path)
+	}
+}
+
+func TestBuildAccessor(t *testing.T) {
+	row := rowTester{"foo", int32(42), true, []string{"a"}, map[string]any{},
+		[]any{}, rowTester{"jane", int32(30)}, map[string]int32{}}
+
+	acc, err := iceberg.BuildAccessor(tableSchemaNested, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int32(42), acc.Get(row))
+
+	acc, err = iceberg.BuildAccessor(tableSchemaNested, 17)
+	require.NoError(t, err)
+	assert.Equal(t, "Accessor(position=6, inner=Accessor(position=1, inner=<nil>))", acc.String())
+	assert.Equal(t, int32(30), acc.Get(row))
+
+	acc, err = iceberg.BuildAccessor(tableSchemaNested, 16)
+	require.NoError(t, err)
+	assert.Equal(t, "jane", acc.Get(row))
+
+	// a null parent struct yields a null value
+	row[6] = nil
+	assert.Nil(t, acc.Get(row))
+
+	_, err = iceberg.BuildAccessor(tableSchemaNested, 10)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	assert.ErrorContains(t, err, "field 10 is nested within a list or map")
+
+	_, err = iceberg.BuildAccessor(tableSchemaNested, 13)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	_, err = iceberg.BuildAccessor(tableSchemaNested, 99)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+}
+
 func TestSchemaFindType(t *testing.T) {
 	_, ok := tableSchemaSimple.FindTypeByID(0)
 	assert.False(t, ok)
@@ -715,6 +784,42 @@ func TestPruneColumnsSelectOriginalSchema(t *testing.T) {
 	assert.True(t, sc.Equals(tableSchemaNested))
 }
 
+func TestSchemaProject(t *testing.T) {
+	sc, err := tableSchemaNested.Project(2, 13)
+	require.NoError(t, err)
+
+	assert.True(t, sc.Equals(iceberg.NewSchema(1,
+		iceberg.NestedField{
+			ID: 2, Name: "bar", Type: iceberg.PrimitiveTypes.Int32, Required: true,
+		},
+		iceberg.NestedField{
+			ID: 11, Name: "location", Type: &iceberg.ListType{
+				ElementID: 12, Element: &iceberg.StructType{
+					FieldList: []iceberg.NestedField{
+						{ID: 13, Name: "latitude", Type: iceberg.PrimitiveTypes.Float32, Required: false},
+					},
+				},
+				ElementRequired: true,
+			},
+			Required: true,
+		})))
+	assert.Equal(t, 1, sc.ID)
+
+	lat, ok := sc.FindFieldByName("location.element.latitude")
+	require.True(t, ok)
+	assert.Equal(t, 13, lat.ID)
+	assert.False(t, lat.Required)
+
+	_, ok = sc.FindFieldByID(14)
+	assert.False(t, ok)
+}
+
+func TestSchemaProjectMissingID(t *testing.T) {
+	_, err := tableSchemaNested.Project(1, 100)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+	assert.ErrorContains(t, err, "100")
+}
+
 func TestPruneNilSchema(t *testing.T) {
 	_, err := iceberg.PruneColumns(nil, nil, true)
 	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
@@ -880,3 +985,84 @@ func TestHighestFieldID(t *testing.T) {
 	id := tableSchemaNested.HighestFieldID()
 	assert.Equal(t, 20, id, "expected highest field ID to be 20, got %d", id)
 }
+
+func TestCheckWriteCompatible(t *testing.T) {
+	tableSchema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "price", Type: iceberg.PrimitiveTypes.Float64},
+		iceberg.NestedField{ID: 3, Name: "amount", Type: iceberg.DecimalTypeOf(10, 2)},
+		iceberg.NestedField{ID: 4, Name: "location", Type: &iceberg.StructType{
+			FieldList: []iceberg.NestedField{
+				{ID: 5, Name: "lat", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+				{ID: 6, Name: "long", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			},
+		}},
+		iceberg.NestedField{ID: 7, Name: "tags", Type: &iceberg.ListType{
+			ElementID: 8, Element: iceberg.PrimitiveTypes.Int64, ElementRequired: false,
+		}},
+	)
+
+	t.Run("widened", func(t *testing.T) {
+		// narrower types are promoted, names don't need to match and
+		// optional fields may be left out
+		writeSchema := iceberg.NewSchema(0,
+			iceberg.NestedField{ID: 1, Name: "ID", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+			iceberg.NestedField{ID: 2, Name: "price", Type: iceberg.PrimitiveTypes.Float32, Required: true},
+			iceberg.NestedField{ID: 3, Name: "amount", Type: iceberg.DecimalTypeOf(8, 2)},
+			iceberg.NestedField{ID: 7, Name: "tags", Type: &iceberg.ListType{
+				ElementID: 8, Element: iceberg.PrimitiveTypes.Int32, ElementRequired: true,
+			}},
+		)
+
+		assert.NoError(t, iceberg.CheckWriteCompatible(tableSchema, writeSchema))
+		assert.NoError(t, iceberg.CheckWriteCompatible(tableSchema, tableSchema))
+	})
+
+	t.Run("narrowed", func(t *testing.T) {
+		writeSchema := iceberg.NewSchema(0,
+			iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64},
+			iceberg.NestedField{ID: 2, Name: "price", Type: iceberg.PrimitiveTypes.Float64},
+			iceberg.NestedField{ID: 3, Name: "amount", Type: iceberg.DecimalTypeOf(12, 2)},
+			iceberg.NestedField{ID: 4, Name: "location", Type: &iceberg.StructType{
+				FieldList: []iceberg.NestedField{
+					{ID: 5, Name: "lat", Type: iceberg.PrimitiveTypes.Float32, Required: true},
+				},
+			}},
+			iceberg.NestedField{ID: 7, Name: "tags", Type: iceberg.PrimitiveTypes.String},
+			iceberg.NestedField{ID: 9, Name: "extra", Type: iceberg.PrimitiveTypes.String},
+		)
+
+		err := iceberg.CheckWriteCompatible(tableSchema, writeSchema)
+		require.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, "field 1 (id): cannot write optional field to required field")
+		assert.ErrorContains(t, err, "field 3 (amount): cannot write decimal(12, 2) to decimal(10, 2)")
+		assert.ErrorContains(t, err, "field 6 (long): required field is missing")
+		assert.ErrorContains(t, err, "field 7 (tags): cannot write string to list<long>")
+		assert.ErrorContains(t, err, "field 9 (extra): not found in table schema")
+		assert.NotContains(t, err.Error(), "field 2 ")
+		assert.NotContains(t, err.Error(), "field 5 ")
+
+		// narrowing a promoted type is not allowed either
+		err = iceberg.CheckWriteCompatible(
+			iceberg.NewSchema(0, iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32}),
+			iceberg.NewSchema(0, iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64}))
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, "field 1 (id): cannot write long to int")
+	})
+
+	t.Run("read promotions", func(t *testing.T) {
+		// a decimal can be read with a larger scale, but writing it
+		// would change its values
+		err := iceberg.CheckWriteCompatible(
+			iceberg.NewSchema(0, iceberg.NestedField{ID: 1, Name: "amount", Type: iceberg.DecimalTypeOf(10, 4)}),
+			iceberg.NewSchema(0, iceberg.NestedField{ID: 1, Name: "amount", Type: iceberg.DecimalTypeOf(8, 2)}))
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, "field 1 (amount): cannot write decimal(8, 2) to decimal(10, 4)")
+
+		err = iceberg.CheckWriteCompatible(
+			iceberg.NewSchema(0, iceberg.NestedField{ID: 1, Name: "data", Type: iceberg.PrimitiveTypes.Binary}),
+			iceberg.NewSchema(0, iceberg.NestedField{ID: 1, Name: "data", Type: iceberg.PrimitiveTypes.String}))
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, "field 1 (data): cannot write string to binary")
+	})
+}
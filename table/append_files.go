@@ -0,0 +1,118 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/iceberg-go"
+)
+
+// AppendFilesBuilder appends already written data files to a table as a
+// new snapshot with the append operation.
+type AppendFilesBuilder struct {
+	tbl           *Table
+	files         []iceberg.DataFile
+	snapshotProps iceberg.Properties
+}
+
+// AppendFiles returns a builder for appending data files, e.g. files
+// produced by an external writer, to tbl. Unlike [Transaction.AddFiles]
+// the files are not read: their metadata is taken as is from the given
+// DataFile values.
+func AppendFiles(tbl *Table) *AppendFilesBuilder {
+	return &AppendFilesBuilder{tbl: tbl}
+}
+
+// AddFile adds a data file to be appended.
+func (a *AppendFilesBuilder) AddFile(df iceberg.DataFile) *AppendFilesBuilder {
+	a.files = append(a.files, df)
+
+	return a
+}
+
+// SnapshotProperties sets additional properties to store in the summary of
+// the new snapshot.
+func (a *AppendFilesBuilder) SnapshotProperties(props iceberg.Properties) *AppendFilesBuilder {
+	a.snapshotProps = props
+
+	return a
+}
+
+// Commit writes a new manifest and manifest list for the added files and
+// commits the resulting snapshot through the table's catalog, returning
+// the updated table.
+func (a *AppendFilesBuilder) Commit(ctx context.Context) (*Table, error) {
+	txn := a.tbl.NewTransaction()
+	if err := txn.AppendDataFiles(ctx, a.files, a.snapshotProps); err != nil {
+		return nil, err
+	}
+
+	return txn.Commit(ctx)
+}
+
+// AppendDataFiles stages a new snapshot with the append operation which adds
+// the given data files to the table.
+func (t *Transaction) AppendDataFiles(ctx context.Context, files []iceberg.DataFile, snapshotProps iceberg.Properties) error {
+	if err := t.validateNewDataFiles(files); err != nil {
+		return err
+	}
+
+	fs, err := t.tbl.fsF(ctx)
+	if err != nil {
+		return err
+	}
+
+	appendFiles := t.appendSnapshotProducer(fs, snapshotProps)
+	for _, df := range files {
+		appendFiles.appendDataFile(df)
+	}
+
+	updates, reqs, err := appendFiles.commit()
+	if err != nil {
+		return err
+	}
+
+	return t.apply(updates, reqs)
+}
+
+// validateNewDataFiles checks that files, which are about to be added to the
+// table, are unique data files of one of the table's partition specs.
+func (t *Transaction) validateNewDataFiles(files []iceberg.DataFile) error {
+	seen := make(map[string]struct{}, len(files))
+	for _, df := range files {
+		if df.ContentType() != iceberg.EntryContentData {
+			return fmt.Errorf("%w: cannot add %s file %s, only data files can be added",
+				iceberg.ErrInvalidArgument, df.ContentType(), df.FilePath())
+		}
+
+		if _, err := t.meta.GetSpecByID(int(df.SpecID())); err != nil {
+			return fmt.Errorf("%w: data file %s has unknown partition spec id %d",
+				iceberg.ErrInvalidArgument, df.FilePath(), df.SpecID())
+		}
+
+		if _, ok := seen[df.FilePath()]; ok {
+			return fmt.Errorf("%w: data file %s is added more than once",
+				iceberg.ErrInvalidArgument, df.FilePath())
+		}
+		seen[df.FilePath()] = struct{}{}
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/extensions"
+	"github.com/apache/iceberg-go"
+	"github.com/google/uuid"
+)
+
+// ArrowRow is a view of a single row of an Arrow record which implements
+// the Size, Get and Set methods of the struct-like values that accessors
+// and the expression evaluators operate on, so that for example
+// [iceberg.Evaluate] can test rows of a record against a bound expression
+// without copying them.
+//
+// Get converts the Arrow value to the Go type used for the values of the
+// corresponding Iceberg type, e.g. int32 for ints, [iceberg.Date] for dates,
+// microseconds as [iceberg.Timestamp] for timestamps, [uuid.UUID] for
+// uuids and [iceberg.Decimal] for decimals. Nulls are returned as nil,
+// struct values as a nested *ArrowRow so that accessors of nested fields
+// work, lists as []any and maps as map[any]any, with binary keys converted
+// to strings.
+//
+// The fields of the record must be in the order of the schema the
+// expression was bound to, as returned for example by ToRequestedSchema.
+type ArrowRow struct {
+	cols []arrow.Array
+	row  int
+	set  map[int]any
+}
+
+// NewArrowRow returns a view of the given row of rec.
+func NewArrowRow(rec arrow.Record, row int) *ArrowRow {
+	return &ArrowRow{cols: rec.Columns(), row: row}
+}
+
+// SetRow moves the view to another row of the same record, dropping any
+// values which were Set on the previous row.
+func (r *ArrowRow) SetRow(row int) {
+	r.row, r.set = row, nil
+}
+
+// Row returns the index of the row viewed.
+func (r *ArrowRow) Row() int { return r.row }
+
+func (r *ArrowRow) Size() int { return len(r.cols) }
+
+func (r *ArrowRow) Get(pos int) any {
+	if v, ok := r.set[pos]; ok {
+		return v
+	}
+
+	return arrowRowValue(r.cols[pos], r.row)
+}
+
+// Set overrides the value at pos for the current row. Arrow arrays are
+// immutable, so the record itself is left unchanged.
+func (r *ArrowRow) Set(pos int, val any) {
+	if r.set == nil {
+		r.set = make(map[int]any)
+	}
+	r.set[pos] = val
+}
+
+func arrowRowValue(arr arrow.Array, i int) any {
+	if arr.IsNull(i) {
+		return nil
+	}
+
+	switch a := arr.(type) {
+	case array.ExtensionArray:
+		if _, ok := a.ExtensionType().(*extensions.UUIDType); ok {
+			return uuid.UUID(a.Storage().(*array.FixedSizeBinary).Value(i))
+		}
+
+		return arrowRowValue(a.Storage(), i)
+	case *array.Boolean:
+		return a.Value(i)
+	case *array.Int8:
+		return int32(a.Value(i))
+	case *array.Int16:
+		return int32(a.Value(i))
+	case *array.Int32:
+		return a.Value(i)
+	case *array.Int64:
+		return a.Value(i)
+	case *array.Float32:
+		return a.Value(i)
+	case *array.Float64:
+		return a.Value(i)
+	case *array.String:
+		return a.Value(i)
+	case *array.LargeString:
+		return a.Value(i)
+	case *array.Binary:
+		return a.Value(i)
+	case *array.LargeBinary:
+		return a.Value(i)
+	case *array.FixedSizeBinary:
+		return a.Value(i)
+	case *array.Date32:
+		return iceberg.Date(a.Value(i))
+	case *array.Time64:
+		v := int64(a.Value(i))
+		if a.DataType().(*arrow.Time64Type).Unit == arrow.Nanosecond {
+			v /= 1000
+		}
+
+		return iceberg.Time(v)
+	case *array.Timestamp:
+		unit := a.DataType().(*arrow.TimestampType).Unit
+
+		return iceberg.Timestamp(int64(a.Value(i)) * int64(unit.Multiplier()) /
+			int64(arrow.Microsecond.Multiplier()))
+	case *array.Decimal128:
+		return iceberg.Decimal{
+			Val: a.Value(i), Scale: int(a.DataType().(*arrow.Decimal128Type).Scale),
+		}
+	case *array.Struct:
+		cols := make([]arrow.Array, a.NumField())
+		for f := range cols {
+			cols[f] = a.Field(f)
+		}
+
+		return &ArrowRow{cols: cols, row: i}
+	case *array.Map:
+		start, end := a.ValueOffsets(i)
+		keys, items := a.Keys(), a.Items()
+		out := make(map[any]any, end-start)
+		for j := int(start); j < int(end); j++ {
+			key := arrowRowValue(keys, j)
+			if b, ok := key.([]byte); ok {
+				key = string(b)
+			}
+			out[key] = arrowRowValue(items, j)
+		}
+
+		return out
+	case array.ListLike:
+		start, end := a.ValueOffsets(i)
+		values := a.ListValues()
+		out := make([]any, 0, end-start)
+		for j := int(start); j < int(end); j++ {
+			out = append(out, arrowRowValue(values, j))
+		}
+
+		return out
+	default:
+		return arr.GetOneForMarshal(i)
+	}
+}
@@ -0,0 +1,103 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrowRowEvaluate(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+		iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz},
+		iceberg.NestedField{ID: 4, Name: "location", Type: &iceberg.StructType{
+			FieldList: []iceberg.NestedField{
+				{ID: 5, Name: "lat", Type: iceberg.PrimitiveTypes.Float64},
+				{ID: 6, Name: "long", Type: iceberg.PrimitiveTypes.Float64},
+			},
+		}},
+	)
+
+	arrSc, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+
+	rec, _, err := array.RecordFromJSON(memory.DefaultAllocator, arrSc, strings.NewReader(`[
+		{"id": 1, "name": "foo", "ts": "2024-01-01T00:00:00Z", "location": {"lat": 52.1, "long": 4.3}},
+		{"id": 2, "name": null, "ts": "2024-06-01T00:00:00Z", "location": {"lat": -33.9, "long": 151.2}},
+		{"id": 3, "name": "bar", "ts": null, "location": null},
+		{"id": 4, "name": "foobar", "ts": "2025-01-01T00:00:00Z", "location": {"lat": 40.7, "long": null}}
+	]`))
+	require.NoError(t, err)
+	defer rec.Release()
+
+	row := table.NewArrowRow(rec, 0)
+	assert.Equal(t, 4, row.Size())
+	assert.Equal(t, int32(1), row.Get(0))
+	assert.Equal(t, "foo", row.Get(1))
+	assert.Equal(t, iceberg.Timestamp(1704067200000000), row.Get(2))
+	require.IsType(t, &table.ArrowRow{}, row.Get(3))
+	assert.Equal(t, 52.1, row.Get(3).(*table.ArrowRow).Get(0))
+
+	row.Set(1, "baz")
+	assert.Equal(t, "baz", row.Get(1))
+	row.SetRow(1)
+	assert.Nil(t, row.Get(1))
+
+	tests := []struct {
+		expr     iceberg.BooleanExpression
+		expected []int32
+	}{
+		{iceberg.GreaterThan(iceberg.Reference("id"), int32(2)), []int32{3, 4}},
+		{iceberg.StartsWith(iceberg.Reference("name"), "foo"), []int32{1, 4}},
+		{iceberg.NewNot(iceberg.EqualTo(iceberg.Reference("name"), "foo")), []int32{3, 4}},
+		{iceberg.IsNull(iceberg.Reference("ts")), []int32{3}},
+		{iceberg.LessThan(iceberg.Reference("ts"), "2024-12-01T00:00:00+00:00"), []int32{1, 2}},
+		{iceberg.LessThan(iceberg.Reference("location.lat"), 45.0), []int32{2, 4}},
+		{iceberg.NewOr(iceberg.IsNull(iceberg.Reference("location.long")),
+			iceberg.GreaterThan(iceberg.Reference("location.long"), 100.0)), []int32{2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr.String(), func(t *testing.T) {
+			bound, err := iceberg.BindExpr(sc, tt.expr, true)
+			require.NoError(t, err)
+
+			var selected []int32
+			row := table.NewArrowRow(rec, 0)
+			for i := range int(rec.NumRows()) {
+				row.SetRow(i)
+				ok, err := iceberg.Evaluate(bound, row)
+				require.NoError(t, err)
+				if ok {
+					selected = append(selected, row.Get(0).(int32))
+				}
+			}
+
+			assert.Equal(t, tt.expected, selected)
+		})
+	}
+}
@@ -19,8 +19,10 @@ package table
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"iter"
+	"slices"
 	"strconv"
 	"sync"
 
@@ -29,6 +31,7 @@ import (
 	"github.com/apache/arrow-go/v18/arrow/compute"
 	"github.com/apache/arrow-go/v18/arrow/compute/exprs"
 	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/metadata"
 	"github.com/apache/iceberg-go"
 	iceio "github.com/apache/iceberg-go/io"
 	"github.com/apache/iceberg-go/table/internal"
@@ -151,23 +154,58 @@ func readDeletes(ctx context.Context, fs iceio.IO, dataFile iceberg.DataFile) (m
 
 type set[T comparable] map[T]struct{}
 
-func combinePositionalDeletes(mem memory.Allocator, deletes set[int64], start, end int64) arrow.Array {
+// sortedDeletePositions flattens the positions read from the position
+// delete files of a single data file into a sorted slice without
+// duplicates, since multiple delete files may delete the same row.
+func sortedDeletePositions(deletes positionDeletes) []int64 {
+	n := 0
+	for _, chunk := range deletes {
+		n += chunk.Len()
+	}
+
+	positions := make([]int64, 0, n)
+	for _, chunk := range deletes {
+		for _, a := range chunk.Chunks() {
+			positions = append(positions, a.(*array.Int64).Int64Values()...)
+		}
+	}
+	slices.Sort(positions)
+
+	return slices.Compact(positions)
+}
+
+// combinePositionalDeletes returns the indices, relative to start, of the
+// rows in [start, end) which are not deleted, along with the remaining
+// deleted positions at or after end.
+func combinePositionalDeletes(mem memory.Allocator, deletes []int64, start, end int64) (arrow.Array, []int64) {
 	bldr := array.NewInt64Builder(mem)
 	defer bldr.Release()
+	bldr.Reserve(int(end - start))
 
 	for i := start; i < end; i++ {
-		if _, ok := deletes[i]; !ok {
-			bldr.Append(i)
+		for len(deletes) > 0 && deletes[0] < i {
+			deletes = deletes[1:]
 		}
+
+		if len(deletes) > 0 && deletes[0] == i {
+			deletes = deletes[1:]
+
+			continue
+		}
+		bldr.UnsafeAppend(i - start)
 	}
 
-	return bldr.NewArray()
+	return bldr.NewArray(), deletes
 }
 
 type recProcessFn func(arrow.Record) (arrow.Record, error)
 
-func processPositionalDeletes(ctx context.Context, deletes set[int64]) recProcessFn {
-	nextIdx, mem := int64(0), compute.GetAllocator(ctx)
+// processPositionalDeletes returns a function which removes the rows at the
+// given sorted positions from the records of a data file, the first of
+// which is at position firstRow in the file. Records must be passed in
+// file order, as positions are tracked across calls.
+func processPositionalDeletes(ctx context.Context, deletes []int64, firstRow int64) recProcessFn {
+	nextIdx, mem := firstRow, compute.GetAllocator(ctx)
 
 	return func(r arrow.Record) (arrow.Record, error) {
 		defer r.Release()
@@ -175,7 +213,8 @@ func processPositionalDeletes(ctx context.Context, deletes set[int64]) recProces
 		currentIdx := nextIdx
 		nextIdx += r.NumRows()
 
-		indices := combinePositionalDeletes(mem, deletes, currentIdx, nextIdx)
+		var indices arrow.Array
+		indices, deletes = combinePositionalDeletes(mem, deletes, currentIdx, nextIdx)
 		defer indices.Release()
 
 		out, err := compute.Take(ctx, *compute.DefaultTakeOptions(),
@@ -188,6 +227,203 @@ func processPositionalDeletes(ctx context.Context, deletes set[int64]) recProces
 	}
 }
 
+// equalityDeletes is the set of deleted rows for a single set of equality
+// field IDs, keyed by the values of those fields.
+type equalityDeletes struct {
+	fieldIDs []int
+	keys     set[string]
+}
+
+// appendEqualityKey appends the key identifying the values of cols at row to
+// buf. Values are compared by their string representation, so that columns
+// of promoted types (e.g. int and long) produce the same keys. As required
+// by the spec, null values are equal to each other.
+func appendEqualityKey(buf []byte, cols []arrow.Array, row int) []byte {
+	for _, col := range cols {
+		if col == nil || col.IsNull(row) {
+			buf = append(buf, 'n')
+
+			continue
+		}
+
+		v := col.ValueStr(row)
+		buf = append(buf, 'v')
+		buf = strconv.AppendInt(buf, int64(len(v)), 10)
+		buf = append(buf, ':')
+		buf = append(buf, v...)
+	}
+
+	return buf
+}
+
+// topLevelColumn returns the index of the top-level field with the given
+// ID in sc, or -1 if there is no such field. Equality fields nested in
+// structs are not supported.
+func topLevelColumn(sc *iceberg.Schema, id int) (int, error) {
+	idx := slices.IndexFunc(sc.Fields(), func(f iceberg.NestedField) bool { return f.ID == id })
+	if idx == -1 {
+		if _, ok := sc.FindFieldByID(id); ok {
+			return -1, fmt.Errorf("%w: equality deletes on nested field %d",
+				iceberg.ErrNotImplemented, id)
+		}
+	}
+
+	return idx, nil
+}
+
+func readEqualityDeletes(ctx context.Context, fs iceio.IO, dataFile iceberg.DataFile) (set[string], error) {
+	ids := dataFile.EqualityFieldIDs()
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: equality delete file %s has no equality field ids",
+			ErrInvalidMetadata, dataFile.FilePath())
+	}
+
+	src, err := internal.GetFile(ctx, fs, dataFile, false)
+	if err != nil {
+		return nil, err
+	}
+
+	rdr, err := src.GetReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Close()
+
+	tbl, err := rdr.ReadTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tbl.Release()
+
+	sc, err := ArrowSchemaToIceberg(tbl.Schema(), false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	colIndices := make([]int, len(ids))
+	for i, id := range ids {
+		if colIndices[i], err = topLevelColumn(sc, id); err != nil {
+			return nil, err
+		}
+
+		if colIndices[i] == -1 {
+			return nil, fmt.Errorf("%w: equality field %d not found in delete file %s",
+				iceberg.ErrInvalidSchema, id, dataFile.FilePath())
+		}
+	}
+
+	tr := array.NewTableReader(tbl, -1)
+	defer tr.Release()
+
+	var (
+		keys = set[string]{}
+		cols = make([]arrow.Array, len(ids))
+		buf  []byte
+	)
+
+	for tr.Next() {
+		rec := tr.Record()
+		for i, idx := range colIndices {
+			cols[i] = rec.Column(idx)
+		}
+
+		for row := range int(rec.NumRows()) {
+			buf = appendEqualityKey(buf[:0], cols, row)
+			keys[string(buf)] = struct{}{}
+		}
+	}
+
+	return keys, tr.Err()
+}
+
+// processEqualityDeletes returns a function which removes the rows of the
+// records of a data file whose values for the equality fields of any of the
+// given equality delete files match a deleted row. The delete files are read
+// once, up front, into in-memory hash sets.
+func processEqualityDeletes(ctx context.Context, fs iceio.IO, fileSchema *iceberg.Schema, deleteFiles []iceberg.DataFile) (recProcessFn, error) {
+	byFieldIDs := make(map[string]*equalityDeletes)
+	for _, df := range deleteFiles {
+		keys, err := readEqualityDeletes(ctx, fs, df)
+		if err != nil {
+			return nil, err
+		}
+
+		ids := fmt.Sprint(df.EqualityFieldIDs())
+		deletes, ok := byFieldIDs[ids]
+		if !ok {
+			deletes = &equalityDeletes{fieldIDs: df.EqualityFieldIDs(), keys: set[string]{}}
+			byFieldIDs[ids] = deletes
+		}
+
+		for k := range keys {
+			deletes.keys[k] = struct{}{}
+		}
+	}
+
+	// an equality field that is missing from the data file, e.g. because it
+	// was added to the table later, is null for every row
+	colIndices := make(map[*equalityDeletes][]int, len(byFieldIDs))
+	for _, deletes := range byFieldIDs {
+		indices := make([]int, len(deletes.fieldIDs))
+		for i, id := range deletes.fieldIDs {
+			var err error
+			if indices[i], err = topLevelColumn(fileSchema, id); err != nil {
+				return nil, err
+			}
+		}
+		colIndices[deletes] = indices
+	}
+
+	mem := compute.GetAllocator(ctx)
+
+	return func(r arrow.Record) (arrow.Record, error) {
+		defer r.Release()
+
+		bldr := array.NewBooleanBuilder(mem)
+		defer bldr.Release()
+		bldr.Reserve(int(r.NumRows()))
+
+		var (
+			buf  []byte
+			cols = make(map[*equalityDeletes][]arrow.Array, len(colIndices))
+		)
+
+		for deletes, indices := range colIndices {
+			arrs := make([]arrow.Array, len(indices))
+			for i, idx := range indices {
+				if idx != -1 {
+					arrs[i] = r.Column(idx)
+				}
+			}
+			cols[deletes] = arrs
+		}
+
+		for row := range int(r.NumRows()) {
+			keep := true
+			for deletes, arrs := range cols {
+				buf = appendEqualityKey(buf[:0], arrs, row)
+				if _, deleted := deletes.keys[string(buf)]; deleted {
+					keep = false
+
+					break
+				}
+			}
+			bldr.UnsafeAppend(keep)
+		}
+
+		mask := bldr.NewArray()
+		defer mask.Release()
+
+		result, err := compute.Filter(ctx, compute.NewDatumWithoutOwning(r),
+			compute.NewDatumWithoutOwning(mask), *compute.DefaultFilterOptions())
+		if err != nil {
+			return nil, err
+		}
+
+		return result.(*compute.RecordDatum).Value, nil
+	}, nil
+}
+
 func filterRecords(ctx context.Context, recordFilter expr.Expression) recProcessFn {
 	return func(rec arrow.Record) (arrow.Record, error) {
 		defer rec.Release()
@@ -219,6 +455,9 @@ type arrowScan struct {
 
 	useLargeTypes bool
 	concurrency   int
+	// unordered yields records as soon as they are read, instead of in
+	// the order of the tasks.
+	unordered bool
 
 	nameMapping iceberg.NameMapping
 }
@@ -254,12 +493,16 @@ type enumeratedRecord struct {
 	Err    error
 }
 
-func (as *arrowScan) prepareToRead(ctx context.Context, file iceberg.DataFile) (*iceberg.Schema, []int, internal.FileReader, error) {
+func (as *arrowScan) prepareToRead(ctx context.Context, file iceberg.DataFile, extraFieldIDs []int) (*iceberg.Schema, []int, internal.FileReader, error) {
 	ids, err := as.projectedFieldIDs()
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	for _, id := range extraFieldIDs {
+		ids[id] = struct{}{}
+	}
+
 	src, err := internal.GetFile(ctx, as.fs, file, false)
 	if err != nil {
 		return nil, nil, nil, err
@@ -320,6 +563,48 @@ func (as *arrowScan) getRecordFilter(ctx context.Context, fileSchema *iceberg.Sc
 	return nil, false, nil
 }
 
+// parquetSplitRowGroups returns a filter for the row groups of a Parquet
+// file which begin within the range of the task, along with the position
+// in the file of the first row of those row groups. As every row group
+// begins within exactly one of the splits of a file, each split reads its
+// own rows. The filter is nil when the task covers the whole file, which
+// a task without a Length does too.
+func parquetSplitRowGroups(rdr internal.FileReader, task FileScanTask) (func(*metadata.RowGroupMetaData) (bool, error), int64, error) {
+	if task.Length <= 0 || (task.Start <= 0 && task.Length >= task.File.FileSizeBytes()) {
+		return nil, 0, nil
+	}
+
+	pqmeta, ok := rdr.Metadata().(*metadata.FileMetaData)
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: unexpected metadata %T for parquet file %s",
+			iceberg.ErrInvalidArgument, rdr.Metadata(), task.File.FilePath())
+	}
+
+	inSplit := func(rg *metadata.RowGroupMetaData) (bool, error) {
+		offset, err := internal.RowGroupOffset(rg)
+		if err != nil {
+			return false, err
+		}
+
+		return offset >= task.Start && offset < task.Start+task.Length, nil
+	}
+
+	var firstRow int64
+	for i := range pqmeta.NumRowGroups() {
+		rg := pqmeta.RowGroup(i)
+		offset, err := internal.RowGroupOffset(rg)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if offset < task.Start {
+			firstRow += rg.NumRows()
+		}
+	}
+
+	return inSplit, firstRow, nil
+}
+
 func (as *arrowScan) processRecords(
 	ctx context.Context,
 	task internal.Enumerated[FileScanTask],
@@ -327,6 +612,8 @@ func (as *arrowScan) processRecords(
 	rdr internal.FileReader,
 	columns []int,
 	pipeline []recProcessFn,
+	pruneRowGroups bool,
+	inSplit func(*metadata.RowGroupMetaData) (bool, error),
 	out chan<- enumeratedRecord,
 ) (err error) {
 	var (
@@ -336,9 +623,29 @@ func (as *arrowScan) processRecords(
 
 	switch task.Value.File.FileFormat() {
 	case iceberg.ParquetFile:
-		testRowGroups, err = newParquetRowGroupStatsEvaluator(fileSchema, as.boundRowFilter, false)
-		if err != nil {
-			return err
+		var testStats func(*metadata.RowGroupMetaData, []int) (bool, error)
+		if pruneRowGroups {
+			testStats, err = newParquetRowGroupStatsEvaluator(fileSchema, as.boundRowFilter, false)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case inSplit != nil:
+			testRowGroups = func(rg *metadata.RowGroupMetaData, cols []int) (bool, error) {
+				if ok, err := inSplit(rg); !ok || err != nil {
+					return false, err
+				}
+
+				if testStats == nil {
+					return true, nil
+				}
+
+				return testStats(rg, cols)
+			}
+		case testStats != nil:
+			testRowGroups = testStats
 		}
 	}
 
@@ -354,6 +661,16 @@ func (as *arrowScan) processRecords(
 	)
 
 	for recRdr.Next() {
+		// stop reading the file once the scan is cancelled or stopped,
+		// rather than reading it to the end
+		if err := ctx.Err(); err != nil {
+			if prev != nil {
+				prev.Release()
+			}
+
+			return err
+		}
+
 		if prev != nil {
 			out <- enumeratedRecord{Record: internal.Enumerated[arrow.Record]{
 				Value: prev, Index: idx, Last: false,
@@ -372,12 +689,21 @@ func (as *arrowScan) processRecords(
 		}
 	}
 
-	if prev != nil {
-		out <- enumeratedRecord{Record: internal.Enumerated[arrow.Record]{
-			Value: prev, Index: idx, Last: true,
-		}, Task: task}
+	if prev == nil {
+		// the split may not contain any row group, the ordered iterator
+		// still expects a last record for every task
+		var emptySchema *arrow.Schema
+		emptySchema, err = SchemaToArrowSchema(as.projectedSchema, nil, false, as.useLargeTypes)
+		if err != nil {
+			return err
+		}
+		prev = array.NewRecord(emptySchema, nil, 0)
 	}
 
+	out <- enumeratedRecord{Record: internal.Enumerated[arrow.Record]{
+		Value: prev, Index: idx, Last: true,
+	}, Task: task}
+
 	if recRdr.Err() != nil && recRdr.Err() != io.EOF {
 		err = recRdr.Err()
 	}
@@ -400,24 +726,40 @@ func (as *arrowScan) recordsFromTask(ctx context.Context, task internal.Enumerat
 		dropFile   bool
 	)
 
-	iceSchema, colIndices, rdr, err = as.prepareToRead(ctx, task.Value.File)
+	// the equality fields must be read in order to apply equality deletes,
+	// even when they are not part of the projection
+	var (
+		equalityDeleteFiles []iceberg.DataFile
+		equalityFieldIDs    []int
+	)
+	for _, df := range task.Value.DeleteFiles {
+		if df.ContentType() == iceberg.EntryContentEqDeletes {
+			equalityDeleteFiles = append(equalityDeleteFiles, df)
+			equalityFieldIDs = append(equalityFieldIDs, df.EqualityFieldIDs()...)
+		}
+	}
+
+	iceSchema, colIndices, rdr, err = as.prepareToRead(ctx, task.Value.File, equalityFieldIDs)
 	if err != nil {
 		return
 	}
 	defer rdr.Close()
 
-	pipeline := make([]recProcessFn, 0, 2)
-	if len(positionalDeletes) > 0 {
-		deletes := set[int64]{}
-		for _, chunk := range positionalDeletes {
-			for _, a := range chunk.Chunks() {
-				for _, v := range a.(*array.Int64).Int64Values() {
-					deletes[v] = struct{}{}
-				}
-			}
+	var (
+		inSplit  func(*metadata.RowGroupMetaData) (bool, error)
+		firstRow int64
+	)
+	if task.Value.File.FileFormat() == iceberg.ParquetFile {
+		inSplit, firstRow, err = parquetSplitRowGroups(rdr, task.Value)
+		if err != nil {
+			return
 		}
+	}
 
-		pipeline = append(pipeline, processPositionalDeletes(ctx, deletes))
+	pipeline := make([]recProcessFn, 0, 3)
+	if len(positionalDeletes) > 0 {
+		pipeline = append(pipeline, processPositionalDeletes(ctx,
+			sortedDeletePositions(positionalDeletes), firstRow))
 	}
 
 	filterFunc, dropFile, err = as.getRecordFilter(ctx, iceSchema)
@@ -442,18 +784,34 @@ func (as *arrowScan) recordsFromTask(ctx context.Context, task internal.Enumerat
 		pipeline = append(pipeline, filterFunc)
 	}
 
+	if len(equalityDeleteFiles) > 0 {
+		var eqDeletesFunc recProcessFn
+		eqDeletesFunc, err = processEqualityDeletes(ctx, as.fs, iceSchema, equalityDeleteFiles)
+		if err != nil {
+			return
+		}
+		pipeline = append(pipeline, eqDeletesFunc)
+	}
+
 	pipeline = append(pipeline, func(r arrow.Record) (arrow.Record, error) {
 		defer r.Release()
 
 		return ToRequestedSchema(ctx, as.projectedSchema, iceSchema, r, false, false, as.useLargeTypes)
 	})
 
-	err = as.processRecords(ctx, task, iceSchema, rdr, colIndices, pipeline, out)
+	// skipping row groups would shift the positions of the remaining rows,
+	// so only prune them when there are no positional deletes to apply
+	err = as.processRecords(ctx, task, iceSchema, rdr, colIndices, pipeline,
+		len(positionalDeletes) == 0, inSplit, out)
 
 	return
 }
 
-func createIterator(ctx context.Context, numWorkers uint, records <-chan enumeratedRecord, deletesPerFile perFilePosDeletes, cancel context.CancelCauseFunc, rowLimit int64) iter.Seq2[arrow.Record, error] {
+func createIterator(ctx context.Context, numWorkers uint, records <-chan enumeratedRecord, deletesPerFile perFilePosDeletes, cancel context.CancelCauseFunc, rowLimit int64, unordered bool) iter.Seq2[arrow.Record, error] {
+	if unordered {
+		return iterateRecords(ctx, records, deletesPerFile, cancel, rowLimit)
+	}
+
 	isBeforeAny := func(batch enumeratedRecord) bool {
 		return batch.Task.Index < 0
 	}
@@ -486,6 +844,12 @@ func createIterator(ctx context.Context, numWorkers uint, records <-chan enumera
 			}
 		}, enumeratedRecord{Task: internal.Enumerated[FileScanTask]{Index: -1}})
 
+	return iterateRecords(ctx, sequenced, deletesPerFile, cancel, rowLimit)
+}
+
+// iterateRecords yields the records in the order they are received from
+// the channel, up to rowLimit rows.
+func iterateRecords(ctx context.Context, sequenced <-chan enumeratedRecord, deletesPerFile perFilePosDeletes, cancel context.CancelCauseFunc, rowLimit int64) iter.Seq2[arrow.Record, error] {
 	totalRowCount := int64(0)
 
 	return func(yield func(arrow.Record, error) bool) {
@@ -602,7 +966,7 @@ func (as *arrowScan) recordBatchesFromTasksAndDeletes(ctx context.Context, tasks
 	}()
 
 	return createIterator(ctx, uint(numWorkers), records, deletesPerFile,
-		cancel, as.rowLimit)
+		cancel, as.rowLimit, as.unordered)
 }
 
 func (as *arrowScan) GetRecords(ctx context.Context, tasks []FileScanTask) (*arrow.Schema, iter.Seq2[arrow.Record, error], error) {
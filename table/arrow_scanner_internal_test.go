@@ -0,0 +1,417 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeParquetFixture(t *testing.T, path string, content iceberg.ManifestEntryContent, tbl arrow.Table, equalityFieldIDs ...int) iceberg.DataFile {
+	t.Helper()
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, pqarrow.WriteTable(tbl, f, tbl.NumRows(), nil, pqarrow.DefaultWriterProps()))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	bldr, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, content,
+		path, iceberg.ParquetFile, nil, tbl.NumRows(), info.Size())
+	require.NoError(t, err)
+
+	if len(equalityFieldIDs) > 0 {
+		bldr.EqualityFieldIDs(equalityFieldIDs)
+	}
+
+	return bldr.Build()
+}
+
+func fieldID(id string) arrow.Metadata {
+	return arrow.MetadataFrom(map[string]string{"PARQUET:field_id": id})
+}
+
+// scanMetadata returns the metadata of an unpartitioned table with the
+// given schema, which the scan resolves its name mapping from.
+func scanMetadata(t *testing.T, sc *iceberg.Schema) Metadata {
+	t.Helper()
+
+	meta, err := NewMetadata(sc, iceberg.UnpartitionedSpec, UnsortedSortOrder, t.TempDir(), nil)
+	require.NoError(t, err)
+
+	return meta
+}
+
+func TestArrowScanProjectsByFieldID(t *testing.T) {
+	// the file stores the columns in a different order, and under
+	// different names, than the table schema
+	fileSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "b", Type: arrow.BinaryTypes.String, Nullable: true, Metadata: fieldID("2")},
+		{Name: "a", Type: arrow.PrimitiveTypes.Int64, Nullable: true, Metadata: fieldID("1")},
+	}, nil)
+
+	fileTbl, err := array.TableFromJSON(memory.DefaultAllocator, fileSchema, []string{
+		`[{"b": "one", "a": 1}, {"b": "two", "a": 2}, {"b": null, "a": 3}]`,
+	})
+	require.NoError(t, err)
+	defer fileTbl.Release()
+
+	dataFile := writeParquetFixture(t, filepath.Join(t.TempDir(), "reordered.parquet"),
+		iceberg.EntryContentData, fileTbl)
+
+	projected := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64},
+		iceberg.NestedField{ID: 3, Name: "added", Type: iceberg.PrimitiveTypes.Float64},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+	)
+
+	scan := &arrowScan{
+		fs:              iceio.LocalFS{},
+		metadata:        scanMetadata(t, projected),
+		projectedSchema: projected,
+		boundRowFilter:  iceberg.AlwaysTrue{},
+		caseSensitive:   true,
+		rowLimit:        -1,
+		concurrency:     1,
+	}
+
+	resultSchema, itr, err := scan.GetRecords(context.Background(), []FileScanTask{{File: dataFile}})
+	require.NoError(t, err)
+
+	records := make([]arrow.Record, 0)
+	for rec, err := range itr {
+		require.NoError(t, err)
+		defer rec.Release()
+		records = append(records, rec)
+	}
+
+	result := array.NewTableFromRecords(resultSchema, records)
+	defer result.Release()
+
+	expectedSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "added", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "data", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	assert.True(t, expectedSchema.Equal(result.Schema()), result.Schema().String())
+
+	expected, err := array.TableFromJSON(memory.DefaultAllocator, expectedSchema, []string{
+		`[{"id": 1, "added": null, "data": "one"},
+		  {"id": 2, "added": null, "data": "two"},
+		  {"id": 3, "added": null, "data": null}]`,
+	})
+	require.NoError(t, err)
+	defer expected.Release()
+
+	assert.True(t, array.TableEqual(expected, result), result)
+}
+
+func TestArrowScanPositionalDeletes(t *testing.T) {
+	mem, dir := memory.DefaultAllocator, t.TempDir()
+
+	dataSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true, Metadata: fieldID("1")},
+	}, nil)
+
+	bldr := array.NewRecordBuilder(mem, dataSchema)
+	defer bldr.Release()
+	for i := range 10 {
+		bldr.Field(0).(*array.Int64Builder).Append(int64(i))
+	}
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	dataTbl := array.NewTableFromRecords(dataSchema, []arrow.Record{rec})
+	defer dataTbl.Release()
+
+	dataFile := writeParquetFixture(t, filepath.Join(dir, "data.parquet"),
+		iceberg.EntryContentData, dataTbl)
+
+	deleteSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "file_path", Type: arrow.BinaryTypes.String, Metadata: fieldID("2147483546")},
+		{Name: "pos", Type: arrow.PrimitiveTypes.Int64, Metadata: fieldID("2147483545")},
+	}, nil)
+
+	deleteTbl, err := array.TableFromJSON(mem, deleteSchema, []string{
+		`[{"file_path": "` + dataFile.FilePath() + `", "pos": 2},
+		  {"file_path": "` + dataFile.FilePath() + `", "pos": 7},
+		  {"file_path": "` + filepath.Join(dir, "other.parquet") + `", "pos": 0},
+		  {"file_path": "` + filepath.Join(dir, "other.parquet") + `", "pos": 5}]`,
+	})
+	require.NoError(t, err)
+	defer deleteTbl.Release()
+
+	deleteFile := writeParquetFixture(t, filepath.Join(dir, "deletes.parquet"),
+		iceberg.EntryContentPosDeletes, deleteTbl)
+
+	projected := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64})
+	scan := &arrowScan{
+		fs:              iceio.LocalFS{},
+		metadata:        scanMetadata(t, projected),
+		projectedSchema: projected,
+		boundRowFilter:  iceberg.AlwaysTrue{},
+		caseSensitive:   true,
+		rowLimit:        -1,
+		concurrency:     1,
+	}
+
+	_, itr, err := scan.GetRecords(context.Background(), []FileScanTask{
+		{File: dataFile, DeleteFiles: []iceberg.DataFile{deleteFile}},
+	})
+	require.NoError(t, err)
+
+	ids := make([]int64, 0)
+	for rec, err := range itr {
+		require.NoError(t, err)
+		ids = append(ids, rec.Column(0).(*array.Int64).Int64Values()...)
+		rec.Release()
+	}
+
+	assert.Equal(t, []int64{0, 1, 3, 4, 5, 6, 8, 9}, ids)
+}
+
+func TestCombinePositionalDeletes(t *testing.T) {
+	deletes := []int64{1, 4, 5, 9, 12}
+
+	indices, rest := combinePositionalDeletes(memory.DefaultAllocator, deletes, 0, 6)
+	defer indices.Release()
+	assert.Equal(t, []int64{0, 2, 3}, indices.(*array.Int64).Int64Values())
+	assert.Equal(t, []int64{9, 12}, rest)
+
+	// indices are relative to the start of the batch
+	indices, rest = combinePositionalDeletes(memory.DefaultAllocator, rest, 6, 12)
+	defer indices.Release()
+	assert.Equal(t, []int64{0, 1, 2, 4, 5}, indices.(*array.Int64).Int64Values())
+	assert.Equal(t, []int64{12}, rest)
+}
+
+func TestArrowScanSplitsReadRowGroupsInRange(t *testing.T) {
+	mem, dir := memory.DefaultAllocator, t.TempDir()
+
+	dataSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true, Metadata: fieldID("1")},
+	}, nil)
+
+	bldr := array.NewRecordBuilder(mem, dataSchema)
+	defer bldr.Release()
+	for i := range 10 {
+		bldr.Field(0).(*array.Int64Builder).Append(int64(i))
+	}
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	dataTbl := array.NewTableFromRecords(dataSchema, []arrow.Record{rec})
+	defer dataTbl.Release()
+
+	// row groups of 3 rows: [0, 3), [3, 6), [6, 9) and [9, 10)
+	path := filepath.Join(dir, "data.parquet")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, pqarrow.WriteTable(dataTbl, f, 3, nil, pqarrow.DefaultWriterProps()))
+
+	pf, err := file.OpenParquetFile(path, false)
+	require.NoError(t, err)
+	require.Equal(t, 4, pf.NumRowGroups())
+	thirdRowGroup, err := internal.RowGroupOffset(pf.MetaData().RowGroup(2))
+	require.NoError(t, err)
+	require.NoError(t, pf.Close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	dataFile, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentData,
+		path, iceberg.ParquetFile, nil, 10, info.Size())
+	require.NoError(t, err)
+
+	deleteSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "file_path", Type: arrow.BinaryTypes.String, Metadata: fieldID("2147483546")},
+		{Name: "pos", Type: arrow.PrimitiveTypes.Int64, Metadata: fieldID("2147483545")},
+	}, nil)
+
+	deleteTbl, err := array.TableFromJSON(mem, deleteSchema, []string{
+		`[{"file_path": "` + path + `", "pos": 2}, {"file_path": "` + path + `", "pos": 7}]`,
+	})
+	require.NoError(t, err)
+	defer deleteTbl.Release()
+
+	deleteFile := writeParquetFixture(t, filepath.Join(dir, "deletes.parquet"),
+		iceberg.EntryContentPosDeletes, deleteTbl)
+
+	projected := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64})
+	scan := &arrowScan{
+		fs:              iceio.LocalFS{},
+		metadata:        scanMetadata(t, projected),
+		projectedSchema: projected,
+		boundRowFilter:  iceberg.AlwaysTrue{},
+		caseSensitive:   true,
+		rowLimit:        -1,
+		concurrency:     2,
+	}
+
+	// the last split does not contain the start of any row group
+	deletes := []iceberg.DataFile{deleteFile}
+	tasks := []FileScanTask{
+		{File: dataFile.Build(), DeleteFiles: deletes, Start: 0, Length: thirdRowGroup},
+		{File: dataFile.Build(), DeleteFiles: deletes, Start: thirdRowGroup, Length: info.Size() - 1 - thirdRowGroup},
+		{File: dataFile.Build(), DeleteFiles: deletes, Start: info.Size() - 1, Length: 1},
+	}
+
+	_, itr, err := scan.GetRecords(context.Background(), tasks)
+	require.NoError(t, err)
+
+	ids := make([]int64, 0)
+	for rec, err := range itr {
+		require.NoError(t, err)
+		ids = append(ids, rec.Column(0).(*array.Int64).Int64Values()...)
+		rec.Release()
+	}
+
+	// every row is read once, and the deleted positions are relative to
+	// the start of the file rather than of the split
+	assert.Equal(t, []int64{0, 1, 3, 4, 5, 6, 8, 9}, ids)
+}
+
+func TestArrowScanEqualityDeletes(t *testing.T) {
+	mem, dir := memory.DefaultAllocator, t.TempDir()
+
+	dataSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true, Metadata: fieldID("1")},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true, Metadata: fieldID("2")},
+	}, nil)
+
+	dataTbl, err := array.TableFromJSON(mem, dataSchema, []string{
+		`[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}, {"id": 3, "name": "c"},
+		  {"id": 4, "name": "d"}, {"id": 5, "name": null}]`,
+	})
+	require.NoError(t, err)
+	defer dataTbl.Release()
+
+	dataFile := writeParquetFixture(t, filepath.Join(dir, "data.parquet"),
+		iceberg.EntryContentData, dataTbl)
+
+	// the delete file stores the id as an int, as it was written before
+	// the column was promoted to a long
+	idDeletesSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Metadata: fieldID("1")},
+	}, nil)
+	idDeletesTbl, err := array.TableFromJSON(mem, idDeletesSchema, []string{`[{"id": 2}, {"id": 42}]`})
+	require.NoError(t, err)
+	defer idDeletesTbl.Release()
+
+	idDeletes := writeParquetFixture(t, filepath.Join(dir, "id-deletes.parquet"),
+		iceberg.EntryContentEqDeletes, idDeletesTbl, 1)
+
+	compositeDeletesTbl, err := array.TableFromJSON(mem, dataSchema, []string{
+		`[{"id": 3, "name": "c"}, {"id": 4, "name": "x"}, {"id": 5, "name": null}]`,
+	})
+	require.NoError(t, err)
+	defer compositeDeletesTbl.Release()
+
+	compositeDeletes := writeParquetFixture(t, filepath.Join(dir, "composite-deletes.parquet"),
+		iceberg.EntryContentEqDeletes, compositeDeletesTbl, 1, 2)
+
+	readNames := func(deleteFiles ...iceberg.DataFile) []string {
+		// the equality fields are read even though only name is projected
+		projected := iceberg.NewSchema(0,
+			iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String})
+		scan := &arrowScan{
+			fs:              iceio.LocalFS{},
+			metadata:        scanMetadata(t, projected),
+			projectedSchema: projected,
+			boundRowFilter:  iceberg.AlwaysTrue{},
+			caseSensitive:   true,
+			rowLimit:        -1,
+			concurrency:     1,
+		}
+
+		_, itr, err := scan.GetRecords(context.Background(), []FileScanTask{
+			{File: dataFile, DeleteFiles: deleteFiles},
+		})
+		require.NoError(t, err)
+
+		names := make([]string, 0)
+		for rec, err := range itr {
+			require.NoError(t, err)
+			require.EqualValues(t, 1, rec.NumCols())
+			col := rec.Column(0).(*array.String)
+			for i := range col.Len() {
+				names = append(names, col.ValueStr(i))
+			}
+			rec.Release()
+		}
+
+		return names
+	}
+
+	t.Run("single column", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "c", "d", "(null)"}, readNames(idDeletes))
+	})
+
+	t.Run("composite key", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b", "d"}, readNames(compositeDeletes))
+	})
+
+	t.Run("both", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "d"}, readNames(idDeletes, compositeDeletes))
+	})
+}
+
+func TestDeleteIndexEqualityDeleteSequenceNumbers(t *testing.T) {
+	snapshotID := int64(1)
+	newEntry := func(path string, content iceberg.ManifestEntryContent, seq int64) iceberg.ManifestEntry {
+		bldr, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, content,
+			path, iceberg.ParquetFile, nil, 1, 1)
+		require.NoError(t, err)
+
+		if content == iceberg.EntryContentEqDeletes {
+			bldr.EqualityFieldIDs([]int{1})
+		}
+
+		return iceberg.NewManifestEntryBuilder(iceberg.EntryStatusADDED, &snapshotID, bldr.Build()).
+			SequenceNum(seq).Build()
+	}
+
+	data := newEntry("data.parquet", iceberg.EntryContentData, 2)
+	deletes := []iceberg.ManifestEntry{
+		newEntry("lower.parquet", iceberg.EntryContentEqDeletes, 1),
+		newEntry("same.parquet", iceberg.EntryContentEqDeletes, 2),
+		newEntry("higher.parquet", iceberg.EntryContentEqDeletes, 3),
+	}
+
+	idx, err := NewDeleteIndex(iceberg.NewSchema(0), []iceberg.PartitionSpec{*iceberg.UnpartitionedSpec}, deletes)
+	require.NoError(t, err)
+	matched := idx.ForDataFile(data)
+	require.Len(t, matched, 1)
+	assert.Equal(t, "higher.parquet", matched[0].FilePath())
+}
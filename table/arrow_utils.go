@@ -788,6 +788,64 @@ func (a *arrowProjectionVisitor) constructField(field iceberg.NestedField, arrow
 	}
 }
 
+func appendDefaultN[T any](bldr array.Builder, val T, n int) error {
+	b, ok := bldr.(interface{ Append(T) })
+	if !ok {
+		return fmt.Errorf("%w: cannot fill %s column with default value %v",
+			iceberg.ErrInvalidSchema, bldr.Type(), val)
+	}
+
+	for range n {
+		b.Append(val)
+	}
+
+	return nil
+}
+
+// makeArrayOfDefault returns an array of length n repeating the default
+// value of a field, as returned by a NestedField's InitialDefault.
+func makeArrayOfDefault(mem memory.Allocator, dt arrow.DataType, val any, n int) (arrow.Array, error) {
+	bldr := array.NewBuilder(mem, dt)
+	defer bldr.Release()
+	bldr.Reserve(n)
+
+	var err error
+	switch v := val.(type) {
+	case bool:
+		err = appendDefaultN(bldr, v, n)
+	case int32:
+		err = appendDefaultN(bldr, v, n)
+	case int64:
+		err = appendDefaultN(bldr, v, n)
+	case float32:
+		err = appendDefaultN(bldr, v, n)
+	case float64:
+		err = appendDefaultN(bldr, v, n)
+	case iceberg.Date:
+		err = appendDefaultN(bldr, arrow.Date32(v), n)
+	case iceberg.Time:
+		err = appendDefaultN(bldr, arrow.Time64(v), n)
+	case iceberg.Timestamp:
+		err = appendDefaultN(bldr, arrow.Timestamp(v), n)
+	case string:
+		err = appendDefaultN(bldr, v, n)
+	case []byte:
+		err = appendDefaultN(bldr, v, n)
+	case uuid.UUID:
+		err = appendDefaultN(bldr, v, n)
+	case iceberg.Decimal:
+		err = appendDefaultN(bldr, v.Val, n)
+	default:
+		err = fmt.Errorf("%w: unsupported default value %v (%T)", iceberg.ErrInvalidSchema, val, val)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bldr.NewArray(), nil
+}
+
 func (a *arrowProjectionVisitor) Schema(_ *iceberg.Schema, _ arrow.Array, result arrow.Array) arrow.Array {
 	return result
 }
@@ -810,6 +868,16 @@ func (a *arrowProjectionVisitor) Struct(st iceberg.StructType, structArr arrow.A
 			defer arr.Release()
 			fieldArrs[i] = arr
 			fields[i] = a.constructField(field, arr.DataType())
+		} else if field.InitialDefault != nil {
+			// files written before the field was added read as its
+			// initial default rather than null
+			dt := retOrPanic(TypeToArrowType(field.Type, false, a.useLargeTypes))
+
+			arr = retOrPanic(makeArrayOfDefault(compute.GetAllocator(a.ctx), dt,
+				field.InitialDefault, structArr.Len()))
+			defer arr.Release()
+			fieldArrs[i] = arr
+			fields[i] = a.constructField(field, arr.DataType())
 		} else if !field.Required {
 			dt := retOrPanic(TypeToArrowType(field.Type, false, a.useLargeTypes))
 
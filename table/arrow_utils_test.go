@@ -20,6 +20,7 @@ package table_test
 import (
 	"bufio"
 	"context"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -358,6 +359,70 @@ func TestArrowSchemaRoundTripConversion(t *testing.T) {
 	}
 }
 
+func TestArrowSchemaRoundTripAllTypes(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "bool", Type: iceberg.PrimitiveTypes.Bool, Required: true},
+		iceberg.NestedField{ID: 2, Name: "int", Type: iceberg.PrimitiveTypes.Int32},
+		iceberg.NestedField{ID: 3, Name: "long", Type: iceberg.PrimitiveTypes.Int64},
+		iceberg.NestedField{ID: 4, Name: "float", Type: iceberg.PrimitiveTypes.Float32},
+		iceberg.NestedField{ID: 5, Name: "double", Type: iceberg.PrimitiveTypes.Float64},
+		iceberg.NestedField{ID: 6, Name: "decimal", Type: iceberg.DecimalTypeOf(38, 10)},
+		iceberg.NestedField{ID: 7, Name: "date", Type: iceberg.PrimitiveTypes.Date},
+		iceberg.NestedField{ID: 8, Name: "time", Type: iceberg.PrimitiveTypes.Time},
+		iceberg.NestedField{ID: 9, Name: "ts", Type: iceberg.PrimitiveTypes.Timestamp},
+		iceberg.NestedField{ID: 10, Name: "tstz", Type: iceberg.PrimitiveTypes.TimestampTz},
+		iceberg.NestedField{ID: 11, Name: "string", Type: iceberg.PrimitiveTypes.String, Doc: "some doc"},
+		iceberg.NestedField{ID: 12, Name: "uuid", Type: iceberg.PrimitiveTypes.UUID},
+		iceberg.NestedField{ID: 13, Name: "fixed", Type: iceberg.FixedTypeOf(7)},
+		iceberg.NestedField{ID: 14, Name: "binary", Type: iceberg.PrimitiveTypes.Binary},
+		iceberg.NestedField{ID: 15, Name: "struct", Type: &iceberg.StructType{
+			FieldList: []iceberg.NestedField{
+				{ID: 18, Name: "x", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+				{ID: 19, Name: "y", Type: iceberg.PrimitiveTypes.String},
+			},
+		}},
+		iceberg.NestedField{ID: 16, Name: "list", Type: &iceberg.ListType{
+			ElementID: 20, Element: iceberg.PrimitiveTypes.Int64, ElementRequired: true,
+		}},
+		iceberg.NestedField{ID: 17, Name: "map", Type: &iceberg.MapType{
+			KeyID: 21, KeyType: iceberg.PrimitiveTypes.String,
+			ValueID: 22, ValueType: iceberg.DecimalTypeOf(9, 2), ValueRequired: false,
+		}},
+	)
+
+	for _, useLargeTypes := range []bool{false, true} {
+		arrSchema, err := table.SchemaToArrowSchema(sc, nil, true, useLargeTypes)
+		require.NoError(t, err)
+
+		fieldType := func(name string) arrow.DataType {
+			fields, ok := arrSchema.FieldsByName(name)
+			require.True(t, ok, name)
+
+			return fields[0].Type
+		}
+
+		assert.Equal(t, &arrow.Decimal128Type{Precision: 38, Scale: 10}, fieldType("decimal"))
+		assert.Equal(t, &arrow.TimestampType{Unit: arrow.Microsecond}, fieldType("ts"))
+		assert.Equal(t, &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, fieldType("tstz"))
+		// uuids are stored as 16 byte fixed size binaries
+		assert.True(t, arrow.TypeEqual(extensions.NewUUIDType(), fieldType("uuid")))
+		assert.Equal(t, &arrow.FixedSizeBinaryType{ByteWidth: 16},
+			fieldType("uuid").(arrow.ExtensionType).StorageType())
+		assert.Equal(t, &arrow.FixedSizeBinaryType{ByteWidth: 7}, fieldType("fixed"))
+
+		for i, f := range arrSchema.Fields() {
+			id, ok := f.Metadata.GetValue(table.ArrowParquetFieldIDKey)
+			assert.True(t, ok, f.Name)
+			assert.Equal(t, strconv.Itoa(sc.Field(i).ID), id)
+		}
+
+		roundTripped, err := table.ArrowSchemaToIceberg(arrSchema, false, nil)
+		require.NoError(t, err)
+		assert.True(t, sc.Equals(roundTripped), "large types: %t\n%s\n%s",
+			useLargeTypes, sc, roundTripped)
+	}
+}
+
 func TestArrowSchemaWithNameMapping(t *testing.T) {
 	schemaWithoutIDs := arrow.NewSchema([]arrow.Field{
 		{Name: "foo", Type: arrow.BinaryTypes.String, Nullable: true},
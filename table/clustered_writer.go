@@ -0,0 +1,256 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/io"
+)
+
+// ClusteredWriter writes rows to the data files of a table one partition
+// at a time, so that unlike a FanoutWriter it only ever keeps a single
+// file open. In exchange the rows must come in clustered by partition,
+// and sorted by the sort order of the table within each partition, for
+// example by sorting the batches with SortRecord. Writing a row of a
+// partition which was already completed, or a row which sorts before the
+// previous row of its partition, fails with iceberg.ErrInvalidArgument.
+// A file is closed, and the next rows of its partition written to a new
+// file, once it reaches write.target-file-size-bytes.
+//
+// Batches must have the schema of the table, as for DataFileWriter. When
+// writing fails, the open file is aborted and the writer must not be
+// written to anymore, while Close still returns the files completed
+// before the failure, so that the caller can delete them.
+type ClusteredWriter struct {
+	*partitionedFiles
+
+	order    SortOrder
+	sortKeys *SortKeyEncoder
+
+	started    bool
+	currentKey string
+	current    partitionRecord
+	lastSort   []byte
+	completed  set[string]
+	wr         *DataFileWriter
+}
+
+// NewClusteredWriter returns a ClusteredWriter for the current schema,
+// partition spec and sort order of the table described by meta, which
+// writes its files through fs to the data location of the table.
+func NewClusteredWriter(ctx context.Context, fs io.WriteFileIO, meta Metadata) (*ClusteredWriter, error) {
+	files, err := newPartitionedFiles(ctx, fs, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &ClusteredWriter{
+		partitionedFiles: files,
+		order:            meta.SortOrder(),
+		completed:        set[string]{},
+	}
+
+	if len(w.order.Fields) > 0 {
+		if w.sortKeys, err = NewSortKeyEncoder(meta.CurrentSchema(), w.order); err != nil {
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+// Write writes the rows of the batch, after checking that they are
+// clustered and sorted. The open file is aborted if it fails, while the
+// rows of the partitions completed before the failing row are kept.
+func (w *ClusteredWriter) Write(batch arrow.Record) (err error) {
+	defer func() {
+		if err != nil {
+			w.Abort()
+		}
+	}()
+
+	row, start := NewArrowRow(batch, 0), int64(0)
+	for i := range batch.NumRows() {
+		row.SetRow(int(i))
+		rec, err := w.part.partition(row)
+		if err != nil {
+			return err
+		}
+
+		if key := partitionKey(rec); !w.started || key != w.currentKey {
+			if err := w.writeRows(batch, start, i); err != nil {
+				return err
+			}
+			start = i
+
+			if err := w.startPartition(key, rec); err != nil {
+				return err
+			}
+		}
+
+		if w.sortKeys == nil {
+			continue
+		}
+
+		sortKey, err := w.sortKeys.SortKey(row)
+		if err != nil {
+			return err
+		}
+
+		if w.lastSort != nil && bytes.Compare(sortKey, w.lastSort) < 0 {
+			return fmt.Errorf("%w: rows are not sorted by %s, row %d of the batch sorts before the previous row of its partition",
+				iceberg.ErrInvalidArgument, w.order, i)
+		}
+		w.lastSort = sortKey
+	}
+
+	return w.writeRows(batch, start, batch.NumRows())
+}
+
+func (w *ClusteredWriter) startPartition(key string, rec partitionRecord) error {
+	if _, ok := w.completed[key]; ok {
+		return fmt.Errorf("%w: rows are not clustered by partition, partition %s was already written",
+			iceberg.ErrInvalidArgument, w.part.partitionPath(rec))
+	}
+
+	if w.started {
+		w.completed[w.currentKey] = struct{}{}
+		if err := w.closeCurrent(); err != nil {
+			return err
+		}
+	}
+
+	w.started, w.currentKey, w.current, w.lastSort = true, key, rec, nil
+
+	return nil
+}
+
+// writeRows writes the rows from start to end of the batch, which all
+// belong to the current partition.
+func (w *ClusteredWriter) writeRows(batch arrow.Record, start, end int64) error {
+	if start == end {
+		return nil
+	}
+
+	if w.wr == nil {
+		var err error
+		if w.wr, err = w.newFileWriter(w.current); err != nil {
+			return err
+		}
+	}
+
+	rows := batch.NewSlice(start, end)
+	defer rows.Release()
+
+	if err := w.wr.Write(rows); err != nil {
+		return err
+	}
+
+	if w.wr.BytesWritten() >= w.targetFileSize {
+		return w.closeCurrent()
+	}
+
+	return nil
+}
+
+func (w *ClusteredWriter) closeCurrent() error {
+	if w.wr == nil {
+		return nil
+	}
+
+	wr := w.wr
+	w.wr = nil
+
+	return w.closeFile(wr)
+}
+
+// Close closes the file being written and returns all the data files
+// written, with their partition values set. If closing the file fails,
+// the files completed before are returned along with the error.
+func (w *ClusteredWriter) Close() ([]iceberg.DataFile, error) {
+	if err := w.closeCurrent(); err != nil {
+		return w.written, err
+	}
+
+	return w.written, nil
+}
+
+// Abort abandons the file being written, which is never published. The
+// files completed before are kept and still returned by Close.
+func (w *ClusteredWriter) Abort() {
+	if w.wr != nil {
+		w.wr.Abort()
+		w.wr = nil
+	}
+}
+
+// SortRecord returns a record with the rows of batch clustered by their
+// partition of spec, and sorted by order within each partition, such as
+// before writing the batch with a ClusteredWriter for a table with that
+// partition spec and sort order. The batch must have the given schema.
+// Rows which are equal for the sort order keep their relative order.
+func SortRecord(ctx context.Context, batch arrow.Record, schema *iceberg.Schema, spec iceberg.PartitionSpec, order SortOrder) (arrow.Record, error) {
+	enc, err := NewSortKeyEncoder(schema, clusteringSortOrder(spec, order))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([][]byte, batch.NumRows())
+	indices := make([]int64, batch.NumRows())
+	row := NewArrowRow(batch, 0)
+	for i := range keys {
+		row.SetRow(i)
+		if keys[i], err = enc.SortKey(row); err != nil {
+			return nil, err
+		}
+		indices[i] = int64(i)
+	}
+
+	slices.SortStableFunc(indices, func(a, b int64) int {
+		return bytes.Compare(keys[a], keys[b])
+	})
+
+	return takeRows(ctx, batch, indices)
+}
+
+// clusteringSortOrder returns order preceded by the fields of spec, so
+// that rows are sorted by their partition values first. Void fields are
+// always null, so they don't need to be sorted by.
+func clusteringSortOrder(spec iceberg.PartitionSpec, order SortOrder) SortOrder {
+	var fields []SortField
+	for f := range spec.Fields() {
+		if _, ok := f.Transform.(iceberg.VoidTransform); ok {
+			continue
+		}
+
+		fields = append(fields, SortField{
+			SourceID:  f.SourceID,
+			Transform: f.Transform,
+			Direction: SortASC,
+			NullOrder: NullsFirst,
+		})
+	}
+
+	return SortOrder{OrderID: order.OrderID, Fields: append(fields, order.Fields...)}
+}
@@ -0,0 +1,162 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var categoryIDSortOrder = table.SortOrder{OrderID: 1, Fields: []table.SortField{
+	{SourceID: 2, Transform: iceberg.IdentityTransform{}, Direction: table.SortASC, NullOrder: table.NullsFirst},
+	{SourceID: 1, Transform: iceberg.IdentityTransform{}, Direction: table.SortDESC, NullOrder: table.NullsLast},
+}}
+
+func TestSortRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	batch := partitionedWriterBatch(mem, 0, "b", "a", "", "b", "a")
+	defer batch.Release()
+
+	sorted, err := table.SortRecord(context.Background(), batch, partitionedWriterSchema,
+		*iceberg.UnpartitionedSpec, categoryIDSortOrder)
+	require.NoError(t, err)
+	defer sorted.Release()
+
+	ids := sorted.Column(0).(*array.Int64)
+	cats := sorted.Column(1).(*array.String)
+	assert.Equal(t, []int64{2, 4, 1, 3, 0}, ids.Int64Values())
+	assert.True(t, cats.IsNull(0))
+	assert.Equal(t, []string{"a", "a", "b", "b"},
+		[]string{cats.Value(1), cats.Value(2), cats.Value(3), cats.Value(4)})
+
+	// rows are clustered by partition even if the sort order doesn't
+	// start with the partition columns
+	meta := partitionedWriterMeta(t, nil, table.SortOrder{OrderID: 1, Fields: []table.SortField{
+		{SourceID: 1, Transform: iceberg.IdentityTransform{}, Direction: table.SortASC, NullOrder: table.NullsFirst},
+	}})
+
+	clustered, err := table.SortRecord(context.Background(), batch, partitionedWriterSchema,
+		meta.PartitionSpec(), meta.SortOrder())
+	require.NoError(t, err)
+	defer clustered.Release()
+
+	assert.Equal(t, []int64{2, 1, 4, 0, 3}, clustered.Column(0).(*array.Int64).Int64Values())
+
+	w, err := table.NewClusteredWriter(context.Background(), iceio.LocalFS{}, meta)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(clustered))
+
+	files, err := w.Close()
+	require.NoError(t, err)
+	assert.Len(t, files, 3)
+}
+
+func TestClusteredWriter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	meta := partitionedWriterMeta(t, nil, categoryIDSortOrder)
+
+	w, err := table.NewClusteredWriter(context.Background(), iceio.LocalFS{}, meta)
+	require.NoError(t, err)
+
+	// a partition may span several batches as long as it stays sorted,
+	// here with ids decreasing as required by the sort order
+	for _, batch := range []arrow.Record{
+		partitionedWriterBatch(mem, 0, "c", "a", "", "a", "b"),
+		partitionedWriterBatch(mem, -10, "c", "c"),
+	} {
+		sorted, err := table.SortRecord(context.Background(), batch, partitionedWriterSchema,
+			meta.PartitionSpec(), meta.SortOrder())
+		batch.Release()
+		require.NoError(t, err)
+
+		require.NoError(t, w.Write(sorted))
+		sorted.Release()
+	}
+
+	files, err := w.Close()
+	require.NoError(t, err)
+
+	// every partition is written to a single file
+	var parts []string
+	for _, df := range files {
+		part, _ := df.Partition()[1000].(string)
+		parts = append(parts, part)
+
+		cats := readCategories(t, df)
+		assert.EqualValues(t, len(cats), df.Count())
+		for _, c := range cats {
+			assert.Equal(t, part, c)
+		}
+	}
+	assert.Equal(t, []string{"", "a", "b", "c"}, parts)
+	assert.EqualValues(t, 3, files[3].Count())
+}
+
+func TestClusteredWriterUnsortedInput(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	meta := partitionedWriterMeta(t, nil, categoryIDSortOrder)
+
+	w, err := table.NewClusteredWriter(context.Background(), iceio.LocalFS{}, meta)
+	require.NoError(t, err)
+
+	// ids are increasing within the partition, while the sort order of
+	// the table sorts them in descending order
+	batch := partitionedWriterBatch(mem, 0, "a", "a", "a")
+	defer batch.Release()
+
+	err = w.Write(batch)
+	require.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	assert.ErrorContains(t, err, "not sorted")
+
+	_, err = w.Close()
+	require.NoError(t, err)
+
+	// partitions which come back after another one was started fail too
+	w, err = table.NewClusteredWriter(context.Background(), iceio.LocalFS{}, meta)
+	require.NoError(t, err)
+
+	batch = partitionedWriterBatch(mem, 0, "a", "b", "a")
+	defer batch.Release()
+
+	err = w.Write(batch)
+	require.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	assert.ErrorContains(t, err, "category=a")
+
+	// the file of b is aborted, while the completed file of a is returned
+	files, err := w.Close()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "a", files[0].Partition()[1000])
+	assert.Equal(t, []string{filepath.FromSlash(files[0].FilePath())}, filesOnDisk(t, meta.Location()))
+}
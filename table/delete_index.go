@@ -0,0 +1,200 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/apache/iceberg-go"
+)
+
+// filePathFieldID is the field ID of file_path in position delete files.
+const filePathFieldID = 2147483546
+
+// DeleteIndex matches the delete files of a snapshot to the data files
+// they may apply to. Besides the sequence numbers and partitions, the
+// column bounds of the delete files are used so that position deletes
+// are only attached to the data files within their file_path range, and
+// equality deletes are skipped for data files whose values can't overlap
+// the deleted ones.
+type DeleteIndex struct {
+	schema             *iceberg.Schema
+	unpartitionedSpecs set[int32]
+	posDeletes         []iceberg.ManifestEntry
+	eqDeletes          []iceberg.ManifestEntry
+}
+
+// NewDeleteIndex returns an index of the given position and equality
+// delete entries, with specs being the partition specs of the table and
+// schema the schema used to interpret the bounds of equality deletes.
+func NewDeleteIndex(schema *iceberg.Schema, specs []iceberg.PartitionSpec, deletes []iceberg.ManifestEntry) (*DeleteIndex, error) {
+	idx := &DeleteIndex{schema: schema, unpartitionedSpecs: set[int32]{}}
+	for _, spec := range specs {
+		if spec.IsUnpartitioned() {
+			idx.unpartitionedSpecs[int32(spec.ID())] = struct{}{}
+		}
+	}
+
+	for _, e := range deletes {
+		switch e.DataFile().ContentType() {
+		case iceberg.EntryContentPosDeletes:
+			idx.posDeletes = append(idx.posDeletes, e)
+		case iceberg.EntryContentEqDeletes:
+			idx.eqDeletes = append(idx.eqDeletes, e)
+		default:
+			return nil, fmt.Errorf("%w: not a delete file (%s): %s",
+				iceberg.ErrInvalidArgument, e.DataFile().ContentType(), e.DataFile().FilePath())
+		}
+	}
+
+	bySequenceNum := func(a, b iceberg.ManifestEntry) int {
+		return cmp.Compare(a.SequenceNum(), b.SequenceNum())
+	}
+	slices.SortStableFunc(idx.posDeletes, bySequenceNum)
+	slices.SortStableFunc(idx.eqDeletes, bySequenceNum)
+
+	return idx, nil
+}
+
+// ForDataFile returns the delete files which may apply to the data file
+// of entry. The entry is needed rather than just its data file since
+// which deletes apply depends on the data sequence number: position
+// deletes apply to data files with the same or a lower sequence number,
+// and equality deletes only to those with a strictly lower one.
+func (d *DeleteIndex) ForDataFile(entry iceberg.ManifestEntry) []iceberg.DataFile {
+	data := entry.DataFile()
+	out := make([]iceberg.DataFile, 0)
+
+	start, _ := slices.BinarySearchFunc(d.posDeletes, entry.SequenceNum(), func(me iceberg.ManifestEntry, seq int64) int {
+		return cmp.Compare(me.SequenceNum(), seq)
+	})
+	for _, relevant := range d.posDeletes[start:] {
+		if df := relevant.DataFile(); pathInBounds(df, data.FilePath()) {
+			out = append(out, df)
+		}
+	}
+
+	start, _ = slices.BinarySearchFunc(d.eqDeletes, entry.SequenceNum()+1, func(me iceberg.ManifestEntry, seq int64) int {
+		return cmp.Compare(me.SequenceNum(), seq)
+	})
+	for _, relevant := range d.eqDeletes[start:] {
+		df := relevant.DataFile()
+		if _, global := d.unpartitionedSpecs[df.SpecID()]; !global {
+			if df.SpecID() != data.SpecID() || !reflect.DeepEqual(df.Partition(), data.Partition()) {
+				continue
+			}
+		}
+
+		if d.eqDeleteMayApply(df, data) {
+			out = append(out, df)
+		}
+	}
+
+	return out
+}
+
+// pathInBounds returns false only if the file_path bounds of the
+// position delete file exclude path.
+func pathInBounds(deletes iceberg.DataFile, path string) bool {
+	if lower, ok := deletes.LowerBoundValues()[filePathFieldID]; ok && bytes.Compare([]byte(path), lower) < 0 {
+		return false
+	}
+	if upper, ok := deletes.UpperBoundValues()[filePathFieldID]; ok && bytes.Compare([]byte(path), upper) > 0 {
+		return false
+	}
+
+	return true
+}
+
+// eqDeleteMayApply returns false if, for any of the equality fields, the
+// range of values in the delete file doesn't overlap the range of values
+// in the data file, in which case no row of the data file can match a
+// deleted row.
+func (d *DeleteIndex) eqDeleteMayApply(deletes, data iceberg.DataFile) bool {
+	for _, id := range deletes.EqualityFieldIDs() {
+		if d.disjointBounds(id, deletes, data) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *DeleteIndex) disjointBounds(id int, deletes, data iceberg.DataFile) bool {
+	field, ok := d.schema.FindFieldByID(id)
+	if !ok {
+		return false
+	}
+
+	switch field.Type.(type) {
+	case iceberg.Float32Type, iceberg.Float64Type:
+		// bounds don't account for NaN
+		return false
+	case iceberg.PrimitiveType:
+	default:
+		return false
+	}
+
+	// a null in the delete file deletes the nulls of the data file
+	// regardless of the bounds
+	if mayContainNulls(deletes, id) && mayContainNulls(data, id) {
+		return false
+	}
+
+	delLower, delUpper, ok := parseBounds(field.Type, deletes, id)
+	if !ok {
+		return false
+	}
+	dataLower, dataUpper, ok := parseBounds(field.Type, data, id)
+	if !ok {
+		return false
+	}
+
+	cmpLit := getCmpLiteral(delLower)
+
+	return cmpLit(delUpper, dataLower) < 0 || cmpLit(delLower, dataUpper) > 0
+}
+
+func mayContainNulls(df iceberg.DataFile, id int) bool {
+	count, ok := df.NullValueCounts()[id]
+
+	return !ok || count > 0
+}
+
+func parseBounds(typ iceberg.Type, df iceberg.DataFile, id int) (lower, upper iceberg.Literal, ok bool) {
+	lowerBytes, okLower := df.LowerBoundValues()[id]
+	upperBytes, okUpper := df.UpperBoundValues()[id]
+	if !okLower || !okUpper {
+		return nil, nil, false
+	}
+
+	lower, err := iceberg.LiteralFromBytes(typ, lowerBytes)
+	if err != nil {
+		return nil, nil, false
+	}
+	upper, err = iceberg.LiteralFromBytes(typ, upperBytes)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return lower, upper, true
+}
@@ -0,0 +1,169 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const filePathID = 2147483546
+
+func deleteIndexEntry(t *testing.T, path string, content iceberg.ManifestEntryContent, seq int64, bldrFn func(*iceberg.DataFileBuilder)) iceberg.ManifestEntry {
+	bldr, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, content,
+		path, iceberg.ParquetFile, nil, 1, 1)
+	require.NoError(t, err)
+	if bldrFn != nil {
+		bldrFn(bldr)
+	}
+
+	snapshotID := int64(1)
+
+	return iceberg.NewManifestEntryBuilder(iceberg.EntryStatusADDED, &snapshotID, bldr.Build()).
+		SequenceNum(seq).Build()
+}
+
+func int32Bound(t *testing.T, v int32) []byte {
+	b, err := iceberg.Int32Literal(v).MarshalBinary()
+	require.NoError(t, err)
+
+	return b
+}
+
+func deleteFilePaths(files []iceberg.DataFile) []string {
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.FilePath()
+	}
+
+	return paths
+}
+
+func TestDeleteIndexPositionDeletePathBounds(t *testing.T) {
+	pathBounds := func(lower, upper string) func(*iceberg.DataFileBuilder) {
+		return func(b *iceberg.DataFileBuilder) {
+			b.LowerBoundValues(map[int][]byte{filePathID: []byte(lower)})
+			b.UpperBoundValues(map[int][]byte{filePathID: []byte(upper)})
+		}
+	}
+
+	deletes := []iceberg.ManifestEntry{
+		deleteIndexEntry(t, "single.parquet", iceberg.EntryContentPosDeletes, 1,
+			pathBounds("s3://bucket/data/a.parquet", "s3://bucket/data/a.parquet")),
+		deleteIndexEntry(t, "range.parquet", iceberg.EntryContentPosDeletes, 1,
+			pathBounds("s3://bucket/data/a.parquet", "s3://bucket/data/c.parquet")),
+		deleteIndexEntry(t, "unbounded.parquet", iceberg.EntryContentPosDeletes, 1, nil),
+	}
+
+	idx, err := table.NewDeleteIndex(iceberg.NewSchema(0),
+		[]iceberg.PartitionSpec{*iceberg.UnpartitionedSpec}, deletes)
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{"s3://bucket/data/a.parquet", []string{"single.parquet", "range.parquet", "unbounded.parquet"}},
+		{"s3://bucket/data/b.parquet", []string{"range.parquet", "unbounded.parquet"}},
+		{"s3://bucket/data/d.parquet", []string{"unbounded.parquet"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			data := deleteIndexEntry(t, tt.path, iceberg.EntryContentData, 1, nil)
+			assert.Equal(t, tt.expected, deleteFilePaths(idx.ForDataFile(data)))
+		})
+	}
+
+	// position deletes don't apply to data files added after them
+	data := deleteIndexEntry(t, "s3://bucket/data/a.parquet", iceberg.EntryContentData, 2, nil)
+	assert.Empty(t, idx.ForDataFile(data))
+}
+
+func TestDeleteIndexEqualityDeleteBounds(t *testing.T) {
+	schema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32, Required: false},
+		iceberg.NestedField{ID: 2, Name: "score", Type: iceberg.PrimitiveTypes.Float64, Required: false},
+	)
+
+	bounds := func(id int, lower, upper []byte, nulls int64) func(*iceberg.DataFileBuilder) {
+		return func(b *iceberg.DataFileBuilder) {
+			b.LowerBoundValues(map[int][]byte{id: lower})
+			b.UpperBoundValues(map[int][]byte{id: upper})
+			b.NullValueCounts(map[int]int64{id: nulls})
+		}
+	}
+	eqDelete := func(path string, ids []int, fn func(*iceberg.DataFileBuilder)) iceberg.ManifestEntry {
+		return deleteIndexEntry(t, path, iceberg.EntryContentEqDeletes, 2, func(b *iceberg.DataFileBuilder) {
+			b.EqualityFieldIDs(ids)
+			if fn != nil {
+				fn(b)
+			}
+		})
+	}
+
+	deletes := []iceberg.ManifestEntry{
+		eqDelete("overlapping.parquet", []int{1}, bounds(1, int32Bound(t, 5), int32Bound(t, 15), 0)),
+		eqDelete("disjoint.parquet", []int{1}, bounds(1, int32Bound(t, 20), int32Bound(t, 30), 0)),
+		eqDelete("disjoint-nulls.parquet", []int{1}, bounds(1, int32Bound(t, 20), int32Bound(t, 30), 1)),
+		eqDelete("no-bounds.parquet", []int{1}, nil),
+		eqDelete("float.parquet", []int{2}, func(b *iceberg.DataFileBuilder) {
+			lower, _ := iceberg.Float64Literal(100).MarshalBinary()
+			upper, _ := iceberg.Float64Literal(200).MarshalBinary()
+			bounds(2, lower, upper, 0)(b)
+		}),
+	}
+
+	idx, err := table.NewDeleteIndex(schema, []iceberg.PartitionSpec{*iceberg.UnpartitionedSpec}, deletes)
+	require.NoError(t, err)
+
+	dataEntry := func(idNulls int64) iceberg.ManifestEntry {
+		lower, _ := iceberg.Float64Literal(0).MarshalBinary()
+		upper, _ := iceberg.Float64Literal(10).MarshalBinary()
+
+		return deleteIndexEntry(t, "data.parquet", iceberg.EntryContentData, 1, func(b *iceberg.DataFileBuilder) {
+			b.LowerBoundValues(map[int][]byte{1: int32Bound(t, 0), 2: lower})
+			b.UpperBoundValues(map[int][]byte{1: int32Bound(t, 10), 2: upper})
+			b.NullValueCounts(map[int]int64{1: idNulls, 2: 0})
+		})
+	}
+
+	t.Run("without nulls", func(t *testing.T) {
+		// float bounds are never used since they don't account for NaN
+		assert.Equal(t, []string{"overlapping.parquet", "no-bounds.parquet", "float.parquet"},
+			deleteFilePaths(idx.ForDataFile(dataEntry(0))))
+	})
+
+	t.Run("with nulls", func(t *testing.T) {
+		// the nulls in the data file may be deleted by the nulls in the
+		// delete file, whatever the bounds of the other values
+		assert.Equal(t, []string{"overlapping.parquet", "disjoint-nulls.parquet", "no-bounds.parquet", "float.parquet"},
+			deleteFilePaths(idx.ForDataFile(dataEntry(3))))
+	})
+}
+
+func TestDeleteIndexRejectsDataFiles(t *testing.T) {
+	_, err := table.NewDeleteIndex(iceberg.NewSchema(0), nil, []iceberg.ManifestEntry{
+		deleteIndexEntry(t, "data.parquet", iceberg.EntryContentData, 1, nil),
+	})
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
@@ -52,6 +52,38 @@ func newManifestEvaluator(spec iceberg.PartitionSpec, schema *iceberg.Schema, pa
 	return (&manifestEvalVisitor{partitionFilter: boundFilter}).Eval, nil
 }
 
+// ManifestEvaluator determines whether a manifest file might contain rows
+// matching a row filter, using the partition field summaries stored in the
+// manifest list so that manifests which cannot match can be skipped during
+// scan planning.
+type ManifestEvaluator struct {
+	eval func(iceberg.ManifestFile) (bool, error)
+}
+
+// NewManifestEvaluator creates a ManifestEvaluator for manifests written with
+// the given partition spec. The unbound row filter is bound to the schema and
+// then inclusively projected onto the partition spec, so the evaluator only
+// returns false when it is certain no rows in the manifest can match.
+func NewManifestEvaluator(spec iceberg.PartitionSpec, schema *iceberg.Schema, rowFilter iceberg.BooleanExpression, caseSensitive bool) (*ManifestEvaluator, error) {
+	partitionFilter, err := newInclusiveProjection(schema, spec, caseSensitive)(rowFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	eval, err := newManifestEvaluator(spec, schema, partitionFilter, caseSensitive)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ManifestEvaluator{eval: eval}, nil
+}
+
+// Eval returns false if the manifest cannot contain any rows matching the
+// filter, and true if it might.
+func (m *ManifestEvaluator) Eval(manifest iceberg.ManifestFile) (bool, error) {
+	return m.eval(manifest)
+}
+
 type manifestEvalVisitor struct {
 	partitionFields []iceberg.FieldSummary
 	partitionFilter iceberg.BooleanExpression
@@ -663,6 +695,31 @@ func (m *metricsEvaluator) isNan(v iceberg.Literal) bool {
 	}
 }
 
+// InclusiveMetricsEvaluator determines whether a data file might contain
+// rows matching a row filter, using the column metrics (value, null and NaN
+// counts along with lower and upper bounds) recorded for the file. Files
+// with missing metrics are always considered a possible match.
+type InclusiveMetricsEvaluator struct {
+	eval func(iceberg.DataFile) (bool, error)
+}
+
+// NewInclusiveMetricsEvaluator binds the row filter to the provided schema
+// and returns an evaluator for data files written with that schema.
+func NewInclusiveMetricsEvaluator(schema *iceberg.Schema, rowFilter iceberg.BooleanExpression, caseSensitive bool) (*InclusiveMetricsEvaluator, error) {
+	eval, err := newInclusiveMetricsEvaluator(schema, rowFilter, caseSensitive, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InclusiveMetricsEvaluator{eval: eval}, nil
+}
+
+// ShouldRead returns false if the data file cannot contain any rows matching
+// the filter, and true if it might.
+func (m *InclusiveMetricsEvaluator) ShouldRead(file iceberg.DataFile) (bool, error) {
+	return m.eval(file)
+}
+
 func newInclusiveMetricsEvaluator(s *iceberg.Schema, expr iceberg.BooleanExpression,
 	caseSensitive bool, includeEmptyFiles bool,
 ) (func(iceberg.DataFile) (bool, error), error) {
@@ -1195,3 +1252,315 @@ func (m *inclusiveMetricsEval) VisitNotStartsWith(t iceberg.BoundTerm, lit icebe
 
 	return rowsMightMatch
 }
+
+// StrictMetricsEvaluator determines whether every row of a data file is
+// guaranteed to match a row filter, using the column metrics recorded for
+// the file. It is used to find files that can be dropped as a whole when
+// deleting or overwriting rows. Files with missing metrics are never
+// considered a full match.
+type StrictMetricsEvaluator struct {
+	eval func(iceberg.DataFile) (bool, error)
+}
+
+// NewStrictMetricsEvaluator binds the row filter to the provided schema
+// and returns an evaluator for data files written with that schema.
+func NewStrictMetricsEvaluator(schema *iceberg.Schema, rowFilter iceberg.BooleanExpression, caseSensitive bool) (*StrictMetricsEvaluator, error) {
+	eval, err := newStrictMetricsEvaluator(schema, rowFilter, caseSensitive)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StrictMetricsEvaluator{eval: eval}, nil
+}
+
+// ShouldDelete returns true if all rows of the data file are guaranteed to
+// match the filter, and false if some rows might not match.
+func (m *StrictMetricsEvaluator) ShouldDelete(file iceberg.DataFile) (bool, error) {
+	return m.eval(file)
+}
+
+func newStrictMetricsEvaluator(s *iceberg.Schema, expr iceberg.BooleanExpression, caseSensitive bool) (func(iceberg.DataFile) (bool, error), error) {
+	rewritten, err := iceberg.RewriteNotExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	bound, err := iceberg.BindExpr(s, rewritten, caseSensitive)
+	if err != nil {
+		return nil, err
+	}
+
+	return (&strictMetricsEval{expr: bound}).Eval, nil
+}
+
+type strictMetricsEval struct {
+	metricsEvaluator
+
+	expr iceberg.BooleanExpression
+}
+
+func (m *strictMetricsEval) Eval(file iceberg.DataFile) (bool, error) {
+	if file.Count() <= 0 {
+		// an empty file has no rows which could fail to match
+		return rowsMustMatch, nil
+	}
+
+	// avoid race condition while maintaining existing state
+	ev := strictMetricsEval{expr: m.expr}
+	ev.valueCounts, ev.nullCounts = file.ValueCounts(), file.NullValueCounts()
+	ev.nanCounts = file.NaNValueCounts()
+	ev.lowerBounds, ev.upperBounds = file.LowerBoundValues(), file.UpperBoundValues()
+
+	return iceberg.VisitExpr(m.expr, &ev)
+}
+
+func (m *strictMetricsEval) canContainNulls(field iceberg.NestedField) bool {
+	if field.Required {
+		return false
+	}
+
+	cnt, ok := m.nullCounts[field.ID]
+
+	return !ok || cnt > 0
+}
+
+func (m *strictMetricsEval) canContainNans(field iceberg.NestedField) bool {
+	switch field.Type.(type) {
+	case iceberg.Float32Type, iceberg.Float64Type:
+	default:
+		return false
+	}
+
+	cnt, ok := m.nanCounts[field.ID]
+
+	return !ok || cnt > 0
+}
+
+// bound returns the decoded bound for the field, reporting false if there
+// is no bound recorded or if the bound is NaN and therefore unreliable.
+func (m *strictMetricsEval) bound(bounds map[int][]byte, field iceberg.NestedField) (iceberg.Literal, bool) {
+	if _, ok := field.Type.(iceberg.PrimitiveType); !ok {
+		panic(fmt.Errorf("%w: expected iceberg.PrimitiveType, got %s",
+			iceberg.ErrInvalidTypeString, field.Type))
+	}
+
+	data := bounds[field.ID]
+	if data == nil {
+		return nil, false
+	}
+
+	lit, err := iceberg.LiteralFromBytes(field.Type, data)
+	if err != nil {
+		panic(err)
+	}
+
+	if m.isNan(lit) {
+		return nil, false
+	}
+
+	return lit, true
+}
+
+func (m *strictMetricsEval) VisitUnbound(iceberg.UnboundPredicate) bool {
+	panic("need bound predicate")
+}
+
+func (m *strictMetricsEval) VisitBound(pred iceberg.BoundPredicate) bool {
+	return iceberg.VisitBoundPredicate(pred, m)
+}
+
+func (m *strictMetricsEval) VisitIsNull(t iceberg.BoundTerm) bool {
+	if m.containsNullsOnly(t.Ref().Field().ID) {
+		return rowsMustMatch
+	}
+
+	return rowsMightNotMatch
+}
+
+func (m *strictMetricsEval) VisitNotNull(t iceberg.BoundTerm) bool {
+	if m.canContainNulls(t.Ref().Field()) {
+		return rowsMightNotMatch
+	}
+
+	return rowsMustMatch
+}
+
+func (m *strictMetricsEval) VisitIsNan(t iceberg.BoundTerm) bool {
+	if m.containsNansOnly(t.Ref().Field().ID) {
+		return rowsMustMatch
+	}
+
+	return rowsMightNotMatch
+}
+
+func (m *strictMetricsEval) VisitNotNan(t iceberg.BoundTerm) bool {
+	fieldID := t.Ref().Field().ID
+	if cnt, exists := m.nanCounts[fieldID]; exists && cnt == 0 {
+		return rowsMustMatch
+	}
+
+	if m.containsNullsOnly(fieldID) {
+		return rowsMustMatch
+	}
+
+	return rowsMightNotMatch
+}
+
+func (m *strictMetricsEval) VisitLess(t iceberg.BoundTerm, lit iceberg.Literal) bool {
+	field := t.Ref().Field()
+	if m.canContainNulls(field) || m.canContainNans(field) {
+		return rowsMightNotMatch
+	}
+
+	if upper, ok := m.bound(m.upperBounds, field); ok && getCmpLiteral(upper)(upper, lit) < 0 {
+		return rowsMustMatch
+	}
+
+	return rowsMightNotMatch
+}
+
+func (m *strictMetricsEval) VisitLessEqual(t iceberg.BoundTerm, lit iceberg.Literal) bool {
+	field := t.Ref().Field()
+	if m.canContainNulls(field) || m.canContainNans(field) {
+		return rowsMightNotMatch
+	}
+
+	if upper, ok := m.bound(m.upperBounds, field); ok && getCmpLiteral(upper)(upper, lit) <= 0 {
+		return rowsMustMatch
+	}
+
+	return rowsMightNotMatch
+}
+
+func (m *strictMetricsEval) VisitGreater(t iceberg.BoundTerm, lit iceberg.Literal) bool {
+	field := t.Ref().Field()
+	if m.canContainNulls(field) || m.canContainNans(field) {
+		return rowsMightNotMatch
+	}
+
+	if lower, ok := m.bound(m.lowerBounds, field); ok && getCmpLiteral(lower)(lower, lit) > 0 {
+		return rowsMustMatch
+	}
+
+	return rowsMightNotMatch
+}
+
+func (m *strictMetricsEval) VisitGreaterEqual(t iceberg.BoundTerm, lit iceberg.Literal) bool {
+	field := t.Ref().Field()
+	if m.canContainNulls(field) || m.canContainNans(field) {
+		return rowsMightNotMatch
+	}
+
+	if lower, ok := m.bound(m.lowerBounds, field); ok && getCmpLiteral(lower)(lower, lit) >= 0 {
+		return rowsMustMatch
+	}
+
+	return rowsMightNotMatch
+}
+
+func (m *strictMetricsEval) VisitEqual(t iceberg.BoundTerm, lit iceberg.Literal) bool {
+	field := t.Ref().Field()
+	if m.canContainNulls(field) || m.canContainNans(field) {
+		return rowsMightNotMatch
+	}
+
+	lower, ok := m.bound(m.lowerBounds, field)
+	if !ok {
+		return rowsMightNotMatch
+	}
+
+	upper, ok := m.bound(m.upperBounds, field)
+	if !ok {
+		return rowsMightNotMatch
+	}
+
+	cmp := getCmpLiteral(lower)
+	if cmp(lower, lit) == 0 && cmp(upper, lit) == 0 {
+		return rowsMustMatch
+	}
+
+	return rowsMightNotMatch
+}
+
+func (m *strictMetricsEval) VisitNotEqual(t iceberg.BoundTerm, lit iceberg.Literal) bool {
+	field := t.Ref().Field()
+	if m.containsNullsOnly(field.ID) || m.containsNansOnly(field.ID) {
+		return rowsMustMatch
+	}
+
+	if lower, ok := m.bound(m.lowerBounds, field); ok && getCmpLiteral(lower)(lower, lit) > 0 {
+		return rowsMustMatch
+	}
+
+	if upper, ok := m.bound(m.upperBounds, field); ok && getCmpLiteral(upper)(upper, lit) < 0 {
+		return rowsMustMatch
+	}
+
+	return rowsMightNotMatch
+}
+
+func (m *strictMetricsEval) VisitIn(t iceberg.BoundTerm, s iceberg.Set[iceberg.Literal]) bool {
+	field := t.Ref().Field()
+	if m.canContainNulls(field) || m.canContainNans(field) {
+		return rowsMightNotMatch
+	}
+
+	lower, ok := m.bound(m.lowerBounds, field)
+	if !ok {
+		return rowsMightNotMatch
+	}
+
+	upper, ok := m.bound(m.upperBounds, field)
+	if !ok {
+		return rowsMightNotMatch
+	}
+
+	// every row can only be guaranteed to match if the column holds a
+	// single distinct value which is part of the set
+	if getCmpLiteral(lower)(lower, upper) != 0 {
+		return rowsMightNotMatch
+	}
+
+	if s.Contains(lower) {
+		return rowsMustMatch
+	}
+
+	return rowsMightNotMatch
+}
+
+func (m *strictMetricsEval) VisitNotIn(t iceberg.BoundTerm, s iceberg.Set[iceberg.Literal]) bool {
+	field := t.Ref().Field()
+	if m.containsNullsOnly(field.ID) || m.containsNansOnly(field.ID) {
+		return rowsMustMatch
+	}
+
+	if s.Len() > inPredicateLimit {
+		// skip evaluating the predicate if the number of values is too big
+		return rowsMightNotMatch
+	}
+
+	values := s.Members()
+	if lower, ok := m.bound(m.lowerBounds, field); ok {
+		values = removeBoundCheck(lower, values, 1)
+		if len(values) == 0 {
+			return rowsMustMatch
+		}
+	}
+
+	if upper, ok := m.bound(m.upperBounds, field); ok {
+		values = removeBoundCheck(upper, values, -1)
+		if len(values) == 0 {
+			return rowsMustMatch
+		}
+	}
+
+	return rowsMightNotMatch
+}
+
+func (m *strictMetricsEval) VisitStartsWith(iceberg.BoundTerm, iceberg.Literal) bool {
+	return rowsMightNotMatch
+}
+
+func (m *strictMetricsEval) VisitNotStartsWith(iceberg.BoundTerm, iceberg.Literal) bool {
+	return rowsMightNotMatch
+}
@@ -1410,6 +1410,39 @@ func (suite *InclusiveMetricsTestSuite) TestMissingStats() {
 	}
 }
 
+func (suite *InclusiveMetricsTestSuite) TestExportedEvaluator() {
+	ref := iceberg.Reference("id")
+	tests := []struct {
+		expr     iceberg.BooleanExpression
+		expected bool
+		msg      string
+	}{
+		{iceberg.EqualTo(ref, IntMinValue-1), false, "should skip: id below lower bound"},
+		{iceberg.EqualTo(ref, IntMaxValue+1), false, "should skip: id above upper bound"},
+		{iceberg.EqualTo(ref, IntMinValue), true, "should read: id equal to lower bound"},
+		{iceberg.EqualTo(ref, IntMaxValue), true, "should read: id equal to upper bound"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.expr.String(), func() {
+			eval, err := NewInclusiveMetricsEvaluator(suite.schemaDataFile, tt.expr, true)
+			suite.Require().NoError(err)
+			shouldRead, err := eval.ShouldRead(suite.dataFiles[0])
+			suite.Require().NoError(err)
+			suite.Equal(tt.expected, shouldRead, tt.msg)
+		})
+	}
+
+	eval, err := NewInclusiveMetricsEvaluator(suite.schemaDataFile, iceberg.EqualTo(ref, IntMaxValue+1), true)
+	suite.Require().NoError(err)
+	shouldRead, err := eval.ShouldRead(&mockDataFile{path: "file_1.parquet", format: iceberg.ParquetFile, count: 50})
+	suite.Require().NoError(err)
+	suite.True(shouldRead, "should read when stats are missing")
+
+	_, err = NewInclusiveMetricsEvaluator(suite.schemaDataFile, iceberg.EqualTo(iceberg.Reference("missing"), int32(1)), true)
+	suite.ErrorIs(err, iceberg.ErrInvalidSchema)
+}
+
 func (suite *InclusiveMetricsTestSuite) TestZeroRecordFileStats() {
 	zeroRecordFile := &mockDataFile{
 		path:   "file_1.parquet",
@@ -2023,6 +2056,166 @@ func (suite *InclusiveMetricsTestSuite) TestNotStartsWith() {
 	}
 }
 
+func TestNewManifestEvaluator(t *testing.T) {
+	schema := iceberg.NewSchema(1,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+	)
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 1, FieldID: 1000, Name: "id_part", Transform: iceberg.IdentityTransform{},
+	})
+
+	lower, _ := iceberg.Int32Literal(IntMinValue).MarshalBinary()
+	upper, _ := iceberg.Int32Literal(IntMaxValue).MarshalBinary()
+	manifest := iceberg.NewManifestFile(2, "", 0, 0, 0).Partitions(
+		[]iceberg.FieldSummary{{ContainsNull: false, LowerBound: &lower, UpperBound: &upper}}).Build()
+
+	ref := iceberg.Reference("id")
+	tests := []struct {
+		expr     iceberg.BooleanExpression
+		expected bool
+	}{
+		{iceberg.LessThan(ref, IntMinValue), false},
+		{iceberg.LessThanEqual(ref, IntMinValue), true},
+		{iceberg.GreaterThan(ref, IntMaxValue), false},
+		{iceberg.GreaterThanEqual(ref, IntMaxValue), true},
+		{iceberg.EqualTo(ref, IntMaxValue+1), false},
+		{iceberg.EqualTo(ref, IntMinValue+10), true},
+		{iceberg.IsIn(ref, int32(1), int32(2)), false},
+		{iceberg.IsIn(ref, int32(1), IntMinValue), true},
+		{iceberg.NotNull(ref), true},
+		{iceberg.IsNull(ref), false},
+		// no partition field for data, so the manifest must be read
+		{iceberg.EqualTo(iceberg.Reference("data"), "foo"), true},
+		{iceberg.NewAnd(iceberg.EqualTo(iceberg.Reference("data"), "foo"),
+			iceberg.GreaterThan(ref, IntMaxValue)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr.String(), func(t *testing.T) {
+			eval, err := NewManifestEvaluator(spec, schema, tt.expr, true)
+			require.NoError(t, err)
+
+			result, err := eval.Eval(manifest)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	_, err := NewManifestEvaluator(spec, schema, iceberg.EqualTo(iceberg.Reference("missing"), int32(1)), true)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+}
+
+func TestStrictMetricsEvaluator(t *testing.T) {
+	schema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+		iceberg.NestedField{ID: 2, Name: "no_stats", Type: iceberg.PrimitiveTypes.Int32},
+		iceberg.NestedField{ID: 3, Name: "all_nulls", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 4, Name: "some_nulls", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 5, Name: "no_nulls", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 6, Name: "no_nans", Type: iceberg.PrimitiveTypes.Float64},
+		iceberg.NestedField{ID: 7, Name: "some_nans", Type: iceberg.PrimitiveTypes.Float64},
+		iceberg.NestedField{ID: 8, Name: "constant", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+	)
+
+	var (
+		IntMin, _      = iceberg.Int32Literal(IntMinValue).MarshalBinary()
+		IntMax, _      = iceberg.Int32Literal(IntMaxValue).MarshalBinary()
+		DblMin, _      = iceberg.Float64Literal(0).MarshalBinary()
+		DblMax, _      = iceberg.Float64Literal(20).MarshalBinary()
+		Constant, _    = iceberg.Int32Literal(5).MarshalBinary()
+		StrMin, StrMax = []byte("a"), []byte("z")
+	)
+
+	file := &mockDataFile{
+		path:        "file_1.parquet",
+		format:      iceberg.ParquetFile,
+		count:       50,
+		filesize:    3,
+		valueCounts: map[int]int64{3: 50, 4: 50, 5: 50, 6: 50, 7: 50, 8: 50},
+		nullCounts:  map[int]int64{3: 50, 4: 10, 5: 0, 6: 0, 7: 0},
+		nanCounts:   map[int]int64{6: 0, 7: 5},
+		lowerBounds: map[int][]byte{1: IntMin, 5: StrMin, 6: DblMin, 7: DblMin, 8: Constant},
+		upperBounds: map[int][]byte{1: IntMax, 5: StrMax, 6: DblMax, 7: DblMax, 8: Constant},
+	}
+
+	id := iceberg.Reference("id")
+	tests := []struct {
+		expr     iceberg.BooleanExpression
+		expected bool
+	}{
+		{iceberg.AlwaysTrue{}, true},
+		{iceberg.AlwaysFalse{}, false},
+		// bounds fully inside the range
+		{iceberg.LessThan(id, IntMaxValue+1), true},
+		{iceberg.LessThanEqual(id, IntMaxValue), true},
+		{iceberg.GreaterThan(id, IntMinValue-1), true},
+		{iceberg.GreaterThanEqual(id, IntMinValue), true},
+		{iceberg.NewAnd(iceberg.GreaterThanEqual(id, IntMinValue),
+			iceberg.LessThan(id, IntMaxValue+1)), true},
+		// bounds partially overlapping the range
+		{iceberg.LessThan(id, IntMaxValue), false},
+		{iceberg.LessThanEqual(id, IntMaxValue-1), false},
+		{iceberg.GreaterThan(id, IntMinValue), false},
+		{iceberg.GreaterThanEqual(id, IntMinValue+1), false},
+		{iceberg.NewAnd(iceberg.GreaterThanEqual(id, IntMinValue),
+			iceberg.LessThan(id, IntMaxValue)), false},
+		{iceberg.NewOr(iceberg.LessThan(id, IntMinValue+10),
+			iceberg.GreaterThan(id, IntMaxValue-10)), false},
+		{iceberg.NewNot(iceberg.LessThan(id, IntMinValue)), true},
+		{iceberg.NewNot(iceberg.LessThan(id, IntMinValue+1)), false},
+		// equality only holds when the column has a single value
+		{iceberg.EqualTo(id, IntMinValue), false},
+		{iceberg.EqualTo(iceberg.Reference("constant"), int32(5)), true},
+		{iceberg.EqualTo(iceberg.Reference("constant"), int32(6)), false},
+		{iceberg.IsIn(iceberg.Reference("constant"), int32(4), int32(5)), true},
+		{iceberg.IsIn(iceberg.Reference("constant"), int32(4), int32(6)), false},
+		{iceberg.IsIn(id, IntMinValue, IntMaxValue), false},
+		{iceberg.NotEqualTo(id, IntMinValue-1), true},
+		{iceberg.NotEqualTo(id, IntMaxValue+1), true},
+		{iceberg.NotEqualTo(id, IntMinValue), false},
+		{iceberg.NotIn(id, IntMinValue-1, IntMaxValue+1), true},
+		{iceberg.NotIn(id, IntMinValue-1, IntMinValue), false},
+		// null and NaN counts
+		{iceberg.IsNull(iceberg.Reference("all_nulls")), true},
+		{iceberg.IsNull(iceberg.Reference("some_nulls")), false},
+		{iceberg.NotNull(iceberg.Reference("no_nulls")), true},
+		{iceberg.NotNull(iceberg.Reference("some_nulls")), false},
+		{iceberg.NotEqualTo(iceberg.Reference("all_nulls"), "a"), true},
+		{iceberg.LessThan(iceberg.Reference("some_nulls"), "zz"), false},
+		{iceberg.LessThan(iceberg.Reference("no_nulls"), "zz"), true},
+		{iceberg.LessThan(iceberg.Reference("no_nans"), 21.0), true},
+		{iceberg.LessThan(iceberg.Reference("some_nans"), 21.0), false},
+		{iceberg.NotNaN(iceberg.Reference("no_nans")), true},
+		{iceberg.NotNaN(iceberg.Reference("some_nans")), false},
+		// missing stats are never a guaranteed match
+		{iceberg.LessThan(iceberg.Reference("no_stats"), int32(100)), false},
+		{iceberg.GreaterThan(iceberg.Reference("no_stats"), int32(-100)), false},
+		{iceberg.NotNull(iceberg.Reference("no_stats")), false},
+		{iceberg.StartsWith(iceberg.Reference("no_nulls"), ""), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr.String(), func(t *testing.T) {
+			eval, err := NewStrictMetricsEvaluator(schema, tt.expr, true)
+			require.NoError(t, err)
+
+			shouldDelete, err := eval.ShouldDelete(file)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, shouldDelete)
+		})
+	}
+
+	eval, err := NewStrictMetricsEvaluator(schema, iceberg.LessThan(id, IntMaxValue+1), true)
+	require.NoError(t, err)
+	shouldDelete, err := eval.ShouldDelete(&mockDataFile{path: "file_2.parquet", format: iceberg.ParquetFile, count: 50})
+	require.NoError(t, err)
+	assert.False(t, shouldDelete, "should not delete when stats are missing")
+
+	_, err = NewStrictMetricsEvaluator(schema, iceberg.EqualTo(iceberg.Reference("missing"), int32(1)), true)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+}
+
 func TestEvaluators(t *testing.T) {
 	suite.Run(t, &ProjectionTestSuite{})
 	suite.Run(t, &InclusiveMetricsTestSuite{})
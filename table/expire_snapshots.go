@@ -0,0 +1,162 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+)
+
+// ExpireSnapshots removes the snapshots of tbl which were committed before
+// olderThan, returning a table with the updated metadata and the paths of
+// the manifest lists, manifests and data files which are no longer
+// referenced by any remaining snapshot and are safe to delete.
+//
+// The current snapshot, the snapshots referenced by branches and tags, and
+// the retainLast most recent snapshots in the history of the current
+// snapshot are never expired. The returned table is not committed; to
+// persist the change, commit a NewRemoveSnapshotsUpdate for the expired
+// snapshots through the table's catalog before deleting any files.
+func ExpireSnapshots(ctx context.Context, tbl *Table, olderThan time.Time, retainLast int) (*Table, []string, error) {
+	if retainLast < 1 {
+		return nil, nil, fmt.Errorf("%w: retainLast must be at least 1, got %d",
+			iceberg.ErrInvalidArgument, retainLast)
+	}
+
+	meta := tbl.metadata
+	retained := make(set[int64])
+	for _, ref := range meta.Refs() {
+		retained[ref.SnapshotID] = struct{}{}
+	}
+
+	snap := meta.CurrentSnapshot()
+	for range retainLast {
+		if snap == nil {
+			break
+		}
+
+		retained[snap.SnapshotID] = struct{}{}
+		if snap.ParentSnapshotID == nil {
+			break
+		}
+		snap = meta.SnapshotByID(*snap.ParentSnapshotID)
+	}
+
+	var expired, kept []Snapshot
+	for _, snap := range meta.Snapshots() {
+		_, keep := retained[snap.SnapshotID]
+		if keep || !time.UnixMilli(snap.TimestampMs).Before(olderThan) {
+			kept = append(kept, snap)
+		} else {
+			expired = append(expired, snap)
+		}
+	}
+
+	if len(expired) == 0 {
+		return tbl, nil, nil
+	}
+
+	fs, err := tbl.fsF(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reachable, err := snapshotFiles(fs, kept, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates, err := snapshotFiles(fs, expired, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orphaned := make([]string, 0, len(candidates))
+	for path := range candidates {
+		if _, ok := reachable[path]; !ok {
+			orphaned = append(orphaned, path)
+		}
+	}
+	slices.Sort(orphaned)
+
+	ids := make([]int64, len(expired))
+	for i, snap := range expired {
+		ids[i] = snap.SnapshotID
+	}
+
+	builder, err := MetadataBuilderFromBase(meta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := builder.RemoveSnapshots(ids); err != nil {
+		return nil, nil, err
+	}
+
+	updated, err := builder.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return New(tbl.identifier, updated, tbl.metadataLocation, tbl.fsF, tbl.cat),
+		orphaned, nil
+}
+
+// snapshotFiles collects the paths of the manifest lists, manifests and
+// data files referenced by the given snapshots. If liveOnly is true,
+// manifest entries marked as deleted are skipped since the files they
+// refer to aren't read through that snapshot.
+func snapshotFiles(fs iceio.IO, snapshots []Snapshot, liveOnly bool) (set[string], error) {
+	files := make(set[string])
+	seenManifests := make(set[string])
+	for _, snap := range snapshots {
+		if snap.ManifestList == "" {
+			continue
+		}
+		files[snap.ManifestList] = struct{}{}
+
+		manifests, err := snap.Manifests(fs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range manifests {
+			if _, ok := seenManifests[m.FilePath()]; ok {
+				continue
+			}
+			seenManifests[m.FilePath()] = struct{}{}
+			files[m.FilePath()] = struct{}{}
+
+			entries, err := m.FetchEntries(fs, liveOnly)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, e := range entries {
+				files[e.DataFile().FilePath()] = struct{}{}
+			}
+		}
+	}
+
+	return files, nil
+}
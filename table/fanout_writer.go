@@ -0,0 +1,207 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"container/list"
+	"context"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/io"
+)
+
+// partitionFileWriter is the data file currently written for a partition.
+type partitionFileWriter struct {
+	key string
+	wr  *DataFileWriter
+}
+
+// FanoutWriter writes rows to the data files of a partitioned table,
+// routing each row of the written batches to a file of its partition as
+// given by the transforms of the partition spec. Rows don't need to be
+// grouped by partition, but each partition being written to keeps a file
+// open, so at most maxOpenFiles files are kept open at once: when a row
+// of another partition comes in, the file of the least recently written
+// partition is closed, and a new file is started if more of its rows come
+// in later. A file is also closed, and the next rows of its partition
+// written to a new file, once it reaches write.target-file-size-bytes.
+//
+// Batches must have the schema of the table, as for DataFileWriter. When
+// writing fails, the open files are aborted and the writer must not be
+// written to anymore, while Close still returns the files completed
+// before the failure, so that the caller can delete them.
+type FanoutWriter struct {
+	*partitionedFiles
+
+	maxOpenFiles int
+	// lru holds the open writers, the most recently written first
+	lru     *list.List
+	writers map[string]*list.Element
+}
+
+// NewFanoutWriter returns a FanoutWriter for the current schema and
+// partition spec of the table described by meta, which writes its files
+// through fs to the data location of the table and keeps at most
+// maxOpenFiles of them open at once.
+func NewFanoutWriter(ctx context.Context, fs io.WriteFileIO, meta Metadata, maxOpenFiles int) (*FanoutWriter, error) {
+	files, err := newPartitionedFiles(ctx, fs, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FanoutWriter{
+		partitionedFiles: files,
+		maxOpenFiles:     max(maxOpenFiles, 1),
+		lru:              list.New(),
+		writers:          make(map[string]*list.Element),
+	}, nil
+}
+
+// partitionRows is the rows of a batch belonging to a single partition.
+type partitionRows struct {
+	rec     partitionRecord
+	indices []int64
+}
+
+// Write routes the rows of the batch to the files of their partitions.
+// The open files are aborted if it fails.
+func (w *FanoutWriter) Write(batch arrow.Record) (err error) {
+	defer func() {
+		if err != nil {
+			w.Abort()
+		}
+	}()
+
+	var (
+		order  []string
+		groups = make(map[string]*partitionRows)
+		row    = NewArrowRow(batch, 0)
+	)
+
+	for i := range int(batch.NumRows()) {
+		row.SetRow(i)
+		rec, err := w.part.partition(row)
+		if err != nil {
+			return err
+		}
+
+		key := partitionKey(rec)
+		g, ok := groups[key]
+		if !ok {
+			g = &partitionRows{rec: rec}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.indices = append(g.indices, int64(i))
+	}
+
+	for _, key := range order {
+		if err := w.writePartition(key, groups[key], batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *FanoutWriter) writePartition(key string, rows *partitionRows, batch arrow.Record) error {
+	rec := batch
+	if int64(len(rows.indices)) != batch.NumRows() {
+		var err error
+		if rec, err = takeRows(w.ctx, batch, rows.indices); err != nil {
+			return err
+		}
+		defer rec.Release()
+	}
+
+	pw, err := w.writerFor(key, rows.rec)
+	if err != nil {
+		return err
+	}
+
+	if err := pw.wr.Write(rec); err != nil {
+		return err
+	}
+
+	if pw.wr.BytesWritten() >= w.targetFileSize {
+		return w.closeWriter(w.writers[key])
+	}
+
+	return nil
+}
+
+// writerFor returns the open writer of the partition, opening a new file
+// and closing the least recently written one if needed.
+func (w *FanoutWriter) writerFor(key string, rec partitionRecord) (*partitionFileWriter, error) {
+	if elem, ok := w.writers[key]; ok {
+		w.lru.MoveToFront(elem)
+
+		return elem.Value.(*partitionFileWriter), nil
+	}
+
+	if w.lru.Len() >= w.maxOpenFiles {
+		if err := w.closeWriter(w.lru.Back()); err != nil {
+			return nil, err
+		}
+	}
+
+	wr, err := w.newFileWriter(rec)
+	if err != nil {
+		return nil, err
+	}
+
+	pw := &partitionFileWriter{key: key, wr: wr}
+	w.writers[key] = w.lru.PushFront(pw)
+
+	return pw, nil
+}
+
+func (w *FanoutWriter) closeWriter(elem *list.Element) error {
+	pw := w.lru.Remove(elem).(*partitionFileWriter)
+	delete(w.writers, pw.key)
+
+	return w.closeFile(pw.wr)
+}
+
+// Close closes the files which are still open and returns all the data
+// files written, with their partition values set. If closing a file
+// fails, the other open files are aborted, and the files completed so
+// far are returned along with the error.
+func (w *FanoutWriter) Close() ([]iceberg.DataFile, error) {
+	for w.lru.Len() > 0 {
+		if err := w.closeWriter(w.lru.Back()); err != nil {
+			w.Abort()
+
+			return w.written, err
+		}
+	}
+
+	return w.written, nil
+}
+
+// Abort abandons the files which are still open, which are never
+// published. The files completed before are kept and still returned by
+// Close.
+func (w *FanoutWriter) Abort() {
+	for w.lru.Len() > 0 {
+		pw := w.lru.Remove(w.lru.Back()).(*partitionFileWriter)
+		delete(w.writers, pw.key)
+		pw.wr.Abort()
+	}
+}
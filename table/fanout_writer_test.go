@@ -0,0 +1,256 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var partitionedWriterSchema = iceberg.NewSchema(0,
+	iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+	iceberg.NestedField{ID: 2, Name: "category", Type: iceberg.PrimitiveTypes.String},
+)
+
+func partitionedWriterMeta(t *testing.T, props iceberg.Properties, sortOrder table.SortOrder) table.Metadata {
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 2, FieldID: 1000, Name: "category", Transform: iceberg.IdentityTransform{},
+	})
+
+	meta, err := table.NewMetadata(partitionedWriterSchema, &spec, sortOrder, t.TempDir(), props)
+	require.NoError(t, err)
+
+	return meta
+}
+
+// partitionedWriterBatch builds a batch with one row per category, ids
+// starting at firstID. An empty category is written as null.
+func partitionedWriterBatch(mem memory.Allocator, firstID int64, categories ...string) arrow.Record {
+	bldr := array.NewRecordBuilder(mem, arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "category", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil))
+	defer bldr.Release()
+
+	ids, cats := bldr.Field(0).(*array.Int64Builder), bldr.Field(1).(*array.StringBuilder)
+	for i, c := range categories {
+		ids.Append(firstID + int64(i))
+		if c == "" {
+			cats.AppendNull()
+		} else {
+			cats.Append(c)
+		}
+	}
+
+	return bldr.NewRecord()
+}
+
+// readCategories returns the category column of the parquet data file,
+// with nulls as empty strings.
+func readCategories(t *testing.T, df iceberg.DataFile) []string {
+	rdr, err := file.OpenParquetFile(df.FilePath(), false)
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	fr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+
+	tbl, err := fr.ReadTable(context.Background())
+	require.NoError(t, err)
+	defer tbl.Release()
+
+	var out []string
+	for _, chunk := range tbl.Column(1).Data().Chunks() {
+		arr := chunk.(*array.String)
+		for i := range arr.Len() {
+			out = append(out, arr.Value(i))
+		}
+	}
+
+	return out
+}
+
+// filesOnDisk returns the paths of the files under dir, including the
+// temporary files of writes which are still in progress.
+func filesOnDisk(t *testing.T, dir string) []string {
+	var out []string
+	require.NoError(t, filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			out = append(out, path)
+		}
+
+		return err
+	}))
+
+	return out
+}
+
+// failingCreateFS fails to create the failAt-th file created through it.
+type failingCreateFS struct {
+	iceio.LocalFS
+
+	creates, failAt int
+}
+
+func (f *failingCreateFS) Create(name string) (iceio.FileWriter, error) {
+	if f.creates++; f.creates == f.failAt {
+		return nil, errors.New("create failed")
+	}
+
+	return f.LocalFS.Create(name)
+}
+
+func TestFanoutWriter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	meta := partitionedWriterMeta(t, nil, table.UnsortedSortOrder)
+
+	// with only two open files, the rows of the third partition close the
+	// file of the least recently written one
+	w, err := table.NewFanoutWriter(context.Background(), iceio.LocalFS{}, meta, 2)
+	require.NoError(t, err)
+
+	batches := [][]string{
+		{"a", "b", "a", "", "b"},
+		{"c", "a", "c"},
+		{"b", "b", "", "a"},
+	}
+
+	var id int64
+	for _, cats := range batches {
+		batch := partitionedWriterBatch(mem, id, cats...)
+		require.NoError(t, w.Write(batch))
+		batch.Release()
+		id += int64(len(cats))
+	}
+
+	files, err := w.Close()
+	require.NoError(t, err)
+
+	rowsPerPartition := make(map[string]int64)
+	filesPerPartition := make(map[string]int)
+	for _, df := range files {
+		part, _ := df.Partition()[1000].(string)
+		filesPerPartition[part]++
+		rowsPerPartition[part] += df.Count()
+
+		assert.Equal(t, iceberg.EntryContentData, df.ContentType())
+		_, err := os.Stat(df.FilePath())
+		require.NoError(t, err)
+
+		if part == "" {
+			assert.NotContains(t, df.Partition(), 1000)
+			assert.Contains(t, filepath.ToSlash(df.FilePath()), "/category=null/")
+		} else {
+			assert.Contains(t, filepath.ToSlash(df.FilePath()), "/category="+part+"/")
+		}
+
+		for _, c := range readCategories(t, df) {
+			assert.Equal(t, part, c)
+		}
+	}
+
+	assert.Equal(t, map[string]int64{"a": 4, "b": 4, "c": 2, "": 2}, rowsPerPartition)
+	for part, n := range filesPerPartition {
+		assert.GreaterOrEqual(t, n, 1, part)
+	}
+	assert.Greater(t, len(files), len(filesPerPartition),
+		"spilled partitions should have been written to more than one file")
+}
+
+func TestFanoutWriterRollsFiles(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	meta := partitionedWriterMeta(t, iceberg.Properties{
+		table.WriteTargetFileSizeBytesKey: "1",
+	}, table.UnsortedSortOrder)
+
+	w, err := table.NewFanoutWriter(context.Background(), iceio.LocalFS{}, meta, 10)
+	require.NoError(t, err)
+
+	for i := range 3 {
+		batch := partitionedWriterBatch(mem, int64(i*2), "a", "a")
+		require.NoError(t, w.Write(batch))
+		batch.Release()
+	}
+
+	files, err := w.Close()
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	for _, df := range files {
+		assert.Equal(t, "a", df.Partition()[1000])
+		assert.EqualValues(t, 2, df.Count())
+	}
+}
+
+func TestFanoutWriterAbortsOnError(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	meta := partitionedWriterMeta(t, nil, table.UnsortedSortOrder)
+
+	// the file of partition c can't be created, after the file of a was
+	// closed to make room for it while the file of b is still open
+	w, err := table.NewFanoutWriter(context.Background(), &failingCreateFS{failAt: 3}, meta, 2)
+	require.NoError(t, err)
+
+	batch := partitionedWriterBatch(mem, 0, "a")
+	require.NoError(t, w.Write(batch))
+	batch.Release()
+
+	batch = partitionedWriterBatch(mem, 1, "b", "c")
+	require.ErrorContains(t, w.Write(batch), "create failed")
+	batch.Release()
+
+	// the file of b is aborted, while the completed file of a is returned
+	files, err := w.Close()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "a", files[0].Partition()[1000])
+	assert.Equal(t, []string{filepath.FromSlash(files[0].FilePath())}, filesOnDisk(t, meta.Location()))
+
+	w, err = table.NewFanoutWriter(context.Background(), iceio.LocalFS{}, meta, 2)
+	require.NoError(t, err)
+
+	batch = partitionedWriterBatch(mem, 0, "a", "b")
+	require.NoError(t, w.Write(batch))
+	batch.Release()
+
+	w.Abort()
+	files, err = w.Close()
+	require.NoError(t, err)
+	assert.Empty(t, files)
+	assert.Len(t, filesOnDisk(t, meta.Location()), 1)
+}
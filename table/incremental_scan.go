@@ -0,0 +1,99 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/apache/iceberg-go"
+)
+
+// IncrementalAppendScan returns the data files added by the snapshots after
+// fromSnapshotID up to and including toSnapshotID, oldest first, which
+// allows reading only the rows appended in between.
+//
+// fromSnapshotID must be an ancestor of toSnapshotID. Replace snapshots,
+// which only rewrite existing data, are skipped, while an overwrite or
+// delete snapshot in the range is an error since the files added before it
+// may no longer be live.
+func IncrementalAppendScan(ctx context.Context, tbl *Table, fromSnapshotID, toSnapshotID int64) ([]iceberg.DataFile, error) {
+	meta := tbl.metadata
+
+	snap := meta.SnapshotByID(toSnapshotID)
+	if snap == nil {
+		return nil, fmt.Errorf("%w: snapshot %d not found", iceberg.ErrInvalidArgument, toSnapshotID)
+	}
+
+	var snapshots []*Snapshot
+	for snap.SnapshotID != fromSnapshotID {
+		switch op := snap.Operation(); op {
+		case OpAppend:
+			snapshots = append(snapshots, snap)
+		case OpReplace:
+		default:
+			return nil, fmt.Errorf("%w: cannot incrementally scan over %s snapshot %d",
+				ErrInvalidOperation, op, snap.SnapshotID)
+		}
+
+		if snap.ParentSnapshotID == nil {
+			return nil, fmt.Errorf("%w: snapshot %d is not an ancestor of snapshot %d",
+				iceberg.ErrInvalidArgument, fromSnapshotID, toSnapshotID)
+		}
+
+		if snap = meta.SnapshotByID(*snap.ParentSnapshotID); snap == nil {
+			return nil, fmt.Errorf("%w: snapshot %d is not an ancestor of snapshot %d",
+				iceberg.ErrInvalidArgument, fromSnapshotID, toSnapshotID)
+		}
+	}
+	slices.Reverse(snapshots)
+
+	fs, err := tbl.fsF(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []iceberg.DataFile
+	for _, snap := range snapshots {
+		manifests, err := snap.Manifests(fs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range manifests {
+			if m.ManifestContent() != iceberg.ManifestContentData ||
+				m.SnapshotID() != snap.SnapshotID {
+				continue
+			}
+
+			entries, err := m.FetchEntries(fs, true)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, e := range entries {
+				if e.Status() == iceberg.EntryStatusADDED && e.SnapshotID() == snap.SnapshotID {
+					files = append(files, e.DataFile())
+				}
+			}
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,654 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/extensions"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/google/uuid"
+	"github.com/hamba/avro/v2"
+	"github.com/hamba/avro/v2/ocf"
+)
+
+// avroBatchSize is the maximum number of rows in each record produced when
+// reading an Avro data file.
+const avroBatchSize = 1 << 14
+
+type AvroFileSource struct {
+	mem  memory.Allocator
+	fs   iceio.IO
+	file iceberg.DataFile
+}
+
+func (afs *AvroFileSource) GetReader(ctx context.Context) (FileReader, error) {
+	f, err := afs.fs.Open(afs.file.FilePath())
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+
+		return nil, err
+	}
+
+	dec, err := ocf.NewDecoder(f, ocf.WithDecoderSchemaCache(&avro.SchemaCache{}))
+	if err != nil {
+		f.Close()
+
+		return nil, err
+	}
+
+	rec, ok := dec.Schema().(*avro.RecordSchema)
+	if !ok {
+		f.Close()
+
+		return nil, fmt.Errorf("%w: avro data file schema must be a record, got %s",
+			iceberg.ErrInvalidSchema, dec.Schema().Type())
+	}
+
+	return &avroFileReader{
+		mem:    afs.mem,
+		file:   f,
+		size:   info.Size(),
+		dec:    dec,
+		schema: rec,
+	}, nil
+}
+
+type avroFileReader struct {
+	mem    memory.Allocator
+	file   iceio.File
+	size   int64
+	dec    *ocf.Decoder
+	schema *avro.RecordSchema
+
+	// columns are the converted top-level fields of the file, populated
+	// by PrunedSchema or lazily when reading.
+	columns []avroColumn
+}
+
+func (r *avroFileReader) Metadata() Metadata    { return r.dec.Metadata() }
+func (r *avroFileReader) SourceFileSize() int64 { return r.size }
+func (r *avroFileReader) Close() error          { return r.file.Close() }
+
+func (r *avroFileReader) convert(mapping iceberg.NameMapping) error {
+	cols, err := avroRecordColumns(r.schema, &iceberg.MappedField{Fields: mapping})
+	if err != nil {
+		return err
+	}
+
+	r.columns = cols
+
+	return nil
+}
+
+func (r *avroFileReader) Schema() (*arrow.Schema, error) {
+	if r.columns == nil {
+		if err := r.convert(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := make([]arrow.Field, len(r.columns))
+	for i, c := range r.columns {
+		fields[i] = c.field
+	}
+
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// PrunedSchema returns the top-level fields of the file which contain any
+// of the projected field IDs. Nested fields are not pruned, so the types of
+// the returned fields are complete.
+func (r *avroFileReader) PrunedSchema(projectedIDs map[int]struct{}, mapping iceberg.NameMapping) (*arrow.Schema, []int, error) {
+	if err := r.convert(mapping); err != nil {
+		return nil, nil, err
+	}
+
+	fields, indices := make([]arrow.Field, 0, len(r.columns)), make([]int, 0, len(r.columns))
+	for i, c := range r.columns {
+		for _, id := range c.ids {
+			if _, ok := projectedIDs[id]; ok {
+				fields = append(fields, c.field)
+				indices = append(indices, i)
+
+				break
+			}
+		}
+	}
+
+	return arrow.NewSchema(fields, nil), indices, nil
+}
+
+// GetRecords returns a reader for the provided top-level columns of the
+// file. Avro files carry no statistics to skip parts of the file with, so
+// the tester must be nil.
+func (r *avroFileReader) GetRecords(ctx context.Context, cols []int, tester any) (array.RecordReader, error) {
+	if tester != nil {
+		return nil, fmt.Errorf("%w: avro files do not support filtering with a tester",
+			iceberg.ErrNotImplemented)
+	}
+
+	if r.columns == nil {
+		if err := r.convert(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if cols == nil {
+		cols = make([]int, len(r.columns))
+		for i := range cols {
+			cols[i] = i
+		}
+	}
+
+	selected, fields := make([]avroColumn, len(cols)), make([]arrow.Field, len(cols))
+	for i, c := range cols {
+		if c < 0 || c >= len(r.columns) {
+			return nil, fmt.Errorf("%w: column index %d out of range", iceberg.ErrInvalidArgument, c)
+		}
+
+		selected[i], fields[i] = r.columns[c], r.columns[c].field
+	}
+
+	return &avroRecordReader{
+		refCount: 1,
+		ctx:      ctx,
+		mem:      r.mem,
+		dec:      r.dec,
+		schema:   arrow.NewSchema(fields, nil),
+		columns:  selected,
+	}, nil
+}
+
+func (r *avroFileReader) ReadTable(ctx context.Context) (arrow.Table, error) {
+	rdr, err := r.GetRecords(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Release()
+
+	var recs []arrow.Record
+	defer func() {
+		for _, rec := range recs {
+			rec.Release()
+		}
+	}()
+
+	for rdr.Next() {
+		rec := rdr.Record()
+		rec.Retain()
+		recs = append(recs, rec)
+	}
+
+	if err := rdr.Err(); err != nil {
+		return nil, err
+	}
+
+	return array.NewTableFromRecords(rdr.Schema(), recs), nil
+}
+
+type avroRecordReader struct {
+	refCount int64
+
+	ctx     context.Context
+	mem     memory.Allocator
+	dec     *ocf.Decoder
+	schema  *arrow.Schema
+	columns []avroColumn
+
+	cur arrow.Record
+	err error
+}
+
+func (r *avroRecordReader) Retain() { atomic.AddInt64(&r.refCount, 1) }
+func (r *avroRecordReader) Release() {
+	if atomic.AddInt64(&r.refCount, -1) == 0 && r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+}
+
+func (r *avroRecordReader) Schema() *arrow.Schema { return r.schema }
+func (r *avroRecordReader) Record() arrow.Record  { return r.cur }
+func (r *avroRecordReader) Err() error            { return r.err }
+
+func (r *avroRecordReader) Next() bool {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+
+	if r.err != nil {
+		return false
+	}
+
+	bldr := array.NewRecordBuilder(r.mem, r.schema)
+	defer bldr.Release()
+
+	rows := 0
+	for rows < avroBatchSize && r.dec.HasNext() {
+		if err := r.ctx.Err(); err != nil {
+			r.err = err
+
+			return false
+		}
+
+		var val any
+		if err := r.dec.Decode(&val); err != nil {
+			r.err = err
+
+			return false
+		}
+
+		row, ok := val.(map[string]any)
+		if !ok {
+			r.err = fmt.Errorf("%w: expected avro record, got %T", iceberg.ErrInvalidSchema, val)
+
+			return false
+		}
+
+		for i, c := range r.columns {
+			if err := appendAvroValue(c.appendValue, bldr.Field(i), row[c.name]); err != nil {
+				r.err = err
+
+				return false
+			}
+		}
+		rows++
+	}
+
+	if err := r.dec.Error(); err != nil {
+		r.err = err
+
+		return false
+	}
+
+	if rows == 0 {
+		return false
+	}
+
+	r.cur = bldr.NewRecord()
+
+	return true
+}
+
+// appendAvroValue calls the append function, turning a panic caused by a
+// value not matching the file schema into an error.
+func appendAvroValue(fn func(array.Builder, any), b array.Builder, v any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: unexpected avro value: %v", iceberg.ErrInvalidSchema, r)
+		}
+	}()
+
+	fn(b, v)
+
+	return nil
+}
+
+// avroColumn is a field of an avro record converted to arrow, along with
+// the function used to append a generically decoded avro value to a
+// builder for that field's type.
+type avroColumn struct {
+	name        string
+	field       arrow.Field
+	ids         []int
+	appendValue func(array.Builder, any)
+}
+
+func avroRecordColumns(rec *avro.RecordSchema, mapping *iceberg.MappedField) (cols []avroColumn, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch e := r.(type) {
+			case error:
+				err = e
+			default:
+				err = fmt.Errorf("%v", e)
+			}
+		}
+	}()
+
+	return avroFields(rec, mapping), nil
+}
+
+func avroFields(rec *avro.RecordSchema, mapping *iceberg.MappedField) []avroColumn {
+	cols := make([]avroColumn, len(rec.Fields()))
+	for i, f := range rec.Fields() {
+		var fieldMap *iceberg.MappedField
+		if mapping != nil {
+			fieldMap = mapping.GetField(f.Name())
+		}
+
+		cols[i] = avroColumnFor(f.Name(), f.Type(), avroFieldID(f.Prop("field-id"), fieldMap, f.Name()), fieldMap)
+	}
+
+	return cols
+}
+
+// avroFieldID returns the field id stored in the avro schema, the name
+// mapping is only used to resolve the ids of files written without them.
+func avroFieldID(prop any, mapping *iceberg.MappedField, name string) int {
+	switch v := prop.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	case string:
+		if id, err := strconv.Atoi(v); err == nil {
+			return id
+		}
+	}
+
+	if mapping != nil && mapping.FieldID != nil {
+		return *mapping.FieldID
+	}
+
+	panic(fmt.Errorf("%w: cannot convert avro field %s to Iceberg field, missing field-id",
+		iceberg.ErrInvalidSchema, name))
+}
+
+func avroColumnFor(name string, sc avro.Schema, id int, mapping *iceberg.MappedField) avroColumn {
+	nullable := false
+	if u, ok := sc.(*avro.UnionSchema); ok {
+		sc, nullable = avroNullableType(u), true
+	}
+
+	typ, ids, fn := avroToArrow(sc, mapping)
+
+	col := avroColumn{
+		name: name,
+		field: arrow.Field{
+			Name: name, Type: typ, Nullable: nullable,
+			Metadata: arrow.NewMetadata([]string{"PARQUET:field_id"}, []string{strconv.Itoa(id)}),
+		},
+		ids: append([]int{id}, ids...),
+	}
+
+	if !nullable {
+		col.appendValue = fn
+
+		return col
+	}
+
+	col.appendValue = func(b array.Builder, v any) {
+		if v == nil {
+			b.AppendNull()
+
+			return
+		}
+
+		// generic decoding of a union wraps the value in a map keyed by
+		// the name of the type in the union
+		if m, ok := v.(map[string]any); ok && len(m) == 1 {
+			for _, inner := range m {
+				v = inner
+			}
+		}
+
+		fn(b, v)
+	}
+
+	return col
+}
+
+func avroNullableType(u *avro.UnionSchema) avro.Schema {
+	if !u.Nullable() {
+		panic(fmt.Errorf("%w: only unions of null and a single type are supported, got %s",
+			iceberg.ErrInvalidSchema, u))
+	}
+
+	for _, t := range u.Types() {
+		if t.Type() != avro.Null {
+			return t
+		}
+	}
+
+	panic(fmt.Errorf("%w: invalid union %s", iceberg.ErrInvalidSchema, u))
+}
+
+func avroLogicalType(sc avro.Schema) avro.LogicalType {
+	if ls, ok := sc.(avro.LogicalTypeSchema); ok && ls.Logical() != nil {
+		return ls.Logical().Type()
+	}
+
+	return ""
+}
+
+// avroToArrow converts the avro schema to the equivalent arrow type, which
+// is the same type that the equivalent Iceberg type would be converted to.
+// It returns the ids of any nested fields and a function appending avro
+// values to a builder of the arrow type.
+func avroToArrow(sc avro.Schema, mapping *iceberg.MappedField) (arrow.DataType, []int, func(array.Builder, any)) {
+	if ref, ok := sc.(*avro.RefSchema); ok {
+		sc = ref.Schema()
+	}
+
+	switch sc.Type() {
+	case avro.Boolean:
+		return arrow.FixedWidthTypes.Boolean, nil, func(b array.Builder, v any) {
+			b.(*array.BooleanBuilder).Append(v.(bool))
+		}
+	case avro.Int:
+		switch avroLogicalType(sc) {
+		case avro.Date:
+			return arrow.FixedWidthTypes.Date32, nil, func(b array.Builder, v any) {
+				b.(*array.Date32Builder).Append(arrow.Date32FromTime(v.(time.Time)))
+			}
+		case "":
+			return arrow.PrimitiveTypes.Int32, nil, func(b array.Builder, v any) {
+				b.(*array.Int32Builder).Append(int32(v.(int)))
+			}
+		}
+	case avro.Long:
+		switch avroLogicalType(sc) {
+		case avro.TimeMicros:
+			return arrow.FixedWidthTypes.Time64us, nil, func(b array.Builder, v any) {
+				b.(*array.Time64Builder).Append(arrow.Time64(v.(time.Duration).Microseconds()))
+			}
+		case avro.TimestampMicros:
+			typ := &arrow.TimestampType{Unit: arrow.Microsecond}
+			if utc, _ := sc.(*avro.PrimitiveSchema).Prop("adjust-to-utc").(bool); utc {
+				typ = arrow.FixedWidthTypes.Timestamp_us.(*arrow.TimestampType)
+			}
+
+			return typ, nil, func(b array.Builder, v any) {
+				b.(*array.TimestampBuilder).Append(arrow.Timestamp(v.(time.Time).UnixMicro()))
+			}
+		case "":
+			return arrow.PrimitiveTypes.Int64, nil, func(b array.Builder, v any) {
+				b.(*array.Int64Builder).Append(v.(int64))
+			}
+		}
+	case avro.Float:
+		return arrow.PrimitiveTypes.Float32, nil, func(b array.Builder, v any) {
+			b.(*array.Float32Builder).Append(v.(float32))
+		}
+	case avro.Double:
+		return arrow.PrimitiveTypes.Float64, nil, func(b array.Builder, v any) {
+			b.(*array.Float64Builder).Append(v.(float64))
+		}
+	case avro.String, avro.Enum:
+		if avroLogicalType(sc) == avro.UUID {
+			return extensions.NewUUIDType(), nil, func(b array.Builder, v any) {
+				b.(*extensions.UUIDBuilder).Append(uuid.MustParse(v.(string)))
+			}
+		}
+
+		return arrow.BinaryTypes.String, nil, func(b array.Builder, v any) {
+			b.(*array.StringBuilder).Append(v.(string))
+		}
+	case avro.Bytes:
+		if avroLogicalType(sc) == avro.Decimal {
+			return avroDecimal(sc.(*avro.PrimitiveSchema).Logical().(*avro.DecimalLogicalSchema))
+		}
+
+		return arrow.BinaryTypes.Binary, nil, func(b array.Builder, v any) {
+			b.(*array.BinaryBuilder).Append(v.([]byte))
+		}
+	case avro.Fixed:
+		fixed := sc.(*avro.FixedSchema)
+		switch avroLogicalType(sc) {
+		case avro.Decimal:
+			return avroDecimal(fixed.Logical().(*avro.DecimalLogicalSchema))
+		case avro.UUID:
+			return extensions.NewUUIDType(), nil, func(b array.Builder, v any) {
+				b.(*extensions.UUIDBuilder).Append(uuid.UUID(v.([16]byte)))
+			}
+		}
+
+		return &arrow.FixedSizeBinaryType{ByteWidth: fixed.Size()}, nil, func(b array.Builder, v any) {
+			arr := reflect.ValueOf(v)
+			buf := make([]byte, arr.Len())
+			reflect.Copy(reflect.ValueOf(buf), arr)
+			b.(*array.FixedSizeBinaryBuilder).Append(buf)
+		}
+	case avro.Record:
+		return avroStruct(sc.(*avro.RecordSchema), mapping)
+	case avro.Array:
+		return avroList(sc.(*avro.ArraySchema), mapping)
+	case avro.Map:
+		return avroMap(sc.(*avro.MapSchema), mapping)
+	}
+
+	panic(fmt.Errorf("%w: unsupported avro type for Iceberg data files: %s",
+		iceberg.ErrInvalidSchema, sc))
+}
+
+func avroDecimal(dec *avro.DecimalLogicalSchema) (arrow.DataType, []int, func(array.Builder, any)) {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(dec.Scale())), nil)
+
+	return &arrow.Decimal128Type{Precision: int32(dec.Precision()), Scale: int32(dec.Scale())}, nil,
+		func(b array.Builder, v any) {
+			r := v.(*big.Rat)
+			unscaled := new(big.Int).Mul(r.Num(), scale)
+			unscaled.Quo(unscaled, r.Denom())
+			b.(*array.Decimal128Builder).Append(decimal128.FromBigInt(unscaled))
+		}
+}
+
+func avroStruct(rec *avro.RecordSchema, mapping *iceberg.MappedField) (arrow.DataType, []int, func(array.Builder, any)) {
+	children := avroFields(rec, mapping)
+
+	fields, ids := make([]arrow.Field, len(children)), []int{}
+	for i, c := range children {
+		fields[i] = c.field
+		ids = append(ids, c.ids...)
+	}
+
+	return arrow.StructOf(fields...), ids, func(b array.Builder, v any) {
+		sb, row := b.(*array.StructBuilder), v.(map[string]any)
+		sb.Append(true)
+		for i, c := range children {
+			c.appendValue(sb.FieldBuilder(i), row[c.name])
+		}
+	}
+}
+
+// avroKeyValue returns the key and value fields if the record is the
+// element of an array used to store a map with non-string keys.
+func avroKeyValue(sc avro.Schema) (key, value *avro.Field, ok bool) {
+	rec, ok := sc.(*avro.RecordSchema)
+	if !ok || len(rec.Fields()) != 2 {
+		return nil, nil, false
+	}
+
+	key, value = rec.Fields()[0], rec.Fields()[1]
+
+	return key, value, key.Name() == "key" && value.Name() == "value"
+}
+
+func avroList(arr *avro.ArraySchema, mapping *iceberg.MappedField) (arrow.DataType, []int, func(array.Builder, any)) {
+	if key, value, ok := avroKeyValue(arr.Items()); ok && arr.Prop("element-id") == nil {
+		return avroKeyValueMap(key, value, mapping)
+	}
+
+	var elemMapping *iceberg.MappedField
+	if mapping != nil {
+		elemMapping = mapping.GetField("element")
+	}
+
+	elem := avroColumnFor("element", arr.Items(),
+		avroFieldID(arr.Prop("element-id"), elemMapping, "element"), elemMapping)
+
+	return arrow.ListOfField(elem.field), elem.ids, func(b array.Builder, v any) {
+		lb := b.(*array.ListBuilder)
+		lb.Append(true)
+		for _, e := range v.([]any) {
+			elem.appendValue(lb.ValueBuilder(), e)
+		}
+	}
+}
+
+func avroKeyValueMap(key, value *avro.Field, mapping *iceberg.MappedField) (arrow.DataType, []int, func(array.Builder, any)) {
+	var keyMapping, valMapping *iceberg.MappedField
+	if mapping != nil {
+		keyMapping, valMapping = mapping.GetField("key"), mapping.GetField("value")
+	}
+
+	k := avroColumnFor("key", key.Type(), avroFieldID(key.Prop("field-id"), keyMapping, "key"), keyMapping)
+	val := avroColumnFor("value", value.Type(), avroFieldID(value.Prop("field-id"), valMapping, "value"), valMapping)
+
+	return arrow.MapOfFields(k.field, val.field), append(k.ids, val.ids...), func(b array.Builder, v any) {
+		mb := b.(*array.MapBuilder)
+		mb.Append(true)
+		for _, e := range v.([]any) {
+			kv := e.(map[string]any)
+			k.appendValue(mb.KeyBuilder(), kv["key"])
+			val.appendValue(mb.ItemBuilder(), kv["value"])
+		}
+	}
+}
+
+func avroMap(m *avro.MapSchema, mapping *iceberg.MappedField) (arrow.DataType, []int, func(array.Builder, any)) {
+	var keyMapping, valMapping *iceberg.MappedField
+	if mapping != nil {
+		keyMapping, valMapping = mapping.GetField("key"), mapping.GetField("value")
+	}
+
+	keyID := avroFieldID(m.Prop("key-id"), keyMapping, "key")
+	keyField := arrow.Field{
+		Name: "key", Type: arrow.BinaryTypes.String,
+		Metadata: arrow.NewMetadata([]string{"PARQUET:field_id"}, []string{strconv.Itoa(keyID)}),
+	}
+	val := avroColumnFor("value", m.Values(), avroFieldID(m.Prop("value-id"), valMapping, "value"), valMapping)
+
+	return arrow.MapOfFields(keyField, val.field), append([]int{keyID}, val.ids...), func(b array.Builder, v any) {
+		mb := b.(*array.MapBuilder)
+		mb.Append(true)
+		for k, e := range v.(map[string]any) {
+			mb.KeyBuilder().(*array.StringBuilder).Append(k)
+			val.appendValue(mb.ItemBuilder(), e)
+		}
+	}
+}
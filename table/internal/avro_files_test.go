@@ -0,0 +1,211 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/apache/iceberg-go/table/internal"
+	"github.com/hamba/avro/v2"
+	"github.com/hamba/avro/v2/ocf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeAvroDataFile(t *testing.T, schema string, records []map[string]any) iceberg.DataFile {
+	sc, err := avro.Parse(schema)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "data.avro")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	// use the same encoder options as manifest files so that the
+	// field-id properties are kept in the file schema
+	enc, err := ocf.NewEncoderWithSchema(sc, f,
+		ocf.WithSchemaMarshaler(ocf.FullSchemaMarshaler),
+		ocf.WithEncoderSchemaCache(&avro.SchemaCache{}),
+		ocf.WithCodec(ocf.Deflate))
+	require.NoError(t, err)
+
+	for _, r := range records {
+		require.NoError(t, enc.Encode(r))
+	}
+	require.NoError(t, enc.Close())
+	require.NoError(t, f.Close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	bldr, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentData,
+		path, iceberg.AvroFile, nil, int64(len(records)), info.Size())
+	require.NoError(t, err)
+
+	return bldr.Build()
+}
+
+func TestReadAvroDataFile(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	dataFile := writeAvroDataFile(t, `{
+		"type": "record",
+		"name": "r1",
+		"fields": [
+			{"name": "id", "type": "long", "field-id": 1},
+			{"name": "data", "type": ["null", "string"], "field-id": 2},
+			{"name": "ts", "type": {"type": "long", "logicalType": "timestamp-micros", "adjust-to-utc": true}, "field-id": 3},
+			{"name": "location", "type": {
+				"type": "record",
+				"name": "r4",
+				"fields": [
+					{"name": "lat", "type": "double", "field-id": 5},
+					{"name": "long", "type": "double", "field-id": 6}
+				]
+			}, "field-id": 4},
+			{"name": "tags", "type": {"type": "array", "items": "string", "element-id": 8}, "field-id": 7}
+		]
+	}`, []map[string]any{
+		{
+			"id": int64(1), "data": "foo", "ts": time.UnixMicro(1_000_000).UTC(),
+			"location": map[string]any{"lat": 52.37, "long": 4.89}, "tags": []any{"a", "b"},
+		},
+		{
+			"id": int64(2), "data": nil, "ts": time.UnixMicro(2_000_000).UTC(),
+			"location": map[string]any{"lat": 51.51, "long": -0.13}, "tags": []any{},
+		},
+		{
+			"id": int64(3), "data": "bar", "ts": time.UnixMicro(3_000_000).UTC(),
+			"location": map[string]any{"lat": 48.85, "long": 2.35}, "tags": []any{"c"},
+		},
+	})
+
+	ctx := compute.WithAllocator(context.Background(), mem)
+	src, err := internal.GetFile(ctx, iceio.LocalFS{}, dataFile, false)
+	require.NoError(t, err)
+
+	rdr, err := src.GetReader(ctx)
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	full, err := rdr.Schema()
+	require.NoError(t, err)
+	fullSchema, err := table.ArrowSchemaToIceberg(full, false, nil)
+	require.NoError(t, err)
+	assert.True(t, fullSchema.Equals(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz, Required: true},
+		iceberg.NestedField{ID: 4, Name: "location", Type: &iceberg.StructType{
+			FieldList: []iceberg.NestedField{
+				{ID: 5, Name: "lat", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+				{ID: 6, Name: "long", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			},
+		}, Required: true},
+		iceberg.NestedField{ID: 7, Name: "tags", Type: &iceberg.ListType{
+			ElementID: 8, Element: iceberg.PrimitiveTypes.String, ElementRequired: true,
+		}, Required: true},
+	)), fullSchema.String())
+
+	// project the data and location.long columns
+	pruned, cols, err := rdr.PrunedSchema(map[int]struct{}{2: {}, 6: {}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, cols)
+
+	fileSchema, err := table.ArrowSchemaToIceberg(pruned, false, nil)
+	require.NoError(t, err)
+
+	records, err := rdr.GetRecords(ctx, cols, nil)
+	require.NoError(t, err)
+	defer records.Release()
+
+	require.True(t, records.Next())
+	rec := records.Record()
+
+	projected := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 4, Name: "location", Type: &iceberg.StructType{
+			FieldList: []iceberg.NestedField{
+				{ID: 6, Name: "long", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			},
+		}, Required: true},
+	)
+
+	out, err := table.ToRequestedSchema(ctx, projected, fileSchema, rec, false, false, false)
+	require.NoError(t, err)
+	defer out.Release()
+
+	expected, _, err := array.RecordFromJSON(mem, out.Schema(),
+		strings.NewReader(`[
+			{"data": "foo", "location": {"long": 4.89}},
+			{"data": null, "location": {"long": -0.13}},
+			{"data": "bar", "location": {"long": 2.35}}
+		]`))
+	require.NoError(t, err)
+	defer expected.Release()
+
+	assert.Truef(t, array.RecordEqual(expected, out), "expected: %s\ngot: %s", expected, out)
+	assert.False(t, records.Next())
+	assert.NoError(t, records.Err())
+}
+
+func TestReadAvroDataFileMissingFieldIDs(t *testing.T) {
+	dataFile := writeAvroDataFile(t, `{
+		"type": "record",
+		"name": "r1",
+		"fields": [{"name": "id", "type": "int"}]
+	}`, []map[string]any{{"id": 1}})
+
+	ctx := context.Background()
+	src, err := internal.GetFile(ctx, iceio.LocalFS{}, dataFile, false)
+	require.NoError(t, err)
+
+	rdr, err := src.GetReader(ctx)
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	_, _, err = rdr.PrunedSchema(map[int]struct{}{1: {}}, nil)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+
+	mapping, err := iceberg.ParseNameMapping(`[{"field-id": 1, "names": ["id"]}]`)
+	require.NoError(t, err)
+
+	pruned, cols, err := rdr.PrunedSchema(map[int]struct{}{1: {}}, mapping)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, cols)
+	assert.True(t, arrow.TypeEqual(arrow.PrimitiveTypes.Int32, pruned.Field(0).Type))
+
+	records, err := rdr.GetRecords(ctx, cols, nil)
+	require.NoError(t, err)
+	defer records.Release()
+
+	require.True(t, records.Next())
+	assert.Equal(t, []int32{1}, records.Record().Column(0).(*array.Int32).Int32Values())
+}
@@ -42,8 +42,14 @@ func GetFile(ctx context.Context, fs iceio.IO, dataFile iceberg.DataFile, isPosD
 			fs:   fs,
 			file: dataFile,
 		}, nil
+	case iceberg.AvroFile:
+		return &AvroFileSource{
+			mem:  compute.GetAllocator(ctx),
+			fs:   fs,
+			file: dataFile,
+		}, nil
 	default:
-		return nil, fmt.Errorf("%w: only parquet format is implemented, got %s",
+		return nil, fmt.Errorf("%w: only parquet and avro formats are implemented, got %s",
 			iceberg.ErrNotImplemented, dataFile.FileFormat())
 	}
 }
@@ -79,6 +85,19 @@ type FileFormat interface {
 	DataFileStatsFromMeta(rdr Metadata, statsCols map[int]StatisticsCollector, colMapping map[string]int) *DataFileStatistics
 	GetWriteProperties(iceberg.Properties) any
 	WriteDataFile(ctx context.Context, fs iceio.WriteFileIO, info WriteFileInfo, batches []arrow.Record) (iceberg.DataFile, error)
+	NewDataFileWriter(ctx context.Context, fs iceio.WriteFileIO, info WriteFileInfo, sc *arrow.Schema) (DataFileWriter, error)
+}
+
+// DataFileWriter incrementally writes record batches to a single data
+// file. Close finishes the file and returns the DataFile describing it,
+// while Abort discards the file so that it is never published.
+// BytesWritten is an estimate of the size of the file so far, including
+// the buffered rows.
+type DataFileWriter interface {
+	Write(arrow.Record) error
+	BytesWritten() int64
+	Close() (iceberg.DataFile, error)
+	Abort()
 }
 
 func GetFileFormat(format iceberg.FileFormat) FileFormat {
@@ -105,4 +124,8 @@ type WriteFileInfo struct {
 	FileName   string
 	StatsCols  map[int]StatisticsCollector
 	WriteProps any
+	// PartitionData is the partition of the rows written to the file,
+	// keyed by partition field ID. If nil, the partition values are
+	// inferred from the column bounds of the file.
+	PartitionData map[int]any
 }
@@ -0,0 +1,702 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"bytes"
+	"cmp"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/apache/iceberg-go"
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ORCTypeKind is the kind of a column in the type tree of an ORC file.
+type ORCTypeKind uint64
+
+const (
+	ORCBoolean ORCTypeKind = iota
+	ORCByte
+	ORCShort
+	ORCInt
+	ORCLong
+	ORCFloat
+	ORCDouble
+	ORCString
+	ORCBinary
+	ORCTimestamp
+	ORCList
+	ORCMap
+	ORCStruct
+	ORCUnion
+	ORCDecimal
+	ORCDate
+	ORCVarchar
+	ORCChar
+	ORCTimestampInstant
+)
+
+// ORCIcebergIDKey is the ORC type attribute that holds the iceberg field
+// ID of a column.
+const ORCIcebergIDKey = "iceberg.id"
+
+const (
+	orcMagic           = "ORC"
+	orcDefaultBlockLen = 256 * 1024
+	orcMaxBlockLen     = 1<<23 - 1
+)
+
+// the compression kinds of an ORC file, as stored in its postscript
+const (
+	orcCompressionNone = iota
+	orcCompressionZlib
+	orcCompressionSnappy
+	orcCompressionLzo
+	orcCompressionLz4
+	orcCompressionZstd
+)
+
+// ORCType is a node of the flattened type tree of an ORC file, the
+// index of a type in ORCFileTail.Types is its column ID.
+type ORCType struct {
+	Kind       ORCTypeKind
+	Subtypes   []uint32
+	FieldNames []string
+	Precision  uint32
+	Scale      uint32
+	Attributes map[string]string
+}
+
+// ORCColumnStats are the statistics of a column of an ORC file.
+//
+// Min and Max are nil when the statistics don't have bounds. Otherwise
+// they are int64 for integer, date (days from the unix epoch) and
+// timestamp (microseconds from the unix epoch, in UTC) columns, float64
+// for floating point columns, string for string columns and *big.Rat
+// for decimal columns.
+type ORCColumnStats struct {
+	NumValues uint64
+	HasNull   bool
+	Min, Max  any
+}
+
+// ORCFileTail is the metadata stored at the end of an ORC file, with
+// the column statistics of all its stripes merged.
+type ORCFileTail struct {
+	NumRows uint64
+	Types   []ORCType
+	Stats   []ORCColumnStats
+}
+
+// ReadORCTail reads the postscript, footer and metadata sections of the
+// ORC file r of the given size, without reading any of its stripes.
+//
+// The statistics of each column are merged from the stripe statistics
+// of the metadata section, falling back to the file statistics of the
+// footer if the file has no stripe statistics.
+func ReadORCTail(r io.ReaderAt, size int64) (*ORCFileTail, error) {
+	if size < int64(len(orcMagic))+1 {
+		return nil, fmt.Errorf("%w: orc file too small: %d bytes", iceberg.ErrInvalidArgument, size)
+	}
+
+	var psLen [1]byte
+	if _, err := r.ReadAt(psLen[:], size-1); err != nil {
+		return nil, fmt.Errorf("failed to read orc postscript length: %w", err)
+	}
+
+	psStart := size - 1 - int64(psLen[0])
+	if psStart < 0 {
+		return nil, fmt.Errorf("%w: invalid orc postscript length %d", iceberg.ErrInvalidArgument, psLen[0])
+	}
+
+	psBytes := make([]byte, psLen[0])
+	if _, err := r.ReadAt(psBytes, psStart); err != nil {
+		return nil, fmt.Errorf("failed to read orc postscript: %w", err)
+	}
+
+	ps, err := parseORCPostScript(psBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	tailStart := psStart - int64(ps.footerLen) - int64(ps.metadataLen)
+	if tailStart < 0 {
+		return nil, fmt.Errorf("%w: invalid orc footer and metadata lengths", iceberg.ErrInvalidArgument)
+	}
+
+	tail := make([]byte, ps.footerLen+ps.metadataLen)
+	if _, err := r.ReadAt(tail, tailStart); err != nil {
+		return nil, fmt.Errorf("failed to read orc footer: %w", err)
+	}
+
+	footer, err := ps.decompress(tail[ps.metadataLen:])
+	if err != nil {
+		return nil, err
+	}
+
+	result, fileStats, err := parseORCFooter(footer)
+	if err != nil {
+		return nil, err
+	}
+
+	var stripeStats [][]ORCColumnStats
+	if ps.metadataLen > 0 {
+		metadata, err := ps.decompress(tail[:ps.metadataLen])
+		if err != nil {
+			return nil, err
+		}
+
+		if stripeStats, err = parseORCMetadata(metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(stripeStats) == 0 {
+		result.Stats = fileStats
+	} else {
+		result.Stats = stripeStats[0]
+		for _, stats := range stripeStats[1:] {
+			for i := range min(len(result.Stats), len(stats)) {
+				result.Stats[i] = result.Stats[i].merge(stats[i])
+			}
+		}
+	}
+
+	return result, nil
+}
+
+type orcPostScript struct {
+	footerLen, metadataLen uint64
+	compression            uint64
+	blockSize              uint64
+}
+
+// decompress decodes a stream of compressed chunks, each one prefixed by
+// a 3 byte little endian header holding the length of the chunk and
+// whether it is stored uncompressed in its lowest bit. A chunk holds at
+// most the compression block size of the file once decompressed.
+func (ps orcPostScript) decompress(data []byte) ([]byte, error) {
+	if ps.compression == orcCompressionNone {
+		return data, nil
+	}
+
+	var out bytes.Buffer
+	for len(data) > 0 {
+		if len(data) < 3 {
+			return nil, fmt.Errorf("%w: truncated orc compression chunk header", iceberg.ErrInvalidArgument)
+		}
+
+		header := int(data[0]) | int(data[1])<<8 | int(data[2])<<16
+		isOriginal, n := header&1 == 1, header>>1
+		data = data[3:]
+		if n > len(data) {
+			return nil, fmt.Errorf("%w: truncated orc compression chunk", iceberg.ErrInvalidArgument)
+		}
+
+		chunk := data[:n]
+		data = data[n:]
+		if isOriginal {
+			out.Write(chunk)
+			continue
+		}
+
+		dec, err := ps.decompressChunk(chunk)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to decompress orc chunk: %w", iceberg.ErrInvalidArgument, err)
+		}
+
+		if uint64(len(dec)) > ps.blockSize {
+			return nil, fmt.Errorf("%w: orc chunk larger than the compression block size %d",
+				iceberg.ErrInvalidArgument, ps.blockSize)
+		}
+		out.Write(dec)
+	}
+
+	return out.Bytes(), nil
+}
+
+// decompressChunk decodes a compressed chunk. Zlib chunks are raw deflate
+// streams, snappy and lz4 chunks raw blocks and zstd chunks zstd frames,
+// as written by the Java and C++ ORC writers.
+func (ps orcPostScript) decompressChunk(chunk []byte) ([]byte, error) {
+	switch ps.compression {
+	case orcCompressionZlib:
+		return io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(chunk)), int64(ps.blockSize)+1))
+	case orcCompressionSnappy:
+		n, err := snappy.DecodedLen(chunk)
+		if err != nil {
+			return nil, err
+		}
+
+		if uint64(n) > ps.blockSize {
+			return nil, fmt.Errorf("decompressed length %d exceeds the compression block size", n)
+		}
+
+		return snappy.Decode(nil, chunk)
+	case orcCompressionLz4:
+		dec := make([]byte, ps.blockSize)
+		n, err := lz4.UncompressBlock(chunk, dec)
+		if err != nil {
+			return nil, err
+		}
+
+		return dec[:n], nil
+	case orcCompressionZstd:
+		dec, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxMemory(ps.blockSize))
+		if err != nil {
+			return nil, err
+		}
+		defer dec.Close()
+
+		return dec.DecodeAll(chunk, nil)
+	}
+
+	return nil, fmt.Errorf("%w: orc compression kind %d", iceberg.ErrNotImplemented, ps.compression)
+}
+
+// orcProtoDescriptor declares the messages of the ORC file tail, as
+// defined by orc_proto.proto of the ORC specification, that are needed to
+// read the schema and the column statistics of a file. Fields which
+// aren't declared are kept as unknown fields when decoding. The type kind
+// and compression kind enums are declared as integers, so that kinds
+// added by newer writers aren't dropped as unknown values of a closed
+// proto2 enum.
+const orcProtoDescriptor = `
+name: "orc_proto.proto"
+package: "orc.proto"
+message_type: {
+	name: "IntegerStatistics"
+	field: {name: "minimum" number: 1 label: LABEL_OPTIONAL type: TYPE_SINT64}
+	field: {name: "maximum" number: 2 label: LABEL_OPTIONAL type: TYPE_SINT64}
+	field: {name: "sum" number: 3 label: LABEL_OPTIONAL type: TYPE_SINT64}
+}
+message_type: {
+	name: "DoubleStatistics"
+	field: {name: "minimum" number: 1 label: LABEL_OPTIONAL type: TYPE_DOUBLE}
+	field: {name: "maximum" number: 2 label: LABEL_OPTIONAL type: TYPE_DOUBLE}
+	field: {name: "sum" number: 3 label: LABEL_OPTIONAL type: TYPE_DOUBLE}
+}
+message_type: {
+	name: "StringStatistics"
+	field: {name: "minimum" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING}
+	field: {name: "maximum" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING}
+	field: {name: "sum" number: 3 label: LABEL_OPTIONAL type: TYPE_SINT64}
+	field: {name: "lowerBound" number: 4 label: LABEL_OPTIONAL type: TYPE_STRING}
+	field: {name: "upperBound" number: 5 label: LABEL_OPTIONAL type: TYPE_STRING}
+}
+message_type: {
+	name: "DecimalStatistics"
+	field: {name: "minimum" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING}
+	field: {name: "maximum" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING}
+	field: {name: "sum" number: 3 label: LABEL_OPTIONAL type: TYPE_STRING}
+}
+message_type: {
+	name: "DateStatistics"
+	field: {name: "minimum" number: 1 label: LABEL_OPTIONAL type: TYPE_SINT32}
+	field: {name: "maximum" number: 2 label: LABEL_OPTIONAL type: TYPE_SINT32}
+}
+message_type: {
+	name: "TimestampStatistics"
+	field: {name: "minimum" number: 1 label: LABEL_OPTIONAL type: TYPE_SINT64}
+	field: {name: "maximum" number: 2 label: LABEL_OPTIONAL type: TYPE_SINT64}
+	field: {name: "minimumUtc" number: 3 label: LABEL_OPTIONAL type: TYPE_SINT64}
+	field: {name: "maximumUtc" number: 4 label: LABEL_OPTIONAL type: TYPE_SINT64}
+	field: {name: "minimumNanos" number: 5 label: LABEL_OPTIONAL type: TYPE_INT32}
+	field: {name: "maximumNanos" number: 6 label: LABEL_OPTIONAL type: TYPE_INT32}
+}
+message_type: {
+	name: "ColumnStatistics"
+	field: {name: "numberOfValues" number: 1 label: LABEL_OPTIONAL type: TYPE_UINT64}
+	field: {name: "intStatistics" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".orc.proto.IntegerStatistics"}
+	field: {name: "doubleStatistics" number: 3 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".orc.proto.DoubleStatistics"}
+	field: {name: "stringStatistics" number: 4 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".orc.proto.StringStatistics"}
+	field: {name: "decimalStatistics" number: 6 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".orc.proto.DecimalStatistics"}
+	field: {name: "dateStatistics" number: 7 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".orc.proto.DateStatistics"}
+	field: {name: "timestampStatistics" number: 9 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".orc.proto.TimestampStatistics"}
+	field: {name: "hasNull" number: 10 label: LABEL_OPTIONAL type: TYPE_BOOL}
+}
+message_type: {
+	name: "StringPair"
+	field: {name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING}
+	field: {name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING}
+}
+message_type: {
+	name: "Type"
+	field: {name: "kind" number: 1 label: LABEL_OPTIONAL type: TYPE_UINT32}
+	field: {name: "subtypes" number: 2 label: LABEL_REPEATED type: TYPE_UINT32 options: {packed: true}}
+	field: {name: "fieldNames" number: 3 label: LABEL_REPEATED type: TYPE_STRING}
+	field: {name: "maximumLength" number: 4 label: LABEL_OPTIONAL type: TYPE_UINT32}
+	field: {name: "precision" number: 5 label: LABEL_OPTIONAL type: TYPE_UINT32}
+	field: {name: "scale" number: 6 label: LABEL_OPTIONAL type: TYPE_UINT32}
+	field: {name: "attributes" number: 7 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".orc.proto.StringPair"}
+}
+message_type: {
+	name: "Footer"
+	field: {name: "headerLength" number: 1 label: LABEL_OPTIONAL type: TYPE_UINT64}
+	field: {name: "contentLength" number: 2 label: LABEL_OPTIONAL type: TYPE_UINT64}
+	field: {name: "types" number: 4 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".orc.proto.Type"}
+	field: {name: "numberOfRows" number: 6 label: LABEL_OPTIONAL type: TYPE_UINT64}
+	field: {name: "statistics" number: 7 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".orc.proto.ColumnStatistics"}
+	field: {name: "rowIndexStride" number: 8 label: LABEL_OPTIONAL type: TYPE_UINT32}
+}
+message_type: {
+	name: "StripeStatistics"
+	field: {name: "colStats" number: 1 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".orc.proto.ColumnStatistics"}
+}
+message_type: {
+	name: "Metadata"
+	field: {name: "stripeStats" number: 1 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".orc.proto.StripeStatistics"}
+}
+message_type: {
+	name: "PostScript"
+	field: {name: "footerLength" number: 1 label: LABEL_OPTIONAL type: TYPE_UINT64}
+	field: {name: "compression" number: 2 label: LABEL_OPTIONAL type: TYPE_UINT32}
+	field: {name: "compressionBlockSize" number: 3 label: LABEL_OPTIONAL type: TYPE_UINT64}
+	field: {name: "version" number: 4 label: LABEL_REPEATED type: TYPE_UINT32 options: {packed: true}}
+	field: {name: "metadataLength" number: 5 label: LABEL_OPTIONAL type: TYPE_UINT64}
+	field: {name: "writerVersion" number: 6 label: LABEL_OPTIONAL type: TYPE_UINT32}
+	field: {name: "stripeStatisticsLength" number: 7 label: LABEL_OPTIONAL type: TYPE_UINT64}
+	field: {name: "magic" number: 8000 label: LABEL_OPTIONAL type: TYPE_STRING}
+}
+`
+
+var orcProto = sync.OnceValues(func() (protoreflect.FileDescriptor, error) {
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(orcProtoDescriptor), &fd); err != nil {
+		return nil, err
+	}
+
+	return protodesc.NewFile(&fd, nil)
+})
+
+// unmarshalORC decodes b as the ORC tail message with the given name.
+func unmarshalORC(name protoreflect.Name, b []byte) (protoreflect.Message, error) {
+	file, err := orcProto()
+	if err != nil {
+		return nil, err
+	}
+
+	msg := dynamicpb.NewMessage(file.Messages().ByName(name))
+	if err := proto.Unmarshal(b, msg); err != nil {
+		return nil, fmt.Errorf("%w: invalid orc %s: %w", iceberg.ErrInvalidArgument, name, err)
+	}
+
+	return msg, nil
+}
+
+// orcField returns the value of the named field of msg, and whether it
+// is set.
+func orcField(msg protoreflect.Message, name protoreflect.Name) (protoreflect.Value, bool) {
+	fd := msg.Descriptor().Fields().ByName(name)
+
+	return msg.Get(fd), msg.Has(fd)
+}
+
+func orcUint(msg protoreflect.Message, name protoreflect.Name) uint64 {
+	v, _ := orcField(msg, name)
+
+	return v.Uint()
+}
+
+// orcMessages returns the values of the named repeated message field.
+func orcMessages(msg protoreflect.Message, name protoreflect.Name) []protoreflect.Message {
+	v, _ := orcField(msg, name)
+	list := v.List()
+
+	out := make([]protoreflect.Message, list.Len())
+	for i := range out {
+		out[i] = list.Get(i).Message()
+	}
+
+	return out
+}
+
+func parseORCPostScript(b []byte) (orcPostScript, error) {
+	msg, err := unmarshalORC("PostScript", b)
+	if err != nil {
+		return orcPostScript{}, err
+	}
+
+	if magic, _ := orcField(msg, "magic"); magic.String() != orcMagic {
+		return orcPostScript{}, fmt.Errorf("%w: not an orc file", iceberg.ErrInvalidArgument)
+	}
+
+	ps := orcPostScript{
+		footerLen:   orcUint(msg, "footerLength"),
+		metadataLen: orcUint(msg, "metadataLength"),
+		compression: orcUint(msg, "compression"),
+		blockSize:   orcDefaultBlockLen,
+	}
+
+	if v, ok := orcField(msg, "compressionBlockSize"); ok {
+		ps.blockSize = v.Uint()
+	}
+
+	// the length of a chunk is stored in the 23 bits of its header
+	if ps.blockSize > orcMaxBlockLen {
+		return orcPostScript{}, fmt.Errorf("%w: invalid orc compression block size %d",
+			iceberg.ErrInvalidArgument, ps.blockSize)
+	}
+
+	return ps, nil
+}
+
+func parseORCFooter(b []byte) (*ORCFileTail, []ORCColumnStats, error) {
+	msg, err := unmarshalORC("Footer", b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := ORCFileTail{NumRows: orcUint(msg, "numberOfRows")}
+	for _, typ := range orcMessages(msg, "types") {
+		result.Types = append(result.Types, parseORCType(typ))
+	}
+
+	var stats []ORCColumnStats
+	for _, col := range orcMessages(msg, "statistics") {
+		s, err := parseORCColumnStats(col)
+		if err != nil {
+			return nil, nil, err
+		}
+		stats = append(stats, s)
+	}
+
+	if len(result.Types) == 0 || result.Types[0].Kind != ORCStruct {
+		return nil, nil, fmt.Errorf("%w: orc file schema must be a struct", iceberg.ErrInvalidSchema)
+	}
+
+	return &result, stats, nil
+}
+
+func parseORCType(msg protoreflect.Message) ORCType {
+	typ := ORCType{
+		Kind:      ORCTypeKind(orcUint(msg, "kind")),
+		Precision: uint32(orcUint(msg, "precision")),
+		Scale:     uint32(orcUint(msg, "scale")),
+	}
+
+	subtypes, _ := orcField(msg, "subtypes")
+	for i := range subtypes.List().Len() {
+		typ.Subtypes = append(typ.Subtypes, uint32(subtypes.List().Get(i).Uint()))
+	}
+
+	names, _ := orcField(msg, "fieldNames")
+	for i := range names.List().Len() {
+		typ.FieldNames = append(typ.FieldNames, names.List().Get(i).String())
+	}
+
+	for _, attr := range orcMessages(msg, "attributes") {
+		if typ.Attributes == nil {
+			typ.Attributes = make(map[string]string)
+		}
+
+		key, _ := orcField(attr, "key")
+		val, _ := orcField(attr, "value")
+		typ.Attributes[key.String()] = val.String()
+	}
+
+	return typ
+}
+
+func parseORCMetadata(b []byte) ([][]ORCColumnStats, error) {
+	msg, err := unmarshalORC("Metadata", b)
+	if err != nil {
+		return nil, err
+	}
+
+	var result [][]ORCColumnStats
+	for _, stripe := range orcMessages(msg, "stripeStats") {
+		var stats []ORCColumnStats
+		for _, col := range orcMessages(stripe, "colStats") {
+			s, err := parseORCColumnStats(col)
+			if err != nil {
+				return nil, err
+			}
+			stats = append(stats, s)
+		}
+		result = append(result, stats)
+	}
+
+	return result, nil
+}
+
+func parseORCColumnStats(msg protoreflect.Message) (ORCColumnStats, error) {
+	stats := ORCColumnStats{NumValues: orcUint(msg, "numberOfValues")}
+	if v, ok := orcField(msg, "hasNull"); ok {
+		stats.HasNull = v.Bool()
+	}
+
+	if v, ok := orcField(msg, "intStatistics"); ok {
+		stats.Min, stats.Max = orcBounds(v.Message(), protoreflect.Value.Int)
+	}
+
+	if v, ok := orcField(msg, "doubleStatistics"); ok {
+		stats.Min, stats.Max = orcBounds(v.Message(), protoreflect.Value.Float)
+	}
+
+	if v, ok := orcField(msg, "stringStatistics"); ok {
+		parseORCStringStats(&stats, v.Message())
+	}
+
+	if v, ok := orcField(msg, "decimalStatistics"); ok {
+		if err := parseORCDecimalStats(&stats, v.Message()); err != nil {
+			return stats, err
+		}
+	}
+
+	// the bounds of date columns are days from the unix epoch
+	if v, ok := orcField(msg, "dateStatistics"); ok {
+		stats.Min, stats.Max = orcBounds(v.Message(), protoreflect.Value.Int)
+	}
+
+	if v, ok := orcField(msg, "timestampStatistics"); ok {
+		parseORCTimestampStats(&stats, v.Message())
+	}
+
+	return stats, nil
+}
+
+// orcBounds returns the minimum and maximum fields of msg converted with
+// fn, or nil for the fields which aren't set.
+func orcBounds[T any](msg protoreflect.Message, fn func(protoreflect.Value) T) (lower, upper any) {
+	if v, ok := orcField(msg, "minimum"); ok {
+		lower = fn(v)
+	}
+
+	if v, ok := orcField(msg, "maximum"); ok {
+		upper = fn(v)
+	}
+
+	return lower, upper
+}
+
+// parseORCStringStats uses the exact minimum and maximum if they were
+// written, or else the truncated lower and upper bounds which writers
+// store for long values instead.
+func parseORCStringStats(stats *ORCColumnStats, msg protoreflect.Message) {
+	stats.Min, stats.Max = orcBounds(msg, protoreflect.Value.String)
+
+	if v, ok := orcField(msg, "lowerBound"); ok && stats.Min == nil {
+		stats.Min = v.String()
+	}
+
+	if v, ok := orcField(msg, "upperBound"); ok && stats.Max == nil {
+		stats.Max = v.String()
+	}
+}
+
+func parseORCDecimalStats(stats *ORCColumnStats, msg protoreflect.Message) (err error) {
+	lower, upper := orcBounds(msg, protoreflect.Value.String)
+	if stats.Min, err = orcDecimal(lower); err != nil {
+		return err
+	}
+
+	stats.Max, err = orcDecimal(upper)
+
+	return err
+}
+
+// orcDecimal parses the decimal statistics value v, if it is set.
+func orcDecimal(v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	r, ok := new(big.Rat).SetString(v.(string))
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid orc decimal statistics value %q",
+			iceberg.ErrInvalidArgument, v)
+	}
+
+	return r, nil
+}
+
+// parseORCTimestampStats reads the UTC bounds of a timestamp column in
+// microseconds. The bounds are stored as milliseconds since the epoch
+// (minimumUtc and maximumUtc), which are rounded down, and the writer adds
+// the nanoseconds within that millisecond offset by one (minimumNanos and
+// maximumNanos), so that 0 means unset. An unset minimum is at the start
+// of its millisecond, and an unset maximum at its end, i.e. 999999
+// nanoseconds, as read by the Java ORC reader.
+func parseORCTimestampStats(stats *ORCColumnStats, msg protoreflect.Message) {
+	minNanos, maxNanos := int64(0), int64(999999)
+	if v, ok := orcField(msg, "minimumNanos"); ok && v.Int() > 0 {
+		minNanos = v.Int() - 1
+	}
+
+	if v, ok := orcField(msg, "maximumNanos"); ok && v.Int() > 0 {
+		maxNanos = v.Int() - 1
+	}
+
+	// the milliseconds are rounded down, also before the epoch, so the
+	// nanoseconds are always added
+	if v, ok := orcField(msg, "minimumUtc"); ok {
+		stats.Min = v.Int()*1000 + minNanos/1000
+	}
+
+	if v, ok := orcField(msg, "maximumUtc"); ok {
+		stats.Max = v.Int()*1000 + maxNanos/1000
+	}
+}
+
+// merge combines the statistics of a column from two stripes.
+func (s ORCColumnStats) merge(other ORCColumnStats) ORCColumnStats {
+	s.NumValues += other.NumValues
+	s.HasNull = s.HasNull || other.HasNull
+
+	// a stripe without bounds, e.g. with only nulls, doesn't narrow
+	// the bounds of the other one
+	if s.Min == nil || (other.Min != nil && compareORCBound(other.Min, s.Min) < 0) {
+		s.Min = other.Min
+	}
+	if s.Max == nil || (other.Max != nil && compareORCBound(other.Max, s.Max) > 0) {
+		s.Max = other.Max
+	}
+
+	return s
+}
+
+func compareORCBound(a, b any) int {
+	switch a := a.(type) {
+	case int64:
+		return cmp.Compare(a, b.(int64))
+	case float64:
+		return cmp.Compare(a, b.(float64))
+	case string:
+		return strings.Compare(a, b.(string))
+	case *big.Rat:
+		return a.Cmp(b.(*big.Rat))
+	}
+
+	panic(errors.New("unsupported orc bound type"))
+}
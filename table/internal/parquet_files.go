@@ -25,6 +25,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"unsafe"
 
 	"github.com/apache/arrow-go/v18/arrow"
@@ -71,7 +72,8 @@ func (parquetFormat) Open(ctx context.Context, fs iceio.IO, path string) (FileRe
 		return nil, err
 	}
 
-	rdr, err := file.NewParquetReader(inputfile)
+	src := &prefetchFile{File: inputfile}
+	rdr, err := file.NewParquetReader(src)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +84,7 @@ func (parquetFormat) Open(ctx context.Context, fs iceio.IO, path string) (FileRe
 		return nil, err
 	}
 
-	return wrapPqArrowReader{arrRdr}, nil
+	return wrapPqArrowReader{arrRdr, src}, nil
 }
 
 func (parquetFormat) PathToIDMapping(sc *iceberg.Schema) (map[string]int, error) {
@@ -192,6 +194,15 @@ func (parquetFormat) PrimitiveTypeToPhysicalType(typ iceberg.PrimitiveType) stri
 	}
 }
 
+// parquetWriteProps are the write properties of the parquet format, as
+// returned by GetWriteProperties.
+type parquetWriteProps struct {
+	writerProps []parquet.WriterProperty
+	// rowGroupSize is the target size in bytes of each row group, a new
+	// row group is started once the current one reaches it.
+	rowGroupSize int64
+}
+
 func (parquetFormat) GetWriteProperties(props iceberg.Properties) any {
 	writerProps := []parquet.WriterProperty{
 		parquet.WithDictionaryDefault(false),
@@ -232,49 +243,141 @@ func (parquetFormat) GetWriteProperties(props iceberg.Properties) any {
 		// warn
 	}
 
-	return append(writerProps, parquet.WithCompression(codec),
-		parquet.WithCompressionLevel(compressionLevel))
+	return parquetWriteProps{
+		writerProps: append(writerProps, parquet.WithCompression(codec),
+			parquet.WithCompressionLevel(compressionLevel)),
+		rowGroupSize: int64(props.GetInt(ParquetRowGroupSizeBytesKey,
+			ParquetRowGroupSizeBytesDefault)),
+	}
 }
 
 func (p parquetFormat) WriteDataFile(ctx context.Context, fs iceio.WriteFileIO, info WriteFileInfo, batches []arrow.Record) (iceberg.DataFile, error) {
+	w, err := p.NewDataFileWriter(ctx, fs, info, batches[0].Schema())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, batch := range batches {
+		if err := w.Write(batch); err != nil {
+			w.Abort()
+
+			return nil, err
+		}
+	}
+
+	return w.Close()
+}
+
+func (p parquetFormat) NewDataFileWriter(ctx context.Context, fs iceio.WriteFileIO, info WriteFileInfo, sc *arrow.Schema) (DataFileWriter, error) {
+	props, ok := info.WriteProps.(parquetWriteProps)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected parquet write properties, got %T",
+			iceberg.ErrInvalidArgument, info.WriteProps)
+	}
+
+	colMapping, err := p.PathToIDMapping(info.FileSchema)
+	if err != nil {
+		return nil, err
+	}
+
 	fw, err := fs.Create(info.FileName)
 	if err != nil {
 		return nil, err
 	}
-	defer fw.Close()
 
-	cntWriter := internal.CountingWriter{W: fw}
+	out := &parquetDataFileWriter{
+		format:       p,
+		info:         info,
+		out:          fw,
+		cnt:          &internal.CountingWriter{W: fw},
+		colMapping:   colMapping,
+		rowGroupSize: props.rowGroupSize,
+	}
+
 	mem := compute.GetAllocator(ctx)
-	writerProps := parquet.NewWriterProperties(info.WriteProps.([]parquet.WriterProperty)...)
+	writerProps := parquet.NewWriterProperties(props.writerProps...)
 	arrProps := pqarrow.NewArrowWriterProperties(pqarrow.WithAllocator(mem), pqarrow.WithStoreSchema())
 
-	writer, err := pqarrow.NewFileWriter(batches[0].Schema(), &cntWriter, writerProps, arrProps)
+	out.wr, err = pqarrow.NewFileWriter(sc, out.cnt, writerProps, arrProps)
 	if err != nil {
+		iceio.AbortWrite(fw)
+
 		return nil, err
 	}
+	out.chunkSize = max(writerProps.WriteBatchSize(), 1)
 
-	for _, batch := range batches {
-		if err := writer.WriteBuffered(batch); err != nil {
-			return nil, err
+	return out, nil
+}
+
+type parquetDataFileWriter struct {
+	format     parquetFormat
+	info       WriteFileInfo
+	out        iceio.FileWriter
+	cnt        *internal.CountingWriter
+	wr         *pqarrow.FileWriter
+	colMapping map[string]int
+
+	rowGroupSize int64
+	chunkSize    int64
+}
+
+// Write buffers the batch into the current row group, in chunks of at
+// most the page row limit so that a row group is cut close to the
+// configured row group size even for large batches.
+func (w *parquetDataFileWriter) Write(batch arrow.Record) error {
+	for offset := int64(0); offset < batch.NumRows(); offset += w.chunkSize {
+		chunk := batch.NewSlice(offset, min(offset+w.chunkSize, batch.NumRows()))
+		err := w.wr.WriteBuffered(chunk)
+		chunk.Release()
+		if err != nil {
+			return err
+		}
+
+		if w.rowGroupSize > 0 && w.wr.RowGroupTotalBytesWritten() >= w.rowGroupSize {
+			w.wr.NewBufferedRowGroup()
 		}
 	}
 
-	if err := writer.Close(); err != nil {
+	return nil
+}
+
+func (w *parquetDataFileWriter) BytesWritten() int64 {
+	return w.cnt.Count + w.wr.RowGroupTotalBytesWritten()
+}
+
+func (w *parquetDataFileWriter) Close() (iceberg.DataFile, error) {
+	if err := w.wr.Close(); err != nil {
+		iceio.AbortWrite(w.out)
+
 		return nil, err
 	}
 
-	filemeta, err := writer.FileMetadata()
+	filemeta, err := w.wr.FileMetadata()
 	if err != nil {
+		iceio.AbortWrite(w.out)
+
 		return nil, err
 	}
 
-	colMapping, err := p.PathToIDMapping(info.FileSchema)
-	if err != nil {
+	// the file is only published once it is closed
+	if err := w.out.Close(); err != nil {
 		return nil, err
 	}
 
-	return p.DataFileStatsFromMeta(filemeta, info.StatsCols, colMapping).
-		ToDataFile(info.FileSchema, info.Spec, info.FileName, iceberg.ParquetFile, cntWriter.Count), nil
+	stats := w.format.DataFileStatsFromMeta(filemeta, w.info.StatsCols, w.colMapping)
+	if w.info.PartitionData != nil {
+		return stats.ToPartitionedDataFile(w.info.Spec, w.info.PartitionData,
+			w.info.FileName, iceberg.ParquetFile, w.cnt.Count)
+	}
+
+	return stats.ToDataFile(w.info.FileSchema, w.info.Spec, w.info.FileName, iceberg.ParquetFile, w.cnt.Count), nil
+}
+
+// Abort abandons the file, so that it isn't published, before closing
+// the Parquet writer to release its buffers.
+func (w *parquetDataFileWriter) Abort() {
+	iceio.AbortWrite(w.out)
+	w.wr.Close()
 }
 
 type decAsIntAgg[T int32 | int64] struct {
@@ -414,20 +517,14 @@ func (p parquetFormat) DataFileStatsFromMeta(meta Metadata, statsCols map[int]St
 	for rg := range pqmeta.NumRowGroups() {
 		// reference: https://github.com/apache/iceberg-python/blob/main/pyiceberg/io/pyarrow.py#L2285
 		rowGroup := pqmeta.RowGroup(rg)
-		colChunk, err := rowGroup.ColumnChunk(0)
+		offset, err := RowGroupOffset(rowGroup)
 		if err != nil {
 			panic(err)
 		}
-
-		dataOffset, dictOffset := colChunk.DataPageOffset(), colChunk.DictionaryPageOffset()
-		if colChunk.HasDictionaryPage() && dictOffset < dataOffset {
-			splitOffsets = append(splitOffsets, dictOffset)
-		} else {
-			splitOffsets = append(splitOffsets, dataOffset)
-		}
+		splitOffsets = append(splitOffsets, offset)
 
 		for pos := range rowGroup.NumColumns() {
-			colChunk, err = rowGroup.ColumnChunk(pos)
+			colChunk, err := rowGroup.ColumnChunk(pos)
 			if err != nil {
 				panic(err)
 			}
@@ -517,6 +614,198 @@ func (p parquetFormat) DataFileStatsFromMeta(meta Metadata, statsCols map[int]St
 	}
 }
 
+// RowGroupOffset returns the offset of the first page of a row group,
+// which is the split offset recorded for it in the data file.
+func RowGroupOffset(rowGroup *metadata.RowGroupMetaData) (int64, error) {
+	colChunk, err := rowGroup.ColumnChunk(0)
+	if err != nil {
+		return 0, err
+	}
+
+	return columnChunkOffset(colChunk), nil
+}
+
+// columnChunkOffset returns the offset of the first page of a column
+// chunk, which is its dictionary page if it has one.
+func columnChunkOffset(colChunk *metadata.ColumnChunkMetaData) int64 {
+	dataOffset, dictOffset := colChunk.DataPageOffset(), colChunk.DictionaryPageOffset()
+	if colChunk.HasDictionaryPage() && dictOffset > 0 && dictOffset < dataOffset {
+		return dictOffset
+	}
+
+	return dataOffset
+}
+
+// prefetchFile reads all the column chunks read from a row group with as
+// few reads as possible, see [iceio.ReadRanges], when the first of them
+// is read, instead of with a read per column chunk. On object stores,
+// where every read is a ranged GET request, this saves a request per
+// column for each row group. A fetched chunk is dropped once its last
+// byte has been read, and reads outside of the column chunks being read,
+// such as of the footer, are passed through.
+type prefetchFile struct {
+	iceio.File
+
+	// mx guards rowGroups and the chunks of the row groups, it isn't
+	// held while fetching them
+	mx        sync.Mutex
+	rowGroups []*prefetchRowGroup
+}
+
+// prefetchRowGroup holds the column chunks which are read from a row
+// group, which are fetched together the first time one of them is read.
+type prefetchRowGroup struct {
+	ranges []iceio.ByteRange
+
+	fetch sync.Once
+	err   error
+
+	fetched bool
+	chunks  []fetchedChunk
+}
+
+type fetchedChunk struct {
+	iceio.ByteRange
+
+	data []byte
+}
+
+func (c fetchedChunk) contains(p []byte, off int64) bool {
+	return off >= c.Offset && off+int64(len(p)) <= c.Offset+c.Length
+}
+
+// setColumnChunks sets the column chunks which are read from the given
+// row groups, or from all of them if rowGroups is nil.
+func (f *prefetchFile) setColumnChunks(meta *metadata.FileMetaData, rowGroups, cols []int) error {
+	if rowGroups == nil {
+		rowGroups = make([]int, meta.NumRowGroups())
+		for i := range rowGroups {
+			rowGroups[i] = i
+		}
+	}
+
+	prefetch := make([]*prefetchRowGroup, len(rowGroups))
+	for i, rg := range rowGroups {
+		rgMeta := meta.RowGroup(rg)
+
+		rgCols := cols
+		if rgCols == nil {
+			rgCols = make([]int, rgMeta.NumColumns())
+			for c := range rgCols {
+				rgCols[c] = c
+			}
+		}
+
+		prefetch[i] = &prefetchRowGroup{ranges: make([]iceio.ByteRange, 0, len(rgCols))}
+		for _, c := range rgCols {
+			colChunk, err := rgMeta.ColumnChunk(c)
+			if err != nil {
+				return err
+			}
+
+			prefetch[i].ranges = append(prefetch[i].ranges, iceio.ByteRange{
+				Offset: columnChunkOffset(colChunk),
+				Length: colChunk.TotalCompressedSize(),
+			})
+		}
+	}
+
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	f.rowGroups = prefetch
+
+	return nil
+}
+
+func (f *prefetchFile) ReadAt(p []byte, off int64) (int, error) {
+	f.mx.Lock()
+	n, ok := f.readFetched(p, off)
+	rg := f.rowGroupToFetch(p, off)
+	f.mx.Unlock()
+
+	if ok {
+		return n, nil
+	}
+
+	if rg != nil {
+		// concurrent readers of the row group wait for the first of them
+		// to fetch its chunks
+		rg.fetch.Do(func() {
+			var data [][]byte
+			data, rg.err = iceio.ReadRanges(f.File, rg.ranges, iceio.DefaultMaxRangeGap)
+			if rg.err != nil {
+				return
+			}
+
+			f.mx.Lock()
+			defer f.mx.Unlock()
+			for i, r := range rg.ranges {
+				rg.chunks = append(rg.chunks, fetchedChunk{ByteRange: r, data: data[i]})
+			}
+			rg.fetched = true
+		})
+
+		if rg.err != nil {
+			return 0, rg.err
+		}
+
+		f.mx.Lock()
+		n, ok = f.readFetched(p, off)
+		f.mx.Unlock()
+
+		if ok {
+			return n, nil
+		}
+	}
+
+	// the chunk was already read whole
+	return f.File.ReadAt(p, off)
+}
+
+// rowGroupToFetch returns the row group which wasn't fetched yet with a
+// column chunk containing the bytes at off, if any.
+func (f *prefetchFile) rowGroupToFetch(p []byte, off int64) *prefetchRowGroup {
+	for _, rg := range f.rowGroups {
+		if rg.fetched {
+			continue
+		}
+
+		if slices.ContainsFunc(rg.ranges, func(r iceio.ByteRange) bool {
+			return fetchedChunk{ByteRange: r}.contains(p, off)
+		}) {
+			return rg
+		}
+	}
+
+	return nil
+}
+
+// readFetched copies the bytes at off from the fetched column chunks to
+// p. As column chunks are read sequentially and only once, a chunk is
+// dropped once its last byte has been read, as is a row group once all of
+// its chunks have been.
+func (f *prefetchFile) readFetched(p []byte, off int64) (int, bool) {
+	for i, rg := range f.rowGroups {
+		for j, c := range rg.chunks {
+			if !c.contains(p, off) {
+				continue
+			}
+
+			n := copy(p, c.data[off-c.Offset:])
+			if off+int64(n) == c.Offset+c.Length {
+				rg.chunks = slices.Delete(rg.chunks, j, j+1)
+				if len(rg.chunks) == 0 {
+					f.rowGroups = slices.Delete(f.rowGroups, i, i+1)
+				}
+			}
+
+			return n, true
+		}
+	}
+
+	return 0, false
+}
+
 type ParquetFileSource struct {
 	mem  memory.Allocator
 	fs   iceio.IO
@@ -525,6 +814,8 @@ type ParquetFileSource struct {
 
 type wrapPqArrowReader struct {
 	*pqarrow.FileReader
+
+	src *prefetchFile
 }
 
 func (w wrapPqArrowReader) Metadata() Metadata {
@@ -573,7 +864,195 @@ func (w wrapPqArrowReader) GetRecords(ctx context.Context, cols []int, tester an
 		}
 	}
 
-	return w.GetRecordReader(ctx, cols, rgList)
+	if cols == nil {
+		if err := w.src.setColumnChunks(w.ParquetReader().MetaData(), rgList, nil); err != nil {
+			return nil, err
+		}
+
+		return w.GetRecordReader(ctx, nil, rgList)
+	}
+
+	// pqarrow can't read only some of the fields of a struct, so structs
+	// are read whole and pruned to the selected fields after the read
+	selected := make(map[int]struct{}, len(cols))
+	for _, c := range cols {
+		selected[c] = struct{}{}
+	}
+
+	var (
+		readCols []int
+		fields   []arrow.Field
+	)
+	for _, f := range w.Manifest.Fields {
+		if pruned, ok := prunedReadField(f, selected); ok {
+			fields = append(fields, pruned)
+			readCols = appendReadLeaves(readCols, f, selected, false)
+		}
+	}
+
+	if err := w.src.setColumnChunks(w.ParquetReader().MetaData(), rgList, readCols); err != nil {
+		return nil, err
+	}
+
+	rdr, err := w.GetRecordReader(ctx, readCols, rgList)
+	if err != nil || len(readCols) == len(selected) {
+		return rdr, err
+	}
+
+	md := rdr.Schema().Metadata()
+
+	return &projectedRecordReader{
+		RecordReader: rdr,
+		schema:       arrow.NewSchema(fields, &md),
+	}, nil
+}
+
+// hasSelectedLeaf reports whether any of the leaves of field is selected.
+func hasSelectedLeaf(field pqarrow.SchemaField, selected map[int]struct{}) bool {
+	if field.IsLeaf() {
+		_, ok := selected[field.ColIndex]
+
+		return ok
+	}
+
+	return slices.ContainsFunc(field.Children, func(c pqarrow.SchemaField) bool {
+		return hasSelectedLeaf(c, selected)
+	})
+}
+
+// appendReadLeaves appends the leaves of field which are read to read
+// the selected ones, which are all the leaves of the structs and maps
+// with a selected leaf.
+func appendReadLeaves(out []int, field pqarrow.SchemaField, selected map[int]struct{}, whole bool) []int {
+	if field.IsLeaf() {
+		if _, ok := selected[field.ColIndex]; ok || whole {
+			out = append(out, field.ColIndex)
+		}
+
+		return out
+	}
+
+	switch field.Field.Type.ID() {
+	case arrow.STRUCT, arrow.MAP:
+		whole = whole || hasSelectedLeaf(field, selected)
+	}
+
+	for _, c := range field.Children {
+		out = appendReadLeaves(out, c, selected, whole)
+	}
+
+	return out
+}
+
+// prunedReadField returns field with only the struct fields which have a
+// selected leaf, and false if it has none.
+func prunedReadField(field pqarrow.SchemaField, selected map[int]struct{}) (arrow.Field, bool) {
+	if !hasSelectedLeaf(field, selected) {
+		return arrow.Field{}, false
+	}
+
+	result := *field.Field
+	switch t := field.Field.Type.(type) {
+	case *arrow.StructType:
+		children := make([]arrow.Field, 0, len(field.Children))
+		for _, c := range field.Children {
+			if pruned, ok := prunedReadField(c, selected); ok {
+				children = append(children, pruned)
+			}
+		}
+		result.Type = arrow.StructOf(children...)
+	case *arrow.MapType:
+		value, _ := prunedReadField(field.Children[0].Children[1], selected)
+		if value.Type != nil {
+			result.Type = (&pruneParquetSchema{}).projectMap(t, value.Type)
+		}
+	case arrow.ListLikeType:
+		elem, _ := prunedReadField(field.Children[0], selected)
+		result.Type = (&pruneParquetSchema{}).projectList(t, elem.Type)
+	}
+
+	if arrow.TypeEqual(result.Type, field.Field.Type) {
+		return *field.Field, true
+	}
+
+	return result, true
+}
+
+// projectedRecordReader prunes the structs of the records it reads down
+// to the fields of its schema.
+type projectedRecordReader struct {
+	array.RecordReader
+
+	schema *arrow.Schema
+	rec    arrow.Record
+}
+
+func (r *projectedRecordReader) Schema() *arrow.Schema { return r.schema }
+func (r *projectedRecordReader) Record() arrow.Record  { return r.rec }
+
+func (r *projectedRecordReader) Next() bool {
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+
+	if !r.RecordReader.Next() {
+		return false
+	}
+
+	rec := r.RecordReader.Record()
+	cols := make([]arrow.Array, len(r.schema.Fields()))
+	for i, f := range r.schema.Fields() {
+		data := projectArrayData(rec.Column(i).Data(), f.Type)
+		cols[i] = array.MakeFromData(data)
+		data.Release()
+	}
+	r.rec = array.NewRecord(r.schema, cols, rec.NumRows())
+
+	for _, c := range cols {
+		c.Release()
+	}
+
+	return true
+}
+
+func (r *projectedRecordReader) Release() {
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+
+	r.RecordReader.Release()
+}
+
+// projectArrayData returns data with only the struct fields of typ, which
+// is the type of data with some of the fields of its structs removed.
+func projectArrayData(data arrow.ArrayData, typ arrow.DataType) arrow.ArrayData {
+	if arrow.TypeEqual(data.DataType(), typ) {
+		data.Retain()
+
+		return data
+	}
+
+	var children []arrow.ArrayData
+	switch t := typ.(type) {
+	case *arrow.StructType:
+		src := data.DataType().(*arrow.StructType)
+		for _, f := range t.Fields() {
+			idx, _ := src.FieldIdx(f.Name)
+			children = append(children, projectArrayData(data.Children()[idx], f.Type))
+		}
+	case arrow.ListLikeType:
+		children = append(children, projectArrayData(data.Children()[0], t.Elem()))
+	}
+
+	defer func() {
+		for _, c := range children {
+			c.Release()
+		}
+	}()
+
+	return array.NewData(typ, data.Len(), data.Buffers(), children, data.NullN(), data.Offset())
 }
 
 func (pfs *ParquetFileSource) GetReader(ctx context.Context) (FileReader, error) {
@@ -582,7 +1061,8 @@ func (pfs *ParquetFileSource) GetReader(ctx context.Context) (FileReader, error)
 		return nil, err
 	}
 
-	rdr, err := file.NewParquetReader(pf,
+	src := &prefetchFile{File: pf}
+	rdr, err := file.NewParquetReader(src,
 		file.WithReadProps(parquet.NewReaderProperties(pfs.mem)))
 	if err != nil {
 		return nil, err
@@ -604,7 +1084,7 @@ func (pfs *ParquetFileSource) GetReader(ctx context.Context) (FileReader, error)
 		return nil, err
 	}
 
-	return wrapPqArrowReader{fr}, nil
+	return wrapPqArrowReader{fr, src}, nil
 }
 
 type manifestVisitor[T any] interface {
@@ -720,8 +1200,9 @@ func getFieldID(f arrow.Field) *int {
 		return nil
 	}
 
+	// pqarrow sets a field_id of -1 for files written without field ids
 	id, err := strconv.Atoi(fieldIDStr)
-	if err != nil {
+	if err != nil || id < 0 {
 		return nil
 	}
 
@@ -736,17 +1217,17 @@ type pruneParquetSchema struct {
 	indices []int
 }
 
+// fieldID returns the field id stored in the file for the field, the name
+// mapping is only used to resolve the ids of files written without them.
 func (p *pruneParquetSchema) fieldID(field arrow.Field, mapping *iceberg.MappedField) int {
-	if mapping != nil {
-		if mapping.FieldID != nil {
-			return *mapping.FieldID
-		}
-	}
-
 	if id := getFieldID(field); id != nil {
 		return *id
 	}
 
+	if mapping != nil && mapping.FieldID != nil {
+		return *mapping.FieldID
+	}
+
 	panic(fmt.Errorf("%w: cannot convert %s to Iceberg field, missing field_id",
 		iceberg.ErrInvalidSchema, field))
 }
@@ -771,12 +1252,12 @@ func (p *pruneParquetSchema) Struct(field pqarrow.SchemaField, children []arrow.
 		field := fields[i]
 		if arrow.TypeEqual(field.Field.Type, t.Type) {
 			selected = append(selected, *field.Field)
-		} else if t.Type == nil {
+		} else if t.Type != nil {
 			sameType = false
 			// type has changed, create a new field with the projected type
 			selected = append(selected, arrow.Field{
 				Name:     field.Field.Name,
-				Type:     field.Field.Type,
+				Type:     t.Type,
 				Nullable: field.Field.Nullable,
 				Metadata: field.Field.Metadata,
 			})
@@ -19,11 +19,17 @@ package internal_test
 
 import (
 	"bytes"
+	"context"
+	"io"
 	"math/big"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
 	"github.com/apache/arrow-go/v18/arrow/decimal128"
 	"github.com/apache/arrow-go/v18/arrow/memory"
@@ -32,6 +38,7 @@ import (
 	"github.com/apache/arrow-go/v18/parquet/metadata"
 	"github.com/apache/arrow-go/v18/parquet/pqarrow"
 	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
 	"github.com/apache/iceberg-go/table"
 	"github.com/apache/iceberg-go/table/internal"
 	"github.com/google/uuid"
@@ -323,3 +330,193 @@ func TestMetricsPrimitiveTypes(t *testing.T) {
 		Scale: 2,
 	})
 }
+
+func TestReadParquetWithNameMapping(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	// imported files don't have field ids in their schema
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "record_id", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "location", Type: arrow.StructOf(
+			arrow.Field{Name: "lat", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+			arrow.Field{Name: "long", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		), Nullable: true},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+
+	rec, _, err := array.RecordFromJSON(mem, arrSchema, strings.NewReader(`[
+		{"record_id": 1, "location": {"lat": 52.37, "long": 4.89}, "name": "amsterdam"},
+		{"record_id": 2, "location": {"lat": 48.85, "long": 2.35}, "name": "paris"}
+	]`))
+	require.NoError(t, err)
+	defer rec.Release()
+
+	path := filepath.Join(t.TempDir(), "no-ids.parquet")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	tbl := array.NewTableFromRecords(arrSchema, []arrow.Record{rec})
+	defer tbl.Release()
+	// hide Close from the writer, which would otherwise close f
+	require.NoError(t, pqarrow.WriteTable(tbl, struct{ io.Writer }{f}, tbl.NumRows(), nil, pqarrow.DefaultWriterProps()))
+	require.NoError(t, f.Close())
+
+	mapping, err := iceberg.ParseNameMapping(`[
+		{"field-id": 1, "names": ["id", "record_id"]},
+		{"field-id": 2, "names": ["location"], "fields": [
+			{"field-id": 3, "names": ["latitude", "lat"]},
+			{"field-id": 4, "names": ["longitude", "long"]}
+		]},
+		{"field-id": 5, "names": ["name"]}
+	]`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	rdr, err := internal.GetFileFormat(iceberg.ParquetFile).Open(ctx, iceio.LocalFS{}, path)
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	// project id and location.long, which can only be found by name
+	pruned, cols, err := rdr.PrunedSchema(map[int]struct{}{1: {}, 4: {}}, mapping)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 2}, cols)
+
+	fileSchema, err := table.ArrowSchemaToIceberg(pruned, false, mapping)
+	require.NoError(t, err)
+	assert.True(t, fileSchema.Equals(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "record_id", Type: iceberg.PrimitiveTypes.Int64},
+		iceberg.NestedField{ID: 2, Name: "location", Type: &iceberg.StructType{
+			FieldList: []iceberg.NestedField{
+				{ID: 4, Name: "long", Type: iceberg.PrimitiveTypes.Float64},
+			},
+		}},
+	)), fileSchema.String())
+
+	_, err = table.ArrowSchemaToIceberg(pruned, false, nil)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+
+	// the location struct is read whole and pruned to long after the read
+	records, err := rdr.GetRecords(ctx, cols, nil)
+	require.NoError(t, err)
+	defer records.Release()
+	assert.True(t, pruned.Equal(records.Schema()), records.Schema().String())
+
+	require.True(t, records.Next())
+	out := records.Record()
+	require.EqualValues(t, 2, out.NumRows())
+	assert.True(t, pruned.Equal(out.Schema()), out.Schema().String())
+	assert.Equal(t, []int64{1, 2}, out.Column(0).(*array.Int64).Int64Values())
+	long := out.Column(1).(*array.Struct).Field(0).(*array.Float64)
+	assert.Equal(t, []float64{4.89, 2.35}, long.Float64Values())
+	assert.False(t, records.Next())
+}
+
+// countingIO records the reads of the files opened through it.
+type countingIO struct {
+	iceio.LocalFS
+
+	mx    sync.Mutex
+	reads []iceio.ByteRange
+}
+
+type countingFile struct {
+	iceio.File
+
+	io *countingIO
+}
+
+func (c *countingIO) Open(name string) (iceio.File, error) {
+	f, err := c.LocalFS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return countingFile{File: f, io: c}, nil
+}
+
+func (f countingFile) ReadAt(p []byte, off int64) (int, error) {
+	f.io.mx.Lock()
+	f.io.reads = append(f.io.reads, iceio.ByteRange{Offset: off, Length: int64(len(p))})
+	f.io.mx.Unlock()
+
+	return f.File.ReadAt(p, off)
+}
+
+func TestReadParquetPrefetchesRowGroups(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "b", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "c", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+
+	rec, _, err := array.RecordFromJSON(mem, arrSchema, strings.NewReader(`[
+		{"a": 1, "b": 10, "c": 100}, {"a": 2, "b": 20, "c": 200},
+		{"a": 3, "b": 30, "c": 300}, {"a": 4, "b": 40, "c": 400},
+		{"a": 5, "b": 50, "c": 500}, {"a": 6, "b": 60, "c": 600}
+	]`))
+	require.NoError(t, err)
+	defer rec.Release()
+
+	path := filepath.Join(t.TempDir(), "row-groups.parquet")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	tbl := array.NewTableFromRecords(arrSchema, []arrow.Record{rec})
+	defer tbl.Release()
+	// three row groups of two rows
+	require.NoError(t, pqarrow.WriteTable(tbl, struct{ io.Writer }{f}, 2, nil, pqarrow.DefaultWriterProps()))
+	require.NoError(t, f.Close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	dataFile, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentData,
+		path, iceberg.ParquetFile, nil, 6, info.Size())
+	require.NoError(t, err)
+
+	open := map[string]func(fs iceio.IO) (internal.FileReader, error){
+		"serial": func(fs iceio.IO) (internal.FileReader, error) {
+			return internal.GetFileFormat(iceberg.ParquetFile).Open(context.Background(), fs, path)
+		},
+		// the columns are read concurrently, so are the chunks of a row group
+		"parallel": func(fs iceio.IO) (internal.FileReader, error) {
+			src, err := internal.GetFile(context.Background(), fs, dataFile.Build(), false)
+			if err != nil {
+				return nil, err
+			}
+
+			return src.GetReader(context.Background())
+		},
+	}
+
+	for name, cols := range map[string][]int{"all": nil, "projected": {0, 2}} {
+		for mode, open := range open {
+			t.Run(name+"/"+mode, func(t *testing.T) {
+				fs := &countingIO{}
+				rdr, err := open(fs)
+				require.NoError(t, err)
+
+				// the footer is read when opening the file
+				fs.reads = nil
+				records, err := rdr.GetRecords(context.Background(), cols, nil)
+				require.NoError(t, err)
+
+				var a, c []int64
+				for records.Next() {
+					out := records.Record()
+					a = append(a, out.Column(0).(*array.Int64).Int64Values()...)
+					c = append(c, out.Column(int(out.NumCols())-1).(*array.Int64).Int64Values()...)
+				}
+				require.NoError(t, records.Err())
+				records.Release()
+				require.NoError(t, rdr.Close())
+
+				assert.Equal(t, []int64{1, 2, 3, 4, 5, 6}, a)
+				assert.Equal(t, []int64{100, 200, 300, 400, 500, 600}, c)
+				// the chunks read from each row group are fetched with one read
+				assert.Len(t, fs.reads, 3, "%v", fs.reads)
+			})
+		}
+	}
+}
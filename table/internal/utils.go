@@ -18,17 +18,14 @@
 package internal
 
 import (
-	"bytes"
 	"container/heap"
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"iter"
 	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
-	"unicode/utf8"
 	_ "unsafe"
 
 	"github.com/apache/arrow-go/v18/arrow/decimal"
@@ -246,10 +243,16 @@ func (d *DataFileStatistics) ToDataFile(schema *iceberg.Schema, spec iceberg.Par
 		}
 	}
 
+	return must(d.ToPartitionedDataFile(spec, fieldIDToPartitionData, path, format, filesize))
+}
+
+// ToPartitionedDataFile is like ToDataFile, with the partition values of
+// the file given rather than inferred from the column bounds.
+func (d *DataFileStatistics) ToPartitionedDataFile(spec iceberg.PartitionSpec, partition map[int]any, path string, format iceberg.FileFormat, filesize int64) (iceberg.DataFile, error) {
 	bldr, err := iceberg.NewDataFileBuilder(spec, iceberg.EntryContentData,
-		path, format, fieldIDToPartitionData, d.RecordCount, filesize)
+		path, format, partition, d.RecordCount, filesize)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	lowerBounds := make(map[int][]byte)
@@ -279,7 +282,7 @@ func (d *DataFileStatistics) ToDataFile(schema *iceberg.Schema, spec iceberg.Par
 	bldr.NaNValueCounts(d.NanValueCounts)
 	bldr.SplitOffsets(d.SplitOffsets)
 
-	return bldr.Build()
+	return bldr.Build(), nil
 }
 
 type MetricModeType string
@@ -405,8 +408,7 @@ func (s *statsAggregator[T]) MinAsBytes() ([]byte, error) {
 	}
 
 	if s.truncLen > 0 {
-		return s.toBytes((&iceberg.TruncateTransform{Width: s.truncLen}).
-			Apply(iceberg.Optional[iceberg.Literal]{Valid: true, Val: s.curMin}).Val)
+		return s.toBytes(iceberg.TruncateLowerBound(s.curMin, s.truncLen))
 	}
 
 	return s.toBytes(s.curMin)
@@ -422,72 +424,44 @@ func (s *statsAggregator[T]) MaxAsBytes() ([]byte, error) {
 	}
 
 	switch s.primitiveType.(type) {
-	case iceberg.StringType:
-		if !s.curMax.Type().Equals(s.primitiveType) {
-			return nil, errors.New("expected current max to be a string")
-		}
-
-		curMax := any(s.curMax.Value()).(string)
-		result := TruncateUpperBoundText(curMax, s.truncLen)
-		if result != "" {
-			return s.toBytes(iceberg.StringLiteral(result))
-		}
-
-		return nil, nil
-	case iceberg.BinaryType:
+	case iceberg.StringType, iceberg.BinaryType:
 		if !s.curMax.Type().Equals(s.primitiveType) {
-			return nil, errors.New("expected current max to be a binary")
+			return nil, fmt.Errorf("expected current max to be a %s", s.primitiveType)
 		}
 
-		curMax := any(s.curMax.Value()).([]byte)
-		result := TruncateUpperBoundBinary(curMax, s.truncLen)
-		if len(result) > 0 {
-			return s.toBytes(iceberg.BinaryLiteral(result))
+		result, ok := iceberg.TruncateUpperBound(s.curMax, s.truncLen)
+		if !ok {
+			return nil, nil
 		}
 
-		return nil, nil
+		return s.toBytes(result)
 	default:
 		return nil, fmt.Errorf("%s cannot be truncated for upper bound", s.primitiveType)
 	}
 }
 
+// TruncateUpperBoundText truncates s to an upper bound of at most trunc
+// code points, see [iceberg.TruncateUpperBound], or returns "" if there
+// is none.
 func TruncateUpperBoundText(s string, trunc int) string {
-	if trunc >= utf8.RuneCountInString(s) {
-		return s
-	}
-
-	result := []rune(s)[:trunc]
-	for i := len(result) - 1; i >= 0; i-- {
-		next := result[i] + 1
-		if utf8.ValidRune(next) {
-			result[i] = next
-
-			return string(result)
-		}
+	lit, ok := iceberg.TruncateUpperBound(iceberg.StringLiteral(s), trunc)
+	if !ok {
+		return ""
 	}
 
-	return ""
+	return string(lit.(iceberg.StringLiteral))
 }
 
+// TruncateUpperBoundBinary truncates val to an upper bound of at most
+// trunc bytes, see [iceberg.TruncateUpperBound], or returns nil if there
+// is none.
 func TruncateUpperBoundBinary(val []byte, trunc int) []byte {
-	if trunc >= len(val) {
-		return val
-	}
-
-	result := val[:trunc]
-	if bytes.Equal(result, val) {
-		return result
-	}
-
-	for i := len(result) - 1; i >= 0; i-- {
-		if result[i] < 255 {
-			result[i]++
-
-			return result
-		}
+	lit, ok := iceberg.TruncateUpperBound(iceberg.BinaryLiteral(val), trunc)
+	if !ok {
+		return nil
 	}
 
-	return nil
+	return lit.(iceberg.BinaryLiteral)
 }
 
 func MapExec[T, S any](nWorkers int, slice iter.Seq[T], fn func(T) (S, error)) iter.Seq2[S, error] {
@@ -63,6 +63,8 @@ func TestMetricsModePairs(t *testing.T) {
 
 func TestTruncateUpperBoundString(t *testing.T) {
 	assert.Equal(t, "ab", internal.TruncateUpperBoundText("aaaa", 2))
+	// the surrogates are skipped when incrementing a code point
+	assert.Equal(t, "\uE000", internal.TruncateUpperBoundText("\uD7FFa", 1))
 	// \u10FFFF\u10FFFF\x00
 	assert.Equal(t, "", internal.TruncateUpperBoundText("\xf4\x8f\xbf\xbf\xf4\x8f\xbf\xbf\x00", 2))
 }
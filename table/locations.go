@@ -20,13 +20,23 @@ package table
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 
 	"github.com/apache/iceberg-go"
 	"github.com/google/uuid"
+	"github.com/twmb/murmur3"
 )
 
+// LocationProvider decides where the data and metadata files of a table
+// are written.
 type LocationProvider interface {
+	// NewDataLocation returns the location of a new data file of an
+	// unpartitioned table.
 	NewDataLocation(dataFileName string) string
+	// NewPartitionedDataLocation returns the location of a new data file
+	// for the partition with the given path, as produced by
+	// [iceberg.PartitionSpec.PartitionToPath].
+	NewPartitionedDataLocation(partitionPath, dataFileName string) string
 	NewTableMetadataFileLocation(newVersion int) (string, error)
 	NewMetadataLocation(metadataFileName string) string
 }
@@ -42,6 +52,10 @@ func (slp *simpleLocationProvider) NewDataLocation(dataFileName string) string {
 	return slp.dataPath.JoinPath(dataFileName).String()
 }
 
+func (slp *simpleLocationProvider) NewPartitionedDataLocation(partitionPath, dataFileName string) string {
+	return slp.dataPath.JoinPath(partitionPath, dataFileName).String()
+}
+
 func (slp *simpleLocationProvider) NewTableMetadataFileLocation(newVersion int) (string, error) {
 	if newVersion < 0 {
 		return "", fmt.Errorf("%w: table metadata version %d must be a non-negative integer",
@@ -90,6 +104,18 @@ func newSimpleLocationProvider(tableLoc *url.URL, tableProps iceberg.Properties)
 	return out, nil
 }
 
+const (
+	// number of bits of the file name hash used to spread the data files
+	// across prefixes, split into entropyDirDepth directories of
+	// entropyDirLength characters followed by the remaining bits.
+	hashBinaryStringBits = 20
+	entropyDirLength     = 4
+	entropyDirDepth      = 3
+)
+
+// objectStoreLocationProvider injects a hash of the file name into the
+// data file locations so that they are spread across many prefixes, which
+// improves the request throughput of object stores such as S3.
 type objectStoreLocationProvider struct {
 	*simpleLocationProvider
 
@@ -109,6 +135,46 @@ func newObjectStoreLocationProvider(tableLoc *url.URL, tableProps iceberg.Proper
 	}, nil
 }
 
+func (olp *objectStoreLocationProvider) NewDataLocation(dataFileName string) string {
+	hashed := computeHash(dataFileName)
+	if olp.includePartitionPaths {
+		return olp.dataPath.JoinPath(hashed, dataFileName).String()
+	}
+
+	return olp.dataPath.JoinPath(hashed + "-" + dataFileName).String()
+}
+
+func (olp *objectStoreLocationProvider) NewPartitionedDataLocation(partitionPath, dataFileName string) string {
+	if olp.includePartitionPaths && partitionPath != "" {
+		return olp.NewDataLocation(partitionPath + "/" + dataFileName)
+	}
+
+	return olp.NewDataLocation(dataFileName)
+}
+
+// computeHash returns the low hashBinaryStringBits bits of the murmur3
+// hash of the file name as a binary string split into directories. It is
+// compatible with the Java and Python implementations.
+func computeHash(dataFileName string) string {
+	const topMask = 1 << hashBinaryStringBits
+
+	// setting the top bit keeps the leading zeros of the hash
+	hash := murmur3.Sum32([]byte(dataFileName))&(topMask-1) | topMask
+	bits := strconv.FormatUint(uint64(hash), 2)[1:]
+
+	out := make([]byte, 0, len(bits)+entropyDirDepth)
+	for i := 0; i < entropyDirLength*entropyDirDepth; i += entropyDirLength {
+		out = append(out, bits[i:i+entropyDirLength]...)
+		out = append(out, '/')
+	}
+
+	return string(append(out, bits[entropyDirLength*entropyDirDepth:]...))
+}
+
+// LoadLocationProvider returns the LocationProvider of the table at
+// tableLocation, which hashes the data file locations if
+// write.object-storage.enabled is set and otherwise writes them under
+// write.data.path, defaulting to the data directory of the table.
 func LoadLocationProvider(tableLocation string, tableProps iceberg.Properties) (LocationProvider, error) {
 	u, err := url.Parse(tableLocation)
 	if err != nil {
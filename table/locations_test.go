@@ -74,3 +74,61 @@ func TestLocationProviderMetadataFileLocationCustomPath(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "s3://table-location/custom/path/00001-30313233-3435-4637-b839-616263646566.metadata.json", loc)
 }
+
+func TestLocationProviderDataLocation(t *testing.T) {
+	provider, err := table.LoadLocationProvider("table_location", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "table_location/data/test.parquet", provider.NewDataLocation("test.parquet"))
+	assert.Equal(t, "table_location/data/id=1/test.parquet",
+		provider.NewPartitionedDataLocation("id=1", "test.parquet"))
+}
+
+func TestLocationProviderDataLocationCustomPath(t *testing.T) {
+	provider, err := table.LoadLocationProvider("table_location",
+		iceberg.Properties{table.WriteDataPathKey: "s3://table-location/custom/data/"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3://table-location/custom/data/test.parquet", provider.NewDataLocation("test.parquet"))
+	assert.Equal(t, "s3://table-location/custom/data/id=1/test.parquet",
+		provider.NewPartitionedDataLocation("id=1", "test.parquet"))
+}
+
+func TestObjectStorageLocationProvider(t *testing.T) {
+	provider, err := table.LoadLocationProvider("table_location",
+		iceberg.Properties{table.ObjectStoreEnabledKey: "true"})
+	require.NoError(t, err)
+
+	loc := provider.NewDataLocation("test.parquet")
+	assert.Equal(t, "table_location/data/0110/1010/0011/11101000/test.parquet", loc)
+	assert.Equal(t, loc, provider.NewDataLocation("test.parquet"), "hash must be deterministic")
+
+	assert.Equal(t, "table_location/data/1110/1010/0111/11111000/id=1/test.parquet",
+		provider.NewPartitionedDataLocation("id=1", "test.parquet"))
+}
+
+func TestObjectStorageLocationProviderWithoutPartitionPaths(t *testing.T) {
+	provider, err := table.LoadLocationProvider("table_location",
+		iceberg.Properties{
+			table.ObjectStoreEnabledKey:               "true",
+			table.WriteObjectStorePartitionedPathsKey: "false",
+		})
+	require.NoError(t, err)
+
+	assert.Equal(t, "table_location/data/0110/1010/0011/11101000-test.parquet",
+		provider.NewDataLocation("test.parquet"))
+	assert.Equal(t, "table_location/data/0110/1010/0011/11101000-test.parquet",
+		provider.NewPartitionedDataLocation("id=1", "test.parquet"))
+}
+
+func TestObjectStorageLocationProviderCustomPath(t *testing.T) {
+	provider, err := table.LoadLocationProvider("table_location",
+		iceberg.Properties{
+			table.ObjectStoreEnabledKey: "true",
+			table.WriteDataPathKey:      "s3://bucket/custom/data",
+		})
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3://bucket/custom/data/0110/1010/0011/11101000/test.parquet",
+		provider.NewDataLocation("test.parquet"))
+}
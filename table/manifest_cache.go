@@ -0,0 +1,124 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"container/list"
+	"slices"
+	"sync"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/io"
+)
+
+type manifestCacheKey struct {
+	path   string
+	length int64
+}
+
+type manifestCacheEntry struct {
+	key     manifestCacheKey
+	entries []iceberg.ManifestEntry
+}
+
+// ManifestCache is a size-bounded LRU cache of parsed manifest entries,
+// keyed on the manifest's path and length. Manifests are never modified
+// once written, so a cached manifest is always valid for as long as it
+// is held. A ManifestCache is safe for concurrent use and can be shared
+// by any number of scans.
+type ManifestCache struct {
+	maxEntries int
+
+	mx    sync.Mutex
+	ll    *list.List
+	items map[manifestCacheKey]*list.Element
+}
+
+// NewManifestCache returns a cache holding the entries of up to
+// maxManifests manifests.
+func NewManifestCache(maxManifests int) *ManifestCache {
+	return &ManifestCache{
+		maxEntries: max(maxManifests, 1),
+		ll:         list.New(),
+		items:      make(map[manifestCacheKey]*list.Element),
+	}
+}
+
+// Len returns the number of manifests currently cached.
+func (c *ManifestCache) Len() int {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return c.ll.Len()
+}
+
+func (c *ManifestCache) get(key manifestCacheKey) ([]iceberg.ManifestEntry, bool) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(elem)
+
+	return elem.Value.(*manifestCacheEntry).entries, true
+}
+
+func (c *ManifestCache) put(key manifestCacheKey, entries []iceberg.ManifestEntry) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.ll.MoveToFront(elem)
+
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&manifestCacheEntry{key: key, entries: entries})
+	for c.ll.Len() > c.maxEntries {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*manifestCacheEntry).key)
+	}
+}
+
+// FetchEntries returns the entries of the manifest, reading it through
+// fs only when it isn't already cached. All entries, including deleted
+// ones, are cached so that the same cached manifest serves both values
+// of discardDeleted.
+func (c *ManifestCache) FetchEntries(fs io.IO, manifest iceberg.ManifestFile, discardDeleted bool) ([]iceberg.ManifestEntry, error) {
+	key := manifestCacheKey{path: manifest.FilePath(), length: manifest.Length()}
+
+	entries, ok := c.get(key)
+	if !ok {
+		var err error
+		if entries, err = manifest.FetchEntries(fs, false); err != nil {
+			return nil, err
+		}
+		c.put(key, entries)
+	}
+
+	if !discardDeleted {
+		return slices.Clone(entries), nil
+	}
+
+	return slices.DeleteFunc(slices.Clone(entries), func(e iceberg.ManifestEntry) bool {
+		return e.Status() == iceberg.EntryStatusDELETED
+	}), nil
+}
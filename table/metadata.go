@@ -122,6 +122,9 @@ type Metadata interface {
 	// DefaultSortOrder returns the ID of the current sort order that writers
 	// should use by default.
 	DefaultSortOrder() int
+	// SortOrderByID returns the sort order with the given ID, and false if
+	// the table has no such sort order.
+	SortOrderByID(int) (SortOrder, bool)
 	// Properties is a string to string map of table properties. This is used
 	// to control settings that affect reading and writing and is not intended
 	// to be used for arbitrary metadata. For example, commit.retry.num-retries
@@ -366,6 +369,42 @@ func (b *MetadataBuilder) RemoveProperties(keys []string) (*MetadataBuilder, err
 	return b, nil
 }
 
+// RemoveSnapshots removes the snapshots with the given ids from the metadata,
+// along with any refs pointing to them and the snapshot log up to the last
+// entry of a removed snapshot. The current snapshot cannot be removed.
+func (b *MetadataBuilder) RemoveSnapshots(ids []int64) (*MetadataBuilder, error) {
+	if len(ids) == 0 {
+		return b, nil
+	}
+
+	if b.currentSnapshotID != nil && slices.Contains(ids, *b.currentSnapshotID) {
+		return nil, fmt.Errorf("%w: can't remove current snapshot %d",
+			iceberg.ErrInvalidArgument, *b.currentSnapshotID)
+	}
+
+	b.updates = append(b.updates, NewRemoveSnapshotsUpdate(ids))
+	b.snapshotList = slices.DeleteFunc(slices.Clone(b.snapshotList), func(s Snapshot) bool {
+		return slices.Contains(ids, s.SnapshotID)
+	})
+	// an entry of a removed snapshot also removes the history before it,
+	// otherwise the log shows an older snapshot as current at that time
+	log := make([]SnapshotLogEntry, 0, len(b.snapshotLog))
+	for _, e := range b.snapshotLog {
+		if slices.Contains(ids, e.SnapshotID) {
+			log = log[:0]
+
+			continue
+		}
+		log = append(log, e)
+	}
+	b.snapshotLog = log
+	maps.DeleteFunc(b.refs, func(_ string, ref SnapshotRef) bool {
+		return slices.Contains(ids, ref.SnapshotID)
+	})
+
+	return b, nil
+}
+
 func (b *MetadataBuilder) SetCurrentSchemaID(currentSchemaID int) (*MetadataBuilder, error) {
 	if currentSchemaID == -1 {
 		currentSchemaID = maxBy(b.schemaList, func(s *iceberg.Schema) int {
@@ -570,8 +609,8 @@ func (b *MetadataBuilder) SetSnapshotRef(
 		b.lastUpdatedMS = snapshot.TimestampMs
 	}
 
+	b.updates = append(b.updates, NewSetSnapshotRefUpdate(name, snapshotID, refType, maxRefAgeMs, maxSnapshotAgeMs, minSnapshotsToKeep))
 	if name == MainBranch {
-		b.updates = append(b.updates, NewSetSnapshotRefUpdate(name, snapshotID, refType, maxRefAgeMs, maxSnapshotAgeMs, minSnapshotsToKeep))
 		b.currentSnapshotID = &snapshotID
 		if !isAddedSnapshot {
 			b.lastUpdatedMS = time.Now().Local().UnixMilli()
@@ -679,8 +718,7 @@ func (b *MetadataBuilder) SnapshotByID(id int64) (*Snapshot, error) {
 
 func (b *MetadataBuilder) NameMapping() iceberg.NameMapping {
 	if nameMappingJson, ok := b.props[DefaultNameMappingKey]; ok {
-		nm := iceberg.NameMapping{}
-		if err := json.Unmarshal([]byte(nameMappingJson), &nm); err == nil {
+		if nm, err := iceberg.ParseNameMapping(nameMappingJson); err == nil {
 			return nm
 		}
 	}
@@ -805,6 +843,13 @@ func ParseMetadataBytes(b []byte) (Metadata, error) {
 	return ret, json.Unmarshal(b, ret)
 }
 
+// MetadataToJSON serializes table metadata to JSON, laying out the fields
+// in the order used by the reference implementation. Parsing the result
+// with ParseMetadataBytes and serializing it again produces the same bytes.
+func MetadataToJSON(m Metadata) ([]byte, error) {
+	return json.Marshal(m)
+}
+
 func sliceEqualHelper[T interface{ Equals(T) bool }](s1, s2 []T) bool {
 	return slices.EqualFunc(s1, s2, func(t1, t2 T) bool {
 		return t1.Equals(t2)
@@ -833,6 +878,56 @@ type commonMetadata struct {
 	SnapshotRefs       map[string]SnapshotRef  `json:"refs,omitempty"`
 }
 
+// metadataJSON is the serialized form of the table metadata, it lists the
+// fields in the order of the spec rather than the order of commonMetadata
+// so that metadata files are laid out like the ones written by other engines.
+type metadataJSON struct {
+	FormatVersion      int                      `json:"format-version"`
+	UUID               uuid.UUID                `json:"table-uuid"`
+	Loc                string                   `json:"location"`
+	LastSeqNum         *int64                   `json:"last-sequence-number,omitempty"`
+	LastUpdatedMS      int64                    `json:"last-updated-ms"`
+	LastColumnId       int                      `json:"last-column-id"`
+	Schema             *iceberg.Schema          `json:"schema,omitempty"`
+	CurrentSchemaID    int                      `json:"current-schema-id"`
+	SchemaList         []*iceberg.Schema        `json:"schemas"`
+	Partition          []iceberg.PartitionField `json:"partition-spec,omitempty"`
+	DefaultSpecID      int                      `json:"default-spec-id"`
+	Specs              []iceberg.PartitionSpec  `json:"partition-specs"`
+	LastPartitionID    *int                     `json:"last-partition-id,omitempty"`
+	DefaultSortOrderID int                      `json:"default-sort-order-id"`
+	SortOrderList      []SortOrder              `json:"sort-orders"`
+	Props              iceberg.Properties       `json:"properties,omitempty"`
+	CurrentSnapshotID  *int64                   `json:"current-snapshot-id,omitempty"`
+	SnapshotRefs       map[string]SnapshotRef   `json:"refs,omitempty"`
+	SnapshotList       []Snapshot               `json:"snapshots,omitempty"`
+	SnapshotLog        []SnapshotLogEntry       `json:"snapshot-log,omitempty"`
+	MetadataLog        []MetadataLogEntry       `json:"metadata-log,omitempty"`
+}
+
+func (c *commonMetadata) toJSON() metadataJSON {
+	return metadataJSON{
+		FormatVersion:      c.FormatVersion,
+		UUID:               c.UUID,
+		Loc:                c.Loc,
+		LastUpdatedMS:      c.LastUpdatedMS,
+		LastColumnId:       c.LastColumnId,
+		CurrentSchemaID:    c.CurrentSchemaID,
+		SchemaList:         c.SchemaList,
+		DefaultSpecID:      c.DefaultSpecID,
+		Specs:              c.Specs,
+		LastPartitionID:    c.LastPartitionID,
+		DefaultSortOrderID: c.DefaultSortOrderID,
+		SortOrderList:      c.SortOrderList,
+		Props:              c.Props,
+		CurrentSnapshotID:  c.CurrentSnapshotID,
+		SnapshotRefs:       c.SnapshotRefs,
+		SnapshotList:       c.SnapshotList,
+		SnapshotLog:        c.SnapshotLog,
+		MetadataLog:        c.MetadataLog,
+	}
+}
+
 func (c *commonMetadata) Ref() SnapshotRef                     { return c.SnapshotRefs[MainBranch] }
 func (c *commonMetadata) Refs() iter.Seq2[string, SnapshotRef] { return maps.All(c.SnapshotRefs) }
 func (c *commonMetadata) SnapshotLogs() iter.Seq[SnapshotLogEntry] {
@@ -961,6 +1056,16 @@ func (c *commonMetadata) DefaultSortOrder() int {
 	return c.DefaultSortOrderID
 }
 
+func (c *commonMetadata) SortOrderByID(id int) (SortOrder, bool) {
+	for _, s := range c.SortOrderList {
+		if s.OrderID == id {
+			return s, true
+		}
+	}
+
+	return SortOrder{}, false
+}
+
 func (c *commonMetadata) Properties() iceberg.Properties {
 	return c.Props
 }
@@ -976,6 +1081,10 @@ func (c *commonMetadata) preValidate() {
 		c.CurrentSnapshotID = nil
 	}
 
+	if c.SnapshotRefs == nil {
+		c.SnapshotRefs = make(map[string]SnapshotRef)
+	}
+
 	if c.CurrentSnapshotID != nil {
 		if _, ok := c.SnapshotRefs[MainBranch]; !ok {
 			c.SnapshotRefs[MainBranch] = SnapshotRef{
@@ -989,10 +1098,6 @@ func (c *commonMetadata) preValidate() {
 		c.MetadataLog = []MetadataLogEntry{}
 	}
 
-	if c.SnapshotRefs == nil {
-		c.SnapshotRefs = make(map[string]SnapshotRef)
-	}
-
 	if c.SnapshotLog == nil {
 		c.SnapshotLog = []SnapshotLogEntry{}
 	}
@@ -1077,8 +1182,7 @@ func (c *commonMetadata) validate() error {
 
 func (c *commonMetadata) NameMapping() iceberg.NameMapping {
 	if nameMappingJson, ok := c.Props[DefaultNameMappingKey]; ok {
-		nm := iceberg.NameMapping{}
-		if err := json.Unmarshal([]byte(nameMappingJson), &nm); err == nil {
+		if nm, err := iceberg.ParseNameMapping(nameMappingJson); err == nil {
 			return nm
 		}
 	}
@@ -1141,6 +1245,13 @@ func (m *metadataV1) preValidate() {
 	m.commonMetadata.preValidate()
 }
 
+func (m *metadataV1) MarshalJSON() ([]byte, error) {
+	out := m.commonMetadata.toJSON()
+	out.Schema, out.Partition = m.Schema, m.Partition
+
+	return json.Marshal(out)
+}
+
 func (m *metadataV1) UnmarshalJSON(b []byte) error {
 	type Alias metadataV1
 	aux := (*Alias)(m)
@@ -1189,6 +1300,13 @@ func (m *metadataV2) Equals(other Metadata) bool {
 		m.commonMetadata.Equals(&rhs.commonMetadata)
 }
 
+func (m *metadataV2) MarshalJSON() ([]byte, error) {
+	out := m.commonMetadata.toJSON()
+	out.LastSeqNum = &m.LastSeqNum
+
+	return json.Marshal(out)
+}
+
 func (m *metadataV2) UnmarshalJSON(b []byte) error {
 	type Alias metadataV2
 	aux := (*Alias)(m)
@@ -18,7 +18,10 @@
 package table
 
 import (
+	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"slices"
 	"testing"
 
@@ -267,6 +270,79 @@ func TestSerializeMetadataV2(t *testing.T) {
 		string(data))
 }
 
+func TestMetadataJSONGolden(t *testing.T) {
+	golden, err := os.ReadFile(filepath.Join("testdata", "table_metadata_v2.json"))
+	require.NoError(t, err)
+	golden = bytes.TrimSpace(golden)
+
+	meta, err := ParseMetadataBytes(golden)
+	require.NoError(t, err)
+
+	// the golden file is laid out in the spec order, so re-serializing
+	// it must produce exactly the same bytes
+	data, err := MetadataToJSON(meta)
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), string(data))
+
+	reparsed, err := ParseMetadataBytes(data)
+	require.NoError(t, err)
+	assert.True(t, meta.Equals(reparsed))
+}
+
+func TestMetadataJSONRoundTripStable(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		json string
+	}{
+		{"v1", ExampleTableMetadataV1},
+		{"v2", ExampleTableMetadataV2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := ParseMetadataString(tt.json)
+			require.NoError(t, err)
+
+			first, err := MetadataToJSON(meta)
+			require.NoError(t, err)
+
+			reparsed, err := ParseMetadataBytes(first)
+			require.NoError(t, err)
+			assert.True(t, meta.Equals(reparsed))
+
+			second, err := MetadataToJSON(reparsed)
+			require.NoError(t, err)
+			assert.Equal(t, string(first), string(second))
+		})
+	}
+
+	// the fields are written in the order of the spec
+	meta, err := ParseMetadataString(ExampleTableMetadataV2)
+	require.NoError(t, err)
+	data, err := MetadataToJSON(meta)
+	require.NoError(t, err)
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	_, err = dec.Token()
+	require.NoError(t, err)
+
+	var keys []string
+	for dec.More() {
+		key, err := dec.Token()
+		require.NoError(t, err)
+		keys = append(keys, key.(string))
+
+		var skip json.RawMessage
+		require.NoError(t, dec.Decode(&skip))
+	}
+
+	assert.Equal(t, []string{
+		"format-version", "table-uuid", "location", "last-sequence-number",
+		"last-updated-ms", "last-column-id", "current-schema-id", "schemas",
+		"default-spec-id", "partition-specs", "last-partition-id",
+		"default-sort-order-id", "sort-orders", "properties",
+		"current-snapshot-id", "refs", "snapshots", "snapshot-log", "metadata-log",
+	}, keys)
+}
+
 func TestInvalidFormatVersion(t *testing.T) {
 	metadataInvalidFormat := `{
         "format-version": -1,
@@ -373,6 +449,97 @@ func TestSortOrderNotFound(t *testing.T) {
 	assert.ErrorContains(t, err, "default-sort-order-id 4 can't be found in [3: [\n2 asc nulls-first\nbucket[4](3) desc nulls-last\n]]")
 }
 
+func TestMetadataSortOrders(t *testing.T) {
+	const metadata = `{
+        "format-version": 2,
+        "table-uuid": "9c12d441-03fe-4693-9a96-a0705ddf69c1",
+        "location": "s3://bucket/test/location",
+        "last-sequence-number": 0,
+        "last-updated-ms": 1602638573590,
+        "last-column-id": 3,
+        "current-schema-id": 0,
+        "schemas": [
+            {
+                "type": "struct",
+                "schema-id": 0,
+                "fields": [
+                    {"id": 1, "name": "x", "required": true, "type": "long"},
+                    {"id": 2, "name": "y", "required": true, "type": "long"},
+                    {"id": 3, "name": "z", "required": false, "type": "timestamp"}
+                ]
+            }
+        ],
+        "default-spec-id": 0,
+        "partition-specs": [{"spec-id": 0, "fields": []}],
+        "last-partition-id": 999,
+        "default-sort-order-id": 2,
+        "sort-orders": [
+            {
+                "order-id": 1,
+                "fields": [
+                    {"transform": "identity", "source-id": 1, "direction": "asc", "null-order": "nulls-first"}
+                ]
+            },
+            {
+                "order-id": 2,
+                "fields": [
+                    {"transform": "day", "source-id": 3, "direction": "desc", "null-order": "nulls-last"},
+                    {"transform": "bucket[16]", "source-id": 2, "direction": "asc", "null-order": "nulls-last"}
+                ]
+            }
+        ],
+        "properties": {}
+    }`
+
+	meta, err := ParseMetadataString(metadata)
+	require.NoError(t, err)
+
+	assert.Len(t, meta.SortOrders(), 2)
+	assert.Equal(t, 2, meta.DefaultSortOrder())
+
+	expected := SortOrder{OrderID: 2, Fields: []SortField{
+		{SourceID: 3, Transform: iceberg.DayTransform{}, Direction: SortDESC, NullOrder: NullsLast},
+		{SourceID: 2, Transform: iceberg.BucketTransform{NumBuckets: 16}, Direction: SortASC, NullOrder: NullsLast},
+	}}
+	assert.True(t, expected.Equals(meta.SortOrder()), "got %s", meta.SortOrder())
+
+	order, ok := meta.SortOrderByID(1)
+	require.True(t, ok)
+	assert.True(t, order.Equals(SortOrder{OrderID: 1, Fields: []SortField{
+		{SourceID: 1, Transform: iceberg.IdentityTransform{}, Direction: SortASC, NullOrder: NullsFirst},
+	}}), "got %s", order)
+
+	order, ok = meta.SortOrderByID(2)
+	require.True(t, ok)
+	assert.True(t, expected.Equals(order))
+
+	_, ok = meta.SortOrderByID(3)
+	assert.False(t, ok)
+
+	data, err := MetadataToJSON(meta)
+	require.NoError(t, err)
+
+	reparsed, err := ParseMetadataBytes(data)
+	require.NoError(t, err)
+	assert.True(t, meta.Equals(reparsed))
+	assert.Equal(t, 2, reparsed.DefaultSortOrder())
+	assert.True(t, expected.Equals(reparsed.SortOrder()))
+
+	var raw struct {
+		SortOrders json.RawMessage `json:"sort-orders"`
+	}
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.JSONEq(t, `[
+        {"order-id": 1, "fields": [
+            {"source-id": 1, "transform": "identity", "direction": "asc", "null-order": "nulls-first"}
+        ]},
+        {"order-id": 2, "fields": [
+            {"source-id": 3, "transform": "day", "direction": "desc", "null-order": "nulls-last"},
+            {"source-id": 2, "transform": "bucket[16]", "direction": "asc", "null-order": "nulls-last"}
+        ]}
+    ]`, string(raw.SortOrders))
+}
+
 func TestSortOrderUnsorted(t *testing.T) {
 	sortOrderUnsorted := `{
         "format-version": 2,
@@ -0,0 +1,173 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"golang.org/x/sync/errgroup"
+)
+
+// SnapshotRow is a row of the snapshots metadata table, describing a
+// single snapshot of the table.
+type SnapshotRow struct {
+	CommittedAt  time.Time
+	SnapshotID   int64
+	ParentID     *int64
+	Operation    Operation
+	ManifestList string
+	Summary      map[string]string
+}
+
+// SnapshotsTable returns one row per snapshot in the table metadata,
+// ordered by commit time, similar to the snapshots metadata table
+// provided by other Iceberg implementations.
+func SnapshotsTable(tbl *Table) ([]SnapshotRow, error) {
+	snapshots := tbl.metadata.Snapshots()
+	rows := make([]SnapshotRow, 0, len(snapshots))
+	for _, snap := range snapshots {
+		row := SnapshotRow{
+			CommittedAt:  time.UnixMilli(snap.TimestampMs).UTC(),
+			SnapshotID:   snap.SnapshotID,
+			ParentID:     snap.ParentSnapshotID,
+			ManifestList: snap.ManifestList,
+		}
+
+		if snap.Summary != nil {
+			row.Operation = snap.Summary.Operation
+			row.Summary = maps.Clone(snap.Summary.Properties)
+		}
+
+		rows = append(rows, row)
+	}
+
+	slices.SortStableFunc(rows, func(a, b SnapshotRow) int {
+		return cmp.Compare(a.CommittedAt.UnixMilli(), b.CommittedAt.UnixMilli())
+	})
+
+	return rows, nil
+}
+
+// FileRow is a row of the files metadata table, describing a single live
+// file of the current snapshot.
+type FileRow struct {
+	Content       iceberg.ManifestEntryContent
+	FilePath      string
+	FileFormat    iceberg.FileFormat
+	SpecID        int32
+	RecordCount   int64
+	FileSizeBytes int64
+	// Partition maps the partition field names of the file's partition
+	// spec to the file's partition values.
+	Partition map[string]any
+}
+
+// FilesTable returns the rows of the files metadata table, one for each
+// data and delete file that is live in the current snapshot of the table.
+// Files which were removed by the snapshot, i.e. whose manifest entries
+// have the DELETED status, are not included.
+//
+// The manifests are read concurrently with a bounded number of workers,
+// which only keep the rows of each manifest rather than its entries. The
+// rows are ordered as the manifests in the snapshot's manifest list, and
+// within a manifest as its entries.
+func FilesTable(ctx context.Context, tbl *Table) ([]FileRow, error) {
+	snap := tbl.CurrentSnapshot()
+	if snap == nil {
+		return []FileRow{}, nil
+	}
+
+	fs, err := tbl.fsF(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests, err := snap.Manifests(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	specs := tbl.metadata.PartitionSpecs()
+	specByID := make(map[int32]iceberg.PartitionSpec, len(specs))
+	for _, spec := range specs {
+		specByID[int32(spec.ID())] = spec
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(runtime.GOMAXPROCS(0))
+
+	results := make([][]FileRow, len(manifests))
+	for i, m := range manifests {
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			rows, err := manifestFileRows(fs, m, specByID)
+			results[i] = rows
+
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(results...), nil
+}
+
+// manifestFileRows returns the files metadata table rows of the live
+// entries of the manifest m.
+func manifestFileRows(fs iceio.IO, m iceberg.ManifestFile, specByID map[int32]iceberg.PartitionSpec) ([]FileRow, error) {
+	entries, err := m.FetchEntries(fs, true)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]FileRow, 0, len(entries))
+	for _, e := range entries {
+		df := e.DataFile()
+		spec := specByID[df.SpecID()]
+
+		partition := make(map[string]any, spec.NumFields())
+		for j := range spec.NumFields() {
+			field := spec.Field(j)
+			partition[field.Name] = df.Partition()[field.FieldID]
+		}
+
+		rows = append(rows, FileRow{
+			Content:       df.ContentType(),
+			FilePath:      df.FilePath(),
+			FileFormat:    df.FileFormat(),
+			SpecID:        df.SpecID(),
+			RecordCount:   df.Count(),
+			FileSizeBytes: df.FileSizeBytes(),
+			Partition:     partition,
+		})
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,545 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	tblutils "github.com/apache/iceberg-go/table/internal"
+	"github.com/google/uuid"
+)
+
+// Metrics are the column metrics of a data file, keyed by field ID, as
+// stored in its DataFile metadata to allow pruning files during scans.
+type Metrics struct {
+	RecordCount     int64
+	ValueCounts     map[int]int64
+	NullValueCounts map[int]int64
+	LowerBounds     map[int][]byte
+	UpperBounds     map[int][]byte
+}
+
+// MetricsConfig determines which metrics are collected for each column,
+// as configured by the write.metadata.metrics.* table properties: none,
+// counts, truncate(n) or full.
+type MetricsConfig struct {
+	defaultMode tblutils.MetricsMode
+	columnModes map[string]tblutils.MetricsMode
+}
+
+// NewMetricsConfig parses the metrics modes of the given table properties.
+// The mode of a column is set with the property
+// write.metadata.metrics.column.<column name>, falling back to the
+// write.metadata.metrics.default property, which defaults to truncate(16).
+func NewMetricsConfig(props iceberg.Properties) (MetricsConfig, error) {
+	defaultMode, err := tblutils.MatchMetricsMode(props.Get(DefaultWriteMetricsModeKey, DefaultWriteMetricsModeDefault))
+	if err != nil {
+		return MetricsConfig{}, fmt.Errorf("%w: %s: %s", iceberg.ErrInvalidArgument, DefaultWriteMetricsModeKey, err)
+	}
+
+	cfg := MetricsConfig{defaultMode: defaultMode, columnModes: make(map[string]tblutils.MetricsMode)}
+	for k, v := range props {
+		col, ok := strings.CutPrefix(k, MetricsModeColumnConfPrefix+".")
+		if !ok {
+			continue
+		}
+
+		mode, err := tblutils.MatchMetricsMode(v)
+		if err != nil {
+			return MetricsConfig{}, fmt.Errorf("%w: %s: %s", iceberg.ErrInvalidArgument, k, err)
+		}
+		cfg.columnModes[col] = mode
+	}
+
+	return cfg, nil
+}
+
+func (c MetricsConfig) columnMode(name string) tblutils.MetricsMode {
+	if mode, ok := c.columnModes[name]; ok {
+		return mode
+	}
+
+	return c.defaultMode
+}
+
+// MetricsOption configures how ComputeMetrics computes metrics.
+type MetricsOption func(*MetricsConfig)
+
+// WithMetricsConfig sets the metrics modes of the columns, e.g. as parsed
+// from the table properties with NewMetricsConfig.
+func WithMetricsConfig(cfg MetricsConfig) MetricsOption {
+	return func(c *MetricsConfig) {
+		*c = cfg
+	}
+}
+
+// WithMetricsTruncateLength sets the length that lower and upper bounds of
+// string and binary columns are truncated to by default, in characters and
+// bytes respectively. A length of zero or less disables truncation.
+func WithMetricsTruncateLength(n int) MetricsOption {
+	return func(c *MetricsConfig) {
+		if n > 0 {
+			c.defaultMode = tblutils.MetricsMode{Typ: tblutils.MetricModeTruncate, Len: n}
+		} else {
+			c.defaultMode = tblutils.MetricsMode{Typ: tblutils.MetricModeFull}
+		}
+	}
+}
+
+// ComputeMetrics computes the metrics of a data file containing the given
+// record batches, whose columns are matched by name to the fields of
+// schema. Metrics are computed for the primitive fields of schema,
+// including those nested in structs. Elements of lists and maps only get
+// value and null counts, as their bounds aren't used for pruning.
+//
+// Which metrics are computed for a column depends on its metrics mode,
+// truncate(16) unless configured otherwise: none omits all metrics, counts
+// only computes value and null counts, and truncate(n) and full also compute
+// lower and upper bounds. With truncate(n), bounds of string and binary
+// columns are truncated as defined by the Iceberg spec: lower bounds are
+// truncated to a prefix of length n and upper bounds are rounded up after
+// truncation, or omitted if there is no such upper bound. NaN values are
+// not taken into account for bounds.
+func ComputeMetrics(schema *iceberg.Schema, batches []arrow.Record, opts ...MetricsOption) (Metrics, error) {
+	cfg, err := NewMetricsConfig(nil)
+	if err != nil {
+		return Metrics{}, err
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	mc := metricsCollector{
+		cfg:       cfg,
+		schema:    schema,
+		mins:      make(map[int]iceberg.Literal),
+		maxs:      make(map[int]iceberg.Literal),
+		truncLens: make(map[int]int),
+		result:    Metrics{ValueCounts: make(map[int]int64), NullValueCounts: make(map[int]int64)},
+	}
+
+	for _, rec := range batches {
+		mc.result.RecordCount += rec.NumRows()
+		for _, field := range schema.Fields() {
+			idx := rec.Schema().FieldIndices(field.Name)
+			if len(idx) == 0 {
+				return Metrics{}, fmt.Errorf("%w: column %s not found in record batch",
+					iceberg.ErrInvalidSchema, field.Name)
+			}
+
+			if err := mc.collect(field, rec.Column(idx[0]), true); err != nil {
+				return Metrics{}, err
+			}
+		}
+	}
+
+	return mc.bounds()
+}
+
+type metricsCollector struct {
+	cfg        MetricsConfig
+	schema     *iceberg.Schema
+	mins, maxs map[int]iceberg.Literal
+	// truncLens holds the truncation length of the bounds of each column,
+	// zero if they aren't truncated
+	truncLens map[int]int
+	result    Metrics
+}
+
+func (mc *metricsCollector) collect(field iceberg.NestedField, arr arrow.Array, withBounds bool) error {
+	switch typ := field.Type.(type) {
+	case *iceberg.StructType:
+		structArr, ok := arr.(*array.Struct)
+		if !ok {
+			return fmt.Errorf("%w: expected struct array for field %s, got %s",
+				iceberg.ErrInvalidSchema, field.Name, arr.DataType())
+		}
+
+		st := structArr.DataType().(*arrow.StructType)
+		for _, child := range typ.FieldList {
+			idx, ok := st.FieldIdx(child.Name)
+			if !ok {
+				return fmt.Errorf("%w: field %s not found in struct %s",
+					iceberg.ErrInvalidSchema, child.Name, field.Name)
+			}
+
+			if err := mc.collect(child, structArr.Field(idx), withBounds); err != nil {
+				return err
+			}
+		}
+	case *iceberg.ListType:
+		listArr, ok := arr.(array.ListLike)
+		if !ok {
+			return fmt.Errorf("%w: expected list array for field %s, got %s",
+				iceberg.ErrInvalidSchema, field.Name, arr.DataType())
+		}
+
+		return mc.collect(typ.ElementField(), listArr.ListValues(), false)
+	case *iceberg.MapType:
+		mapArr, ok := arr.(*array.Map)
+		if !ok {
+			return fmt.Errorf("%w: expected map array for field %s, got %s",
+				iceberg.ErrInvalidSchema, field.Name, arr.DataType())
+		}
+
+		if err := mc.collect(typ.KeyField(), mapArr.Keys(), false); err != nil {
+			return err
+		}
+
+		return mc.collect(typ.ValueField(), mapArr.Items(), false)
+	case iceberg.PrimitiveType:
+		name, _ := mc.schema.FindColumnName(field.ID)
+		mode := mc.cfg.columnMode(name)
+		if mode.Typ == tblutils.MetricModeNone {
+			return nil
+		}
+
+		mc.result.ValueCounts[field.ID] += int64(arr.Len())
+		mc.result.NullValueCounts[field.ID] += int64(arr.NullN())
+
+		if !withBounds || mode.Typ == tblutils.MetricModeCounts {
+			return nil
+		}
+
+		if mode.Typ == tblutils.MetricModeTruncate {
+			mc.truncLens[field.ID] = mode.Len
+		}
+
+		return mc.updateBounds(field.ID, typ, arr)
+	}
+
+	return nil
+}
+
+func (mc *metricsCollector) updateBounds(id int, typ iceberg.PrimitiveType, arr arrow.Array) error {
+	var cmp func(iceberg.Literal, iceberg.Literal) int
+	for i := range arr.Len() {
+		if arr.IsNull(i) {
+			continue
+		}
+
+		lit, err := arrowValueToLiteral(arr, i, typ)
+		if err != nil {
+			return err
+		}
+
+		if lit == nil {
+			continue
+		}
+
+		if cmp == nil {
+			cmp = getCmpLiteral(lit)
+		}
+
+		if cur, ok := mc.mins[id]; !ok || cmp(lit, cur) < 0 {
+			mc.mins[id] = lit
+		}
+
+		if cur, ok := mc.maxs[id]; !ok || cmp(lit, cur) > 0 {
+			mc.maxs[id] = lit
+		}
+	}
+
+	return nil
+}
+
+func (mc *metricsCollector) bounds() (Metrics, error) {
+	mc.result.LowerBounds = make(map[int][]byte, len(mc.mins))
+	mc.result.UpperBounds = make(map[int][]byte, len(mc.maxs))
+
+	for id, lit := range mc.mins {
+		if trunc := mc.truncLens[id]; trunc > 0 {
+			lit = iceberg.TruncateLowerBound(lit, trunc)
+		}
+
+		b, err := lit.MarshalBinary()
+		if err != nil {
+			return Metrics{}, err
+		}
+		mc.result.LowerBounds[id] = b
+	}
+
+	for id, lit := range mc.maxs {
+		if trunc := mc.truncLens[id]; trunc > 0 {
+			var ok bool
+			if lit, ok = iceberg.TruncateUpperBound(lit, trunc); !ok {
+				continue
+			}
+		}
+
+		b, err := lit.MarshalBinary()
+		if err != nil {
+			return Metrics{}, err
+		}
+		mc.result.UpperBounds[id] = b
+	}
+
+	return mc.result, nil
+}
+
+// arrowValueToLiteral returns the value of arr at index i as a literal of
+// typ, or nil if the value can't be used for bounds, i.e. NaN values and
+// values of arrow types which bounds aren't computed for.
+func arrowValueToLiteral(arr arrow.Array, i int, typ iceberg.PrimitiveType) (iceberg.Literal, error) {
+	var lit iceberg.Literal
+	switch a := arr.(type) {
+	case array.ExtensionArray:
+		return arrowValueToLiteral(a.Storage(), i, typ)
+	case *array.Boolean:
+		lit = iceberg.NewLiteral(a.Value(i))
+	case *array.Int8:
+		lit = iceberg.NewLiteral(int32(a.Value(i)))
+	case *array.Int16:
+		lit = iceberg.NewLiteral(int32(a.Value(i)))
+	case *array.Int32:
+		lit = iceberg.NewLiteral(a.Value(i))
+	case *array.Int64:
+		lit = iceberg.NewLiteral(a.Value(i))
+	case *array.Float32:
+		if math.IsNaN(float64(a.Value(i))) {
+			return nil, nil
+		}
+		lit = iceberg.NewLiteral(a.Value(i))
+	case *array.Float64:
+		if math.IsNaN(a.Value(i)) {
+			return nil, nil
+		}
+		lit = iceberg.NewLiteral(a.Value(i))
+	case *array.String:
+		lit = iceberg.NewLiteral(a.Value(i))
+	case *array.LargeString:
+		lit = iceberg.NewLiteral(a.Value(i))
+	case *array.Binary:
+		lit = iceberg.NewLiteral(slices.Clone(a.Value(i)))
+	case *array.LargeBinary:
+		lit = iceberg.NewLiteral(slices.Clone(a.Value(i)))
+	case *array.FixedSizeBinary:
+		if _, ok := typ.(iceberg.UUIDType); ok {
+			u, err := uuid.FromBytes(a.Value(i))
+			if err != nil {
+				return nil, err
+			}
+			lit = iceberg.NewLiteral(u)
+		} else {
+			lit = iceberg.FixedLiteral(slices.Clone(a.Value(i)))
+		}
+	case *array.Date32:
+		lit = iceberg.NewLiteral(iceberg.Date(a.Value(i)))
+	case *array.Time64:
+		v := int64(a.Value(i))
+		if a.DataType().(*arrow.Time64Type).Unit == arrow.Nanosecond {
+			v /= 1000
+		}
+		lit = iceberg.NewLiteral(iceberg.Time(v))
+	case *array.Timestamp:
+		unit := a.DataType().(*arrow.TimestampType).Unit
+		v := int64(a.Value(i)) * int64(unit.Multiplier()) / int64(arrow.Microsecond.Multiplier())
+		lit = iceberg.NewLiteral(iceberg.Timestamp(v))
+	case *array.Decimal128:
+		lit = iceberg.NewLiteral(iceberg.Decimal{
+			Val: a.Value(i), Scale: int(a.DataType().(*arrow.Decimal128Type).Scale),
+		})
+	default:
+		return nil, nil
+	}
+
+	return lit.To(typ)
+}
+
+// ORCMetrics reads the metrics of the ORC data file at path from its
+// file tail, merging the column statistics of all its stripes, without
+// reading any of the rows of the file. This allows adding existing ORC
+// files to a table and pruning them with the metrics evaluators.
+//
+// Columns are matched to the fields of schema by the iceberg.id attribute
+// of their ORC type, falling back to matching them by name. Metrics are
+// only returned for primitive columns which aren't nested in a list or
+// map, as ORC statistics don't distinguish between null and missing
+// values of repeated columns. Bounds are returned as stored by the
+// writer, without truncation.
+func ORCMetrics(fs iceio.IO, path string, schema *iceberg.Schema) (Metrics, error) {
+	f, err := fs.Open(path)
+	if err != nil {
+		return Metrics{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return Metrics{}, err
+	}
+
+	tail, err := tblutils.ReadORCTail(f, info.Size())
+	if err != nil {
+		return Metrics{}, fmt.Errorf("failed to read orc file %s: %w", path, err)
+	}
+
+	result := Metrics{
+		RecordCount:     int64(tail.NumRows),
+		ValueCounts:     make(map[int]int64),
+		NullValueCounts: make(map[int]int64),
+		LowerBounds:     make(map[int][]byte),
+		UpperBounds:     make(map[int][]byte),
+	}
+
+	var collect func(col uint32, path []string) error
+	collect = func(col uint32, path []string) error {
+		if int(col) >= len(tail.Types) {
+			return fmt.Errorf("%w: invalid orc column id %d", iceberg.ErrInvalidSchema, col)
+		}
+
+		typ := tail.Types[col]
+		switch typ.Kind {
+		case tblutils.ORCStruct:
+			if len(typ.FieldNames) != len(typ.Subtypes) {
+				return fmt.Errorf("%w: orc struct column %d has %d field names for %d fields",
+					iceberg.ErrInvalidSchema, col, len(typ.FieldNames), len(typ.Subtypes))
+			}
+
+			for i, child := range typ.Subtypes {
+				if err := collect(child, append(slices.Clone(path), typ.FieldNames[i])); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		case tblutils.ORCList, tblutils.ORCMap, tblutils.ORCUnion:
+			return nil
+		}
+
+		if int(col) >= len(tail.Stats) {
+			return nil
+		}
+
+		field, ok := orcColumnField(schema, typ, path)
+		if !ok {
+			return nil
+		}
+
+		fieldType, ok := field.Type.(iceberg.PrimitiveType)
+		if !ok {
+			return nil
+		}
+
+		stats := tail.Stats[col]
+		result.ValueCounts[field.ID] = int64(tail.NumRows)
+		result.NullValueCounts[field.ID] = int64(tail.NumRows) - int64(stats.NumValues)
+
+		if stats.NumValues == 0 || stats.Min == nil || stats.Max == nil {
+			return nil
+		}
+
+		lower, err := orcBoundToLiteral(typ, stats.Min, fieldType)
+		if err != nil {
+			return err
+		}
+		upper, err := orcBoundToLiteral(typ, stats.Max, fieldType)
+		if err != nil {
+			return err
+		}
+
+		if lower == nil || upper == nil {
+			return nil
+		}
+
+		if result.LowerBounds[field.ID], err = lower.MarshalBinary(); err != nil {
+			return err
+		}
+		result.UpperBounds[field.ID], err = upper.MarshalBinary()
+
+		return err
+	}
+
+	if err := collect(0, nil); err != nil {
+		return Metrics{}, err
+	}
+
+	return result, nil
+}
+
+// orcColumnField returns the field of schema for the ORC column with the
+// given type and field name path.
+func orcColumnField(schema *iceberg.Schema, typ tblutils.ORCType, path []string) (iceberg.NestedField, bool) {
+	if idStr, ok := typ.Attributes[tblutils.ORCIcebergIDKey]; ok {
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return iceberg.NestedField{}, false
+		}
+
+		return schema.FindFieldByID(id)
+	}
+
+	return schema.FindFieldByName(strings.Join(path, "."))
+}
+
+// orcBoundToLiteral converts a bound of the ORC column statistics of a
+// column with type typ to a literal of the field type, or returns nil if
+// the bound can't be used for the field, e.g. NaN or mismatched types.
+func orcBoundToLiteral(typ tblutils.ORCType, v any, fieldType iceberg.PrimitiveType) (iceberg.Literal, error) {
+	var lit iceberg.Literal
+	switch typ.Kind {
+	case tblutils.ORCByte, tblutils.ORCShort, tblutils.ORCInt, tblutils.ORCLong:
+		lit = iceberg.NewLiteral(v.(int64))
+	case tblutils.ORCFloat, tblutils.ORCDouble:
+		if math.IsNaN(v.(float64)) {
+			return nil, nil
+		}
+		lit = iceberg.NewLiteral(v.(float64))
+	case tblutils.ORCString, tblutils.ORCVarchar, tblutils.ORCChar:
+		lit = iceberg.NewLiteral(v.(string))
+	case tblutils.ORCDate:
+		lit = iceberg.NewLiteral(iceberg.Date(v.(int64)))
+	case tblutils.ORCTimestamp, tblutils.ORCTimestampInstant:
+		lit = iceberg.NewLiteral(iceberg.Timestamp(v.(int64)))
+	case tblutils.ORCDecimal:
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(typ.Scale)), nil)
+		unscaled := new(big.Rat).Mul(v.(*big.Rat), new(big.Rat).SetInt(scale))
+		if !unscaled.IsInt() {
+			return nil, fmt.Errorf("%w: orc decimal bound %s doesn't have scale %d",
+				iceberg.ErrInvalidArgument, v.(*big.Rat).FloatString(int(typ.Scale)), typ.Scale)
+		}
+		lit = iceberg.NewLiteral(iceberg.Decimal{
+			Val: decimal128.FromBigInt(unscaled.Num()), Scale: int(typ.Scale),
+		})
+	default:
+		return nil, nil
+	}
+
+	lit, err := lit.To(fieldType)
+	if err != nil {
+		return nil, nil
+	}
+
+	switch lit.(type) {
+	case iceberg.AboveMaxLiteral, iceberg.BelowMinLiteral:
+		return nil, nil
+	}
+
+	return lit, nil
+}
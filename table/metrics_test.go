@@ -0,0 +1,433 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func mustMarshal(t *testing.T, lit iceberg.Literal) []byte {
+	t.Helper()
+
+	b, err := lit.MarshalBinary()
+	require.NoError(t, err)
+
+	return b
+}
+
+var metricsTestSchema = iceberg.NewSchema(0,
+	iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+	iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String},
+	iceberg.NestedField{ID: 3, Name: "payload", Type: iceberg.PrimitiveTypes.Binary},
+	iceberg.NestedField{ID: 4, Name: "score", Type: iceberg.PrimitiveTypes.Float64},
+	iceberg.NestedField{ID: 5, Name: "location", Type: &iceberg.StructType{
+		FieldList: []iceberg.NestedField{
+			{ID: 6, Name: "lat", Type: iceberg.PrimitiveTypes.Float32},
+		},
+	}},
+)
+
+func metricsTestBatches(t *testing.T) []arrow.Record {
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "payload", Type: arrow.BinaryTypes.Binary, Nullable: true},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "location", Type: arrow.StructOf(
+			arrow.Field{Name: "lat", Type: arrow.PrimitiveTypes.Float32, Nullable: true},
+		), Nullable: true},
+	}, nil)
+
+	longName := strings.Repeat("b", 18) + "z"
+	batches := make([]arrow.Record, 0, 2)
+	for _, data := range []string{
+		`[{"id": 3, "name": "` + longName + `", "payload": "Af//", "score": 1.5, "location": {"lat": 1.0}},
+		  {"id": 1, "name": null, "payload": null, "score": "NaN", "location": {"lat": null}}]`,
+		`[{"id": 2, "name": "abc", "payload": "AQ==", "score": null, "location": {"lat": -2.5}}]`,
+	} {
+		rec, _, err := array.RecordFromJSON(memory.DefaultAllocator, arrSchema, strings.NewReader(data))
+		require.NoError(t, err)
+		t.Cleanup(rec.Release)
+		batches = append(batches, rec)
+	}
+
+	return batches
+}
+
+func TestComputeMetrics(t *testing.T) {
+	sc, batches := metricsTestSchema, metricsTestBatches(t)
+
+	metrics, err := table.ComputeMetrics(sc, batches)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 3, metrics.RecordCount)
+	assert.Equal(t, map[int]int64{1: 3, 2: 3, 3: 3, 4: 3, 6: 3}, metrics.ValueCounts)
+	assert.Equal(t, map[int]int64{1: 0, 2: 1, 3: 1, 4: 1, 6: 1}, metrics.NullValueCounts)
+
+	assert.Equal(t, map[int][]byte{
+		1: mustMarshal(t, iceberg.Int64Literal(1)),
+		2: []byte("abc"),
+		3: {0x01},
+		4: mustMarshal(t, iceberg.Float64Literal(1.5)),
+		6: mustMarshal(t, iceberg.Float32Literal(-2.5)),
+	}, metrics.LowerBounds)
+
+	// the upper bound of name is truncated to 16 characters and rounded up
+	assert.Equal(t, map[int][]byte{
+		1: mustMarshal(t, iceberg.Int64Literal(3)),
+		2: []byte(strings.Repeat("b", 15) + "c"),
+		3: {0x01, 0xff, 0xff},
+		4: mustMarshal(t, iceberg.Float64Literal(1.5)),
+		6: mustMarshal(t, iceberg.Float32Literal(1.0)),
+	}, metrics.UpperBounds)
+
+	metrics, err = table.ComputeMetrics(sc, batches, table.WithMetricsTruncateLength(2))
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("ab"), metrics.LowerBounds[2])
+	assert.Equal(t, []byte("bc"), metrics.UpperBounds[2])
+	assert.Equal(t, []byte{0x01}, metrics.LowerBounds[3])
+	// 0x01ff can't be rounded up in its last byte, so the carry moves left
+	assert.Equal(t, []byte{0x02, 0xff}, metrics.UpperBounds[3])
+
+	// there is no upper bound for a value whose prefix is all 0xff
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, arrow.NewSchema([]arrow.Field{
+		{Name: "payload", Type: arrow.BinaryTypes.Binary},
+	}, nil))
+	defer bldr.Release()
+	bldr.Field(0).(*array.BinaryBuilder).Append([]byte{0xff, 0xff, 0xff})
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	metrics, err = table.ComputeMetrics(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 3, Name: "payload", Type: iceberg.PrimitiveTypes.Binary}),
+		[]arrow.Record{rec}, table.WithMetricsTruncateLength(2))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0xff}, metrics.LowerBounds[3])
+	assert.NotContains(t, metrics.UpperBounds, 3)
+}
+
+func TestComputeMetricsModes(t *testing.T) {
+	cfg, err := table.NewMetricsConfig(iceberg.Properties{
+		table.DefaultWriteMetricsModeKey:                    "counts",
+		table.MetricsModeColumnConfPrefix + ".id":           "full",
+		table.MetricsModeColumnConfPrefix + ".name":         "truncate(4)",
+		table.MetricsModeColumnConfPrefix + ".payload":      "none",
+		table.MetricsModeColumnConfPrefix + ".location.lat": "truncate(2)",
+		"write.metadata.metrics.unrelated":                  "ignored",
+	})
+	require.NoError(t, err)
+
+	metrics, err := table.ComputeMetrics(metricsTestSchema, metricsTestBatches(t), table.WithMetricsConfig(cfg))
+	require.NoError(t, err)
+
+	// payload is set to none, so it has no metrics at all
+	assert.Equal(t, map[int]int64{1: 3, 2: 3, 4: 3, 6: 3}, metrics.ValueCounts)
+	assert.Equal(t, map[int]int64{1: 0, 2: 1, 4: 1, 6: 1}, metrics.NullValueCounts)
+
+	// score falls back to the counts default, and truncation doesn't
+	// apply to the float lat column
+	assert.Equal(t, map[int][]byte{
+		1: mustMarshal(t, iceberg.Int64Literal(1)),
+		2: []byte("abc"),
+		6: mustMarshal(t, iceberg.Float32Literal(-2.5)),
+	}, metrics.LowerBounds)
+	assert.Equal(t, map[int][]byte{
+		1: mustMarshal(t, iceberg.Int64Literal(3)),
+		2: []byte("bbbc"),
+		6: mustMarshal(t, iceberg.Float32Literal(1.0)),
+	}, metrics.UpperBounds)
+
+	bldr, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentData,
+		"s3://bucket/data.parquet", iceberg.ParquetFile, nil, metrics.RecordCount, 1024)
+	require.NoError(t, err)
+
+	df := bldr.ValueCounts(metrics.ValueCounts).
+		NullValueCounts(metrics.NullValueCounts).
+		LowerBoundValues(metrics.LowerBounds).
+		UpperBoundValues(metrics.UpperBounds).
+		Build()
+	assert.NotContains(t, df.ValueCounts(), 3)
+	assert.NotContains(t, df.NullValueCounts(), 3)
+	assert.NotContains(t, df.LowerBoundValues(), 3)
+	assert.NotContains(t, df.LowerBoundValues(), 4)
+	assert.Equal(t, []byte("bbbc"), df.UpperBoundValues()[2])
+
+	// the default mode applies to all columns without an override
+	cfg, err = table.NewMetricsConfig(iceberg.Properties{table.DefaultWriteMetricsModeKey: "none"})
+	require.NoError(t, err)
+
+	metrics, err = table.ComputeMetrics(metricsTestSchema, metricsTestBatches(t), table.WithMetricsConfig(cfg))
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, metrics.RecordCount)
+	assert.Empty(t, metrics.ValueCounts)
+	assert.Empty(t, metrics.NullValueCounts)
+	assert.Empty(t, metrics.LowerBounds)
+	assert.Empty(t, metrics.UpperBounds)
+
+	_, err = table.NewMetricsConfig(iceberg.Properties{table.MetricsModeColumnConfPrefix + ".id": "truncate(0)"})
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	_, err = table.NewMetricsConfig(iceberg.Properties{table.DefaultWriteMetricsModeKey: "all"})
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
+
+// orcMessage encodes the given protobuf fields, as built by orcVarint and
+// orcBytes, into a message.
+func orcMessage(fields ...[]byte) []byte {
+	return slices.Concat(fields...)
+}
+
+func orcVarint(num, v uint64) []byte {
+	return protowire.AppendVarint(protowire.AppendTag(nil, protowire.Number(num), protowire.VarintType), v)
+}
+
+func orcBytes(num uint64, b []byte) []byte {
+	return protowire.AppendBytes(protowire.AppendTag(nil, protowire.Number(num), protowire.BytesType), b)
+}
+
+// orcZigZag encodes v as a protobuf sint64 or sint32 value.
+func orcZigZag(v int64) uint64 {
+	return protowire.EncodeZigZag(v)
+}
+
+// orcTimestampStats encodes the statistics of a timestamp column, with
+// the sub millisecond nanoseconds only written when they aren't zero.
+func orcTimestampStats(minMillis, minNanos, maxMillis, maxNanos int64) []byte {
+	fields := [][]byte{orcVarint(3, orcZigZag(minMillis)), orcVarint(4, orcZigZag(maxMillis))}
+	if minNanos != 0 {
+		fields = append(fields, orcVarint(5, uint64(minNanos+1)))
+	}
+	if maxNanos != 0 {
+		fields = append(fields, orcVarint(6, uint64(maxNanos+1)))
+	}
+
+	return orcMessage(fields...)
+}
+
+func orcType(kind uint64, id string, subtypes []uint64, names ...string) []byte {
+	fields := [][]byte{orcVarint(1, kind)}
+	for _, s := range subtypes {
+		fields = append(fields, orcVarint(2, s))
+	}
+	for _, n := range names {
+		fields = append(fields, orcBytes(3, []byte(n)))
+	}
+	if kind == 14 {
+		fields = append(fields, orcVarint(5, 10), orcVarint(6, 2))
+	}
+	if id != "" {
+		fields = append(fields, orcBytes(7, orcMessage(orcBytes(1, []byte("iceberg.id")), orcBytes(2, []byte(id)))))
+	}
+
+	return orcMessage(fields...)
+}
+
+// orcStripeStats returns the statistics of the columns of the file
+// written by writeORCTail, in column order.
+func orcStripeStats(rows uint64, minID, maxID int64, nullNames uint64, minName, maxName, minPrice, maxPrice string, minDate, maxDate int64, ts []byte) [][]byte {
+	return [][]byte{
+		orcMessage(orcVarint(1, rows)),
+		orcMessage(orcVarint(1, rows), orcBytes(2, orcMessage(
+			orcVarint(1, orcZigZag(minID)), orcVarint(2, orcZigZag(maxID))))),
+		orcMessage(orcVarint(1, rows-nullNames), orcBytes(4, orcMessage(
+			orcBytes(1, []byte(minName)), orcBytes(2, []byte(maxName)))), orcVarint(10, 1)),
+		orcMessage(orcVarint(1, rows), orcBytes(6, orcMessage(
+			orcBytes(1, []byte(minPrice)), orcBytes(2, []byte(maxPrice))))),
+		orcMessage(orcVarint(1, rows), orcBytes(7, orcMessage(
+			orcVarint(1, orcZigZag(minDate)), orcVarint(2, orcZigZag(maxDate))))),
+		orcMessage(orcVarint(1, rows)),
+		orcMessage(orcVarint(1, rows*2), orcBytes(4, orcMessage(
+			orcBytes(1, []byte("x")), orcBytes(2, []byte("y"))))),
+		orcMessage(orcVarint(1, rows), orcBytes(9, ts)),
+	}
+}
+
+// orcCodecs compress a chunk of an ORC file for each compression kind of
+// the ORC postscript, as the Java and C++ ORC writers do.
+var orcCodecs = map[uint64]func(t *testing.T, b []byte) []byte{
+	1: func(t *testing.T, b []byte) []byte {
+		var buf bytes.Buffer
+		w, err := flate.NewWriter(&buf, flate.BestCompression)
+		require.NoError(t, err)
+		_, err = w.Write(b)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		return buf.Bytes()
+	},
+	2: func(t *testing.T, b []byte) []byte {
+		return snappy.Encode(nil, b)
+	},
+	4: func(t *testing.T, b []byte) []byte {
+		var c lz4.Compressor
+		out := make([]byte, lz4.CompressBlockBound(len(b)))
+		n, err := c.CompressBlock(b, out)
+		require.NoError(t, err)
+		require.NotZero(t, n, "incompressible chunk")
+
+		return out[:n]
+	},
+	5: func(t *testing.T, b []byte) []byte {
+		enc, err := zstd.NewWriter(nil)
+		require.NoError(t, err)
+		defer enc.Close()
+
+		return enc.EncodeAll(b, nil)
+	},
+}
+
+// writeORCTail writes an ORC file without stripe data, which is all that
+// is needed to read its metrics. With compression the statistics of its
+// two stripes are stored in the metadata section, otherwise only the
+// merged file statistics are stored in the footer.
+func writeORCTail(t *testing.T, compression uint64) string {
+	stripes := [][][]byte{
+		orcStripeStats(3, 1, 3, 1, "b", "d", "1.50", "20.00", 19000, 19010,
+			orcTimestampStats(1000, 500000, 2000, 0)),
+		orcStripeStats(2, -5, 2, 0, "a", "c", "3.25", "99.99", -3, 19005,
+			orcTimestampStats(-1, 0, 2000, 250000)),
+	}
+
+	footer := [][]byte{
+		orcVarint(6, 5),
+		orcBytes(4, orcType(12, "", []uint64{1, 2, 3, 4, 5, 7}, "id", "name", "price", "d", "tags", "ts")),
+		orcBytes(4, orcType(4, "1", nil)),
+		orcBytes(4, orcType(7, "2", nil)),
+		orcBytes(4, orcType(14, "3", nil)),
+		orcBytes(4, orcType(15, "", nil)),
+		orcBytes(4, orcType(10, "5", []uint64{6})),
+		orcBytes(4, orcType(7, "6", nil)),
+		orcBytes(4, orcType(9, "7", nil)),
+		// fields which aren't needed to read the metrics are skipped
+		orcBytes(100, bytes.Repeat([]byte("unknown"), 100)),
+	}
+
+	var metadata []byte
+	if compression != 0 {
+		var stripeStats [][]byte
+		for _, stats := range stripes {
+			var cols [][]byte
+			for _, col := range stats {
+				cols = append(cols, orcBytes(1, col))
+			}
+			stripeStats = append(stripeStats, orcBytes(1, orcMessage(cols...)))
+		}
+		stripeStats = append(stripeStats, orcBytes(100, bytes.Repeat([]byte("unknown"), 100)))
+		metadata = orcMessage(stripeStats...)
+	} else {
+		for _, col := range orcStripeStats(5, -5, 3, 1, "a", "d", "1.50", "99.99", -3, 19010,
+			orcTimestampStats(-1, 0, 2000, 0)) {
+			footer = append(footer, orcBytes(7, col))
+		}
+	}
+
+	footerBytes := orcMessage(footer...)
+	if compression != 0 {
+		chunk := func(b []byte) []byte {
+			compressed := orcCodecs[compression](t, b)
+			header := len(compressed) << 1
+
+			return append([]byte{byte(header), byte(header >> 8), byte(header >> 16)}, compressed...)
+		}
+		metadata, footerBytes = chunk(metadata), chunk(footerBytes)
+	}
+
+	ps := orcMessage(
+		orcVarint(1, uint64(len(footerBytes))),
+		orcVarint(2, compression),
+		orcVarint(3, 256*1024),
+		orcVarint(5, uint64(len(metadata))),
+		orcBytes(8000, []byte("ORC")),
+	)
+
+	path := filepath.Join(t.TempDir(), "data.orc")
+	require.NoError(t, os.WriteFile(path,
+		slices.Concat([]byte("ORC"), metadata, footerBytes, ps, []byte{byte(len(ps))}), 0o644))
+
+	return path
+}
+
+func TestORCMetrics(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "price", Type: iceberg.DecimalTypeOf(10, 2), Required: true},
+		iceberg.NestedField{ID: 4, Name: "d", Type: iceberg.PrimitiveTypes.Date, Required: true},
+		iceberg.NestedField{ID: 5, Name: "tags", Type: &iceberg.ListType{
+			ElementID: 6, Element: iceberg.PrimitiveTypes.String, ElementRequired: true,
+		}, Required: true},
+		iceberg.NestedField{ID: 7, Name: "ts", Type: iceberg.PrimitiveTypes.Timestamp, Required: true},
+	)
+
+	compressions := map[string]uint64{"none": 0, "zlib": 1, "snappy": 2, "lz4": 4, "zstd": 5}
+	for name, compression := range compressions {
+		t.Run(name, func(t *testing.T) {
+			metrics, err := table.ORCMetrics(iceio.LocalFS{}, writeORCTail(t, compression), sc)
+			require.NoError(t, err)
+
+			assert.EqualValues(t, 5, metrics.RecordCount)
+			// list elements don't get metrics, and the date column is
+			// matched by name as it has no iceberg.id attribute
+			assert.Equal(t, map[int]int64{1: 5, 2: 5, 3: 5, 4: 5, 7: 5}, metrics.ValueCounts)
+			assert.Equal(t, map[int]int64{1: 0, 2: 1, 3: 0, 4: 0, 7: 0}, metrics.NullValueCounts)
+
+			assert.Equal(t, map[int][]byte{
+				1: mustMarshal(t, iceberg.Int64Literal(-5)),
+				2: []byte("a"),
+				3: mustMarshal(t, iceberg.DecimalLiteral{Val: decimal128.FromI64(150), Scale: 2}),
+				4: mustMarshal(t, iceberg.DateLiteral(-3)),
+				// the millisecond before the epoch, without nanoseconds
+				7: mustMarshal(t, iceberg.TimestampLiteral(-1000)),
+			}, metrics.LowerBounds)
+			assert.Equal(t, map[int][]byte{
+				1: mustMarshal(t, iceberg.Int64Literal(3)),
+				2: []byte("d"),
+				3: mustMarshal(t, iceberg.DecimalLiteral{Val: decimal128.FromI64(9999), Scale: 2}),
+				4: mustMarshal(t, iceberg.DateLiteral(19010)),
+				// an upper bound without nanoseconds extends to the end
+				// of its millisecond
+				7: mustMarshal(t, iceberg.TimestampLiteral(2_000_999)),
+			}, metrics.UpperBounds)
+		})
+	}
+
+	_, err := table.ORCMetrics(iceio.LocalFS{}, filepath.Join(t.TempDir(), "missing.orc"), sc)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
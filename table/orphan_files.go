@@ -0,0 +1,267 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+)
+
+// OrphanFilesOption configures FindOrphanFiles.
+type OrphanFilesOption func(*orphanFilesCfg)
+
+type orphanFilesCfg struct {
+	equalSchemes     map[string]string
+	equalAuthorities map[string]string
+}
+
+// WithEqualSchemes sets the schemes which are considered equal when
+// comparing the listed files to the files referenced by the table, as a
+// map of comma separated schemes to the scheme they are equal to. The
+// default is {"s3a,s3n": "s3"}, and the given schemes are added to it.
+func WithEqualSchemes(schemes map[string]string) OrphanFilesOption {
+	return func(cfg *orphanFilesCfg) {
+		addEquivalences(cfg.equalSchemes, schemes)
+	}
+}
+
+// WithEqualAuthorities sets the authorities, e.g. the buckets of an
+// object store, which are considered equal when comparing the listed
+// files to the files referenced by the table, as a map of comma separated
+// authorities to the authority they are equal to.
+func WithEqualAuthorities(authorities map[string]string) OrphanFilesOption {
+	return func(cfg *orphanFilesCfg) {
+		addEquivalences(cfg.equalAuthorities, authorities)
+	}
+}
+
+func addEquivalences(dst, equivalences map[string]string) {
+	for keys, v := range equivalences {
+		for _, k := range strings.Split(keys, ",") {
+			dst[strings.ToLower(strings.TrimSpace(k))] = strings.ToLower(v)
+		}
+	}
+}
+
+// FindOrphanFiles lists the files under the data and metadata locations of
+// tbl and returns the paths of those which aren't referenced by the table:
+// the current and previous metadata files, and the manifest lists,
+// manifests, data and delete files of its snapshots.
+//
+// Files are compared by their path, with the scheme and authority of the
+// listed and referenced locations compared separately so that e.g.
+// file:/tmp/a and file:///tmp/a, or s3a://bucket/a and s3://bucket/a, are
+// the same file. A missing scheme or authority matches any. A listed file
+// with the path of a referenced file but another scheme or authority
+// can't be told apart from it, which is an error, see WithEqualSchemes
+// and WithEqualAuthorities.
+//
+// Only files last modified before olderThan are returned, since the files
+// of writes which are still in progress aren't referenced by the table
+// yet. The directories are listed through fsys, which must return a
+// [iceio.ReadDirFile] when opening a directory.
+func FindOrphanFiles(ctx context.Context, tbl *Table, fsys iceio.IO, olderThan time.Time, opts ...OrphanFilesOption) ([]string, error) {
+	cfg := orphanFilesCfg{
+		equalSchemes:     map[string]string{"s3a": "s3", "s3n": "s3"},
+		equalAuthorities: map[string]string{},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	meta := tbl.metadata
+
+	files, err := snapshotFiles(fsys, meta.Snapshots(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	if tbl.metadataLocation != "" {
+		files[tbl.metadataLocation] = struct{}{}
+	}
+	for entry := range meta.PreviousFiles() {
+		files[entry.MetadataFile] = struct{}{}
+	}
+
+	referenced := make(map[string][]fileLocation, len(files))
+	for f := range files {
+		loc := cfg.parseLocation(f)
+		referenced[loc.path] = append(referenced[loc.path], loc)
+	}
+
+	var (
+		orphaned []string
+		visited  = make(set[string])
+	)
+	for _, dir := range tableDirs(meta.Location(), meta.Properties()) {
+		err := walkFiles(ctx, fsys, dir, visited, func(path string, info fs.FileInfo) error {
+			listed := cfg.parseLocation(path)
+			if refs, ok := referenced[listed.path]; ok {
+				for _, ref := range refs {
+					if !listed.matches(ref) {
+						return fmt.Errorf("%w: cannot tell whether %s is an orphan, the table references %s with the same path but another scheme or authority",
+							iceberg.ErrInvalidArgument, path, ref.raw)
+					}
+				}
+
+				return nil
+			}
+
+			if info.ModTime().Before(olderThan) {
+				orphaned = append(orphaned, path)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	slices.Sort(orphaned)
+
+	return orphaned, nil
+}
+
+// tableDirs returns the data and metadata directories of a table, which
+// are listed as strings rather than URLs since local paths may contain
+// characters such as '#' which aren't valid in a URL.
+func tableDirs(location string, props iceberg.Properties) []string {
+	location = strings.TrimSuffix(location, "/")
+
+	return []string{
+		props.Get(WriteDataPathKey, location+"/data"),
+		props.Get(WriteMetadataPathKey, location+"/metadata"),
+	}
+}
+
+// fileLocation is a file location split into its scheme, authority and
+// path, with the scheme and authority mapped to their equivalent ones.
+type fileLocation struct {
+	raw, scheme, authority, path string
+}
+
+func (cfg orphanFilesCfg) parseLocation(raw string) fileLocation {
+	loc := fileLocation{raw: raw, path: raw}
+
+	if i := strings.Index(raw, ":"); i > 1 && isScheme(raw[:i]) {
+		loc.scheme, loc.path = strings.ToLower(raw[:i]), raw[i+1:]
+		if rest, ok := strings.CutPrefix(loc.path, "//"); ok {
+			loc.authority, loc.path, _ = strings.Cut(rest, "/")
+			loc.authority = strings.ToLower(loc.authority)
+			loc.path = "/" + loc.path
+		}
+	}
+
+	if s, ok := cfg.equalSchemes[loc.scheme]; ok {
+		loc.scheme = s
+	}
+	if a, ok := cfg.equalAuthorities[loc.authority]; ok {
+		loc.authority = a
+	}
+
+	return loc
+}
+
+// isScheme reports whether s is a URI scheme, the single letter of a
+// Windows drive isn't one.
+func isScheme(s string) bool {
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r >= '0' && r <= '9' || r == '+' || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// matches reports whether two locations with the same path are the same
+// file, with a missing scheme or authority matching any.
+func (l fileLocation) matches(other fileLocation) bool {
+	return (l.scheme == "" || other.scheme == "" || l.scheme == other.scheme) &&
+		(l.authority == "" || other.authority == "" || l.authority == other.authority)
+}
+
+// walkFiles calls fn for every regular file under dir, stopping at the
+// first error. Directories which were already visited are skipped and a
+// missing dir isn't an error.
+func walkFiles(ctx context.Context, fsys iceio.IO, dir string, visited set[string], fn func(string, fs.FileInfo) error) error {
+	dir = strings.TrimSuffix(dir, "/")
+	if _, ok := visited[dir]; ok {
+		return nil
+	}
+	visited[dir] = struct{}{}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f, err := fsys.Open(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+	defer f.Close()
+
+	d, ok := f.(iceio.ReadDirFile)
+	if !ok {
+		return fmt.Errorf("%w: cannot list directory %s", errors.ErrUnsupported, dir)
+	}
+
+	entries, err := d.ReadDir(-1)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		path := dir + "/" + e.Name()
+		if e.IsDir() {
+			if err := walkFiles(ctx, fsys, path, visited, fn); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().IsRegular() {
+			if err := fn(path, info); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrphanFilesLocationMatching(t *testing.T) {
+	cfg := orphanFilesCfg{
+		equalSchemes:     map[string]string{"s3a": "s3", "s3n": "s3"},
+		equalAuthorities: map[string]string{},
+	}
+	WithEqualSchemes(map[string]string{"gs, gcs": "gs"})(&cfg)
+	WithEqualAuthorities(map[string]string{"bucket-alias": "bucket"})(&cfg)
+
+	tests := []struct {
+		listed, referenced string
+		matches            bool
+	}{
+		{"/tmp/t/data/a.parquet", "/tmp/t/data/a.parquet", true},
+		{"/tmp/t/data/a.parquet", "file:/tmp/t/data/a.parquet", true},
+		{"file:/tmp/t/data/a.parquet", "file:///tmp/t/data/a.parquet", true},
+		{"s3a://bucket/t/a.parquet", "s3://bucket/t/a.parquet", true},
+		{"S3N://Bucket/t/a.parquet", "s3://bucket/t/a.parquet", true},
+		{"gcs://bucket/t/a.parquet", "gs://bucket/t/a.parquet", true},
+		{"s3://bucket-alias/t/a.parquet", "s3://bucket/t/a.parquet", true},
+		{"s3://bucket/t/a.parquet", "/t/a.parquet", true},
+		{"s3://bucket/t/a.parquet", "gs://bucket/t/a.parquet", false},
+		{"s3://other/t/a.parquet", "s3://bucket/t/a.parquet", false},
+	}
+
+	for _, tt := range tests {
+		listed, referenced := cfg.parseLocation(tt.listed), cfg.parseLocation(tt.referenced)
+		assert.Equal(t, referenced.path, listed.path, tt.listed)
+		assert.Equal(t, tt.matches, listed.matches(referenced), "%s and %s", tt.listed, tt.referenced)
+	}
+
+	// a windows drive isn't a scheme
+	assert.Equal(t, "C:/t/a.parquet", cfg.parseLocation("C:/t/a.parquet").path)
+}
@@ -0,0 +1,375 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+)
+
+// ErrConflictingDeletes is returned when committing an overwrite if rows
+// matching its filter were deleted by a concurrent commit.
+var ErrConflictingDeletes = errors.New("found conflicting deletes")
+
+// OverwriteFilesBuilder replaces the data files of a table which match a
+// row filter with new data files, as a new snapshot with the overwrite
+// operation.
+type OverwriteFilesBuilder struct {
+	tbl           *Table
+	rowFilter     iceberg.BooleanExpression
+	caseSensitive bool
+	files         []iceberg.DataFile
+	snapshotProps iceberg.Properties
+}
+
+// OverwriteFiles returns a builder for overwriting the data of tbl which
+// matches a row filter with new data files.
+func OverwriteFiles(tbl *Table) *OverwriteFilesBuilder {
+	return &OverwriteFilesBuilder{
+		tbl:           tbl,
+		rowFilter:     iceberg.AlwaysFalse{},
+		caseSensitive: true,
+	}
+}
+
+// OverwriteByRowFilter deletes the data files whose rows all match filter.
+// Files are only deleted as a whole, so it is an error for filter to match
+// some, but not all, rows of a file; e.g. a filter on partition source
+// columns always selects whole files.
+func (o *OverwriteFilesBuilder) OverwriteByRowFilter(filter iceberg.BooleanExpression) *OverwriteFilesBuilder {
+	o.rowFilter = filter
+
+	return o
+}
+
+// CaseSensitive sets whether column names in the row filter are matched
+// case sensitively, which they are by default.
+func (o *OverwriteFilesBuilder) CaseSensitive(caseSensitive bool) *OverwriteFilesBuilder {
+	o.caseSensitive = caseSensitive
+
+	return o
+}
+
+// AddFile adds a new data file to be added by the overwrite.
+func (o *OverwriteFilesBuilder) AddFile(df iceberg.DataFile) *OverwriteFilesBuilder {
+	o.files = append(o.files, df)
+
+	return o
+}
+
+// SnapshotProperties sets additional properties to store in the summary of
+// the new snapshot.
+func (o *OverwriteFilesBuilder) SnapshotProperties(props iceberg.Properties) *OverwriteFilesBuilder {
+	o.snapshotProps = props
+
+	return o
+}
+
+// Commit stages the overwrite and commits it through the table's catalog,
+// returning the updated table.
+//
+// If the table was changed since tbl was loaded, the overwrite is applied
+// on top of the latest version of the table, as long as none of the
+// snapshots committed in the meantime, i.e. with a higher sequence number,
+// deleted rows which match the row filter. Otherwise ErrConflictingDeletes
+// is returned.
+func (o *OverwriteFilesBuilder) Commit(ctx context.Context) (*Table, error) {
+	tbl := o.tbl
+	if tbl.cat != nil {
+		latest, err := tbl.loadLatest(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if latest != nil {
+			fs, err := latest.fsF(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			err = validateNoConflictingDeletes(fs, tbl.metadata, latest.metadata,
+				o.rowFilter, o.caseSensitive)
+			if err != nil {
+				return nil, err
+			}
+			tbl = latest
+		}
+	}
+
+	txn := tbl.NewTransaction()
+	err := txn.OverwriteDataFiles(ctx, o.rowFilter, o.caseSensitive, o.files, o.snapshotProps)
+	if err != nil {
+		return nil, err
+	}
+
+	return txn.Commit(ctx)
+}
+
+// OverwriteDataFiles stages a new snapshot which deletes the data files
+// whose rows all match rowFilter and adds the given data files. See
+// [OverwriteFilesBuilder.OverwriteByRowFilter] for how files are matched.
+func (t *Transaction) OverwriteDataFiles(ctx context.Context, rowFilter iceberg.BooleanExpression, caseSensitive bool, files []iceberg.DataFile, snapshotProps iceberg.Properties) error {
+	if err := t.validateNewDataFiles(files); err != nil {
+		return err
+	}
+
+	fs, err := t.tbl.fsF(ctx)
+	if err != nil {
+		return err
+	}
+
+	meta, err := t.stagedMetadata()
+	if err != nil {
+		return err
+	}
+
+	toDelete, err := dataFilesMatchingFilter(fs, meta, rowFilter, caseSensitive)
+	if err != nil {
+		return err
+	}
+
+	updater := t.updateSnapshot(fs, snapshotProps).mergeOverwrite(nil)
+	for _, df := range toDelete {
+		updater.deleteDataFile(df)
+	}
+
+	for _, df := range files {
+		updater.appendDataFile(df)
+	}
+
+	updates, reqs, err := updater.commit()
+	if err != nil {
+		return err
+	}
+
+	return t.apply(updates, reqs)
+}
+
+// fileFilterEvaluator determines whether none, some or all of the rows of
+// a file may match a row filter, using the file's partition values and
+// column metrics.
+type fileFilterEvaluator struct {
+	rowFilter     iceberg.BooleanExpression
+	schema        *iceberg.Schema
+	specs         map[int32]iceberg.PartitionSpec
+	residuals     map[int32]*iceberg.ResidualEvaluator
+	metricsEval   func(iceberg.DataFile) (bool, error)
+	strictEval    func(iceberg.DataFile) (bool, error)
+	caseSensitive bool
+}
+
+func newFileFilterEvaluator(meta Metadata, rowFilter iceberg.BooleanExpression, caseSensitive bool) (*fileFilterEvaluator, error) {
+	metricsEval, err := newInclusiveMetricsEvaluator(meta.CurrentSchema(), rowFilter, caseSensitive, false)
+	if err != nil {
+		return nil, err
+	}
+
+	strictEval, err := newStrictMetricsEvaluator(meta.CurrentSchema(), rowFilter, caseSensitive)
+	if err != nil {
+		return nil, err
+	}
+
+	specs := make(map[int32]iceberg.PartitionSpec)
+	for _, spec := range meta.PartitionSpecs() {
+		specs[int32(spec.ID())] = spec
+	}
+
+	return &fileFilterEvaluator{
+		rowFilter:     rowFilter,
+		schema:        meta.CurrentSchema(),
+		specs:         specs,
+		residuals:     make(map[int32]*iceberg.ResidualEvaluator),
+		metricsEval:   metricsEval,
+		strictEval:    strictEval,
+		caseSensitive: caseSensitive,
+	}, nil
+}
+
+// residual returns the part of the row filter which remains to be evaluated
+// for the rows of df after taking its partition values into account.
+func (f *fileFilterEvaluator) residual(df iceberg.DataFile) (iceberg.BooleanExpression, error) {
+	spec, ok := f.specs[df.SpecID()]
+	if !ok {
+		return nil, fmt.Errorf("%w: data file %s has unknown partition spec id %d",
+			ErrInvalidMetadata, df.FilePath(), df.SpecID())
+	}
+
+	eval, ok := f.residuals[df.SpecID()]
+	if !ok {
+		var err error
+		eval, err = iceberg.NewResidualEvaluator(spec, f.schema, f.rowFilter, f.caseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		f.residuals[df.SpecID()] = eval
+	}
+
+	return eval.Residual(getPartitionRecord(df, spec.PartitionType(f.schema)))
+}
+
+// mayMatch returns whether any row of df may match the row filter.
+func (f *fileFilterEvaluator) mayMatch(df iceberg.DataFile) (bool, error) {
+	residual, err := f.residual(df)
+	if err != nil {
+		return false, err
+	}
+
+	if residual.Equals(iceberg.AlwaysFalse{}) {
+		return false, nil
+	}
+
+	return f.metricsEval(df)
+}
+
+// dataFilesMatchingFilter returns the live data files of the current
+// snapshot of meta whose rows all match rowFilter, either because of their
+// partition values or because of their column metrics. It fails if a file
+// may contain rows which match rowFilter as well as rows which don't.
+func dataFilesMatchingFilter(fs iceio.IO, meta Metadata, rowFilter iceberg.BooleanExpression, caseSensitive bool) ([]iceberg.DataFile, error) {
+	snap := meta.CurrentSnapshot()
+	if snap == nil || rowFilter.Equals(iceberg.AlwaysFalse{}) {
+		return nil, nil
+	}
+
+	eval, err := newFileFilterEvaluator(meta, rowFilter, caseSensitive)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests, err := snap.Manifests(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []iceberg.DataFile
+	for _, m := range manifests {
+		if m.ManifestContent() != iceberg.ManifestContentData {
+			continue
+		}
+
+		entries, err := m.FetchEntries(fs, true)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range entries {
+			df := e.DataFile()
+			residual, err := eval.residual(df)
+			if err != nil {
+				return nil, err
+			}
+
+			switch {
+			case residual.Equals(iceberg.AlwaysTrue{}):
+				out = append(out, df)
+			case residual.Equals(iceberg.AlwaysFalse{}):
+			default:
+				mustMatch, err := eval.strictEval(df)
+				if err != nil {
+					return nil, err
+				}
+
+				if mustMatch {
+					out = append(out, df)
+
+					continue
+				}
+
+				mayMatch, err := eval.metricsEval(df)
+				if err != nil {
+					return nil, err
+				}
+
+				if mayMatch {
+					return nil, fmt.Errorf("%w: cannot delete file %s where some, but not all, rows match filter %s",
+						ErrInvalidOperation, df.FilePath(), rowFilter)
+				}
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// validateNoConflictingDeletes checks that none of the snapshots committed
+// to latest after the current snapshot of base removed data files or added
+// delete files which may contain rows matching rowFilter.
+func validateNoConflictingDeletes(fs iceio.IO, base, latest Metadata, rowFilter iceberg.BooleanExpression, caseSensitive bool) error {
+	baseSnap := base.CurrentSnapshot()
+
+	var eval *fileFilterEvaluator
+	for snap := latest.CurrentSnapshot(); snap != nil; {
+		if baseSnap != nil && (snap.SnapshotID == baseSnap.SnapshotID ||
+			(snap.SequenceNumber > 0 && snap.SequenceNumber <= baseSnap.SequenceNumber)) {
+			break
+		}
+
+		if eval == nil {
+			var err error
+			if eval, err = newFileFilterEvaluator(latest, rowFilter, caseSensitive); err != nil {
+				return err
+			}
+		}
+
+		manifests, err := snap.Manifests(fs)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range manifests {
+			if m.SnapshotID() != snap.SnapshotID {
+				continue
+			}
+
+			entries, err := m.FetchEntries(fs, false)
+			if err != nil {
+				return err
+			}
+
+			for _, e := range entries {
+				df := e.DataFile()
+				deletesRows := (e.Status() == iceberg.EntryStatusDELETED && df.ContentType() == iceberg.EntryContentData) ||
+					(e.Status() == iceberg.EntryStatusADDED && df.ContentType() != iceberg.EntryContentData)
+				if !deletesRows || e.SnapshotID() != snap.SnapshotID {
+					continue
+				}
+
+				mayMatch, err := eval.mayMatch(df)
+				if err != nil {
+					return err
+				}
+
+				if mayMatch {
+					return fmt.Errorf("%w: snapshot %d removed rows of %s which may match filter %s",
+						ErrConflictingDeletes, snap.SnapshotID, df.FilePath(), rowFilter)
+				}
+			}
+		}
+
+		if snap.ParentSnapshotID == nil {
+			break
+		}
+		snap = latest.SnapshotByID(*snap.ParentSnapshotID)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/io"
+	"github.com/google/uuid"
+)
+
+type partitionSourceField struct {
+	iceberg.PartitionField
+	accessor *iceberg.Accessor
+}
+
+// partitioner computes the partition of rows of a schema by applying the
+// transforms of a partition spec to their source columns.
+type partitioner struct {
+	spec   iceberg.PartitionSpec
+	schema *iceberg.Schema
+	fields []partitionSourceField
+}
+
+func newPartitioner(schema *iceberg.Schema, spec iceberg.PartitionSpec) (*partitioner, error) {
+	fields := make([]partitionSourceField, 0, spec.NumFields())
+	for field := range spec.Fields() {
+		src, ok := schema.FindFieldByID(field.SourceID)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot find source column for partition field %s",
+				iceberg.ErrInvalidSchema, field)
+		}
+
+		if !field.Transform.CanTransform(src.Type) {
+			return nil, fmt.Errorf("%w: cannot apply transform %s to %s",
+				iceberg.ErrInvalidArgument, field.Transform, src.Type)
+		}
+
+		acc, err := iceberg.BuildAccessor(schema, field.SourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, partitionSourceField{PartitionField: field, accessor: acc})
+	}
+
+	return &partitioner{spec: spec, schema: schema, fields: fields}, nil
+}
+
+// partition returns the transformed partition values of the row, in the
+// order of the partition fields and with nil for null values.
+func (p *partitioner) partition(row structLike) (partitionRecord, error) {
+	out := make(partitionRecord, len(p.fields))
+	for i, f := range p.fields {
+		v := f.accessor.Get(row)
+		if v == nil {
+			continue
+		}
+
+		lit, err := valueToLiteral(v)
+		if err != nil {
+			return nil, fmt.Errorf("partition field %s: %w", f.Name, err)
+		}
+
+		if result := f.Transform.Apply(iceberg.Optional[iceberg.Literal]{Val: lit, Valid: true}); result.Valid {
+			out[i] = result.Val.Any()
+		}
+	}
+
+	return out, nil
+}
+
+// partitionData returns the values of rec keyed by partition field ID,
+// as expected by iceberg.NewDataFileBuilder.
+func (p *partitioner) partitionData(rec partitionRecord) map[int]any {
+	data := make(map[int]any, len(rec))
+	for i, f := range p.fields {
+		if rec[i] != nil {
+			data[f.FieldID] = rec[i]
+		}
+	}
+
+	return data
+}
+
+// partitionKey returns a string uniquely identifying the partition.
+func partitionKey(rec partitionRecord) string {
+	return fmt.Sprintf("%#v", []any(rec))
+}
+
+func (p *partitioner) partitionPath(rec partitionRecord) string {
+	if p.spec.IsUnpartitioned() {
+		return ""
+	}
+
+	return p.spec.PartitionToPath(rec, p.schema)
+}
+
+// partitionedFiles creates the data files of the partitioned writers,
+// named after a single write UUID and numbered in the order they are
+// created, and collects the files once they are closed.
+type partitionedFiles struct {
+	ctx            context.Context
+	fs             io.WriteFileIO
+	loc            LocationProvider
+	schema         *iceberg.Schema
+	props          iceberg.Properties
+	part           *partitioner
+	targetFileSize int64
+	writeUUID      uuid.UUID
+	fileCount      int
+
+	written []iceberg.DataFile
+}
+
+func newPartitionedFiles(ctx context.Context, fs io.WriteFileIO, meta Metadata) (*partitionedFiles, error) {
+	loc, err := LoadLocationProvider(meta.Location(), meta.Properties())
+	if err != nil {
+		return nil, err
+	}
+
+	part, err := newPartitioner(meta.CurrentSchema(), meta.PartitionSpec())
+	if err != nil {
+		return nil, err
+	}
+
+	return &partitionedFiles{
+		ctx:    ctx,
+		fs:     fs,
+		loc:    loc,
+		schema: meta.CurrentSchema(),
+		props:  meta.Properties(),
+		part:   part,
+		targetFileSize: int64(meta.Properties().GetInt(WriteTargetFileSizeBytesKey,
+			WriteTargetFileSizeBytesDefault)),
+		writeUUID: uuid.New(),
+	}, nil
+}
+
+func (p *partitionedFiles) newFileWriter(rec partitionRecord) (*DataFileWriter, error) {
+	name := WriteTask{Uuid: p.writeUUID, ID: p.fileCount}.GenerateDataFileName("parquet")
+	p.fileCount++
+
+	path := p.loc.NewDataLocation(name)
+	if partPath := p.part.partitionPath(rec); partPath != "" {
+		path = p.loc.NewPartitionedDataLocation(partPath, name)
+	}
+
+	wr, err := NewDataFileWriter(p.ctx, p.fs, path, p.schema, p.part.spec, p.props)
+	if err != nil {
+		return nil, err
+	}
+	wr.info.PartitionData = p.part.partitionData(rec)
+
+	return wr, nil
+}
+
+func (p *partitionedFiles) closeFile(wr *DataFileWriter) error {
+	df, err := wr.Close()
+	if err != nil {
+		return err
+	}
+	p.written = append(p.written, df)
+
+	return nil
+}
+
+func takeRows(ctx context.Context, batch arrow.Record, indices []int64) (arrow.Record, error) {
+	bldr := array.NewInt64Builder(compute.GetAllocator(ctx))
+	defer bldr.Release()
+	bldr.AppendValues(indices, nil)
+
+	idx := bldr.NewArray()
+	defer idx.Release()
+
+	out, err := compute.Take(ctx, *compute.DefaultTakeOptions(),
+		compute.NewDatumWithoutOwning(batch), compute.NewDatumWithoutOwning(idx))
+	if err != nil {
+		return nil, err
+	}
+
+	return out.(*compute.RecordDatum).Value, nil
+}
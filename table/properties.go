@@ -71,4 +71,23 @@ const (
 
 	WriteTargetFileSizeBytesKey     = "write.target-file-size-bytes"
 	WriteTargetFileSizeBytesDefault = 512 * 1024 * 1024 // 512 MB
+
+	// WriteWapEnabledKey enables the write-audit-publish workflow: commits
+	// with a WapIDKey snapshot property are staged instead of moving the
+	// branch, until they are published with Publish.
+	WriteWapEnabledKey     = "write.wap.enabled"
+	WriteWapEnabledDefault = false
+
+	// WapIDKey is the snapshot property identifying the staged write of
+	// the write-audit-publish workflow.
+	WapIDKey = "wap.id"
+
+	CommitNumRetriesKey     = "commit.retry.num-retries"
+	CommitNumRetriesDefault = 4
+
+	CommitMinRetryWaitMsKey     = "commit.retry.min-wait-ms"
+	CommitMinRetryWaitMsDefault = 100
+
+	CommitMaxRetryWaitMsKey     = "commit.retry.max-wait-ms"
+	CommitMaxRetryWaitMsDefault = 60 * 1000 // 1 minute
 )
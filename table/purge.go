@@ -0,0 +1,53 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	iceio "github.com/apache/iceberg-go/io"
+)
+
+// PurgeFiles deletes all of the files of tbl: the data and delete files,
+// manifests and manifest lists of every snapshot, and the current and
+// previous metadata files. Catalogs use it to purge a table once it was
+// dropped. The files are deleted with [iceio.DeleteBatch], so files which
+// don't exist anymore are skipped and failures are returned joined
+// together.
+func PurgeFiles(ctx context.Context, tbl *Table) error {
+	fsys, err := tbl.fsF(ctx)
+	if err != nil {
+		return err
+	}
+
+	files, err := snapshotFiles(fsys, tbl.metadata.Snapshots(), false)
+	if err != nil {
+		return err
+	}
+
+	for entry := range tbl.metadata.PreviousFiles() {
+		files[entry.MetadataFile] = struct{}{}
+	}
+	if tbl.metadataLocation != "" {
+		files[tbl.metadataLocation] = struct{}{}
+	}
+
+	return iceio.DeleteBatch(ctx, fsys, slices.Sorted(maps.Keys(files)))
+}
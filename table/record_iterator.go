@@ -0,0 +1,98 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"io"
+	"iter"
+
+	"github.com/apache/arrow-go/v18/arrow"
+)
+
+// RecordIterator pulls the records of a scan one at a time, see
+// Scan.Records.
+type RecordIterator struct {
+	ctx    context.Context
+	schema *arrow.Schema
+	next   func() (arrow.Record, error, bool)
+	stop   func()
+	err    error
+}
+
+// Records returns an iterator over the records of the scan, for callers
+// which pull records rather than range over the ToArrowRecords iterator.
+// Planning errors are returned directly, like for ToArrowRecords.
+//
+// The iterator must be closed once it is no longer used, unless Next
+// returned an error, so that the file readers of the scan are closed.
+func (scan *Scan) Records(ctx context.Context) (*RecordIterator, error) {
+	schema, itr, err := scan.ToArrowRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	next, stop := iter.Pull2(itr)
+
+	return &RecordIterator{ctx: ctx, schema: schema, next: next, stop: stop}, nil
+}
+
+// Schema returns the arrow schema of the records.
+func (it *RecordIterator) Schema() *arrow.Schema { return it.schema }
+
+// Next returns the next record, which must be released by the caller.
+// It returns io.EOF once all records were returned, and the error of the
+// iterator's context once it is done, which is checked between records.
+// After returning an error the scan is stopped and its readers closed,
+// and Next keeps returning the same error.
+func (it *RecordIterator) Next() (arrow.Record, error) {
+	if it.err != nil {
+		return nil, it.err
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		return nil, it.fail(err)
+	}
+
+	rec, err, ok := it.next()
+	switch {
+	case !ok:
+		return nil, it.fail(io.EOF)
+	case err != nil:
+		return nil, it.fail(err)
+	}
+
+	return rec, nil
+}
+
+func (it *RecordIterator) fail(err error) error {
+	it.err = err
+	it.stop()
+
+	return err
+}
+
+// Close stops the scan, waiting for its file readers to be closed. Next
+// returns io.EOF after the iterator was closed. Close can be called any
+// number of times.
+func (it *RecordIterator) Close() {
+	if it.err == nil {
+		it.err = io.EOF
+	}
+	it.stop()
+}
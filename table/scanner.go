@@ -18,13 +18,12 @@
 package table
 
 import (
-	"cmp"
 	"context"
-	"errors"
 	"fmt"
 	"iter"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
@@ -87,10 +86,12 @@ func (p partitionRecord) Size() int            { return len(p) }
 func (p partitionRecord) Get(pos int) any      { return p[pos] }
 func (p partitionRecord) Set(pos int, val any) { p[pos] = val }
 
-// manifestEntries holds the data and positional delete entries read from manifests.
+// manifestEntries holds the data, positional delete and equality delete
+// entries read from manifests.
 type manifestEntries struct {
 	dataEntries             []iceberg.ManifestEntry
 	positionalDeleteEntries []iceberg.ManifestEntry
+	equalityDeleteEntries   []iceberg.ManifestEntry
 	mu                      sync.Mutex
 }
 
@@ -98,6 +99,7 @@ func newManifestEntries() *manifestEntries {
 	return &manifestEntries{
 		dataEntries:             make([]iceberg.ManifestEntry, 0),
 		positionalDeleteEntries: make([]iceberg.ManifestEntry, 0),
+		equalityDeleteEntries:   make([]iceberg.ManifestEntry, 0),
 	}
 }
 
@@ -113,6 +115,12 @@ func (m *manifestEntries) addPositionalDeleteEntry(e iceberg.ManifestEntry) {
 	m.positionalDeleteEntries = append(m.positionalDeleteEntries, e)
 }
 
+func (m *manifestEntries) addEqualityDeleteEntry(e iceberg.ManifestEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.equalityDeleteEntries = append(m.equalityDeleteEntries, e)
+}
+
 func getPartitionRecord(dataFile iceberg.DataFile, partitionType *iceberg.StructType) partitionRecord {
 	partitionData := dataFile.Partition()
 
@@ -124,10 +132,18 @@ func getPartitionRecord(dataFile iceberg.DataFile, partitionType *iceberg.Struct
 	return out
 }
 
-func openManifest(io io.IO, manifest iceberg.ManifestFile,
+func openManifest(io io.IO, manifest iceberg.ManifestFile, cache *ManifestCache,
 	partitionFilter, metricsEval func(iceberg.DataFile) (bool, error),
 ) ([]iceberg.ManifestEntry, error) {
-	entries, err := manifest.FetchEntries(io, true)
+	var (
+		entries []iceberg.ManifestEntry
+		err     error
+	)
+	if cache != nil {
+		entries, err = cache.FetchEntries(io, manifest, true)
+	} else {
+		entries, err = manifest.FetchEntries(io, true)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +180,8 @@ type Scan struct {
 
 	partitionFilters *keyDefaultMap[int, iceberg.BooleanExpression]
 	concurrency      int
+	unordered        bool
+	manifestCache    *ManifestCache
 }
 
 func (scan *Scan) UseRowLimit(n int64) *Scan {
@@ -173,6 +191,31 @@ func (scan *Scan) UseRowLimit(n int64) *Scan {
 	return &out
 }
 
+// Filter returns a copy of the scan whose row filter is the conjunction of
+// the existing row filter and the provided expression.
+func (scan *Scan) Filter(expr iceberg.BooleanExpression) *Scan {
+	out := *scan
+	out.rowFilter = iceberg.NewAnd(scan.rowFilter, expr)
+	out.partitionFilters = newKeyDefaultMapWrapErr(out.buildPartitionProjection)
+
+	return &out
+}
+
+// Select returns a copy of the scan that projects only the given columns.
+// If the scan already has a narrower selection, the result is the
+// intersection of the two.
+func (scan *Scan) Select(cols ...string) *Scan {
+	out := *scan
+	if slices.Contains(scan.selectedFields, "*") {
+		out.selectedFields = slices.Clone(cols)
+	} else {
+		out.selectedFields = slices.DeleteFunc(slices.Clone(scan.selectedFields),
+			func(c string) bool { return !slices.Contains(cols, c) })
+	}
+
+	return &out
+}
+
 func (scan *Scan) UseRef(name string) (*Scan, error) {
 	if scan.snapshotID != nil {
 		return nil, fmt.Errorf("%w: cannot override ref, already set snapshot id %d",
@@ -190,6 +233,26 @@ func (scan *Scan) UseRef(name string) (*Scan, error) {
 	return nil, fmt.Errorf("%w: cannot scan unknown ref=%s", iceberg.ErrInvalidArgument, name)
 }
 
+// AsOf returns a copy of the scan which reads the snapshot that was the
+// current snapshot of the table at time t, see [AsOfTime].
+func (scan *Scan) AsOf(t time.Time) (*Scan, error) {
+	if scan.snapshotID != nil {
+		return nil, fmt.Errorf("%w: cannot scan as of %s, already set snapshot id %d",
+			iceberg.ErrInvalidArgument, t, *scan.snapshotID)
+	}
+
+	snap, err := snapshotAsOf(scan.metadata, t)
+	if err != nil {
+		return nil, err
+	}
+
+	out := *scan
+	out.snapshotID = &snap.SnapshotID
+	out.partitionFilters = newKeyDefaultMapWrapErr(out.buildPartitionProjection)
+
+	return &out, nil
+}
+
 func (scan *Scan) Snapshot() *Snapshot {
 	if scan.snapshotID != nil {
 		return scan.metadata.SnapshotByID(*scan.snapshotID)
@@ -254,6 +317,21 @@ func (scan *Scan) buildPartitionEvaluator(specID int) func(iceberg.DataFile) (bo
 	}
 }
 
+func (scan *Scan) buildResidualEvaluator(specID int) func(iceberg.DataFile) (iceberg.BooleanExpression, error) {
+	spec := scan.metadata.PartitionSpecs()[specID]
+	partType := spec.PartitionType(scan.metadata.CurrentSchema())
+	eval, err := iceberg.NewResidualEvaluator(spec, scan.metadata.CurrentSchema(),
+		scan.rowFilter, scan.caseSensitive)
+
+	return func(d iceberg.DataFile) (iceberg.BooleanExpression, error) {
+		if err != nil {
+			return nil, err
+		}
+
+		return eval.Residual(getPartitionRecord(d, partType))
+	}
+}
+
 func (scan *Scan) checkSequenceNumber(minSeqNum int64, manifest iceberg.ManifestFile) bool {
 	return manifest.ManifestContent() == iceberg.ManifestContentData ||
 		(manifest.ManifestContent() == iceberg.ManifestContentDeletes &&
@@ -271,32 +349,6 @@ func minSequenceNum(manifests []iceberg.ManifestFile) int64 {
 	return n
 }
 
-func matchDeletesToData(entry iceberg.ManifestEntry, positionalDeletes []iceberg.ManifestEntry) ([]iceberg.DataFile, error) {
-	idx, _ := slices.BinarySearchFunc(positionalDeletes, entry, func(me1, me2 iceberg.ManifestEntry) int {
-		return cmp.Compare(me1.SequenceNum(), me2.SequenceNum())
-	})
-
-	evaluator, err := newInclusiveMetricsEvaluator(iceberg.PositionalDeleteSchema,
-		iceberg.EqualTo(iceberg.Reference("file_path"), entry.DataFile().FilePath()), true, false)
-	if err != nil {
-		return nil, err
-	}
-
-	out := make([]iceberg.DataFile, 0)
-	for _, relevant := range positionalDeletes[idx:] {
-		df := relevant.DataFile()
-		ok, err := evaluator(df)
-		if err != nil {
-			return nil, err
-		}
-		if ok {
-			out = append(out, df)
-		}
-	}
-
-	return out, nil
-}
-
 // fetchPartitionSpecFilteredManifests retrieves the table's current snapshot,
 // fetches its manifest files, and applies partition-spec filters to remove irrelevant manifests.
 func (scan *Scan) fetchPartitionSpecFilteredManifests(ctx context.Context) ([]iceberg.ManifestFile, error) {
@@ -363,7 +415,7 @@ func (scan *Scan) collectManifestEntries(
 				return err
 			}
 			partEval := partitionEvaluators.Get(int(mf.PartitionSpecID()))
-			manifestEntries, err := openManifest(fs, mf, partEval, metricsEval)
+			manifestEntries, err := openManifest(fs, mf, scan.manifestCache, partEval, metricsEval)
 			if err != nil {
 				return err
 			}
@@ -376,7 +428,7 @@ func (scan *Scan) collectManifestEntries(
 				case iceberg.EntryContentPosDeletes:
 					entries.addPositionalDeleteEntry(e)
 				case iceberg.EntryContentEqDeletes:
-					return errors.New("iceberg-go does not yet support equality deletes")
+					entries.addEqualityDeleteEntry(e)
 				default:
 					return fmt.Errorf("%w: unknown DataFileContent type (%s): %s",
 						ErrInvalidMetadata, df.ContentType(), e)
@@ -409,22 +461,27 @@ func (scan *Scan) PlanFiles(ctx context.Context) ([]FileScanTask, error) {
 		return nil, err
 	}
 
-	// Step 3: Sort positional deletes and match them to data files.
-	slices.SortFunc(entries.positionalDeleteEntries, func(a, b iceberg.ManifestEntry) int {
-		return cmp.Compare(a.SequenceNum(), b.SequenceNum())
-	})
+	// Step 3: Index the positional and equality deletes to match them to data files.
+	deletes, err := NewDeleteIndex(scan.metadata.CurrentSchema(), scan.metadata.PartitionSpecs(),
+		slices.Concat(entries.positionalDeleteEntries, entries.equalityDeleteEntries))
+	if err != nil {
+		return nil, err
+	}
+
+	residualEvaluators := newKeyDefaultMap(scan.buildResidualEvaluator)
 
 	results := make([]FileScanTask, 0, len(entries.dataEntries))
 	for _, e := range entries.dataEntries {
-		deleteFiles, err := matchDeletesToData(e, entries.positionalDeleteEntries)
+		residual, err := residualEvaluators.Get(int(e.DataFile().SpecID()))(e.DataFile())
 		if err != nil {
 			return nil, err
 		}
 		results = append(results, FileScanTask{
 			File:        e.DataFile(),
-			DeleteFiles: deleteFiles,
+			DeleteFiles: deletes.ForDataFile(e),
 			Start:       0,
 			Length:      e.DataFile().FileSizeBytes(),
+			Residual:    residual,
 		})
 	}
 
@@ -435,6 +492,9 @@ type FileScanTask struct {
 	File          iceberg.DataFile
 	DeleteFiles   []iceberg.DataFile
 	Start, Length int64
+	// Residual is the bound portion of the row filter that still has to
+	// be applied to the rows read from File after partition pruning.
+	Residual iceberg.BooleanExpression
 }
 
 // ToArrowRecords returns the arrow schema of the expected records and an interator
@@ -450,6 +510,7 @@ func (scan *Scan) ToArrowRecords(ctx context.Context) (*arrow.Schema, iter.Seq2[
 	if err != nil {
 		return nil, nil, err
 	}
+	tasks = splitScanTasks(tasks, NewSplitOptions(scan.metadata.Properties()))
 
 	var boundFilter iceberg.BooleanExpression
 	if scan.rowFilter != nil {
@@ -478,6 +539,7 @@ func (scan *Scan) ToArrowRecords(ctx context.Context) (*arrow.Schema, iter.Seq2[
 		rowLimit:        scan.limit,
 		options:         scan.options,
 		concurrency:     scan.concurrency,
+		unordered:       scan.unordered,
 	}).GetRecords(ctx, tasks)
 }
 
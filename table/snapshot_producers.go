@@ -19,7 +19,6 @@ package table
 
 import (
 	"fmt"
-	"io"
 	"maps"
 	"slices"
 	"sync/atomic"
@@ -120,7 +119,7 @@ func (of *overwriteFiles) existingManifests() ([]iceberg.ManifestFile, error) {
 	// determine if there are any existing manifest files
 	existingFiles := make([]iceberg.ManifestFile, 0)
 
-	snap := of.base.txn.meta.currentSnapshot()
+	snap := of.base.txn.branchSnapshot()
 	if snap == nil {
 		return existingFiles, nil
 	}
@@ -161,19 +160,27 @@ func (of *overwriteFiles) existingManifests() ([]iceberg.ManifestFile, error) {
 			return existingFiles, err
 		}
 
-		wr, path, counter, err := of.base.newManifestWriter(*spec)
+		wr, path, counter, out, err := of.base.newManifestWriter(*spec)
 		if err != nil {
 			return existingFiles, err
 		}
 
 		for _, entry := range notDeleted {
 			if err := wr.Existing(entry); err != nil {
+				iceio.AbortWrite(out)
+
 				return existingFiles, err
 			}
 		}
 
 		// close the writer to force a flush and ensure counter.Count is accurate
 		if err := wr.Close(); err != nil {
+			iceio.AbortWrite(out)
+
+			return existingFiles, err
+		}
+
+		if err := out.Close(); err != nil {
 			return existingFiles, err
 		}
 
@@ -260,7 +267,7 @@ func (m *manifestMergeManager) groupBySpec(manifests []iceberg.ManifestFile) map
 }
 
 func (m *manifestMergeManager) createManifest(specID int, bin []iceberg.ManifestFile) (iceberg.ManifestFile, error) {
-	wr, path, counter, err := m.snap.newManifestWriter(m.snap.spec(specID))
+	wr, path, counter, out, err := m.snap.newManifestWriter(m.snap.spec(specID))
 	if err != nil {
 		return nil, err
 	}
@@ -268,6 +275,8 @@ func (m *manifestMergeManager) createManifest(specID int, bin []iceberg.Manifest
 	for _, manifest := range bin {
 		entries, err := m.snap.fetchManifestEntry(manifest, false)
 		if err != nil {
+			iceio.AbortWrite(out)
+
 			return nil, err
 		}
 
@@ -288,6 +297,12 @@ func (m *manifestMergeManager) createManifest(specID int, bin []iceberg.Manifest
 
 	// close the writer to force a flush and ensure counter.Count is accurate
 	if err := wr.Close(); err != nil {
+		iceio.AbortWrite(out)
+
+		return nil, err
+	}
+
+	if err := out.Close(); err != nil {
 		return nil, err
 	}
 
@@ -410,6 +425,66 @@ func (m *mergeAppendFiles) processManifests(manifests []iceberg.ManifestFile) ([
 	return append(result, unmergedDeleteManifests...), nil
 }
 
+type rewriteManifests struct {
+	base *snapshotProducer
+
+	targetSizeBytes int64
+}
+
+func newRewriteManifestsProducer(txn *Transaction, fs iceio.WriteFileIO, commitUUID *uuid.UUID, snapshotProps iceberg.Properties, targetSizeBytes int64) *snapshotProducer {
+	prod := createSnapshotProducer(OpReplace, txn, fs, commitUUID, snapshotProps)
+	prod.producerImpl = &rewriteManifests{base: prod, targetSizeBytes: targetSizeBytes}
+
+	return prod
+}
+
+func (rm *rewriteManifests) processManifests(manifests []iceberg.ManifestFile) ([]iceberg.ManifestFile, error) {
+	return manifests, nil
+}
+
+// existingManifests returns the data manifests of the current snapshot
+// packed into manifests of up to targetSizeBytes per partition spec. The
+// live entries of rewritten manifests are kept as existing entries with
+// their snapshot id and sequence numbers, delete manifests are kept as is.
+func (rm *rewriteManifests) existingManifests() ([]iceberg.ManifestFile, error) {
+	snap := rm.base.txn.branchSnapshot()
+	if snap == nil {
+		return nil, nil
+	}
+
+	manifests, err := snap.Manifests(rm.base.io)
+	if err != nil {
+		return nil, err
+	}
+
+	dataManifests, deleteManifests := []iceberg.ManifestFile{}, []iceberg.ManifestFile{}
+	for _, m := range manifests {
+		if m.ManifestContent() == iceberg.ManifestContentData {
+			dataManifests = append(dataManifests, m)
+		} else {
+			deleteManifests = append(deleteManifests, m)
+		}
+	}
+
+	mergeMgr := manifestMergeManager{
+		targetSizeBytes: int(rm.targetSizeBytes),
+		mergeEnabled:    true,
+		snap:            rm.base,
+	}
+
+	result, err := mergeMgr.mergeManifests(dataManifests)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(result, deleteManifests...), nil
+}
+
+func (rm *rewriteManifests) deletedEntries() ([]iceberg.ManifestEntry, error) {
+	// rewriting manifests doesn't remove any files
+	return nil, nil
+}
+
 type snapshotProducer struct {
 	producerImpl
 
@@ -437,7 +512,7 @@ func createSnapshotProducer(op Operation, txn *Transaction, fs iceio.WriteFileIO
 		commit = *commitUUID
 	}
 
-	if snap := txn.meta.currentSnapshot(); snap != nil {
+	if snap := txn.branchSnapshot(); snap != nil {
 		parentSnapshot = snap.SnapshotID
 	}
 
@@ -474,25 +549,29 @@ func (sp *snapshotProducer) deleteDataFile(df iceberg.DataFile) *snapshotProduce
 	return sp
 }
 
-func (sp *snapshotProducer) newManifestWriter(spec iceberg.PartitionSpec) (*iceberg.ManifestWriter, string, *internal.CountingWriter, error) {
+// newManifestWriter returns a writer for a new manifest file along with
+// its path, the counter of the bytes written to it, and the file itself,
+// which must be closed once the manifest writer is closed, or aborted
+// with iceio.AbortWrite if writing the manifest fails.
+func (sp *snapshotProducer) newManifestWriter(spec iceberg.PartitionSpec) (*iceberg.ManifestWriter, string, *internal.CountingWriter, iceio.FileWriter, error) {
 	out, path, err := sp.newManifestOutput()
 	if err != nil {
-		return nil, "", nil, err
+		return nil, "", nil, nil, err
 	}
 
 	counter := &internal.CountingWriter{W: out}
 	wr, err := iceberg.NewManifestWriter(sp.txn.meta.formatVersion, counter, spec,
 		sp.txn.meta.CurrentSchema(), sp.snapshotID)
 	if err != nil {
-		defer out.Close()
+		iceio.AbortWrite(out)
 
-		return nil, "", nil, err
+		return nil, "", nil, nil, err
 	}
 
-	return wr, path, counter, nil
+	return wr, path, counter, out, nil
 }
 
-func (sp *snapshotProducer) newManifestOutput() (io.WriteCloser, string, error) {
+func (sp *snapshotProducer) newManifestOutput() (iceio.FileWriter, string, error) {
 	provider, err := sp.txn.tbl.LocationProvider()
 	if err != nil {
 		return nil, "", err
@@ -518,17 +597,7 @@ func (sp *snapshotProducer) manifests() ([]iceberg.ManifestFile, error) {
 
 	if len(sp.addedFiles) > 0 {
 		g.Go(func() error {
-			out, path, err := sp.newManifestOutput()
-			if err != nil {
-				return err
-			}
-			defer out.Close()
-
-			counter := &internal.CountingWriter{W: out}
-
-			wr, err := iceberg.NewManifestWriter(sp.txn.meta.formatVersion, counter,
-				sp.txn.meta.CurrentSpec(), sp.txn.meta.CurrentSchema(),
-				sp.snapshotID)
+			wr, path, counter, out, err := sp.newManifestWriter(sp.txn.meta.CurrentSpec())
 			if err != nil {
 				return err
 			}
@@ -537,12 +606,20 @@ func (sp *snapshotProducer) manifests() ([]iceberg.ManifestFile, error) {
 				err := wr.Add(iceberg.NewManifestEntry(iceberg.EntryStatusADDED, &sp.snapshotID,
 					nil, nil, df))
 				if err != nil {
+					iceio.AbortWrite(out)
+
 					return err
 				}
 			}
 
 			// close the writer to force a flush and ensure counter.Count is accurate
 			if err := wr.Close(); err != nil {
+				iceio.AbortWrite(out)
+
+				return err
+			}
+
+			if err := out.Close(); err != nil {
 				return err
 			}
 
@@ -575,11 +652,16 @@ func (sp *snapshotProducer) manifests() ([]iceberg.ManifestFile, error) {
 				if err != nil {
 					return err
 				}
-				defer out.Close()
 
 				mf, err := iceberg.WriteManifest(path, out, sp.txn.meta.formatVersion,
 					sp.spec(specid), sp.txn.meta.CurrentSchema(), sp.snapshotID, entries)
 				if err != nil {
+					iceio.AbortWrite(out)
+
+					return err
+				}
+
+				if err := out.Close(); err != nil {
 					return err
 				}
 				results[1] = append(results[1], mf)
@@ -646,6 +728,13 @@ func (sp *snapshotProducer) summary(props iceberg.Properties) (Summary, error) {
 	}, previousSummary)
 }
 
+// isStaged returns true if the snapshot is a staged write of the
+// write-audit-publish workflow.
+func (sp *snapshotProducer) isStaged() bool {
+	return sp.snapshotProps[WapIDKey] != "" &&
+		sp.txn.meta.props.GetBool(WriteWapEnabledKey, WriteWapEnabledDefault)
+}
+
 func (sp *snapshotProducer) commit() ([]Update, []Requirement, error) {
 	newManifests, err := sp.manifests()
 	if err != nil {
@@ -675,11 +764,16 @@ func (sp *snapshotProducer) commit() ([]Update, []Requirement, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	defer out.Close()
 
 	err = iceberg.WriteManifestList(sp.txn.meta.formatVersion, out,
 		sp.snapshotID, parentSnapshot, &nextSequence, newManifests)
 	if err != nil {
+		iceio.AbortWrite(out)
+
+		return nil, nil, err
+	}
+
+	if err := out.Close(); err != nil {
 		return nil, nil, err
 	}
 
@@ -693,10 +787,30 @@ func (sp *snapshotProducer) commit() ([]Update, []Requirement, error) {
 		TimestampMs:      time.Now().UnixMilli(),
 	}
 
+	// staged snapshots of the write-audit-publish workflow are added to the
+	// table without moving the branch, until they are published
+	if sp.isStaged() {
+		return []Update{NewAddSnapshotUpdate(&snapshot)}, nil, nil
+	}
+
+	// the branch keeps its retention settings when moved to the new snapshot
+	branch := sp.txn.branch
+	ref, ok := sp.txn.meta.refs[branch]
+	current := ref.SnapshotID
+	branchSnapshotID := &current
+	if !ok {
+		ref = SnapshotRef{SnapshotRefType: BranchRef}
+		branchSnapshotID = nil
+		if branch == MainBranch {
+			branchSnapshotID = sp.txn.meta.currentSnapshotID
+		}
+	}
+	ref.SnapshotID = sp.snapshotID
+
 	return []Update{
-			NewAddSnapshotUpdate(&snapshot),
-			NewSetSnapshotRefUpdate("main", sp.snapshotID, BranchRef, -1, -1, -1),
-		}, []Requirement{
-			AssertRefSnapshotID("main", sp.txn.meta.currentSnapshotID),
-		}, nil
+		NewAddSnapshotUpdate(&snapshot),
+		refUpdate(branch, ref),
+	}, []Requirement{
+		AssertRefSnapshotID(branch, branchSnapshotID),
+	}, nil
 }
@@ -26,6 +26,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/apache/iceberg-go"
 	iceio "github.com/apache/iceberg-go/io"
@@ -83,6 +84,8 @@ const (
 	totalFileSizeKey          = "total-files-size"
 	changedPartitionCountProp = "changed-partition-count"
 	changedPartitionPrefix    = "partitions."
+	sourceSnapshotIDKey       = "source-snapshot-id"
+	publishedWapIDKey         = "published-wap-id"
 )
 
 type updateMetrics struct {
@@ -293,6 +296,66 @@ func (s Snapshot) Equals(other Snapshot) bool {
 		s.Summary.Equals(other.Summary)
 }
 
+// Operation returns the operation which produced the snapshot, or an empty
+// Operation if the snapshot has no summary.
+func (s Snapshot) Operation() Operation {
+	if s.Summary == nil {
+		return ""
+	}
+
+	return s.Summary.Operation
+}
+
+// summaryInt parses the integer value of key in the summary, returning
+// false if the key is absent or isn't a valid integer.
+func (s Snapshot) summaryInt(key string) (int64, bool) {
+	if s.Summary == nil {
+		return 0, false
+	}
+
+	v, ok := s.Summary.Properties[key]
+	if !ok {
+		return 0, false
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// AddedDataFiles returns the added-data-files summary value.
+func (s Snapshot) AddedDataFiles() (int64, bool) { return s.summaryInt(addedDataFilesKey) }
+
+// DeletedDataFiles returns the deleted-data-files summary value.
+func (s Snapshot) DeletedDataFiles() (int64, bool) { return s.summaryInt(deletedDataFilesKey) }
+
+// TotalDataFiles returns the total-data-files summary value.
+func (s Snapshot) TotalDataFiles() (int64, bool) { return s.summaryInt(totalDataFilesKey) }
+
+// AddedDeleteFiles returns the added-delete-files summary value.
+func (s Snapshot) AddedDeleteFiles() (int64, bool) { return s.summaryInt(addedDeleteFilesKey) }
+
+// TotalDeleteFiles returns the total-delete-files summary value.
+func (s Snapshot) TotalDeleteFiles() (int64, bool) { return s.summaryInt(totalDeleteFilesKey) }
+
+// AddedRecords returns the added-records summary value.
+func (s Snapshot) AddedRecords() (int64, bool) { return s.summaryInt(addedRecordsKey) }
+
+// DeletedRecords returns the deleted-records summary value.
+func (s Snapshot) DeletedRecords() (int64, bool) { return s.summaryInt(deletedRecordsKey) }
+
+// TotalRecords returns the total-records summary value.
+func (s Snapshot) TotalRecords() (int64, bool) { return s.summaryInt(totalRecordsKey) }
+
+// AddedFilesSize returns the added-files-size summary value in bytes.
+func (s Snapshot) AddedFilesSize() (int64, bool) { return s.summaryInt(addedFileSizeKey) }
+
+// TotalFilesSize returns the total-files-size summary value in bytes.
+func (s Snapshot) TotalFilesSize() (int64, bool) { return s.summaryInt(totalFileSizeKey) }
+
 func (s Snapshot) Manifests(fio iceio.IO) ([]iceberg.ManifestFile, error) {
 	if s.ManifestList != "" {
 		f, err := fio.Open(s.ManifestList)
@@ -338,6 +401,88 @@ func (s Snapshot) dataFiles(fio iceio.IO, fileFilter set[iceberg.ManifestEntryCo
 	}
 }
 
+// AncestorsOf returns the snapshot with the given id followed by its
+// ancestors, following the parent snapshot ids back to the oldest snapshot
+// which is still in the metadata of tbl.
+func AncestorsOf(tbl *Table, snapshotID int64) ([]Snapshot, error) {
+	return ancestorsOf(tbl.metadata, snapshotID)
+}
+
+// CurrentAncestors returns the current snapshot of tbl followed by its
+// ancestors, or nil if the table has no current snapshot.
+func CurrentAncestors(tbl *Table) ([]Snapshot, error) {
+	current := tbl.metadata.CurrentSnapshot()
+	if current == nil {
+		return nil, nil
+	}
+
+	return ancestorsOf(tbl.metadata, current.SnapshotID)
+}
+
+// AsOfTime returns the snapshot which was the current snapshot of tbl at
+// the given time, that is the latest one in the snapshot log committed at
+// or before t. It can be scanned with [WithSnapshotID] to query the table
+// as of that time. An error is returned if that snapshot has expired.
+func AsOfTime(tbl *Table, t time.Time) (*Snapshot, error) {
+	return snapshotAsOf(tbl.metadata, t)
+}
+
+func snapshotAsOf(meta Metadata, t time.Time) (*Snapshot, error) {
+	// the snapshot log is in commit order, so the last entry at or
+	// before t is the one which was current at t
+	var (
+		found bool
+		id    int64
+	)
+	for entry := range meta.SnapshotLogs() {
+		if entry.TimestampMs > t.UnixMilli() {
+			break
+		}
+		found, id = true, entry.SnapshotID
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: no snapshot committed at or before %s",
+			iceberg.ErrInvalidArgument, t.UTC().Format(time.RFC3339Nano))
+	}
+
+	snap := meta.SnapshotByID(id)
+	if snap == nil {
+		return nil, fmt.Errorf("%w: snapshot %d, which was current at %s, has expired",
+			iceberg.ErrInvalidArgument, id, t.UTC().Format(time.RFC3339Nano))
+	}
+
+	return snap, nil
+}
+
+func ancestorsOf(meta Metadata, snapshotID int64) ([]Snapshot, error) {
+	snap := meta.SnapshotByID(snapshotID)
+	if snap == nil {
+		return nil, fmt.Errorf("%w: snapshot %d not found", iceberg.ErrInvalidArgument, snapshotID)
+	}
+
+	var (
+		out  []Snapshot
+		seen = make(set[int64])
+	)
+	for snap != nil {
+		if _, ok := seen[snap.SnapshotID]; ok {
+			return nil, fmt.Errorf("%w: cycle in the ancestry of snapshot %d at snapshot %d",
+				ErrInvalidMetadata, snapshotID, snap.SnapshotID)
+		}
+		seen[snap.SnapshotID] = struct{}{}
+		out = append(out, *snap)
+
+		if snap.ParentSnapshotID == nil {
+			break
+		}
+		// the parent may have been expired
+		snap = meta.SnapshotByID(*snap.ParentSnapshotID)
+	}
+
+	return out, nil
+}
+
 type MetadataLogEntry struct {
 	MetadataFile string `json:"metadata-file"`
 	TimestampMs  int64  `json:"timestamp-ms"`
@@ -437,7 +582,7 @@ func (s *SnapshotSummaryCollector) build() iceberg.Properties {
 
 func updateSnapshotSummaries(sum Summary, previous iceberg.Properties) (Summary, error) {
 	switch sum.Operation {
-	case OpAppend, OpOverwrite, OpDelete:
+	case OpAppend, OpOverwrite, OpDelete, OpReplace:
 	default:
 		return sum, fmt.Errorf("%w: operation: %s", iceberg.ErrNotImplemented, sum.Operation)
 	}
@@ -152,6 +152,22 @@ func TestMergeSnapshotSummaries(t *testing.T) {
 }
 
 func TestInvalidOperation(t *testing.T) {
-	_, err := updateSnapshotSummaries(Summary{Operation: OpReplace}, nil)
+	_, err := updateSnapshotSummaries(Summary{Operation: Operation("compact")}, nil)
 	assert.ErrorIs(t, err, iceberg.ErrNotImplemented)
 }
+
+func TestReplaceOperationKeepsTotals(t *testing.T) {
+	previous := iceberg.Properties{
+		"total-data-files":       "5",
+		"total-delete-files":     "0",
+		"total-records":          "50",
+		"total-files-size":       "1024",
+		"total-position-deletes": "0",
+		"total-equality-deletes": "0",
+	}
+
+	result, err := updateSnapshotSummaries(Summary{Operation: OpReplace}, previous)
+	require.NoError(t, err)
+	assert.Equal(t, OpReplace, result.Operation)
+	assert.Equal(t, previous, result.Properties)
+}
@@ -19,8 +19,11 @@ package table_test
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/table"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -115,3 +118,175 @@ func TestSnapshotString(t *testing.T) {
 	assert.Equal(t, `append, {"foo":"bar"}: id=25, parent_id=19, schema_id=3, sequence_number=200, timestamp_ms=1602638573590, manifest_list=s3:/a/b/c.avro`,
 		snapshot.String())
 }
+
+func TestSnapshotSummaryAccessors(t *testing.T) {
+	var snapshot table.Snapshot
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"snapshot-id": 25,
+		"sequence-number": 200,
+		"timestamp-ms": 1602638573590,
+		"manifest-list": "s3:/a/b/c.avro",
+		"summary": {
+			"operation": "overwrite",
+			"added-data-files": "2",
+			"added-records": "100",
+			"deleted-records": "0",
+			"total-data-files": "5",
+			"total-records": "not a number"
+		}
+	}`), &snapshot))
+
+	assert.Equal(t, table.OpOverwrite, snapshot.Operation())
+
+	for _, tt := range []struct {
+		name     string
+		fn       func() (int64, bool)
+		expected int64
+		ok       bool
+	}{
+		{"added data files", snapshot.AddedDataFiles, 2, true},
+		{"added records", snapshot.AddedRecords, 100, true},
+		{"deleted records", snapshot.DeletedRecords, 0, true},
+		{"total data files", snapshot.TotalDataFiles, 5, true},
+		{"invalid total records", snapshot.TotalRecords, 0, false},
+		{"missing deleted data files", snapshot.DeletedDataFiles, 0, false},
+		{"missing added delete files", snapshot.AddedDeleteFiles, 0, false},
+		{"missing total delete files", snapshot.TotalDeleteFiles, 0, false},
+		{"missing added files size", snapshot.AddedFilesSize, 0, false},
+		{"missing total files size", snapshot.TotalFilesSize, 0, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := tt.fn()
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+
+	snapshot.Summary = nil
+	assert.Equal(t, table.Operation(""), snapshot.Operation())
+	_, ok := snapshot.AddedRecords()
+	assert.False(t, ok)
+}
+
+const ancestryMetadata = `{
+	"format-version": 2,
+	"table-uuid": "9c12d441-03fe-4693-9a96-a0705ddf69c1",
+	"location": "s3://bucket/test/location",
+	"last-sequence-number": 4,
+	"last-updated-ms": 1602638573590,
+	"last-column-id": 1,
+	"current-schema-id": 0,
+	"schemas": [{"type": "struct", "schema-id": 0, "fields": [{"id": 1, "name": "x", "type": "long", "required": true}]}],
+	"default-spec-id": 0,
+	"partition-specs": [{"spec-id": 0, "fields": []}],
+	"last-partition-id": 999,
+	"default-sort-order-id": 0,
+	"sort-orders": [{"order-id": 0, "fields": []}],
+	"current-snapshot-id": 3,
+	"snapshots": [
+		{"snapshot-id": 1, "sequence-number": 1, "timestamp-ms": 1515100955770, "manifest-list": "s3://a/b/1.avro", "summary": {"operation": "append"}},
+		{"snapshot-id": 2, "parent-snapshot-id": 1, "sequence-number": 2, "timestamp-ms": 1525100955770, "manifest-list": "s3://a/b/2.avro", "summary": {"operation": "append"}},
+		{"snapshot-id": 3, "parent-snapshot-id": 2, "sequence-number": 3, "timestamp-ms": 1535100955770, "manifest-list": "s3://a/b/3.avro", "summary": {"operation": "append"}},
+		{"snapshot-id": 4, "parent-snapshot-id": 2, "sequence-number": 4, "timestamp-ms": 1545100955770, "manifest-list": "s3://a/b/4.avro", "summary": {"operation": "append"}}
+	],
+	"snapshot-log": [
+		{"snapshot-id": 1, "timestamp-ms": 1515100955770},
+		{"snapshot-id": 2, "timestamp-ms": 1525100955770},
+		{"snapshot-id": 3, "timestamp-ms": 1535100955770}
+	],
+	"refs": {
+		"main": {"snapshot-id": 3, "type": "branch"},
+		"audit": {"snapshot-id": 4, "type": "branch"}
+	}
+}`
+
+func snapshotIDs(snaps []table.Snapshot) []int64 {
+	ids := make([]int64, len(snaps))
+	for i, s := range snaps {
+		ids[i] = s.SnapshotID
+	}
+
+	return ids
+}
+
+func TestSnapshotAncestors(t *testing.T) {
+	meta, err := table.ParseMetadataString(ancestryMetadata)
+	require.NoError(t, err)
+	tbl := table.New(table.Identifier{"db", "tbl"}, meta, "", nil, nil)
+
+	ancestors, err := table.CurrentAncestors(tbl)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 2, 1}, snapshotIDs(ancestors))
+
+	// the audit branch shares the history of main up to snapshot 2
+	ancestors, err = table.AncestorsOf(tbl, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4, 2, 1}, snapshotIDs(ancestors))
+
+	ancestors, err = table.AncestorsOf(tbl, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, snapshotIDs(ancestors))
+
+	_, err = table.AncestorsOf(tbl, 5)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
+
+func TestSnapshotAncestorsCycle(t *testing.T) {
+	meta, err := table.ParseMetadataString(strings.Replace(ancestryMetadata,
+		`{"snapshot-id": 1, `, `{"snapshot-id": 1, "parent-snapshot-id": 3, `, 1))
+	require.NoError(t, err)
+	tbl := table.New(table.Identifier{"db", "tbl"}, meta, "", nil, nil)
+
+	_, err = table.CurrentAncestors(tbl)
+	assert.ErrorIs(t, err, table.ErrInvalidMetadata)
+}
+
+func TestSnapshotAsOfTime(t *testing.T) {
+	meta, err := table.ParseMetadataString(ancestryMetadata)
+	require.NoError(t, err)
+	tbl := table.New(table.Identifier{"db", "tbl"}, meta, "", nil, nil)
+
+	tests := []struct {
+		name     string
+		ts       time.Time
+		expected int64
+	}{
+		{"exactly on first", time.UnixMilli(1515100955770), 1},
+		{"between first and second", time.UnixMilli(1520000000000), 1},
+		{"exactly on second", time.UnixMilli(1525100955770), 2},
+		{"just before third", time.UnixMilli(1535100955769), 2},
+		{"after last", time.UnixMilli(1600000000000), 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap, err := table.AsOfTime(tbl, tt.ts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, snap.SnapshotID)
+
+			scan, err := tbl.Scan().AsOf(tt.ts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, scan.Snapshot().SnapshotID)
+		})
+	}
+
+	_, err = table.AsOfTime(tbl, time.UnixMilli(1515100955769))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	_, err = tbl.Scan(table.WithSnapshotID(2)).AsOf(time.UnixMilli(1600000000000))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	// the table can't be read as of the time snapshot 2 was current once
+	// it has expired, rather than falling back to its parent
+	meta, err = table.ParseMetadataString(strings.Replace(ancestryMetadata,
+		`{"snapshot-id": 2, "parent-snapshot-id": 1, "sequence-number": 2, "timestamp-ms": 1525100955770, "manifest-list": "s3://a/b/2.avro", "summary": {"operation": "append"}},`, "", 1))
+	require.NoError(t, err)
+	tbl = table.New(table.Identifier{"db", "tbl"}, meta, "", nil, nil)
+
+	_, err = table.AsOfTime(tbl, time.UnixMilli(1525100955770))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	snap, err := table.AsOfTime(tbl, time.UnixMilli(1520000000000))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, snap.SnapshotID)
+}
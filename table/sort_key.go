@@ -0,0 +1,238 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/apache/iceberg-go"
+	"github.com/google/uuid"
+)
+
+// structLike is a single row, see iceberg.Accessor.
+type structLike interface {
+	Size() int
+	Get(pos int) any
+	Set(pos int, val any)
+}
+
+const (
+	sortKeyNullFirst byte = 0x00
+	sortKeyNotNull   byte = 0x01
+	sortKeyNullLast  byte = 0x02
+)
+
+type sortKeyField struct {
+	SortField
+	accessor *iceberg.Accessor
+}
+
+// SortKeyEncoder produces byte keys for rows of a schema such that
+// comparing the keys with bytes.Compare orders the rows as defined by a
+// sort order, taking the transform, direction and null order of each of
+// its fields into account. It can be used to sort rows before they are
+// written to a table with a sort order.
+type SortKeyEncoder struct {
+	fields []sortKeyField
+}
+
+// NewSortKeyEncoder creates a SortKeyEncoder for rows of the given schema,
+// an error is returned if a source column of the sort order can't be found
+// in the schema or can't be transformed.
+func NewSortKeyEncoder(schema *iceberg.Schema, order SortOrder) (*SortKeyEncoder, error) {
+	fields := make([]sortKeyField, len(order.Fields))
+	for i, f := range order.Fields {
+		if f.Direction != SortASC && f.Direction != SortDESC {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSortDirection, f.Direction)
+		}
+
+		if f.NullOrder != NullsFirst && f.NullOrder != NullsLast {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidNullOrder, f.NullOrder)
+		}
+
+		field, ok := schema.FindFieldByID(f.SourceID)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot find source column for sort field %s",
+				iceberg.ErrInvalidSchema, f.String())
+		}
+
+		if !f.Transform.CanTransform(field.Type) {
+			return nil, fmt.Errorf("%w: cannot apply transform %s to %s",
+				iceberg.ErrInvalidArgument, f.Transform, field.Type)
+		}
+
+		acc, err := iceberg.BuildAccessor(schema, f.SourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		fields[i] = sortKeyField{SortField: f, accessor: acc}
+	}
+
+	return &SortKeyEncoder{fields: fields}, nil
+}
+
+// SortKey returns the key of the given row.
+func (e *SortKeyEncoder) SortKey(row structLike) ([]byte, error) {
+	var key []byte
+	for _, f := range e.fields {
+		val := iceberg.Optional[iceberg.Literal]{}
+		if v := f.accessor.Get(row); v != nil {
+			lit, err := valueToLiteral(v)
+			if err != nil {
+				return nil, err
+			}
+			val = iceberg.Optional[iceberg.Literal]{Val: lit, Valid: true}
+		}
+
+		// the position of nulls doesn't depend on the sort direction
+		result := f.Transform.Apply(val)
+		if !result.Valid {
+			if f.NullOrder == NullsFirst {
+				key = append(key, sortKeyNullFirst)
+			} else {
+				key = append(key, sortKeyNullLast)
+			}
+
+			continue
+		}
+
+		key = append(key, sortKeyNotNull)
+		start := len(key)
+
+		var err error
+		if key, err = appendSortKeyValue(key, result.Val); err != nil {
+			return nil, fmt.Errorf("sort field %s: %w", f.String(), err)
+		}
+
+		if f.Direction == SortDESC {
+			for i := start; i < len(key); i++ {
+				key[i] = ^key[i]
+			}
+		}
+	}
+
+	return key, nil
+}
+
+func valueToLiteral(v any) (iceberg.Literal, error) {
+	switch v := v.(type) {
+	case bool:
+		return iceberg.NewLiteral(v), nil
+	case int32:
+		return iceberg.NewLiteral(v), nil
+	case int64:
+		return iceberg.NewLiteral(v), nil
+	case float32:
+		return iceberg.NewLiteral(v), nil
+	case float64:
+		return iceberg.NewLiteral(v), nil
+	case iceberg.Date:
+		return iceberg.NewLiteral(v), nil
+	case iceberg.Time:
+		return iceberg.NewLiteral(v), nil
+	case iceberg.Timestamp:
+		return iceberg.NewLiteral(v), nil
+	case string:
+		return iceberg.NewLiteral(v), nil
+	case []byte:
+		return iceberg.NewLiteral(v), nil
+	case uuid.UUID:
+		return iceberg.NewLiteral(v), nil
+	case iceberg.Decimal:
+		return iceberg.NewLiteral(v), nil
+	case iceberg.Literal:
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("%w: unsupported sort value type %T", iceberg.ErrInvalidArgument, v)
+}
+
+// appendSortKeyValue appends the ascending, order preserving encoding of
+// a literal to the key. Fixed width values are written big endian with the
+// sign bit flipped, variable length values are escaped and terminated so
+// that a value sorts before any value it is a prefix of.
+func appendSortKeyValue(key []byte, lit iceberg.Literal) ([]byte, error) {
+	switch v := lit.(type) {
+	case iceberg.BoolLiteral:
+		if v {
+			return append(key, 1), nil
+		}
+
+		return append(key, 0), nil
+	case iceberg.Int32Literal:
+		return binary.BigEndian.AppendUint32(key, uint32(v)^(1<<31)), nil
+	case iceberg.DateLiteral:
+		return binary.BigEndian.AppendUint32(key, uint32(v)^(1<<31)), nil
+	case iceberg.Int64Literal:
+		return binary.BigEndian.AppendUint64(key, uint64(v)^(1<<63)), nil
+	case iceberg.TimeLiteral:
+		return binary.BigEndian.AppendUint64(key, uint64(v)^(1<<63)), nil
+	case iceberg.TimestampLiteral:
+		return binary.BigEndian.AppendUint64(key, uint64(v)^(1<<63)), nil
+	case iceberg.Float32Literal:
+		// flipping all the bits of negative values and only the sign bit of
+		// positive ones orders -NaN < -Infinity < -0 < 0 < Infinity < NaN
+		bits := math.Float32bits(float32(v))
+		if bits&(1<<31) != 0 {
+			bits = ^bits
+		} else {
+			bits |= 1 << 31
+		}
+
+		return binary.BigEndian.AppendUint32(key, bits), nil
+	case iceberg.Float64Literal:
+		bits := math.Float64bits(float64(v))
+		if bits&(1<<63) != 0 {
+			bits = ^bits
+		} else {
+			bits |= 1 << 63
+		}
+
+		return binary.BigEndian.AppendUint64(key, bits), nil
+	case iceberg.StringLiteral:
+		return appendEscapedBytes(key, []byte(v)), nil
+	case iceberg.BinaryLiteral:
+		return appendEscapedBytes(key, v), nil
+	case iceberg.FixedLiteral:
+		return appendEscapedBytes(key, v), nil
+	case iceberg.UUIDLiteral:
+		return append(key, v[:]...), nil
+	case iceberg.DecimalLiteral:
+		key = binary.BigEndian.AppendUint64(key, uint64(v.Val.HighBits())^(1<<63))
+
+		return binary.BigEndian.AppendUint64(key, v.Val.LowBits()), nil
+	}
+
+	return nil, fmt.Errorf("%w: unsupported sort key type %s", iceberg.ErrInvalidArgument, lit.Type())
+}
+
+// appendEscapedBytes escapes 0x00 as 0x00 0xff and terminates the value
+// with 0x00 0x01, which sorts before any escaped or regular byte.
+func appendEscapedBytes(key, b []byte) []byte {
+	for _, c := range b {
+		key = append(key, c)
+		if c == 0x00 {
+			key = append(key, 0xff)
+		}
+	}
+
+	return append(key, 0x00, 0x01)
+}
@@ -18,7 +18,9 @@
 package table_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"slices"
 	"testing"
 
 	"github.com/apache/iceberg-go"
@@ -108,3 +110,72 @@ func TestUnmarshalInvalidSortTransform(t *testing.T) {
 	err := json.Unmarshal([]byte(badJson), &order)
 	assert.ErrorIs(t, err, iceberg.ErrInvalidTransform)
 }
+
+type sortRow []any
+
+func (r sortRow) Size() int            { return len(r) }
+func (r sortRow) Get(pos int) any      { return r[pos] }
+func (r sortRow) Set(pos int, val any) { r[pos] = val }
+
+func TestSortKey(t *testing.T) {
+	schema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "category", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 2, Name: "ts", Type: iceberg.PrimitiveTypes.Timestamp},
+		iceberg.NestedField{ID: 3, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+
+	order := table.SortOrder{
+		OrderID: 1,
+		Fields: []table.SortField{
+			{SourceID: 1, Transform: iceberg.IdentityTransform{}, Direction: table.SortASC, NullOrder: table.NullsFirst},
+			{SourceID: 2, Transform: iceberg.DayTransform{}, Direction: table.SortDESC, NullOrder: table.NullsLast},
+		},
+	}
+
+	enc, err := table.NewSortKeyEncoder(schema, order)
+	require.NoError(t, err)
+
+	const day = int64(24 * 60 * 60 * 1_000_000)
+	expected := []sortRow{
+		{nil, iceberg.Timestamp(3 * day), int64(1)},
+		{nil, nil, int64(2)},
+		{"a", iceberg.Timestamp(2*day + 10), int64(3)},
+		{"a", iceberg.Timestamp(-day), int64(4)},
+		{"a", nil, int64(5)},
+		{"a\x00", iceberg.Timestamp(0), int64(6)},
+		{"ab", iceberg.Timestamp(5 * day), int64(7)},
+		{"ab", iceberg.Timestamp(day), int64(8)},
+		{"b", nil, int64(9)},
+	}
+
+	rows := slices.Clone(expected)
+	slices.Reverse(rows)
+	rows[2], rows[5] = rows[5], rows[2]
+
+	keys := make(map[int64][]byte, len(rows))
+	for _, r := range rows {
+		keys[r[2].(int64)], err = enc.SortKey(r)
+		require.NoError(t, err)
+	}
+
+	slices.SortStableFunc(rows, func(a, b sortRow) int {
+		return bytes.Compare(keys[a[2].(int64)], keys[b[2].(int64)])
+	})
+	assert.Equal(t, expected, rows)
+
+	// rows in the same day compare equal, as the key only uses the transformed value
+	k1, err := enc.SortKey(sortRow{"a", iceberg.Timestamp(day + 1), int64(10)})
+	require.NoError(t, err)
+	k2, err := enc.SortKey(sortRow{"a", iceberg.Timestamp(2*day - 1), int64(11)})
+	require.NoError(t, err)
+	assert.Equal(t, k1, k2)
+
+	_, err = table.NewSortKeyEncoder(schema, table.SortOrder{Fields: []table.SortField{
+		{SourceID: 4, Transform: iceberg.IdentityTransform{}, Direction: table.SortASC, NullOrder: table.NullsFirst},
+	}})
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+
+	_, err = table.NewSortKeyEncoder(schema, table.SortOrder{Fields: []table.SortField{
+		{SourceID: 3, Transform: iceberg.DayTransform{}, Direction: table.SortASC, NullOrder: table.NullsFirst},
+	}})
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
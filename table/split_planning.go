@@ -0,0 +1,229 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"slices"
+
+	"github.com/apache/iceberg-go"
+)
+
+const (
+	ReadSplitTargetSizeKey     = "read.split.target-size"
+	ReadSplitTargetSizeDefault = 128 * 1024 * 1024 // 128 MB
+
+	ReadSplitLookbackKey     = "read.split.planning-lookback"
+	ReadSplitLookbackDefault = 10
+
+	ReadSplitOpenFileCostKey     = "read.split.open-file-cost"
+	ReadSplitOpenFileCostDefault = 4 * 1024 * 1024 // 4 MB
+)
+
+// SplitOptions controls how PlanTasks splits files and how CombineTasks
+// combines them.
+type SplitOptions struct {
+	// TargetSize is the size in bytes each combined task aims for.
+	TargetSize int64
+	// Lookback is the number of partially filled tasks that are kept
+	// open while packing, larger values pack tighter at the cost of
+	// reordering files.
+	Lookback int
+	// OpenFileCost is the minimum weight of a split, so that combining
+	// many tiny files still accounts for the cost of opening each one.
+	OpenFileCost int64
+}
+
+// NewSplitOptions returns the SplitOptions configured by the read.split.*
+// table properties, falling back to the defaults for any missing key.
+func NewSplitOptions(props iceberg.Properties) SplitOptions {
+	return SplitOptions{
+		TargetSize:   int64(props.GetInt(ReadSplitTargetSizeKey, ReadSplitTargetSizeDefault)),
+		Lookback:     props.GetInt(ReadSplitLookbackKey, ReadSplitLookbackDefault),
+		OpenFileCost: int64(props.GetInt(ReadSplitOpenFileCostKey, ReadSplitOpenFileCostDefault)),
+	}
+}
+
+func (o SplitOptions) withDefaults() SplitOptions {
+	if o.TargetSize <= 0 {
+		o.TargetSize = ReadSplitTargetSizeDefault
+	}
+	if o.Lookback <= 0 {
+		o.Lookback = ReadSplitLookbackDefault
+	}
+	if o.OpenFileCost < 0 {
+		o.OpenFileCost = ReadSplitOpenFileCostDefault
+	}
+
+	return o
+}
+
+// CombinedScanTask is a group of file splits that should be read together
+// by a single reader.
+type CombinedScanTask struct {
+	Tasks []FileScanTask
+}
+
+// SizeBytes returns the total number of bytes covered by the splits in
+// the task.
+func (c CombinedScanTask) SizeBytes() int64 {
+	var total int64
+	for _, t := range c.Tasks {
+		total += t.Length
+	}
+
+	return total
+}
+
+// PlanTasks splits the Parquet files larger than the target size into
+// offset/length ranges, aligned to the file's split offsets (its row
+// group boundaries) when they are available. Smaller files, and files in
+// other formats, are returned as a single task. The tasks are returned in
+// the order of the files, and can be grouped with CombineTasks.
+//
+// The Parquet reader of a scan reads the row groups which begin within
+// the range of the task, so that every row group is read by exactly one
+// of the splits of a file.
+func PlanTasks(files []iceberg.DataFile, opts SplitOptions) []FileScanTask {
+	opts = opts.withDefaults()
+
+	var tasks []FileScanTask
+	for _, f := range files {
+		tasks = append(tasks, splitFile(f, opts.TargetSize)...)
+	}
+
+	return tasks
+}
+
+// CombineTasks bin-packs the tasks so that small files are combined into
+// tasks of about opts.TargetSize, with each file weighing at least
+// opts.OpenFileCost.
+func CombineTasks(tasks []FileScanTask, opts SplitOptions) []CombinedScanTask {
+	opts = opts.withDefaults()
+
+	weight := func(t FileScanTask) int64 {
+		return max(t.Length, opts.OpenFileCost)
+	}
+
+	type bin struct {
+		tasks  []FileScanTask
+		weight int64
+	}
+
+	var (
+		result []CombinedScanTask
+		bins   []*bin
+	)
+	for _, t := range tasks {
+		w := weight(t)
+		idx := slices.IndexFunc(bins, func(b *bin) bool {
+			return b.weight+w <= opts.TargetSize
+		})
+
+		if idx < 0 {
+			if len(bins) >= opts.Lookback {
+				result = append(result, CombinedScanTask{Tasks: bins[0].tasks})
+				bins = bins[1:]
+			}
+			bins = append(bins, &bin{})
+			idx = len(bins) - 1
+		}
+
+		bins[idx].tasks = append(bins[idx].tasks, t)
+		bins[idx].weight += w
+	}
+
+	for _, b := range bins {
+		result = append(result, CombinedScanTask{Tasks: b.tasks})
+	}
+
+	return result
+}
+
+// splitScanTasks splits the planned tasks of a scan with PlanTasks, each
+// split keeping the delete files and residual of its file.
+func splitScanTasks(tasks []FileScanTask, opts SplitOptions) []FileScanTask {
+	result := make([]FileScanTask, 0, len(tasks))
+	for _, t := range tasks {
+		for _, split := range PlanTasks([]iceberg.DataFile{t.File}, opts) {
+			split.DeleteFiles, split.Residual = t.DeleteFiles, t.Residual
+			result = append(result, split)
+		}
+	}
+
+	return result
+}
+
+func splitFile(f iceberg.DataFile, targetSize int64) []FileScanTask {
+	size := f.FileSizeBytes()
+	if size <= targetSize || f.FileFormat() != iceberg.ParquetFile {
+		return []FileScanTask{{File: f, Start: 0, Length: size}}
+	}
+
+	if offsets := f.SplitOffsets(); validSplitOffsets(offsets, size) {
+		return splitAtOffsets(f, offsets, targetSize)
+	}
+
+	tasks := make([]FileScanTask, 0, (size+targetSize-1)/targetSize)
+	for start := int64(0); start < size; start += targetSize {
+		tasks = append(tasks, FileScanTask{
+			File:   f,
+			Start:  start,
+			Length: min(targetSize, size-start),
+		})
+	}
+
+	return tasks
+}
+
+// splitAtOffsets groups consecutive row groups into ranges of at most
+// targetSize bytes, with a single row group larger than the target
+// making up a range on its own.
+func splitAtOffsets(f iceberg.DataFile, offsets []int64, targetSize int64) []FileScanTask {
+	size := f.FileSizeBytes()
+	ends := append(slices.Clone(offsets[1:]), size)
+
+	var tasks []FileScanTask
+	start := offsets[0]
+	for i, end := range ends {
+		next := size
+		if i+1 < len(ends) {
+			next = ends[i+1]
+		}
+
+		if next-start > targetSize || i == len(ends)-1 {
+			tasks = append(tasks, FileScanTask{File: f, Start: start, Length: end - start})
+			start = end
+		}
+	}
+
+	return tasks
+}
+
+func validSplitOffsets(offsets []int64, size int64) bool {
+	if len(offsets) == 0 || offsets[0] < 0 || offsets[len(offsets)-1] >= size {
+		return false
+	}
+
+	for i := 1; i < len(offsets); i++ {
+		if offsets[i] <= offsets[i-1] {
+			return false
+		}
+	}
+
+	return true
+}
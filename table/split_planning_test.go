@@ -0,0 +1,116 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mb = 1024 * 1024
+
+func splitTestFile(t *testing.T, path string, size int64, offsets []int64) iceberg.DataFile {
+	bldr, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentData,
+		path, iceberg.ParquetFile, nil, 100, size)
+	require.NoError(t, err)
+
+	if offsets != nil {
+		bldr.SplitOffsets(offsets)
+	}
+
+	return bldr.Build()
+}
+
+func TestPlanTasksSplitsLargeFile(t *testing.T) {
+	f := splitTestFile(t, "s3://bucket/large.parquet", 512*mb, nil)
+
+	tasks := table.PlanTasks([]iceberg.DataFile{f}, table.NewSplitOptions(nil))
+	require.Len(t, tasks, 4)
+	for i, task := range tasks {
+		assert.EqualValues(t, int64(i)*128*mb, task.Start)
+		assert.EqualValues(t, 128*mb, task.Length)
+		assert.Same(t, f, task.File)
+	}
+}
+
+func TestPlanTasksAlignsToSplitOffsets(t *testing.T) {
+	// five 100MB row groups, where no two fit in one 128MB split
+	f := splitTestFile(t, "s3://bucket/rowgroups.parquet", 500*mb+4,
+		[]int64{4, 100*mb + 4, 200*mb + 4, 300*mb + 4, 400*mb + 4})
+
+	tasks := table.PlanTasks([]iceberg.DataFile{f}, table.NewSplitOptions(nil))
+	require.Len(t, tasks, 5)
+	for i, task := range tasks {
+		assert.EqualValues(t, int64(i)*100*mb+4, task.Start)
+		assert.EqualValues(t, 100*mb, task.Length)
+	}
+
+	// with a larger target consecutive row groups are grouped together
+	tasks = table.PlanTasks([]iceberg.DataFile{f}, table.SplitOptions{TargetSize: 256 * mb})
+	require.Len(t, tasks, 3)
+	assert.EqualValues(t, 4, tasks[0].Start)
+	assert.EqualValues(t, 200*mb, tasks[0].Length)
+	assert.EqualValues(t, 200*mb+4, tasks[1].Start)
+	assert.EqualValues(t, 200*mb, tasks[1].Length)
+	assert.EqualValues(t, 400*mb+4, tasks[2].Start)
+	assert.EqualValues(t, 100*mb, tasks[2].Length)
+}
+
+func TestPlanTasksKeepsOtherFormatsWhole(t *testing.T) {
+	bldr, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentData,
+		"s3://bucket/large.avro", iceberg.AvroFile, nil, 100, 512*mb)
+	require.NoError(t, err)
+
+	tasks := table.PlanTasks([]iceberg.DataFile{bldr.Build()}, table.NewSplitOptions(nil))
+	require.Len(t, tasks, 1)
+	assert.EqualValues(t, 0, tasks[0].Start)
+	assert.EqualValues(t, 512*mb, tasks[0].Length)
+}
+
+func TestCombineTasksCombinesSmallFiles(t *testing.T) {
+	files := make([]iceberg.DataFile, 20)
+	for i := range files {
+		files[i] = splitTestFile(t, fmt.Sprintf("s3://bucket/small-%d.parquet", i), 1*mb, nil)
+	}
+
+	opts := table.NewSplitOptions(nil)
+	tasks := table.PlanTasks(files, opts)
+	require.Len(t, tasks, 20)
+
+	combined := table.CombineTasks(tasks, opts)
+	require.Len(t, combined, 1)
+	assert.Len(t, combined[0].Tasks, 20)
+	assert.EqualValues(t, 20*mb, combined[0].SizeBytes())
+
+	// the open file cost bounds how many files are packed together
+	opts = table.NewSplitOptions(iceberg.Properties{
+		table.ReadSplitTargetSizeKey:   "10485760",
+		table.ReadSplitOpenFileCostKey: "2097152",
+	})
+	combined = table.CombineTasks(table.PlanTasks(files, opts), opts)
+	require.Len(t, combined, 4)
+	for _, task := range combined {
+		assert.Len(t, task.Tasks, 5)
+		assert.LessOrEqual(t, task.SizeBytes(), int64(10*mb))
+	}
+}
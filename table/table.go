@@ -19,10 +19,15 @@ package table
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"iter"
 	"log"
+	"maps"
+	"math/rand/v2"
 	"runtime"
 	"slices"
+	"time"
 
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
@@ -33,6 +38,11 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// ErrCommitFailed is returned by a CatalogIO when a commit conflicts with a
+// concurrent change to the table. Such commits are retried against the
+// latest metadata of the table as long as the requirements still hold.
+var ErrCommitFailed = errors.New("commit failed, refresh and try again")
+
 type FSysF func(ctx context.Context) (io.IO, error)
 
 type Identifier = []string
@@ -42,6 +52,14 @@ type CatalogIO interface {
 	CommitTable(context.Context, *Table, []Requirement, []Update) (Metadata, string, error)
 }
 
+// CatalogRefresher is the interface implemented by a CatalogIO which caches
+// the tables it loads. RefreshTable loads the latest version of the table,
+// bypassing the cache, so that commits are retried and validated against
+// the current state of the table.
+type CatalogRefresher interface {
+	RefreshTable(context.Context, Identifier) (*Table, error)
+}
+
 type Table struct {
 	identifier       Identifier
 	metadata         Metadata
@@ -69,6 +87,9 @@ func (t Table) Location() string                      { return t.metadata.Locati
 func (t Table) CurrentSnapshot() *Snapshot            { return t.metadata.CurrentSnapshot() }
 func (t Table) SnapshotByID(id int64) *Snapshot       { return t.metadata.SnapshotByID(id) }
 func (t Table) SnapshotByName(name string) *Snapshot  { return t.metadata.SnapshotByName(name) }
+
+// Refs returns the branches and tags of the table by name.
+func (t Table) Refs() map[string]SnapshotRef { return maps.Collect(t.metadata.Refs()) }
 func (t Table) Schemas() map[int]*iceberg.Schema {
 	m := make(map[int]*iceberg.Schema)
 	for _, s := range t.metadata.Schemas() {
@@ -86,9 +107,10 @@ func (t Table) NewTransaction() *Transaction {
 	meta, _ := MetadataBuilderFromBase(t.metadata)
 
 	return &Transaction{
-		tbl:  &t,
-		meta: meta,
-		reqs: []Requirement{},
+		tbl:    &t,
+		meta:   meta,
+		branch: MainBranch,
+		reqs:   []Requirement{},
 	}
 }
 
@@ -112,6 +134,39 @@ func (t Table) Append(ctx context.Context, rdr array.RecordReader, snapshotProps
 	return txn.Commit(ctx)
 }
 
+// RewriteManifests is a shortcut for NewTransaction().RewriteManifests() and then committing the transaction
+func (t Table) RewriteManifests(ctx context.Context, targetSizeBytes int64, snapshotProps iceberg.Properties) (*Table, error) {
+	txn := t.NewTransaction()
+	if err := txn.RewriteManifests(ctx, targetSizeBytes, snapshotProps); err != nil {
+		return nil, err
+	}
+
+	return txn.Commit(ctx)
+}
+
+// CherryPick applies the changes of the append snapshot with the given id
+// onto the main branch of tbl and commits the result, see
+// [Transaction.CherryPick].
+func CherryPick(ctx context.Context, tbl *Table, snapshotID int64) (*Table, error) {
+	txn := tbl.NewTransaction()
+	if err := txn.CherryPick(ctx, snapshotID); err != nil {
+		return nil, err
+	}
+
+	return txn.Commit(ctx)
+}
+
+// Publish promotes the snapshot staged with the given wap id to the main
+// branch of tbl and commits the result, see [Transaction.Publish].
+func Publish(ctx context.Context, tbl *Table, wapID string) (*Table, error) {
+	txn := tbl.NewTransaction()
+	if err := txn.Publish(ctx, wapID); err != nil {
+		return nil, err
+	}
+
+	return txn.Commit(ctx)
+}
+
 func (t Table) AllManifests(ctx context.Context) iter.Seq2[iceberg.ManifestFile, error] {
 	fs, err := t.fsF(ctx)
 	if err != nil {
@@ -200,18 +255,93 @@ func (t Table) AllManifests(ctx context.Context) iter.Seq2[iceberg.ManifestFile,
 	}
 }
 
+// doCommit commits the updates to the catalog. Commits which fail with
+// ErrCommitFailed are retried with a jittered exponential backoff, up to
+// the number of retries configured in the table properties, after reloading
+// the table and checking the requirements against its latest metadata.
 func (t Table) doCommit(ctx context.Context, updates []Update, reqs []Requirement) (*Table, error) {
-	newMeta, newLoc, err := t.cat.CommitTable(ctx, &t, reqs, updates)
-	if err != nil {
-		return nil, err
+	props := t.metadata.Properties()
+	numRetries := props.GetInt(CommitNumRetriesKey, CommitNumRetriesDefault)
+	minWait := props.GetDuration(CommitMinRetryWaitMsKey, CommitMinRetryWaitMsDefault*time.Millisecond)
+	maxWait := props.GetDuration(CommitMaxRetryWaitMsKey, CommitMaxRetryWaitMsDefault*time.Millisecond)
+
+	base := &t
+	for attempt := 0; ; attempt++ {
+		newMeta, newLoc, err := t.cat.CommitTable(ctx, base, reqs, updates)
+		if err == nil {
+			fs, err := t.fsF(ctx)
+			if err != nil {
+				return nil, err
+			}
+			deleteOldMetadata(fs, base.metadata, newMeta)
+
+			return New(t.identifier, newMeta, newLoc, t.fsF, t.cat), nil
+		}
+
+		if !errors.Is(err, ErrCommitFailed) || attempt >= numRetries {
+			return nil, err
+		}
+
+		if err := sleepWithContext(ctx, commitRetryWait(attempt, minWait, maxWait)); err != nil {
+			return nil, err
+		}
+
+		if base, err = t.loadLatest(ctx); err != nil {
+			return nil, err
+		}
+
+		// the updates can only be reapplied if the table still satisfies
+		// the requirements they were created with
+		for _, r := range reqs {
+			if err := r.Validate(base.metadata); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidRequirement, err)
+			}
+		}
 	}
-	fs, err := t.fsF(ctx)
-	if err != nil {
-		return nil, err
+}
+
+// loadLatest loads the latest version of the table from its catalog,
+// bypassing the catalog's cache if it has one.
+func (t Table) loadLatest(ctx context.Context) (*Table, error) {
+	if r, ok := t.cat.(CatalogRefresher); ok {
+		return r.RefreshTable(ctx, t.identifier)
 	}
-	deleteOldMetadata(fs, t.metadata, newMeta)
 
-	return New(t.identifier, newMeta, newLoc, t.fsF, t.cat), nil
+	return t.cat.LoadTable(ctx, t.identifier, nil)
+}
+
+// commitRetryWait returns the time to wait before the given retry attempt,
+// the wait doubles with every attempt up to maxWait and up to 10% of jitter
+// is added so that concurrent writers don't retry in lock step.
+func commitRetryWait(attempt int, minWait, maxWait time.Duration) time.Duration {
+	wait := minWait
+	for range attempt {
+		if wait >= maxWait/2 {
+			wait = maxWait
+
+			break
+		}
+		wait *= 2
+	}
+	wait = min(wait, maxWait)
+
+	if jitter := int64(wait / 10); jitter > 0 {
+		wait += time.Duration(rand.Int64N(jitter + 1))
+	}
+
+	return wait
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 func getFiles(it iter.Seq[MetadataLogEntry]) iter.Seq[string] {
@@ -298,9 +428,10 @@ func WithLimit(n int64) ScanOption {
 	}
 }
 
-// WitMaxConcurrency sets the maximum concurrency for table scan and plan
-// operations. When unset it defaults to runtime.GOMAXPROCS.
-func WitMaxConcurrency(n int) ScanOption {
+// WithConcurrency sets the maximum concurrency for table scan and plan
+// operations, bounding the number of manifests and data files which are
+// read in parallel. When unset it defaults to runtime.GOMAXPROCS.
+func WithConcurrency(n int) ScanOption {
 	if n <= 0 {
 		return noopOption
 	}
@@ -310,6 +441,38 @@ func WitMaxConcurrency(n int) ScanOption {
 	}
 }
 
+// WitMaxConcurrency sets the maximum concurrency for table scan and plan
+// operations.
+//
+// Deprecated: use WithConcurrency.
+func WitMaxConcurrency(n int) ScanOption {
+	return WithConcurrency(n)
+}
+
+// WithOrdered controls whether the records of a scan are returned in the
+// order of the planned files, which is the default. Files which finish
+// reading early are then buffered until the files before them are done.
+// With WithOrdered(false) the records are returned as soon as they are
+// read, in no particular order.
+func WithOrdered(ordered bool) ScanOption {
+	return func(scan *Scan) {
+		scan.unordered = !ordered
+	}
+}
+
+// WithManifestCache makes the scan read manifest entries through the
+// given cache, so that planning the same snapshots again doesn't re-read
+// their manifests.
+func WithManifestCache(cache *ManifestCache) ScanOption {
+	if cache == nil {
+		return noopOption
+	}
+
+	return func(scan *Scan) {
+		scan.manifestCache = cache
+	}
+}
+
 func WithOptions(opts iceberg.Properties) ScanOption {
 	if opts == nil {
 		return noopOption
@@ -350,6 +513,45 @@ func New(ident Identifier, meta Metadata, metadataLocation string, fsF FSysF, ca
 	}
 }
 
+// UpgradeFormatVersion returns a copy of tbl whose metadata is upgraded to
+// format version 2 in memory. Data written by a v1 table has no sequence
+// numbers, so existing snapshots keep sequence number 0, which is also the
+// last-sequence-number of the upgraded metadata. Tables which already use
+// format version 2 are returned as is.
+//
+// The upgrade isn't committed, to persist it commit
+// NewUpgradeFormatVersionUpdate(2) through a transaction.
+func UpgradeFormatVersion(tbl *Table) (*Table, error) {
+	meta := tbl.metadata
+	if meta.Version() >= 2 {
+		return tbl, nil
+	}
+
+	// v1 allows snapshots to list their manifests inline, which v2 does not
+	for _, snap := range meta.Snapshots() {
+		if snap.ManifestList == "" {
+			return nil, fmt.Errorf("%w: cannot upgrade to format version 2, snapshot %d has no manifest list",
+				ErrInvalidMetadata, snap.SnapshotID)
+		}
+	}
+
+	bldr, err := MetadataBuilderFromBase(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := bldr.SetFormatVersion(2); err != nil {
+		return nil, err
+	}
+
+	upgraded, err := bldr.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(tbl.identifier, upgraded, tbl.metadataLocation, tbl.fsF, tbl.cat), nil
+}
+
 func NewFromLocation(
 	ctx context.Context,
 	ident Identifier,
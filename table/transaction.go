@@ -22,8 +22,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"runtime"
 	"slices"
+	"strconv"
 	"sync"
 	"time"
 
@@ -46,7 +48,7 @@ func (s snapshotUpdate) fastAppend() *snapshotProducer {
 
 func (s snapshotUpdate) mergeOverwrite(commitUUID *uuid.UUID) *snapshotProducer {
 	op := OpOverwrite
-	if s.txn.meta.currentSnapshot() == nil {
+	if s.txn.branchSnapshot() == nil {
 		op = OpAppend
 	}
 
@@ -57,9 +59,16 @@ func (s snapshotUpdate) mergeAppend() *snapshotProducer {
 	return newMergeAppendFilesProducer(OpAppend, s.txn, s.io, nil, s.snapshotProps)
 }
 
+func (s snapshotUpdate) rewriteManifests(targetSizeBytes int64) *snapshotProducer {
+	return newRewriteManifestsProducer(s.txn, s.io, nil, s.snapshotProps, targetSizeBytes)
+}
+
 type Transaction struct {
 	tbl  *Table
 	meta *MetadataBuilder
+	// branch is the branch the snapshots produced by the transaction are
+	// committed to, MainBranch unless changed with ToBranch.
+	branch string
 
 	reqs []Requirement
 
@@ -86,13 +95,17 @@ func (t *Transaction) apply(updates []Update, reqs []Requirement) error {
 		}
 	}
 
+	// only the first requirement of each kind is kept, as it is the one
+	// which describes the state of the table the transaction started from
 	existing := map[string]struct{}{}
 	for _, r := range t.reqs {
-		existing[r.GetType()] = struct{}{}
+		existing[requirementKey(r)] = struct{}{}
 	}
 
 	for _, r := range reqs {
-		if _, ok := existing[r.GetType()]; !ok {
+		key := requirementKey(r)
+		if _, ok := existing[key]; !ok {
+			existing[key] = struct{}{}
 			t.reqs = append(t.reqs, r)
 		}
 	}
@@ -116,6 +129,23 @@ func (t *Transaction) apply(updates []Update, reqs []Requirement) error {
 	return nil
 }
 
+// requirementKey identifies the kind of a requirement, requirements on
+// the snapshot of different refs are distinct.
+func requirementKey(r Requirement) string {
+	if ref, ok := r.(*assertRefSnapshotID); ok {
+		return r.GetType() + ":" + ref.Ref
+	}
+
+	return r.GetType()
+}
+
+// stagedMetadata returns the table metadata with the updates applied so
+// far in the transaction, so that builders such as UpdateSpec and
+// UpdateSchema build on the changes already staged.
+func (t *Transaction) stagedMetadata() (Metadata, error) {
+	return t.meta.Build()
+}
+
 func (t *Transaction) appendSnapshotProducer(afs io.IO, props iceberg.Properties) *snapshotProducer {
 	manifestMerge := t.meta.props.GetBool(ManifestMergeEnabledKey, ManifestMergeEnabledDefault)
 	updateSnapshot := t.updateSnapshot(afs, props)
@@ -142,10 +172,315 @@ func (t *Transaction) SetProperties(props iceberg.Properties) error {
 	return nil
 }
 
+// ToBranch makes the snapshots produced by the transaction, such as by
+// appends and overwrites, be committed to the branch name rather than to
+// the main branch. The branch must already exist, unless it is the main
+// branch, and it must be set before any snapshot is staged.
+func (t *Transaction) ToBranch(name string) error {
+	if ref, ok := t.meta.refs[name]; ok {
+		if ref.SnapshotRefType != BranchRef {
+			return fmt.Errorf("%w: %s is a tag, not a branch", iceberg.ErrInvalidArgument, name)
+		}
+	} else if name != MainBranch {
+		return fmt.Errorf("%w: branch %s does not exist", iceberg.ErrInvalidArgument, name)
+	}
+
+	t.branch = name
+
+	return nil
+}
+
+// branchSnapshot returns the snapshot the branch of the transaction points
+// to, or nil if it has none yet.
+func (t *Transaction) branchSnapshot() *Snapshot {
+	if t.branch == MainBranch {
+		return t.meta.currentSnapshot()
+	}
+
+	ref, ok := t.meta.refs[t.branch]
+	if !ok {
+		return nil
+	}
+	snap, _ := t.meta.SnapshotByID(ref.SnapshotID)
+
+	return snap
+}
+
+// CreateBranch creates a branch named name pointing to the snapshot with
+// the given id. The options set the retention of the branch and its
+// snapshots, e.g. WithMaxRefAgeMs and WithMinSnapshotsToKeep.
+func (t *Transaction) CreateBranch(name string, snapshotID int64, opts ...setSnapshotRefOption) error {
+	return t.createRef(name, snapshotID, BranchRef, opts...)
+}
+
+// CreateTag creates a tag named name pointing to the snapshot with the
+// given id. Only WithMaxRefAgeMs applies to tags.
+func (t *Transaction) CreateTag(name string, snapshotID int64, opts ...setSnapshotRefOption) error {
+	return t.createRef(name, snapshotID, TagRef, opts...)
+}
+
+func (t *Transaction) createRef(name string, snapshotID int64, refType RefType, opts ...setSnapshotRefOption) error {
+	if name == "" {
+		return fmt.Errorf("%w: ref name must not be empty", iceberg.ErrInvalidArgument)
+	}
+
+	if _, ok := t.meta.refs[name]; ok {
+		return fmt.Errorf("%w: ref %s already exists", iceberg.ErrInvalidArgument, name)
+	}
+
+	ref := SnapshotRef{SnapshotID: snapshotID, SnapshotRefType: refType}
+	for _, opt := range opts {
+		if err := opt(&ref); err != nil {
+			return err
+		}
+	}
+
+	if refType == TagRef && (ref.MinSnapshotsToKeep != nil || ref.MaxSnapshotAgeMs != nil) {
+		return fmt.Errorf("%w: tags do not support snapshot retention settings", iceberg.ErrInvalidArgument)
+	}
+
+	return t.apply([]Update{refUpdate(name, ref)},
+		[]Requirement{AssertRefSnapshotID(name, nil)})
+}
+
+// SetCurrentSnapshotForBranch moves the existing branch name to the
+// snapshot with the given id, keeping its retention settings. Setting the
+// main branch changes the current snapshot of the table.
+func (t *Transaction) SetCurrentSnapshotForBranch(name string, snapshotID int64) error {
+	ref, ok := t.meta.refs[name]
+	switch {
+	case !ok:
+		return fmt.Errorf("%w: branch %s does not exist", iceberg.ErrInvalidArgument, name)
+	case ref.SnapshotRefType != BranchRef:
+		return fmt.Errorf("%w: %s is a tag, not a branch", iceberg.ErrInvalidArgument, name)
+	}
+
+	current := ref.SnapshotID
+	ref.SnapshotID = snapshotID
+
+	return t.apply([]Update{refUpdate(name, ref)},
+		[]Requirement{AssertRefSnapshotID(name, &current)})
+}
+
+// FastForward moves the branch to the snapshot with the given id, which
+// must be a descendant of the snapshot the branch currently points to so
+// that no committed change is lost.
+func (t *Transaction) FastForward(branch string, toSnapshotID int64) error {
+	ref, ok := t.meta.refs[branch]
+	switch {
+	case !ok && branch != MainBranch:
+		return fmt.Errorf("%w: branch %s does not exist", iceberg.ErrInvalidArgument, branch)
+	case ok && ref.SnapshotRefType != BranchRef:
+		return fmt.Errorf("%w: %s is a tag, not a branch", iceberg.ErrInvalidArgument, branch)
+	}
+
+	meta, err := t.stagedMetadata()
+	if err != nil {
+		return err
+	}
+
+	ancestors, err := ancestorsOf(meta, toSnapshotID)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// a main branch without a snapshot can be moved anywhere
+		return t.createRef(branch, toSnapshotID, BranchRef)
+	}
+
+	if !slices.ContainsFunc(ancestors, func(s Snapshot) bool { return s.SnapshotID == ref.SnapshotID }) {
+		return fmt.Errorf("%w: cannot fast-forward branch %s, snapshot %d is not a descendant of its snapshot %d",
+			ErrInvalidOperation, branch, toSnapshotID, ref.SnapshotID)
+	}
+
+	if ref.SnapshotID == toSnapshotID {
+		return nil
+	}
+
+	return t.SetCurrentSnapshotForBranch(branch, toSnapshotID)
+}
+
+// CherryPick applies the changes of the append snapshot with the given id
+// onto the branch of the transaction. If the snapshot's parent is the
+// branch's snapshot, the branch is fast-forwarded to it, otherwise the
+// data files it added are committed as a new append snapshot, which
+// records the id of the source snapshot in its summary.
+//
+// Only append snapshots can be cherry-picked. Cherry-picking a snapshot
+// which is already part of the branch, or whose files are, is an error.
+func (t *Transaction) CherryPick(ctx context.Context, snapshotID int64) error {
+	meta, err := t.stagedMetadata()
+	if err != nil {
+		return err
+	}
+
+	snap := meta.SnapshotByID(snapshotID)
+	if snap == nil {
+		return fmt.Errorf("%w: snapshot %d not found", iceberg.ErrInvalidArgument, snapshotID)
+	}
+
+	if op := snap.Operation(); op != OpAppend {
+		return fmt.Errorf("%w: cannot cherry-pick %s snapshot %d, only append snapshots can be cherry-picked",
+			ErrInvalidOperation, op, snapshotID)
+	}
+
+	var wapID string
+	if snap.Summary != nil {
+		wapID = snap.Summary.Properties[WapIDKey]
+	}
+
+	current := t.branchSnapshot()
+	if current != nil {
+		ancestors, err := ancestorsOf(meta, current.SnapshotID)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range ancestors {
+			if s.SnapshotID == snapshotID {
+				return fmt.Errorf("%w: cannot cherry-pick snapshot %d, it is already an ancestor of branch %s",
+					ErrInvalidOperation, snapshotID, t.branch)
+			}
+
+			if s.Summary == nil {
+				continue
+			}
+
+			if s.Summary.Properties[sourceSnapshotIDKey] == strconv.FormatInt(snapshotID, 10) {
+				return fmt.Errorf("%w: cannot cherry-pick snapshot %d, it was already cherry-picked as snapshot %d",
+					ErrInvalidOperation, snapshotID, s.SnapshotID)
+			}
+
+			if wapID != "" && (s.Summary.Properties[WapIDKey] == wapID ||
+				s.Summary.Properties[publishedWapIDKey] == wapID) {
+				return fmt.Errorf("%w: cannot cherry-pick snapshot %d, wap id %s was already published by snapshot %d",
+					ErrInvalidOperation, snapshotID, wapID, s.SnapshotID)
+			}
+		}
+	}
+
+	if (current == nil && snap.ParentSnapshotID == nil) ||
+		(current != nil && snap.ParentSnapshotID != nil && *snap.ParentSnapshotID == current.SnapshotID) {
+		return t.FastForward(t.branch, snapshotID)
+	}
+
+	fs, err := t.tbl.fsF(ctx)
+	if err != nil {
+		return err
+	}
+
+	manifests, err := snap.Manifests(fs)
+	if err != nil {
+		return err
+	}
+
+	added := make(map[string]iceberg.DataFile)
+	for _, m := range manifests {
+		if m.SnapshotID() != snapshotID {
+			continue
+		}
+
+		entries, err := m.FetchEntries(fs, true)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range entries {
+			if e.Status() == iceberg.EntryStatusADDED && e.SnapshotID() == snapshotID {
+				added[e.DataFile().FilePath()] = e.DataFile()
+			}
+		}
+	}
+
+	if current != nil {
+		for df, err := range current.dataFiles(fs, nil) {
+			if err != nil {
+				return err
+			}
+
+			if _, ok := added[df.FilePath()]; ok {
+				return fmt.Errorf("%w: cannot cherry-pick snapshot %d, file %s is already in branch %s",
+					ErrInvalidOperation, snapshotID, df.FilePath(), t.branch)
+			}
+		}
+	}
+
+	props := iceberg.Properties{sourceSnapshotIDKey: strconv.FormatInt(snapshotID, 10)}
+	if wapID != "" {
+		props[publishedWapIDKey] = wapID
+	}
+
+	updater := t.updateSnapshot(fs, props).fastAppend()
+	for _, path := range slices.Sorted(maps.Keys(added)) {
+		updater.appendDataFile(added[path])
+	}
+
+	updates, reqs, err := updater.commit()
+	if err != nil {
+		return err
+	}
+
+	return t.apply(updates, reqs)
+}
+
+// Publish promotes the snapshot staged with the given wap id by the
+// write-audit-publish workflow to the transaction's branch, by cherry-picking
+// it. If several snapshots were staged with the same wap id, the latest one
+// is published. A wap id can only be published once.
+func (t *Transaction) Publish(ctx context.Context, wapID string) error {
+	meta, err := t.stagedMetadata()
+	if err != nil {
+		return err
+	}
+
+	var staged *Snapshot
+	for _, s := range meta.Snapshots() {
+		if s.Summary == nil || s.Summary.Properties[WapIDKey] != wapID {
+			continue
+		}
+
+		if staged == nil || s.TimestampMs >= staged.TimestampMs {
+			staged = &s
+		}
+	}
+
+	if staged == nil {
+		return fmt.Errorf("%w: no snapshot staged with wap id %s",
+			iceberg.ErrInvalidArgument, wapID)
+	}
+
+	return t.CherryPick(ctx, staged.SnapshotID)
+}
+
+// refUpdate returns the update setting the ref name to ref.
+func refUpdate(name string, ref SnapshotRef) Update {
+	var (
+		maxRefAgeMs, maxSnapshotAgeMs int64
+		minSnapshotsToKeep            int
+	)
+	if ref.MaxRefAgeMs != nil {
+		maxRefAgeMs = *ref.MaxRefAgeMs
+	}
+	if ref.MaxSnapshotAgeMs != nil {
+		maxSnapshotAgeMs = *ref.MaxSnapshotAgeMs
+	}
+	if ref.MinSnapshotsToKeep != nil {
+		minSnapshotsToKeep = *ref.MinSnapshotsToKeep
+	}
+
+	return NewSetSnapshotRefUpdate(name, ref.SnapshotID, ref.SnapshotRefType,
+		maxRefAgeMs, maxSnapshotAgeMs, minSnapshotsToKeep)
+}
+
 func (t *Transaction) UpdateSpec(caseSensitive bool) *UpdateSpec {
 	return NewUpdateSpec(t, caseSensitive)
 }
 
+func (t *Transaction) UpdateSchema(caseSensitive bool) *UpdateSchema {
+	return NewUpdateSchema(t, caseSensitive)
+}
+
 func (t *Transaction) AppendTable(ctx context.Context, tbl arrow.Table, batchSize int64, snapshotProps iceberg.Properties) error {
 	rdr := array.NewTableReader(tbl, batchSize)
 	defer rdr.Release()
@@ -217,7 +552,7 @@ func (t *Transaction) ReplaceDataFiles(ctx context.Context, filesToDelete, files
 		return errors.New("add file paths must be unique for ReplaceDataFiles")
 	}
 
-	s := t.meta.currentSnapshot()
+	s := t.branchSnapshot()
 	if s == nil {
 		return fmt.Errorf("%w: cannot replace files in a table without an existing snapshot", ErrInvalidOperation)
 	}
@@ -280,6 +615,34 @@ func (t *Transaction) ReplaceDataFiles(ctx context.Context, filesToDelete, files
 	return t.apply(updates, reqs)
 }
 
+// RewriteManifests compacts the data manifests of the current snapshot
+// into manifests of up to targetSizeBytes, grouped by partition spec, to
+// speed up scan planning of tables with many small manifests. The files
+// of the table are left untouched, the rewritten manifests are committed
+// as a new snapshot with the replace operation.
+func (t *Transaction) RewriteManifests(ctx context.Context, targetSizeBytes int64, snapshotProps iceberg.Properties) error {
+	if targetSizeBytes <= 0 {
+		return fmt.Errorf("%w: target size must be positive, got %d",
+			iceberg.ErrInvalidArgument, targetSizeBytes)
+	}
+
+	if t.branchSnapshot() == nil {
+		return fmt.Errorf("%w: cannot rewrite manifests of a table without an existing snapshot", ErrInvalidOperation)
+	}
+
+	fs, err := t.tbl.fsF(ctx)
+	if err != nil {
+		return err
+	}
+
+	updates, reqs, err := t.updateSnapshot(fs, snapshotProps).rewriteManifests(targetSizeBytes).commit()
+	if err != nil {
+		return err
+	}
+
+	return t.apply(updates, reqs)
+}
+
 func (t *Transaction) AddFiles(ctx context.Context, files []string, snapshotProps iceberg.Properties, ignoreDuplicates bool) error {
 	set := make(map[string]string)
 	for _, f := range files {
@@ -291,7 +654,7 @@ func (t *Transaction) AddFiles(ctx context.Context, files []string, snapshotProp
 	}
 
 	if !ignoreDuplicates {
-		if s := t.meta.currentSnapshot(); s != nil {
+		if s := t.branchSnapshot(); s != nil {
 			referenced := make([]string, 0)
 			fs, err := t.tbl.fsF(ctx)
 			if err != nil {
@@ -400,6 +763,15 @@ func (t *Transaction) Commit(ctx context.Context) (*Table, error) {
 	t.committed = true
 
 	if len(t.meta.updates) > 0 {
+		// all the staged updates are committed at once, the requirements
+		// collected from each operation must hold for the table the
+		// transaction was started from for them to be applied in order
+		for _, r := range t.reqs {
+			if err := r.Validate(t.tbl.metadata); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidRequirement, err)
+			}
+		}
+
 		t.reqs = append(t.reqs, AssertTableUUID(t.meta.uuid))
 
 		return t.tbl.doCommit(ctx, t.meta.updates, t.reqs)
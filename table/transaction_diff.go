@@ -0,0 +1,160 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+
+	"github.com/apache/iceberg-go"
+)
+
+// MetadataDiff describes the changes a transaction would make to the
+// table it was started from if it were committed.
+type MetadataDiff struct {
+	// AddedManifests and RemovedManifests are the manifests which are
+	// referenced by the branch's snapshot only after, respectively only
+	// before, the transaction.
+	AddedManifests   []iceberg.ManifestFile
+	RemovedManifests []iceberg.ManifestFile
+	// AddedDataFiles and RemovedDataFiles are the data files added and
+	// deleted by the snapshots produced in the transaction.
+	AddedDataFiles   []iceberg.DataFile
+	RemovedDataFiles []iceberg.DataFile
+	// AddedSchemas are the schemas added by the transaction.
+	AddedSchemas []*iceberg.Schema
+	// CurrentSchema is the new current schema of the table, or nil if
+	// the transaction doesn't change it.
+	CurrentSchema *iceberg.Schema
+	// Summary is the summary of the snapshot the branch will point to,
+	// or nil if the transaction doesn't change the branch's snapshot.
+	Summary *Summary
+	// Updates are the metadata updates which would be committed.
+	Updates []Update
+}
+
+// IsEmpty returns true if committing the transaction wouldn't change the
+// table.
+func (d MetadataDiff) IsEmpty() bool {
+	return len(d.Updates) == 0
+}
+
+// Diff returns the changes the transaction would make to the table,
+// without committing anything. Manifests which were written for the
+// snapshots staged so far are read to list the data files they add
+// and remove.
+func (t *Transaction) Diff(ctx context.Context) (MetadataDiff, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	staged, err := t.meta.Build()
+	if err != nil {
+		return MetadataDiff{}, err
+	}
+	base := t.tbl.metadata
+
+	diff := MetadataDiff{Updates: append([]Update(nil), t.meta.updates...)}
+
+	baseSchemas := make(map[int]struct{})
+	for _, sc := range base.Schemas() {
+		baseSchemas[sc.ID] = struct{}{}
+	}
+	for _, sc := range staged.Schemas() {
+		if _, ok := baseSchemas[sc.ID]; !ok {
+			diff.AddedSchemas = append(diff.AddedSchemas, sc)
+		}
+	}
+	if staged.CurrentSchema().ID != base.CurrentSchema().ID {
+		diff.CurrentSchema = staged.CurrentSchema()
+	}
+
+	baseSnap, stagedSnap := base.SnapshotByName(t.branch), staged.SnapshotByName(t.branch)
+	if stagedSnap == nil || (baseSnap != nil && baseSnap.SnapshotID == stagedSnap.SnapshotID) {
+		return diff, nil
+	}
+	diff.Summary = stagedSnap.Summary
+
+	fs, err := t.tbl.fsF(ctx)
+	if err != nil {
+		return MetadataDiff{}, err
+	}
+
+	stagedManifests, err := stagedSnap.Manifests(fs)
+	if err != nil {
+		return MetadataDiff{}, err
+	}
+
+	var baseManifests []iceberg.ManifestFile
+	if baseSnap != nil {
+		if baseManifests, err = baseSnap.Manifests(fs); err != nil {
+			return MetadataDiff{}, err
+		}
+	}
+
+	basePaths := make(map[string]struct{}, len(baseManifests))
+	for _, m := range baseManifests {
+		basePaths[m.FilePath()] = struct{}{}
+	}
+	stagedPaths := make(map[string]struct{}, len(stagedManifests))
+	for _, m := range stagedManifests {
+		stagedPaths[m.FilePath()] = struct{}{}
+	}
+
+	for _, m := range baseManifests {
+		if _, ok := stagedPaths[m.FilePath()]; !ok {
+			diff.RemovedManifests = append(diff.RemovedManifests, m)
+		}
+	}
+
+	newSnapshots := make(map[int64]struct{})
+	for _, s := range staged.Snapshots() {
+		if base.SnapshotByID(s.SnapshotID) == nil {
+			newSnapshots[s.SnapshotID] = struct{}{}
+		}
+	}
+
+	for _, m := range stagedManifests {
+		if _, ok := basePaths[m.FilePath()]; ok {
+			continue
+		}
+		diff.AddedManifests = append(diff.AddedManifests, m)
+
+		if m.ManifestContent() != iceberg.ManifestContentData {
+			continue
+		}
+
+		entries, err := m.FetchEntries(fs, false)
+		if err != nil {
+			return MetadataDiff{}, err
+		}
+
+		for _, e := range entries {
+			if _, ok := newSnapshots[e.SnapshotID()]; !ok {
+				continue
+			}
+
+			switch e.Status() {
+			case iceberg.EntryStatusADDED:
+				diff.AddedDataFiles = append(diff.AddedDataFiles, e.DataFile())
+			case iceberg.EntryStatusDELETED:
+				diff.RemovedDataFiles = append(diff.RemovedDataFiles, e.DataFile())
+			}
+		}
+	}
+
+	return diff, nil
+}
@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionStagedMetadataErrors(t *testing.T) {
+	meta, err := NewMetadata(tableSchemaSimple, iceberg.UnpartitionedSpec,
+		UnsortedSortOrder, "file:///tmp/tbl", nil)
+	require.NoError(t, err)
+
+	tbl := New(Identifier{"db", "tbl"}, meta, "", func(context.Context) (io.IO, error) {
+		return io.LocalFS{}, nil
+	}, nil)
+
+	// staged metadata which can't be built is reported by everything
+	// which builds on it, rather than falling back to the table's metadata
+	txn := tbl.NewTransaction()
+	txn.meta.lastUpdatedMS = 0
+
+	_, err = txn.stagedMetadata()
+	require.ErrorIs(t, err, ErrInvalidMetadata)
+
+	assert.ErrorIs(t, txn.FastForward(MainBranch, 1), ErrInvalidMetadata)
+	assert.ErrorIs(t, txn.CherryPick(context.Background(), 1), ErrInvalidMetadata)
+	assert.ErrorIs(t, txn.Publish(context.Background(), "wap"), ErrInvalidMetadata)
+	assert.ErrorIs(t, txn.OverwriteDataFiles(context.Background(), iceberg.AlwaysTrue{}, true, nil, nil), ErrInvalidMetadata)
+
+	_, _, err = NewUpdateSpec(txn, true).AddIdentity("foo").BuildUpdates()
+	assert.ErrorIs(t, err, ErrInvalidMetadata)
+
+	_, err = NewUpdateSchema(txn, true).RenameColumn("foo", "qux").Apply()
+	assert.ErrorIs(t, err, ErrInvalidMetadata)
+}
+
+var errWriteFailed = errors.New("write failed")
+
+// failingWriteIO creates local files whose writes always fail.
+type failingWriteIO struct {
+	io.LocalFS
+}
+
+func (f failingWriteIO) Create(name string) (io.FileWriter, error) {
+	w, err := f.LocalFS.Create(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return failingWriter{w.(io.AbortFileWriter)}, nil
+}
+
+type failingWriter struct {
+	io.AbortFileWriter
+}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errWriteFailed }
+
+func TestSnapshotProducerFailedManifestWrite(t *testing.T) {
+	location := t.TempDir()
+	meta, err := NewMetadata(tableSchemaSimple, iceberg.UnpartitionedSpec,
+		UnsortedSortOrder, location, nil)
+	require.NoError(t, err)
+
+	tbl := New(Identifier{"db", "tbl"}, meta, "", func(context.Context) (io.IO, error) {
+		return failingWriteIO{}, nil
+	}, nil)
+
+	df, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentData,
+		filepath.Join(location, "data", "file.parquet"), iceberg.ParquetFile, nil, 100, 1234)
+	require.NoError(t, err)
+
+	sp := newFastAppendFilesProducer(OpAppend, tbl.NewTransaction(), failingWriteIO{}, nil, nil)
+	sp.appendDataFile(df.Build())
+
+	_, _, err = sp.commit()
+	require.ErrorIs(t, err, errWriteFailed)
+
+	// the failed manifest is abandoned rather than published half-written
+	files, err := filepath.Glob(filepath.Join(location, "metadata", "*"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
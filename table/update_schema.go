@@ -0,0 +1,460 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+)
+
+// tableRootID is the parent ID used for top-level columns of the schema.
+const tableRootID = -1
+
+// reservedColumnNames are the names of the metadata columns defined by
+// the Iceberg spec, which can't be used for top-level columns.
+var reservedColumnNames = []string{
+	"_file", "_pos", "_deleted", "_spec_id", "_partition",
+	"_row_id", "_last_updated_sequence_number",
+}
+
+// UpdateSchema implements a builder for evolving a table's schema.
+//
+// It accumulates a sequence of schema update operations (e.g., AddColumn,
+// RenameColumn, PromoteType)
+// which are validated against the table's current schema and applied by
+// Apply and BuildUpdates.
+//
+// Use the builder methods to chain operations, and call Apply to produce the
+// new schema, BuildUpdates to get the corresponding updates and requirements,
+// or Commit to apply the updates in the transaction.
+type UpdateSchema struct {
+	operations []updateSchemaOp
+
+	txn           *Transaction
+	meta          Metadata
+	schema        *iceberg.Schema
+	caseSensitive bool
+	lastColumnID  int
+	// adds holds the columns added to each struct, keyed by the field ID
+	// of the parent struct, or tableRootID for top-level columns.
+	adds map[int][]iceberg.NestedField
+	// renames holds the new names of existing columns, keyed by field ID.
+	renames map[int]string
+	// promotions holds the new types of existing columns, keyed by field ID.
+	promotions map[int]iceberg.Type
+}
+
+type updateSchemaOp func() error
+
+func NewUpdateSchema(t *Transaction, caseSensitive bool) *UpdateSchema {
+	// the error of building the staged metadata is returned when the
+	// schema is applied
+	meta, err := t.stagedMetadata()
+	if err != nil {
+		meta = t.tbl.Metadata()
+	}
+
+	us := &UpdateSchema{
+		txn:           t,
+		meta:          meta,
+		schema:        meta.CurrentSchema(),
+		caseSensitive: caseSensitive,
+		lastColumnID:  meta.LastColumnID(),
+		adds:          make(map[int][]iceberg.NestedField),
+		renames:       make(map[int]string),
+		promotions:    make(map[int]iceberg.Type),
+	}
+	if err != nil {
+		us.operations = append(us.operations, func() error { return err })
+	}
+
+	return us
+}
+
+// AddColumn adds field as the last column of the struct at parentPath, or
+// as a top-level column if parentPath is empty. Nested parents are addressed
+// by their dotted path and may also be lists or maps of structs, in which
+// case the column is added to the element or value struct. The field, and
+// any fields nested in its type, are assigned fresh IDs from the table's
+// last column ID.
+//
+// Adding a required column to a table which already has data is not allowed
+// unless the field has an initial default.
+func (us *UpdateSchema) AddColumn(parentPath string, field iceberg.NestedField) *UpdateSchema {
+	us.operations = append(us.operations, us.addColumn(parentPath, field))
+
+	return us
+}
+
+// RenameColumn changes the name of the column at path to newName. The
+// column keeps its field ID, so existing data files remain readable. The
+// new name must not collide with the name of another column in the same
+// struct, nor with a reserved metadata column name.
+func (us *UpdateSchema) RenameColumn(path, newName string) *UpdateSchema {
+	us.operations = append(us.operations, us.renameColumn(path, newName))
+
+	return us
+}
+
+// PromoteType changes the type of the primitive column at path to newType.
+// Only the promotions allowed by the Iceberg spec are supported, since they
+// don't require rewriting existing data: int to long, float to double, and
+// decimal(P, S) to decimal(P', S) where P' >= P.
+func (us *UpdateSchema) PromoteType(path string, newType iceberg.Type) *UpdateSchema {
+	us.operations = append(us.operations, us.promoteType(path, newType))
+
+	return us
+}
+
+// Apply validates the pending operations and returns the resulting schema,
+// which has a new schema ID. The table's schema isn't modified.
+func (us *UpdateSchema) Apply() (*iceberg.Schema, error) {
+	ops := us.operations
+	us.operations = nil
+	for _, op := range ops {
+		if err := op(); err != nil {
+			return nil, err
+		}
+	}
+
+	return iceberg.NewSchemaWithIdentifiers(us.newSchemaID(), us.schema.IdentifierFieldIDs,
+		us.applyFields(us.schema.Fields(), tableRootID)...), nil
+}
+
+func (us *UpdateSchema) BuildUpdates() ([]Update, []Requirement, error) {
+	newSchema, err := us.Apply()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !us.hasChanges() {
+		return nil, nil, nil
+	}
+
+	updates := []Update{
+		NewAddSchemaUpdate(newSchema, us.lastColumnID, false),
+		NewSetCurrentSchemaUpdate(-1),
+	}
+	requirements := []Requirement{
+		AssertCurrentSchemaID(us.schema.ID),
+		AssertLastAssignedFieldID(us.meta.LastColumnID()),
+	}
+
+	return updates, requirements, nil
+}
+
+func (us *UpdateSchema) Commit() error {
+	updates, requirements, err := us.BuildUpdates()
+	if err != nil {
+		return err
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	return us.txn.apply(updates, requirements)
+}
+
+func (us *UpdateSchema) addColumn(parentPath string, field iceberg.NestedField) updateSchemaOp {
+	return func() error {
+		parentID, siblings := tableRootID, us.schema.Fields()
+		if parentPath != "" {
+			parent, ok := us.findField(parentPath)
+			if !ok {
+				return fmt.Errorf("%w: cannot find parent struct: %s", iceberg.ErrInvalidSchema, parentPath)
+			}
+
+			var err error
+			if parentID, siblings, err = structFields(parent); err != nil {
+				return err
+			}
+		} else if strings.Contains(field.Name, ".") {
+			return fmt.Errorf("%w: cannot add column with ambiguous name: %s, provide the parent path instead",
+				iceberg.ErrInvalidSchema, field.Name)
+		}
+
+		if err := us.checkName(parentID, siblings, -1, field.Name); err != nil {
+			return fmt.Errorf("cannot add column: %w", err)
+		}
+
+		if field.Required && field.InitialDefault == nil && us.meta.CurrentSnapshot() != nil {
+			return fmt.Errorf("%w: cannot add required column without an initial default to a non-empty table: %s",
+				iceberg.ErrInvalidSchema, field.Name)
+		}
+
+		// assign fresh IDs to the field and everything nested in its type,
+		// in the same order as a new schema would assign them
+		fresh, err := iceberg.AssignFreshSchemaIDs(iceberg.NewSchema(0, field), us.nextColumnID)
+		if err != nil {
+			return err
+		}
+
+		added := fresh.Field(0)
+		added.InitialDefault, added.WriteDefault = field.InitialDefault, field.WriteDefault
+		us.adds[parentID] = append(us.adds[parentID], added)
+
+		return nil
+	}
+}
+
+func (us *UpdateSchema) renameColumn(path, newName string) updateSchemaOp {
+	return func() error {
+		field, ok := us.findField(path)
+		if !ok {
+			return fmt.Errorf("%w: cannot find column to rename: %s", iceberg.ErrInvalidSchema, path)
+		}
+
+		parentID, siblings, ok := structContaining(us.schema.Fields(), tableRootID, field.ID)
+		if !ok {
+			return fmt.Errorf("%w: cannot rename list element or map key/value: %s",
+				iceberg.ErrInvalidSchema, path)
+		}
+
+		if parentID == tableRootID && strings.Contains(newName, ".") {
+			return fmt.Errorf("%w: cannot rename column to ambiguous name: %s",
+				iceberg.ErrInvalidSchema, newName)
+		}
+
+		if err := us.checkName(parentID, siblings, field.ID, newName); err != nil {
+			return fmt.Errorf("cannot rename %s: %w", path, err)
+		}
+
+		us.renames[field.ID] = newName
+
+		return nil
+	}
+}
+
+func (us *UpdateSchema) promoteType(path string, newType iceberg.Type) updateSchemaOp {
+	return func() error {
+		field, ok := us.findField(path)
+		if !ok {
+			return fmt.Errorf("%w: cannot find column to promote: %s", iceberg.ErrInvalidSchema, path)
+		}
+
+		if err := validatePromotion(field.Type, newType); err != nil {
+			return fmt.Errorf("cannot change type of %s: %w", path, err)
+		}
+
+		if !field.Type.Equals(newType) {
+			us.promotions[field.ID] = newType
+		}
+
+		return nil
+	}
+}
+
+// validatePromotion returns an error unless values of type from can be read
+// as type to without rewriting data, as defined by the Iceberg spec.
+func validatePromotion(from, to iceberg.Type) error {
+	if _, ok := from.(iceberg.PrimitiveType); !ok {
+		return fmt.Errorf("%w: cannot change type of non-primitive column %s",
+			iceberg.ErrInvalidSchema, from)
+	}
+
+	if from.Equals(to) {
+		return nil
+	}
+
+	switch from := from.(type) {
+	case iceberg.Int32Type:
+		if _, ok := to.(iceberg.Int64Type); ok {
+			return nil
+		}
+	case iceberg.Float32Type:
+		if _, ok := to.(iceberg.Float64Type); ok {
+			return nil
+		}
+	case iceberg.DecimalType:
+		to, ok := to.(iceberg.DecimalType)
+		if !ok {
+			break
+		}
+
+		if to.Scale() != from.Scale() {
+			return fmt.Errorf("%w: cannot change decimal scale from %d to %d",
+				iceberg.ErrInvalidSchema, from.Scale(), to.Scale())
+		}
+
+		if to.Precision() < from.Precision() {
+			return fmt.Errorf("%w: cannot reduce decimal precision from %d to %d",
+				iceberg.ErrInvalidSchema, from.Precision(), to.Precision())
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: cannot promote %s to %s", iceberg.ErrInvalidSchema, from, to)
+}
+
+// checkName validates that name can be used for the column with the given
+// ID in the struct with the given parent ID and existing fields.
+func (us *UpdateSchema) checkName(parentID int, siblings []iceberg.NestedField, id int, name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: column name cannot be empty", iceberg.ErrInvalidSchema)
+	}
+
+	if parentID == tableRootID && slices.Contains(reservedColumnNames, name) {
+		return fmt.Errorf("%w: %s is a reserved metadata column name", iceberg.ErrInvalidSchema, name)
+	}
+
+	for _, f := range slices.Concat(siblings, us.adds[parentID]) {
+		if f.ID != id && us.namesEqual(us.columnName(f), name) {
+			return fmt.Errorf("%w: name already exists: %s", iceberg.ErrInvalidSchema, name)
+		}
+	}
+
+	return nil
+}
+
+// columnName returns the name of the field after any pending rename.
+func (us *UpdateSchema) columnName(f iceberg.NestedField) string {
+	if name, ok := us.renames[f.ID]; ok {
+		return name
+	}
+
+	return f.Name
+}
+
+func (us *UpdateSchema) findField(name string) (iceberg.NestedField, bool) {
+	if us.caseSensitive {
+		return us.schema.FindFieldByName(name)
+	}
+
+	return us.schema.FindFieldByNameCaseInsensitive(name)
+}
+
+func (us *UpdateSchema) namesEqual(a, b string) bool {
+	if us.caseSensitive {
+		return a == b
+	}
+
+	return strings.EqualFold(a, b)
+}
+
+func (us *UpdateSchema) nextColumnID() int {
+	us.lastColumnID++
+
+	return us.lastColumnID
+}
+
+func (us *UpdateSchema) newSchemaID() int {
+	id := us.schema.ID
+	for _, s := range us.meta.Schemas() {
+		id = max(id, s.ID)
+	}
+
+	return id + 1
+}
+
+func (us *UpdateSchema) hasChanges() bool {
+	return len(us.adds) > 0 || len(us.renames) > 0 || len(us.promotions) > 0
+}
+
+// applyFields returns the fields of the struct with the given ID with all
+// of the pending changes applied.
+func (us *UpdateSchema) applyFields(fields []iceberg.NestedField, parentID int) []iceberg.NestedField {
+	out := make([]iceberg.NestedField, 0, len(fields)+len(us.adds[parentID]))
+	for _, f := range fields {
+		f.Name = us.columnName(f)
+		f.Type = us.applyType(f.ID, f.Type)
+		out = append(out, f)
+	}
+
+	return append(out, us.adds[parentID]...)
+}
+
+func (us *UpdateSchema) applyType(id int, typ iceberg.Type) iceberg.Type {
+	if promoted, ok := us.promotions[id]; ok {
+		return promoted
+	}
+
+	switch t := typ.(type) {
+	case *iceberg.StructType:
+		return &iceberg.StructType{FieldList: us.applyFields(t.FieldList, id)}
+	case *iceberg.ListType:
+		return &iceberg.ListType{
+			ElementID:       t.ElementID,
+			Element:         us.applyType(t.ElementID, t.Element),
+			ElementRequired: t.ElementRequired,
+		}
+	case *iceberg.MapType:
+		return &iceberg.MapType{
+			KeyID:         t.KeyID,
+			KeyType:       us.applyType(t.KeyID, t.KeyType),
+			ValueID:       t.ValueID,
+			ValueType:     us.applyType(t.ValueID, t.ValueType),
+			ValueRequired: t.ValueRequired,
+		}
+	}
+
+	return typ
+}
+
+// structFields returns the ID and fields of the struct which columns are
+// added to when field is used as a parent.
+func structFields(field iceberg.NestedField) (int, []iceberg.NestedField, error) {
+	id, typ := field.ID, field.Type
+	switch t := typ.(type) {
+	case *iceberg.ListType:
+		id, typ = t.ElementID, t.Element
+	case *iceberg.MapType:
+		id, typ = t.ValueID, t.ValueType
+	}
+
+	st, ok := typ.(*iceberg.StructType)
+	if !ok {
+		return 0, nil, fmt.Errorf("%w: cannot add column to non-struct type %s: %s",
+			iceberg.ErrInvalidSchema, field.Type, field.Name)
+	}
+
+	return id, st.FieldList, nil
+}
+
+// structContaining finds the struct which directly contains the field with
+// the given ID, returning the struct's ID and fields. Fields are searched
+// recursively, including the element and value structs of lists and maps.
+func structContaining(fields []iceberg.NestedField, structID, id int) (int, []iceberg.NestedField, bool) {
+	for _, f := range fields {
+		if f.ID == id {
+			return structID, fields, true
+		}
+	}
+
+	for _, f := range fields {
+		nestedID, typ := f.ID, f.Type
+		switch t := typ.(type) {
+		case *iceberg.ListType:
+			nestedID, typ = t.ElementID, t.Element
+		case *iceberg.MapType:
+			nestedID, typ = t.ValueID, t.ValueType
+		}
+
+		if st, ok := typ.(*iceberg.StructType); ok {
+			if parentID, siblings, ok := structContaining(st.FieldList, nestedID, id); ok {
+				return parentID, siblings, true
+			}
+		}
+	}
+
+	return 0, nil, false
+}
@@ -0,0 +1,275 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSchemaAddColumn(t *testing.T) {
+	lastColumnID := testNonPartitionedTable.Metadata().LastColumnID()
+
+	t.Run("add top-level column", func(t *testing.T) {
+		txn := testNonPartitionedTable.NewTransaction()
+		require.NoError(t, txn.UpdateSchema(true).
+			AddColumn("", iceberg.NestedField{Name: "email", Type: iceberg.PrimitiveTypes.String}).
+			Commit())
+
+		staged, err := txn.StagedTable()
+		require.NoError(t, err)
+		assert.Equal(t, lastColumnID+1, staged.Metadata().LastColumnID())
+		assert.Equal(t, testNonPartitionedTable.Schema().ID+1, staged.Schema().ID)
+
+		field, ok := staged.Schema().FindFieldByName("email")
+		require.True(t, ok)
+		assert.Equal(t, lastColumnID+1, field.ID)
+		assert.False(t, field.Required)
+		assert.Equal(t, testNonPartitionedTable.Schema().NumFields()+1, staged.Schema().NumFields())
+	})
+
+	t.Run("add nested columns", func(t *testing.T) {
+		txn := testNonPartitionedTable.NewTransaction()
+		update := txn.UpdateSchema(false).
+			AddColumn("ADDRESS", iceberg.NestedField{Name: "country", Type: iceberg.PrimitiveTypes.String}).
+			AddColumn("", iceberg.NestedField{Name: "tags", Type: &iceberg.ListType{
+				Element: iceberg.PrimitiveTypes.String, ElementRequired: true,
+			}})
+
+		sc, err := update.Apply()
+		require.NoError(t, err)
+
+		country, ok := sc.FindFieldByName("address.country")
+		require.True(t, ok)
+		assert.Equal(t, lastColumnID+1, country.ID)
+
+		tags, ok := sc.FindFieldByName("tags")
+		require.True(t, ok)
+		assert.Equal(t, lastColumnID+2, tags.ID)
+		assert.Equal(t, lastColumnID+3, tags.Type.(*iceberg.ListType).ElementID)
+
+		// existing fields keep their IDs
+		zip, ok := sc.FindFieldByName("address.zip_code")
+		require.True(t, ok)
+		assert.Equal(t, 7, zip.ID)
+
+		require.NoError(t, update.Commit())
+		staged, err := txn.StagedTable()
+		require.NoError(t, err)
+		assert.Equal(t, lastColumnID+3, staged.Metadata().LastColumnID())
+		assert.True(t, sc.Equals(staged.Schema()))
+	})
+
+	t.Run("add to non-struct parent", func(t *testing.T) {
+		_, err := testNonPartitionedTable.NewTransaction().UpdateSchema(true).
+			AddColumn("name", iceberg.NestedField{Name: "first", Type: iceberg.PrimitiveTypes.String}).
+			Apply()
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+	})
+
+	t.Run("add duplicate column", func(t *testing.T) {
+		_, err := testNonPartitionedTable.NewTransaction().UpdateSchema(true).
+			AddColumn("address", iceberg.NestedField{Name: "city", Type: iceberg.PrimitiveTypes.String}).
+			Apply()
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, "name already exists: city")
+	})
+
+	t.Run("add required column to empty table", func(t *testing.T) {
+		sc, err := testNonPartitionedTable.NewTransaction().UpdateSchema(true).
+			AddColumn("", iceberg.NestedField{Name: "count", Type: iceberg.PrimitiveTypes.Int32, Required: true}).
+			Apply()
+		require.NoError(t, err)
+
+		field, ok := sc.FindFieldByName("count")
+		require.True(t, ok)
+		assert.True(t, field.Required)
+	})
+
+	t.Run("add required column to non-empty table", func(t *testing.T) {
+		meta, err := table.ParseMetadataBytes([]byte(table.ExampleTableMetadataV2))
+		require.NoError(t, err)
+		tbl := table.New([]string{"t"}, meta, "", nil, nil)
+
+		_, err = tbl.NewTransaction().UpdateSchema(true).
+			AddColumn("", iceberg.NestedField{Name: "count", Type: iceberg.PrimitiveTypes.Int32, Required: true}).
+			Apply()
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, "initial default")
+
+		sc, err := tbl.NewTransaction().UpdateSchema(true).
+			AddColumn("", iceberg.NestedField{
+				Name: "count", Type: iceberg.PrimitiveTypes.Int32, Required: true, InitialDefault: int32(0),
+			}).
+			Apply()
+		require.NoError(t, err)
+
+		field, ok := sc.FindFieldByName("count")
+		require.True(t, ok)
+		assert.Equal(t, meta.LastColumnID()+1, field.ID)
+		assert.Equal(t, int32(0), field.InitialDefault)
+		assert.Equal(t, []int{1, 2}, sc.IdentifierFieldIDs)
+	})
+}
+
+func TestUpdateSchemaRenameColumn(t *testing.T) {
+	t.Run("rename nested column", func(t *testing.T) {
+		txn := testNonPartitionedTable.NewTransaction()
+		update := txn.UpdateSchema(true).RenameColumn("address.zip_code", "postal_code")
+
+		sc, err := update.Apply()
+		require.NoError(t, err)
+
+		_, ok := sc.FindFieldByName("address.zip_code")
+		assert.False(t, ok)
+
+		field, ok := sc.FindFieldByName("address.postal_code")
+		require.True(t, ok)
+		assert.Equal(t, 7, field.ID)
+		assert.Equal(t, iceberg.PrimitiveTypes.Int64, field.Type)
+
+		name, ok := sc.FindColumnName(7)
+		require.True(t, ok)
+		assert.Equal(t, "address.postal_code", name)
+
+		require.NoError(t, update.Commit())
+		staged, err := txn.StagedTable()
+		require.NoError(t, err)
+		assert.Equal(t, testNonPartitionedTable.Metadata().LastColumnID(), staged.Metadata().LastColumnID())
+		assert.True(t, sc.Equals(staged.Schema()))
+	})
+
+	t.Run("rename to freed name", func(t *testing.T) {
+		// paths always refer to columns by their name in the current schema
+		_, err := testNonPartitionedTable.NewTransaction().UpdateSchema(true).
+			RenameColumn("address.street", "tmp").
+			RenameColumn("address.tmp", "street").
+			Apply()
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+
+		sc, err := testNonPartitionedTable.NewTransaction().UpdateSchema(true).
+			RenameColumn("name", "full_name").
+			RenameColumn("id", "name").
+			Apply()
+		require.NoError(t, err)
+
+		field, ok := sc.FindFieldByName("name")
+		require.True(t, ok)
+		assert.Equal(t, 1, field.ID)
+	})
+
+	t.Run("colliding rename", func(t *testing.T) {
+		_, err := testNonPartitionedTable.NewTransaction().UpdateSchema(true).
+			RenameColumn("address.city", "street").
+			Apply()
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, "name already exists: street")
+
+		_, err = testNonPartitionedTable.NewTransaction().UpdateSchema(false).
+			RenameColumn("ID", "NAME").
+			Apply()
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+
+		_, err = testNonPartitionedTable.NewTransaction().UpdateSchema(true).
+			AddColumn("", iceberg.NestedField{Name: "email", Type: iceberg.PrimitiveTypes.String}).
+			RenameColumn("name", "email").
+			Apply()
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+	})
+
+	t.Run("rename to reserved name", func(t *testing.T) {
+		_, err := testNonPartitionedTable.NewTransaction().UpdateSchema(true).
+			RenameColumn("name", "_file").
+			Apply()
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, "reserved")
+
+		// reserved names only apply to top-level columns
+		_, err = testNonPartitionedTable.NewTransaction().UpdateSchema(true).
+			RenameColumn("address.city", "_file").
+			Apply()
+		assert.NoError(t, err)
+	})
+
+	t.Run("rename missing column", func(t *testing.T) {
+		_, err := testNonPartitionedTable.NewTransaction().UpdateSchema(true).
+			RenameColumn("missing", "other").
+			Apply()
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+	})
+}
+
+func TestUpdateSchemaPromoteType(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "i", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+		iceberg.NestedField{ID: 2, Name: "l", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 3, Name: "f", Type: iceberg.PrimitiveTypes.Float32},
+		iceberg.NestedField{ID: 4, Name: "d", Type: iceberg.PrimitiveTypes.Float64},
+		iceberg.NestedField{ID: 5, Name: "dec", Type: iceberg.DecimalTypeOf(9, 2)},
+		iceberg.NestedField{ID: 6, Name: "s", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 7, Name: "ints", Type: &iceberg.ListType{
+			ElementID: 8, Element: iceberg.PrimitiveTypes.Int32, ElementRequired: true,
+		}},
+	)
+	meta, err := table.NewMetadata(sc, iceberg.UnpartitionedSpec, table.UnsortedSortOrder, "", nil)
+	require.NoError(t, err)
+	tbl := table.New([]string{"promote"}, meta, "", nil, nil)
+
+	tests := []struct {
+		path    string
+		newType iceberg.Type
+		errMsg  string
+	}{
+		{"i", iceberg.PrimitiveTypes.Int64, ""},
+		{"f", iceberg.PrimitiveTypes.Float64, ""},
+		{"dec", iceberg.DecimalTypeOf(18, 2), ""},
+		{"dec", iceberg.DecimalTypeOf(9, 2), ""},
+		{"ints.element", iceberg.PrimitiveTypes.Int64, ""},
+		{"l", iceberg.PrimitiveTypes.Int32, "cannot promote long to int"},
+		{"d", iceberg.PrimitiveTypes.Float32, "cannot promote double to float"},
+		{"i", iceberg.PrimitiveTypes.Float64, "cannot promote int to double"},
+		{"i", iceberg.PrimitiveTypes.String, "cannot promote int to string"},
+		{"s", iceberg.PrimitiveTypes.Binary, "cannot promote string to binary"},
+		{"dec", iceberg.DecimalTypeOf(18, 3), "cannot change decimal scale from 2 to 3"},
+		{"dec", iceberg.DecimalTypeOf(7, 2), "cannot reduce decimal precision from 9 to 7"},
+		{"ints", &iceberg.ListType{ElementID: 8, Element: iceberg.PrimitiveTypes.Int64}, "non-primitive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path+" to "+tt.newType.String(), func(t *testing.T) {
+			newSchema, err := tbl.NewTransaction().UpdateSchema(true).
+				PromoteType(tt.path, tt.newType).
+				Apply()
+			if tt.errMsg != "" {
+				assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+				assert.ErrorContains(t, err, tt.errMsg)
+
+				return
+			}
+
+			require.NoError(t, err)
+			field, ok := newSchema.FindFieldByName(tt.path)
+			require.True(t, ok)
+			assert.True(t, tt.newType.Equals(field.Type), "got %s", field.Type)
+		})
+	}
+}
@@ -36,6 +36,7 @@ type UpdateSpec struct {
 	operations []updateSpecOp
 
 	txn                   *Transaction
+	meta                  Metadata
 	nameToField           map[string]iceberg.PartitionField
 	nameToAddedField      map[string]iceberg.PartitionField
 	transformToField      map[transformKey]iceberg.PartitionField
@@ -57,7 +58,14 @@ type transformKey struct {
 func NewUpdateSpec(t *Transaction, caseSensitive bool) *UpdateSpec {
 	transformToField := make(map[transformKey]iceberg.PartitionField)
 	nameToField := make(map[string]iceberg.PartitionField)
-	partitionSpec := t.tbl.Metadata().PartitionSpec()
+	// the error of building the staged metadata is returned when the
+	// updates are built
+	meta, err := t.stagedMetadata()
+	if err != nil {
+		meta = t.tbl.Metadata()
+	}
+
+	partitionSpec := meta.PartitionSpec()
 	for partitionField := range partitionSpec.Fields() {
 		transformToField[transformKey{
 			SourceId:  partitionField.SourceID,
@@ -65,14 +73,15 @@ func NewUpdateSpec(t *Transaction, caseSensitive bool) *UpdateSpec {
 		}] = partitionField
 		nameToField[partitionField.Name] = partitionField
 	}
-	lastAssignedFieldId := t.tbl.Metadata().LastPartitionSpecID()
+	lastAssignedFieldId := meta.LastPartitionSpecID()
 	if lastAssignedFieldId == nil {
 		v := iceberg.PartitionDataIDStart - 1
 		lastAssignedFieldId = &v
 	}
 
-	return &UpdateSpec{
+	us := &UpdateSpec{
 		txn:                   t,
+		meta:                  meta,
 		nameToField:           nameToField,
 		nameToAddedField:      make(map[string]iceberg.PartitionField),
 		transformToField:      transformToField,
@@ -84,6 +93,11 @@ func NewUpdateSpec(t *Transaction, caseSensitive bool) *UpdateSpec {
 		deletes:               make(map[int]bool),
 		lastAssignedFieldId:   *lastAssignedFieldId,
 	}
+	if err != nil {
+		us.operations = append(us.operations, func() error { return err })
+	}
+
+	return us
 }
 
 func (us *UpdateSpec) AddField(sourceColName string, transform iceberg.Transform, partitionFieldName string) *UpdateSpec {
@@ -119,14 +133,14 @@ func (us *UpdateSpec) BuildUpdates() ([]Update, []Requirement, error) {
 	updates := make([]Update, 0)
 	requirements := make([]Requirement, 0)
 
-	if us.txn.tbl.Metadata().DefaultPartitionSpec() != newSpec.ID() {
+	if us.meta.DefaultPartitionSpec() != newSpec.ID() {
 		if us.isNewPartitionSpec(newSpec.ID()) {
 			updates = append(updates, NewAddPartitionSpecUpdate(&newSpec, false))
 			updates = append(updates, NewSetDefaultSpecUpdate(-1))
 		} else {
 			updates = append(updates, NewSetDefaultSpecUpdate(newSpec.ID()))
 		}
-		requiredLastAssignedPartitionId := us.txn.tbl.Metadata().LastPartitionSpecID()
+		requiredLastAssignedPartitionId := us.meta.LastPartitionSpecID()
 		requirements = append(requirements, AssertLastAssignedPartitionID(*requiredLastAssignedPartitionId))
 	}
 
@@ -136,25 +150,25 @@ func (us *UpdateSpec) BuildUpdates() ([]Update, []Requirement, error) {
 func (us *UpdateSpec) Apply() iceberg.PartitionSpec {
 	partitionFields := make([]iceberg.PartitionField, 0)
 	partitionNames := make(map[string]bool)
-	spec := us.txn.tbl.Metadata().PartitionSpec()
+	spec := us.meta.PartitionSpec()
 	for field := range spec.Fields() {
 		var newField iceberg.PartitionField
 		var err error
 		if _, deleted := us.deletes[field.FieldID]; !deleted {
 			if rename, renamed := us.renames[field.Name]; renamed {
-				newField, err = us.addNewField(us.txn.tbl.Schema(), field.SourceID, field.FieldID, rename, field.Transform, partitionNames)
+				newField, err = us.addNewField(us.meta.CurrentSchema(), field.SourceID, field.FieldID, rename, field.Transform, partitionNames)
 			} else {
-				newField, err = us.addNewField(us.txn.tbl.Schema(), field.SourceID, field.FieldID, field.Name, field.Transform, partitionNames)
+				newField, err = us.addNewField(us.meta.CurrentSchema(), field.SourceID, field.FieldID, field.Name, field.Transform, partitionNames)
 			}
 			if err != nil {
 				return iceberg.PartitionSpec{}
 			}
 			partitionFields = append(partitionFields, newField)
-		} else if us.txn.tbl.Metadata().Version() == 1 {
+		} else if us.meta.Version() == 1 {
 			if rename, renamed := us.renames[field.Name]; renamed {
-				newField, err = us.addNewField(us.txn.tbl.Schema(), field.SourceID, field.FieldID, rename, iceberg.VoidTransform{}, partitionNames)
+				newField, err = us.addNewField(us.meta.CurrentSchema(), field.SourceID, field.FieldID, rename, iceberg.VoidTransform{}, partitionNames)
 			} else {
-				newField, err = us.addNewField(us.txn.tbl.Schema(), field.SourceID, field.FieldID, field.Name, iceberg.VoidTransform{}, partitionNames)
+				newField, err = us.addNewField(us.meta.CurrentSchema(), field.SourceID, field.FieldID, field.Name, iceberg.VoidTransform{}, partitionNames)
 			}
 			if err != nil {
 				return iceberg.PartitionSpec{}
@@ -167,7 +181,7 @@ func (us *UpdateSpec) Apply() iceberg.PartitionSpec {
 
 	newSpec := iceberg.NewPartitionSpec(partitionFields...)
 	newSpecId := iceberg.InitialPartitionSpecID
-	for _, spec = range us.txn.tbl.Metadata().PartitionSpecs() {
+	for _, spec = range us.meta.PartitionSpecs() {
 		if newSpec.CompatibleWith(&spec) {
 			newSpecId = spec.ID()
 
@@ -197,7 +211,7 @@ func (us *UpdateSpec) addField(sourceColName string, transform iceberg.Transform
 	return func() error {
 		// Finds the column in the schema and binds it with case sensitivity.
 		ref := iceberg.Reference(sourceColName)
-		boundTerm, err := ref.Bind(us.txn.tbl.Schema(), us.caseSensitive)
+		boundTerm, err := ref.Bind(us.meta.CurrentSchema(), us.caseSensitive)
 		if err != nil {
 			return err
 		}
@@ -312,10 +326,10 @@ func (us *UpdateSpec) renameField(name string, newName string) updateSpecOp {
 }
 
 func (us *UpdateSpec) partitionField(key transformKey, name string) (iceberg.PartitionField, error) {
-	if us.txn.tbl.Metadata().Version() == 2 {
+	if us.meta.Version() == 2 {
 		sourceId, transform := key.SourceId, key.Transform
 		historicalFields := make([]iceberg.PartitionField, 0)
-		for _, spec := range us.txn.tbl.Metadata().PartitionSpecs() {
+		for _, spec := range us.meta.PartitionSpecs() {
 			historicalFields = slices.AppendSeq(historicalFields, spec.Fields())
 		}
 		for _, field := range historicalFields {
@@ -341,7 +355,7 @@ func (us *UpdateSpec) partitionField(key transformKey, name string) (iceberg.Par
 			Transform: transform,
 		}
 		var err error
-		name, err = iceberg.GeneratePartitionFieldName(us.txn.tbl.Schema(), tmpField)
+		name, err = iceberg.GeneratePartitionFieldName(us.meta.CurrentSchema(), tmpField)
 		if err != nil {
 			return iceberg.PartitionField{}, err
 		}
@@ -395,7 +409,7 @@ func (us *UpdateSpec) addNewField(schema *iceberg.Schema, sourceId int, fieldId
 }
 
 func (us *UpdateSpec) isNewPartitionSpec(newSpecId int) bool {
-	return !slices.ContainsFunc(us.txn.tbl.Metadata().PartitionSpecs(), func(s iceberg.PartitionSpec) bool {
+	return !slices.ContainsFunc(us.meta.PartitionSpecs(), func(s iceberg.PartitionSpec) bool {
 		return s.ID() == newSpecId
 	})
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/table"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var testSchema = iceberg.NewSchema(1,
@@ -489,3 +490,67 @@ func TestUpdateSpecCommit(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestUpdateSpecRemoveFieldV1(t *testing.T) {
+	meta, err := table.NewMetadata(testSchema, &partitionSpec, table.UnsortedSortOrder, "",
+		iceberg.Properties{"format-version": "1"})
+	require.NoError(t, err)
+	require.Equal(t, 1, meta.Version())
+	tbl := table.New([]string{"v1_partitioned"}, meta, "", nil, nil)
+
+	txn := tbl.NewTransaction()
+	require.NoError(t, txn.UpdateSpec(false).
+		RemoveField("id_identity").
+		AddField("ts", iceberg.DayTransform{}, "ts_day").
+		Commit())
+
+	staged, err := txn.StagedTable()
+	require.NoError(t, err)
+
+	// v1 specs can't drop fields, so the removed field is kept with its
+	// field ID and replaced by a void transform
+	spec := staged.Spec()
+	assert.Equal(t, 1, spec.ID())
+	require.Equal(t, 3, spec.NumFields())
+	assert.Equal(t, iceberg.PartitionField{
+		SourceID: 1, FieldID: 1000, Name: "id_identity", Transform: iceberg.VoidTransform{},
+	}, spec.Field(0))
+	assert.Equal(t, "street_void", spec.Field(1).Name)
+	assert.Equal(t, iceberg.PartitionField{
+		SourceID: 3, FieldID: 1002, Name: "ts_day", Transform: iceberg.DayTransform{},
+	}, spec.Field(2))
+}
+
+func TestUpdateSpecFieldIDsNotReused(t *testing.T) {
+	txn := testPartitionedTable.NewTransaction()
+
+	require.NoError(t, txn.UpdateSpec(false).
+		AddField("ts", iceberg.YearTransform{}, "ts_year").
+		Commit())
+	require.NoError(t, txn.UpdateSpec(false).
+		RemoveField("ts_year").
+		AddField("address.zip_code", iceberg.BucketTransform{NumBuckets: 8}, "zip_bucket").
+		Commit())
+	require.NoError(t, txn.UpdateSpec(false).
+		AddField("ts", iceberg.MonthTransform{}, "ts_month").
+		Commit())
+
+	staged, err := txn.StagedTable()
+	require.NoError(t, err)
+
+	spec := staged.Spec()
+	assert.Equal(t, 3, spec.ID())
+	assert.Len(t, staged.Metadata().PartitionSpecs(), 4)
+	assert.Equal(t, 1004, *staged.Metadata().LastPartitionSpecID())
+
+	ids := make(map[string]int)
+	for f := range spec.Fields() {
+		ids[f.Name] = f.FieldID
+	}
+	assert.Equal(t, map[string]int{
+		"id_identity": 1000,
+		"street_void": 1001,
+		"zip_bucket":  1003,
+		"ts_month":    1004,
+	}, ids)
+}
@@ -125,3 +125,113 @@ func writeFiles(ctx context.Context, rootLocation string, fs io.WriteFileIO, met
 		return w.writeFile(ctx, t)
 	})
 }
+
+// DataFileWriter writes Arrow record batches to a single Parquet data
+// file. Batches are converted to the file schema, with the Iceberg field
+// IDs embedded in the Parquet schema, and the file is written according
+// to the write.parquet.* properties: row groups are cut once they reach
+// write.parquet.row-group-size-bytes, pages once they reach
+// write.parquet.page-size-bytes, and both are compressed with
+// write.parquet.compression-codec.
+type DataFileWriter struct {
+	ctx        context.Context
+	fs         io.WriteFileIO
+	info       internal.WriteFileInfo
+	format     internal.FileFormat
+	fileSchema *iceberg.Schema
+
+	wr internal.DataFileWriter
+}
+
+// NewDataFileWriter returns a writer for the Parquet file at path holding
+// rows of the given schema. The DataFile returned when closing the writer
+// is built for spec, which is iceberg.UnpartitionedSpec for unpartitioned
+// tables.
+func NewDataFileWriter(ctx context.Context, fs io.WriteFileIO, path string, sc *iceberg.Schema, spec iceberg.PartitionSpec, props iceberg.Properties) (*DataFileWriter, error) {
+	fileSchema, err := iceberg.SanitizeColumnNames(sc)
+	if err != nil {
+		return nil, err
+	}
+
+	statsCols, err := computeStatsPlan(fileSchema, props)
+	if err != nil {
+		return nil, err
+	}
+
+	format := internal.GetFileFormat(iceberg.ParquetFile)
+
+	return &DataFileWriter{
+		ctx:    ctx,
+		fs:     fs,
+		format: format,
+		info: internal.WriteFileInfo{
+			FileSchema: fileSchema,
+			Spec:       spec,
+			FileName:   path,
+			StatsCols:  statsCols,
+			WriteProps: format.GetWriteProperties(props),
+		},
+		fileSchema: sc,
+	}, nil
+}
+
+func (w *DataFileWriter) open(sc *arrow.Schema) (err error) {
+	w.wr, err = w.format.NewDataFileWriter(w.ctx, w.fs, w.info, sc)
+
+	return err
+}
+
+// Write converts the batch to the file schema and writes it to the file.
+// The file itself is created by the first call to Write.
+func (w *DataFileWriter) Write(batch arrow.Record) error {
+	rec, err := ToRequestedSchema(w.ctx, w.info.FileSchema, w.fileSchema,
+		batch, false, true, false)
+	if err != nil {
+		return err
+	}
+	defer rec.Release()
+
+	if w.wr == nil {
+		if err := w.open(rec.Schema()); err != nil {
+			return err
+		}
+	}
+
+	return w.wr.Write(rec)
+}
+
+// BytesWritten returns an estimate of the size of the file so far,
+// counting the rows buffered for the current row group.
+func (w *DataFileWriter) BytesWritten() int64 {
+	if w.wr == nil {
+		return 0
+	}
+
+	return w.wr.BytesWritten()
+}
+
+// Close finishes the file and returns the DataFile describing it, with
+// its size, record count and column metrics. A file without rows is
+// still written if nothing was written to the writer.
+func (w *DataFileWriter) Close() (iceberg.DataFile, error) {
+	if w.wr == nil {
+		sc, err := SchemaToArrowSchema(w.info.FileSchema, nil, true, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := w.open(sc); err != nil {
+			return nil, err
+		}
+	}
+
+	return w.wr.Close()
+}
+
+// Abort abandons the file, which is never published, e.g. after a write
+// failed. The writer must not be used afterwards.
+func (w *DataFileWriter) Abort() {
+	if w.wr != nil {
+		w.wr.Abort()
+	}
+}
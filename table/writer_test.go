@@ -0,0 +1,155 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writerTestBatch(t *testing.T, mem memory.Allocator, nrows int) arrow.Record {
+	bldr := array.NewRecordBuilder(mem, arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "data", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil))
+	defer bldr.Release()
+
+	ids, data := bldr.Field(0).(*array.Int64Builder), bldr.Field(1).(*array.StringBuilder)
+	for i := range nrows {
+		ids.Append(int64(i))
+		data.Append(fmt.Sprintf("row-%d", i))
+	}
+
+	return bldr.NewRecord()
+}
+
+func TestDataFileWriter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+	)
+
+	batch := writerTestBatch(t, mem, 10000)
+	defer batch.Release()
+
+	write := func(name string, props iceberg.Properties) (iceberg.DataFile, *file.Reader) {
+		path := filepath.Join(t.TempDir(), name)
+		w, err := table.NewDataFileWriter(context.Background(), iceio.LocalFS{},
+			path, sc, *iceberg.UnpartitionedSpec, props)
+		require.NoError(t, err)
+		require.NoError(t, w.Write(batch))
+
+		df, err := w.Close()
+		require.NoError(t, err)
+
+		rdr, err := file.OpenParquetFile(path, false)
+		require.NoError(t, err)
+		t.Cleanup(func() { rdr.Close() })
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, path, df.FilePath())
+		assert.Equal(t, iceberg.ParquetFile, df.FileFormat())
+		assert.EqualValues(t, info.Size(), df.FileSizeBytes())
+		assert.EqualValues(t, 10000, df.Count())
+		assert.EqualValues(t, 10000, rdr.NumRows())
+
+		return df, rdr
+	}
+
+	df, rdr := write("default.parquet", nil)
+	assert.Equal(t, 1, rdr.NumRowGroups())
+	assert.Equal(t, map[int]int64{1: 10000, 2: 10000}, df.ValueCounts())
+	assert.Contains(t, df.LowerBoundValues(), 1)
+	assert.Contains(t, df.UpperBoundValues(), 2)
+
+	// the parquet schema carries the iceberg field ids
+	pqSchema := rdr.MetaData().Schema
+	require.Equal(t, 2, pqSchema.NumColumns())
+	assert.EqualValues(t, 1, pqSchema.Column(0).SchemaNode().FieldID())
+	assert.EqualValues(t, 2, pqSchema.Column(1).SchemaNode().FieldID())
+	col, err := rdr.MetaData().RowGroup(0).ColumnChunk(0)
+	require.NoError(t, err)
+	assert.Equal(t, compress.Codecs.Zstd, col.Compression())
+
+	_, rdr = write("small-row-groups.parquet", iceberg.Properties{
+		table.ParquetRowGroupSizeBytesKey: "65536",
+		table.ParquetPageSizeBytesKey:     "1024",
+		table.ParquetPageRowLimitKey:      "1000",
+		table.ParquetCompressionKey:       "uncompressed",
+	})
+
+	// rows are written 1000 at a time, so every row group but the last
+	// holds several of these chunks before reaching the 64KB target
+	require.Greater(t, rdr.NumRowGroups(), 1)
+	var total int64
+	for i := range rdr.NumRowGroups() {
+		rg := rdr.MetaData().RowGroup(i)
+		total += rg.NumRows()
+		col, err := rg.ColumnChunk(0)
+		require.NoError(t, err)
+		assert.Equal(t, compress.Codecs.Uncompressed, col.Compression())
+
+		if i < rdr.NumRowGroups()-1 {
+			assert.Greater(t, rg.NumRows(), int64(1000))
+		}
+	}
+	assert.EqualValues(t, 10000, total)
+}
+
+func TestDataFileWriterAbort(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+	)
+
+	batch := writerTestBatch(t, mem, 100)
+	defer batch.Release()
+
+	dir := t.TempDir()
+	w, err := table.NewDataFileWriter(context.Background(), iceio.LocalFS{},
+		filepath.Join(dir, "aborted.parquet"), sc, *iceberg.UnpartitionedSpec, nil)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(batch))
+	w.Abort()
+
+	// nothing is published, not even a partial file
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
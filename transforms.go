@@ -41,22 +41,18 @@ func ParseTransform(s string) (Transform, error) {
 	s = strings.ToLower(s)
 	switch {
 	case strings.HasPrefix(s, "bucket"):
-		matches := regexFromBrackets.FindStringSubmatch(s)
-		if len(matches) != 2 {
+		n, ok := parseTransformParam(s)
+		if !ok {
 			break
 		}
 
-		n, _ := strconv.Atoi(matches[1])
-
 		return BucketTransform{NumBuckets: n}, nil
 	case strings.HasPrefix(s, "truncate"):
-		matches := regexFromBrackets.FindStringSubmatch(s)
-		if len(matches) != 2 {
+		n, ok := parseTransformParam(s)
+		if !ok {
 			break
 		}
 
-		n, _ := strconv.Atoi(matches[1])
-
 		return TruncateTransform{Width: n}, nil
 	default:
 		switch s {
@@ -78,6 +74,22 @@ func ParseTransform(s string) (Transform, error) {
 	return nil, fmt.Errorf("%w: %s", ErrInvalidTransform, s)
 }
 
+// parseTransformParam extracts the parameter of a transform string such as
+// "bucket[16]", which must be a positive integer.
+func parseTransformParam(s string) (int, bool) {
+	matches := regexFromBrackets.FindStringSubmatch(s)
+	if len(matches) != 2 {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(matches[1])
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+
+	return n, true
+}
+
 // Transform is an interface for the various Transformation types
 // in partition specs. Currently, they do not yet provide actual
 // transformation functions or implementation. That will come later as
@@ -412,9 +424,9 @@ func (t TruncateTransform) Transformer(src Type) (func(any) any, error) {
 				return nil
 			}
 
-			val := v.(int32)
+			val, width := v.(int32), int32(t.Width)
 
-			return val - (val % int32(t.Width))
+			return val - (((val % width) + width) % width)
 		}, nil
 	case Int64Type:
 		return func(v any) any {
@@ -422,15 +434,15 @@ func (t TruncateTransform) Transformer(src Type) (func(any) any, error) {
 				return nil
 			}
 
-			val := v.(int64)
+			val, width := v.(int64), int64(t.Width)
 
-			return val - (val % int64(t.Width))
+			return val - (((val % width) + width) % width)
 		}, nil
 	case StringType, BinaryType:
 		return func(v any) any {
 			switch v := v.(type) {
 			case string:
-				return v[:min(len(v), t.Width)]
+				return truncateString(v, t.Width)
 			case []byte:
 				return v[:min(len(v), t.Width)]
 			default:
@@ -460,6 +472,20 @@ func (t TruncateTransform) Transformer(src Type) (func(any) any, error) {
 		ErrInvalidArgument, src)
 }
 
+// truncateString returns the first n unicode code points of s, so that
+// multi-byte characters are never split.
+func truncateString(s string, n int) string {
+	count := 0
+	for i := range s {
+		if count == n {
+			return s[:i]
+		}
+		count++
+	}
+
+	return s
+}
+
 func (t TruncateTransform) Apply(value Optional[Literal]) (out Optional[Literal]) {
 	if !value.Valid {
 		return
@@ -867,8 +893,6 @@ func (HourTransform) Equals(other Transform) bool {
 func (HourTransform) Transformer(src Type) (func(any) Optional[int32], error) {
 	switch src.(type) {
 	case TimestampType, TimestampTzType:
-		const factor = int64(time.Hour / time.Microsecond)
-
 		return func(v any) Optional[int32] {
 			if v == nil {
 				return Optional[int32]{}
@@ -876,7 +900,7 @@ func (HourTransform) Transformer(src Type) (func(any) Optional[int32], error) {
 
 			return Optional[int32]{
 				Valid: true,
-				Val:   int32(int64(v.(Timestamp)) / factor),
+				Val:   hoursSinceEpoch(v.(Timestamp)),
 			}
 		}, nil
 	}
@@ -892,8 +916,7 @@ func (HourTransform) Apply(value Optional[Literal]) (out Optional[Literal]) {
 
 	switch v := value.Val.(type) {
 	case TimestampLiteral:
-		const factor = int64(time.Hour / time.Microsecond)
-		out.Valid, out.Val = true, Int32Literal(int32(int64(v)/factor))
+		out.Valid, out.Val = true, Int32Literal(hoursSinceEpoch(Timestamp(v)))
 	}
 
 	return
@@ -914,6 +937,20 @@ func (t HourTransform) Project(name string, pred BoundPredicate) (UnboundPredica
 	return projectTimeTransform(t, name, pred)
 }
 
+// hoursSinceEpoch returns the number of hours since the unix epoch,
+// rounding towards negative infinity so that timestamps before the
+// epoch land in the hour that contains them.
+func hoursSinceEpoch(ts Timestamp) int32 {
+	const factor = int64(time.Hour / time.Microsecond)
+
+	hours := int64(ts) / factor
+	if int64(ts)%factor < 0 {
+		hours--
+	}
+
+	return int32(hours)
+}
+
 func removeTransform(partName string, pred BoundPredicate) (UnboundPredicate, error) {
 	switch p := pred.(type) {
 	case BoundUnaryPredicate:
@@ -19,13 +19,16 @@ package iceberg_test
 
 import (
 	"bytes"
+	"math"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/apache/arrow-go/v18/arrow/decimal"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
 	"github.com/apache/iceberg-go"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -80,6 +83,14 @@ func TestParseTransform(t *testing.T) {
 		{"truncate no val", "truncate[]"},
 		{"bucket neg", "bucket[-1]"},
 		{"truncate neg", "truncate[-1]"},
+		{"bucket zero", "bucket[0]"},
+		{"truncate zero", "truncate[0]"},
+		{"bucket overflow", "bucket[99999999999999999999]"},
+		{"bucket non-numeric", "bucket[a]"},
+		{"bucket trailing", "bucket[16]x"},
+		{"truncate unclosed", "truncate[10"},
+		{"parameterized year", "year[1]"},
+		{"void with param", "void[1]"},
 	}
 
 	for _, tt := range errorTests {
@@ -238,6 +249,155 @@ func TestManifestPartitionVals(t *testing.T) {
 	}
 }
 
+func TestBucketTransformSpecValues(t *testing.T) {
+	// the hash values published in the spec, bucketed into MaxInt32
+	// buckets so that the bucket is the positive part of the hash
+	uid := uuid.MustParse("f79c3e09-677c-4bbd-a479-3f349cb785e7")
+	dec := iceberg.Decimal{Val: decimal128.FromI64(1420), Scale: 2}
+
+	tests := []struct {
+		name   string
+		typ    iceberg.Type
+		lit    iceberg.Literal
+		val    any
+		bucket int32
+	}{
+		{"int", iceberg.PrimitiveTypes.Int32, iceberg.Int32Literal(34), int32(34), 2017239379},
+		{"long", iceberg.PrimitiveTypes.Int64, iceberg.Int64Literal(34), int64(34), 2017239379},
+		{"decimal", iceberg.DecimalTypeOf(9, 2), iceberg.DecimalLiteral(dec), dec, 1646729059},
+		{"date", iceberg.PrimitiveTypes.Date, iceberg.DateLiteral(17486), iceberg.Date(17486), 1494153226},
+		{"time", iceberg.PrimitiveTypes.Time, iceberg.TimeLiteral(81068000000), iceberg.Time(81068000000), 1484720659},
+		{
+			"timestamp", iceberg.PrimitiveTypes.Timestamp, iceberg.TimestampLiteral(1510871468000000),
+			iceberg.Timestamp(1510871468000000), 99539207,
+		},
+		{"string", iceberg.PrimitiveTypes.String, iceberg.StringLiteral("iceberg"), "iceberg", 1210000089},
+		{"uuid", iceberg.PrimitiveTypes.UUID, iceberg.UUIDLiteral(uid), uid, 1488055340},
+		{"fixed", iceberg.FixedTypeOf(4), iceberg.FixedLiteral{0, 1, 2, 3}, []byte{0, 1, 2, 3}, 1958800441},
+		{"binary", iceberg.PrimitiveTypes.Binary, iceberg.BinaryLiteral{0, 1, 2, 3}, []byte{0, 1, 2, 3}, 1958800441},
+	}
+
+	transform := iceberg.BucketTransform{NumBuckets: math.MaxInt32}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := transform.Apply(iceberg.Optional[iceberg.Literal]{Val: tt.lit, Valid: true})
+			require.True(t, result.Valid)
+			assert.Equal(t, iceberg.Int32Literal(tt.bucket), result.Val)
+
+			out := transform.Transformer(tt.typ)(tt.val)
+			require.True(t, out.Valid)
+			assert.Equal(t, tt.bucket, out.Val)
+		})
+	}
+}
+
+func TestTemporalTransformApply(t *testing.T) {
+	ts := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	beforeEpoch := time.Date(1969, 12, 31, 23, 59, 59, 999_999_000, time.UTC)
+
+	tests := []struct {
+		transform iceberg.Transform
+		input     iceberg.Literal
+		expected  int32
+	}{
+		{iceberg.YearTransform{}, iceberg.TimestampLiteral(ts.UnixMicro()), 53},
+		{iceberg.MonthTransform{}, iceberg.TimestampLiteral(ts.UnixMicro()), 636},
+		{iceberg.DayTransform{}, iceberg.TimestampLiteral(ts.UnixMicro()), 19358},
+		{iceberg.HourTransform{}, iceberg.TimestampLiteral(ts.UnixMicro()), 464592},
+		{iceberg.YearTransform{}, iceberg.DateLiteral(19358), 53},
+		{iceberg.MonthTransform{}, iceberg.DateLiteral(19358), 636},
+		{iceberg.DayTransform{}, iceberg.DateLiteral(19358), 19358},
+		{iceberg.YearTransform{}, iceberg.TimestampLiteral(beforeEpoch.UnixMicro()), -1},
+		{iceberg.MonthTransform{}, iceberg.TimestampLiteral(beforeEpoch.UnixMicro()), -1},
+		{iceberg.DayTransform{}, iceberg.TimestampLiteral(beforeEpoch.UnixMicro()), -1},
+		{iceberg.HourTransform{}, iceberg.TimestampLiteral(beforeEpoch.UnixMicro()), -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.transform.String()+"/"+tt.input.String(), func(t *testing.T) {
+			result := tt.transform.Apply(iceberg.Optional[iceberg.Literal]{Val: tt.input, Valid: true})
+			require.True(t, result.Valid)
+			assert.Equal(t, iceberg.Int32Literal(tt.expected), result.Val)
+
+			srcTypes := []iceberg.Type{tt.input.Type()}
+			if _, ok := tt.input.(iceberg.TimestampLiteral); ok {
+				srcTypes = append(srcTypes, iceberg.PrimitiveTypes.TimestampTz)
+			}
+
+			for _, typ := range srcTypes {
+				fn, err := tt.transform.(iceberg.TimeTransform).Transformer(typ)
+				require.NoError(t, err)
+				assert.Equal(t, iceberg.Optional[int32]{Valid: true, Val: tt.expected}, fn(tt.input.Any()))
+			}
+		})
+	}
+
+	_, err := iceberg.HourTransform{}.Transformer(iceberg.PrimitiveTypes.Date)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	_, err = iceberg.YearTransform{}.Transformer(iceberg.PrimitiveTypes.String)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
+
+func TestTruncateTransformApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		width    int
+		input    iceberg.Literal
+		expected iceberg.Literal
+	}{
+		{"int32", 10, iceberg.Int32Literal(1), iceberg.Int32Literal(0)},
+		{"int32 negative", 10, iceberg.Int32Literal(-1), iceberg.Int32Literal(-10)},
+		{"int64", 10, iceberg.Int64Literal(15), iceberg.Int64Literal(10)},
+		{"int64 negative", 10, iceberg.Int64Literal(-15), iceberg.Int64Literal(-20)},
+		{"string", 3, iceberg.StringLiteral("iceberg"), iceberg.StringLiteral("ice")},
+		{"string short", 10, iceberg.StringLiteral("ice"), iceberg.StringLiteral("ice")},
+		{"string multibyte", 2, iceberg.StringLiteral("😀🧊iceberg"), iceberg.StringLiteral("😀🧊")},
+		{"string mixed", 4, iceberg.StringLiteral("ab😀cd"), iceberg.StringLiteral("ab😀c")},
+		{"binary", 2, iceberg.BinaryLiteral([]byte{0x01, 0x02, 0x03}), iceberg.BinaryLiteral([]byte{0x01, 0x02})},
+		{"decimal", 50,
+			iceberg.DecimalLiteral{Val: decimal128.FromI64(1265), Scale: 2},
+			iceberg.DecimalLiteral{Val: decimal128.FromI64(1250), Scale: 2}},
+		{"decimal negative", 50,
+			iceberg.DecimalLiteral{Val: decimal128.FromI64(-1265), Scale: 2},
+			iceberg.DecimalLiteral{Val: decimal128.FromI64(-1300), Scale: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := iceberg.TruncateTransform{Width: tt.width}.
+				Apply(iceberg.Optional[iceberg.Literal]{Val: tt.input, Valid: true})
+			require.True(t, result.Valid)
+			assert.True(t, tt.expected.Equals(result.Val), "expected %s, got %s", tt.expected, result.Val)
+		})
+	}
+
+	result := iceberg.TruncateTransform{Width: 10}.
+		Apply(iceberg.Optional[iceberg.Literal]{Val: iceberg.Float64Literal(1.5), Valid: true})
+	assert.False(t, result.Valid)
+
+	_, err := iceberg.TruncateTransform{Width: 10}.Transformer(iceberg.PrimitiveTypes.Float64)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
+
+func TestVoidTransformApply(t *testing.T) {
+	inputs := []iceberg.Literal{
+		iceberg.Int32Literal(34),
+		iceberg.Int64Literal(-1),
+		iceberg.StringLiteral("iceberg"),
+		iceberg.BinaryLiteral([]byte{0x01}),
+		iceberg.DateLiteral(17501),
+		iceberg.DecimalLiteral{Val: decimal128.FromI64(1265), Scale: 2},
+	}
+
+	for _, in := range inputs {
+		result := iceberg.VoidTransform{}.Apply(iceberg.Optional[iceberg.Literal]{Val: in, Valid: true})
+		assert.False(t, result.Valid, in.String())
+		assert.True(t, iceberg.VoidTransform{}.ResultType(in.Type()).Equals(in.Type()))
+	}
+
+	assert.False(t, iceberg.VoidTransform{}.Apply(iceberg.Optional[iceberg.Literal]{}).Valid)
+}
+
 func TestCanTransform(t *testing.T) {
 	tests := []struct {
 		transform  iceberg.Transform
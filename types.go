@@ -20,6 +20,7 @@ package iceberg
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"regexp"
 	"slices"
 	"strconv"
@@ -34,6 +35,10 @@ var (
 	decimalRegex      = regexp.MustCompile(`decimal\(\s*(\d+)\s*,\s*(\d+)\s*\)`)
 )
 
+// Properties is a set of string key/value pairs such as table or
+// catalog properties. The typed getters fall back to the default value
+// when a key is missing or its value can't be parsed, use WithStrict to
+// surface invalid values as errors instead.
 type Properties map[string]string
 
 // Get returns the value of the key if it exists, otherwise it returns the default value.
@@ -45,30 +50,106 @@ func (p Properties) Get(key, defVal string) string {
 	return defVal
 }
 
-func (p Properties) GetBool(key string, defVal bool) bool {
-	if v, ok := p[key]; ok {
-		b, err := strconv.ParseBool(v)
-		if err != nil {
-			return defVal
-		}
+// GetString is the same as Get.
+func (p Properties) GetString(key, defVal string) string {
+	return p.Get(key, defVal)
+}
 
-		return b
+func (p Properties) GetBool(key string, defVal bool) bool {
+	b, err := p.WithStrict().GetBool(key, defVal)
+	if err != nil {
+		return defVal
 	}
 
-	return defVal
+	return b
 }
 
 func (p Properties) GetInt(key string, defVal int) int {
-	if v, ok := p[key]; ok {
-		i, err := strconv.ParseInt(v, 10, 64)
-		if err != nil {
-			return defVal
-		}
+	i, err := p.WithStrict().GetInt(key, defVal)
+	if err != nil {
+		return defVal
+	}
+
+	return i
+}
 
-		return int(i)
+// GetDuration returns the value of the key as a duration, see
+// StrictProperties.GetDuration for the accepted formats.
+func (p Properties) GetDuration(key string, defVal time.Duration) time.Duration {
+	d, err := p.WithStrict().GetDuration(key, defVal)
+	if err != nil {
+		return defVal
 	}
 
-	return defVal
+	return d
+}
+
+// WithStrict returns a view of the properties whose typed getters return
+// an error for values that can't be parsed rather than the default.
+func (p Properties) WithStrict() StrictProperties {
+	return StrictProperties(p)
+}
+
+// StrictProperties provides typed getters over Properties which return
+// ErrInvalidArgument for invalid values. Missing keys still result in the
+// default value.
+type StrictProperties Properties
+
+func (p StrictProperties) GetString(key, defVal string) (string, error) {
+	return Properties(p).Get(key, defVal), nil
+}
+
+func (p StrictProperties) GetBool(key string, defVal bool) (bool, error) {
+	v, ok := p[key]
+	if !ok {
+		return defVal, nil
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return defVal, fmt.Errorf("%w: invalid boolean value for property %s: %q",
+			ErrInvalidArgument, key, v)
+	}
+
+	return b, nil
+}
+
+func (p StrictProperties) GetInt(key string, defVal int) (int, error) {
+	v, ok := p[key]
+	if !ok {
+		return defVal, nil
+	}
+
+	i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	if err != nil {
+		return defVal, fmt.Errorf("%w: invalid integer value for property %s: %q",
+			ErrInvalidArgument, key, v)
+	}
+
+	return int(i), nil
+}
+
+// GetDuration returns the value of the key as a duration. Values are
+// either Go durations such as "1m30s", or plain integers which are taken
+// as milliseconds as used by properties like commit.retry.min-wait-ms.
+func (p StrictProperties) GetDuration(key string, defVal time.Duration) (time.Duration, error) {
+	v, ok := p[key]
+	if !ok {
+		return defVal, nil
+	}
+
+	v = strings.TrimSpace(v)
+	if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Duration(ms) * time.Millisecond, nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return defVal, fmt.Errorf("%w: invalid duration value for property %s: %q",
+			ErrInvalidArgument, key, v)
+	}
+
+	return d, nil
 }
 
 // Type is an interface representing any of the available iceberg types,
@@ -209,18 +290,43 @@ func (n *NestedField) Equals(other NestedField) bool {
 		n.Name == other.Name &&
 		n.Required == other.Required &&
 		n.Doc == other.Doc &&
-		n.InitialDefault == other.InitialDefault &&
-		n.WriteDefault == other.WriteDefault &&
-		n.Type.Equals(other.Type)
+		reflect.DeepEqual(n.InitialDefault, other.InitialDefault) &&
+		reflect.DeepEqual(n.WriteDefault, other.WriteDefault) &&
+		typesEqual(n.Type, other.Type)
+}
+
+// typesEqual compares two types structurally, treating nil types as only
+// equal to each other instead of panicking on incomplete nested types.
+func typesEqual(a, b Type) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Equals(b)
 }
 
 func (n NestedField) MarshalJSON() ([]byte, error) {
 	type Alias NestedField
 
+	initialDefault, err := marshalDefaultValue(n.Type, n.InitialDefault)
+	if err != nil {
+		return nil, fmt.Errorf("invalid initial-default of field %s: %w", n.Name, err)
+	}
+
+	writeDefault, err := marshalDefaultValue(n.Type, n.WriteDefault)
+	if err != nil {
+		return nil, fmt.Errorf("invalid write-default of field %s: %w", n.Name, err)
+	}
+
 	return json.Marshal(struct {
 		Type *typeIFace `json:"type"`
 		*Alias
-	}{Type: &typeIFace{n.Type}, Alias: (*Alias)(&n)})
+		InitialDefault json.RawMessage `json:"initial-default,omitempty"`
+		WriteDefault   json.RawMessage `json:"write-default,omitempty"`
+	}{
+		Type: &typeIFace{n.Type}, Alias: (*Alias)(&n),
+		InitialDefault: initialDefault, WriteDefault: writeDefault,
+	})
 }
 
 func (n *NestedField) UnmarshalJSON(b []byte) error {
@@ -228,6 +334,8 @@ func (n *NestedField) UnmarshalJSON(b []byte) error {
 	aux := struct {
 		Type typeIFace `json:"type"`
 		*Alias
+		InitialDefault json.RawMessage `json:"initial-default"`
+		WriteDefault   json.RawMessage `json:"write-default"`
 	}{
 		Alias: (*Alias)(n),
 	}
@@ -238,16 +346,92 @@ func (n *NestedField) UnmarshalJSON(b []byte) error {
 
 	n.Type = aux.Type.Type
 
+	var err error
+	if n.InitialDefault, err = parseDefaultValue(n.Type, aux.InitialDefault); err != nil {
+		return fmt.Errorf("invalid initial-default of field %s: %w", n.Name, err)
+	}
+
+	if n.WriteDefault, err = parseDefaultValue(n.Type, aux.WriteDefault); err != nil {
+		return fmt.Errorf("invalid write-default of field %s: %w", n.Name, err)
+	}
+
 	return nil
 }
 
+// marshalDefaultValue writes a default value of a primitive field with
+// the JSON single-value serialization of the spec, the defaults of
+// nested fields are written as is.
+func marshalDefaultValue(typ Type, val any) (json.RawMessage, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	if _, ok := typ.(PrimitiveType); !ok {
+		return json.Marshal(val)
+	}
+
+	lit, err := literalOf(val)
+	if err != nil {
+		return nil, err
+	}
+
+	if lit, err = lit.To(typ); err != nil {
+		return nil, err
+	}
+
+	return marshalLiteralValue(typ, lit)
+}
+
+// parseDefaultValue reads a default value written by marshalDefaultValue
+// into the Go type used for values of typ, e.g. int32 for int fields.
+func parseDefaultValue(typ Type, data json.RawMessage) (any, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	if _, ok := typ.(PrimitiveType); !ok {
+		var val any
+		if err := json.Unmarshal(data, &val); err != nil {
+			return nil, err
+		}
+
+		return val, nil
+	}
+
+	lit, err := parseLiteralJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ.(type) {
+	case BinaryType, FixedType:
+		// binary values are written as hex strings
+		if lit, err = parseHexLiteral(typ, lit, data); err != nil {
+			return nil, err
+		}
+
+		return lit.Any(), nil
+	}
+
+	if lit, err = lit.To(typ); err != nil {
+		return nil, err
+	}
+
+	switch lit.(type) {
+	case AboveMaxLiteral, BelowMinLiteral:
+		return nil, fmt.Errorf("%w: %s is out of range for %s", ErrInvalidArgument, data, typ)
+	}
+
+	return lit.Any(), nil
+}
+
 type StructType struct {
 	FieldList []NestedField `json:"fields"`
 }
 
 func (s *StructType) Equals(other Type) bool {
 	st, ok := other.(*StructType)
-	if !ok {
+	if !ok || st == nil {
 		return false
 	}
 
@@ -312,12 +496,12 @@ func (l *ListType) MarshalJSON() ([]byte, error) {
 
 func (l *ListType) Equals(other Type) bool {
 	rhs, ok := other.(*ListType)
-	if !ok {
+	if !ok || rhs == nil {
 		return false
 	}
 
 	return l.ElementID == rhs.ElementID &&
-		l.Element.Equals(rhs.Element) &&
+		typesEqual(l.Element, rhs.Element) &&
 		l.ElementRequired == rhs.ElementRequired
 }
 
@@ -379,14 +563,14 @@ func (m *MapType) MarshalJSON() ([]byte, error) {
 
 func (m *MapType) Equals(other Type) bool {
 	rhs, ok := other.(*MapType)
-	if !ok {
+	if !ok || rhs == nil {
 		return false
 	}
 
 	return m.KeyID == rhs.KeyID &&
-		m.KeyType.Equals(rhs.KeyType) &&
+		typesEqual(m.KeyType, rhs.KeyType) &&
 		m.ValueID == rhs.ValueID &&
-		m.ValueType.Equals(rhs.ValueType) &&
+		typesEqual(m.ValueType, rhs.ValueType) &&
 		m.ValueRequired == rhs.ValueRequired
 }
 
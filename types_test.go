@@ -20,8 +20,11 @@ package iceberg_test
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
 	"github.com/apache/iceberg-go"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -66,6 +69,68 @@ func TestTypesBasic(t *testing.T) {
 	}
 }
 
+func TestNestedFieldDefaults(t *testing.T) {
+	tests := []struct {
+		typ      string
+		value    string
+		expected any
+	}{
+		{"boolean", `true`, true},
+		{"int", `34`, int32(34)},
+		{"long", `34`, int64(34)},
+		{"float", `1.5`, float32(1.5)},
+		{"double", `-2.25`, float64(-2.25)},
+		{"date", `"2017-11-16"`, iceberg.Date(17486)},
+		{"time", `"22:31:08.123456"`, iceberg.Time(81068123456)},
+		{"timestamp", `"2017-11-16T22:31:08"`, iceberg.Timestamp(1510871468000000)},
+		{"string", `"iceberg"`, "iceberg"},
+		{"uuid", `"f79c3e09-677c-4bbd-a479-3f349cb785e7"`, uuid.MustParse("f79c3e09-677c-4bbd-a479-3f349cb785e7")},
+		{"fixed[2]", `"000F"`, []byte{0x00, 0x0f}},
+		{"binary", `"0102FF"`, []byte{0x01, 0x02, 0xff}},
+		{"decimal(9, 2)", `"14.20"`, iceberg.Decimal{Val: decimal128.FromI64(1420), Scale: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			data := `{
+				"id": 1,
+				"name": "test",
+				"type": "` + tt.typ + `",
+				"required": true,
+				"initial-default": ` + tt.value + `,
+				"write-default": ` + tt.value + `
+			}`
+
+			var n iceberg.NestedField
+			require.NoError(t, json.Unmarshal([]byte(data), &n))
+			assert.Equal(t, tt.expected, n.InitialDefault)
+			assert.Equal(t, tt.expected, n.WriteDefault)
+
+			out, err := json.Marshal(n)
+			require.NoError(t, err)
+			assert.JSONEq(t, data, string(out))
+
+			var roundTrip iceberg.NestedField
+			require.NoError(t, json.Unmarshal(out, &roundTrip))
+			assert.True(t, n.Equals(roundTrip))
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		var n iceberg.NestedField
+		assert.Error(t, json.Unmarshal([]byte(`{"id": 1, "name": "test", "type": "int",
+			"required": true, "initial-default": "abc"}`), &n))
+		assert.Error(t, json.Unmarshal([]byte(`{"id": 1, "name": "test", "type": "int",
+			"required": true, "initial-default": 3000000000}`), &n))
+		assert.Error(t, json.Unmarshal([]byte(`{"id": 1, "name": "test", "type": "fixed[3]",
+			"required": true, "write-default": "0102"}`), &n))
+
+		_, err := json.Marshal(iceberg.NestedField{ID: 1, Name: "test",
+			Type: iceberg.PrimitiveTypes.Int32, InitialDefault: struct{}{}})
+		assert.Error(t, err)
+	})
+}
+
 func TestFixedType(t *testing.T) {
 	typ := iceberg.FixedTypeOf(5)
 	assert.Equal(t, 5, typ.Len())
@@ -197,6 +262,61 @@ func TestNonParameterizedTypeEquality(t *testing.T) {
 	}
 }
 
+func TestNestedTypeEquality(t *testing.T) {
+	type parts struct {
+		inner *iceberg.StructType
+		list  *iceberg.ListType
+		mp    *iceberg.MapType
+	}
+
+	nested := func(modify func(parts)) *iceberg.StructType {
+		list := &iceberg.ListType{ElementID: 3, Element: iceberg.DecimalTypeOf(9, 2), ElementRequired: true}
+		mp := &iceberg.MapType{
+			KeyID: 5, KeyType: iceberg.PrimitiveTypes.String,
+			ValueID: 6, ValueType: list, ValueRequired: false,
+		}
+		inner := &iceberg.StructType{FieldList: []iceberg.NestedField{
+			{ID: 2, Name: "amounts", Type: mp, Required: true},
+		}}
+		if modify != nil {
+			modify(parts{inner, list, mp})
+		}
+
+		return &iceberg.StructType{FieldList: []iceberg.NestedField{
+			{ID: 1, Name: "outer", Type: inner, Required: false},
+		}}
+	}
+
+	assert.True(t, nested(nil).Equals(nested(nil)))
+
+	tests := []struct {
+		name   string
+		modify func(parts)
+	}{
+		{"field id", func(p parts) { p.inner.FieldList[0].ID = 20 }},
+		{"field required", func(p parts) { p.inner.FieldList[0].Required = false }},
+		{"element id", func(p parts) { p.list.ElementID = 30 }},
+		{"element required", func(p parts) { p.list.ElementRequired = false }},
+		{"decimal scale", func(p parts) { p.list.Element = iceberg.DecimalTypeOf(9, 3) }},
+		{"decimal precision", func(p parts) { p.list.Element = iceberg.DecimalTypeOf(10, 2) }},
+		{"key type", func(p parts) { p.mp.KeyType = iceberg.PrimitiveTypes.Binary }},
+		{"value id", func(p parts) { p.mp.ValueID = 60 }},
+		{"value required", func(p parts) { p.mp.ValueRequired = true }},
+		{"missing value type", func(p parts) { p.mp.ValueType = nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.False(t, nested(nil).Equals(nested(tt.modify)))
+			assert.False(t, nested(tt.modify).Equals(nested(nil)))
+		})
+	}
+
+	assert.False(t, nested(nil).Equals((*iceberg.StructType)(nil)))
+	assert.False(t, (&iceberg.ListType{ElementID: 1}).Equals((*iceberg.ListType)(nil)))
+	assert.True(t, (&iceberg.ListType{ElementID: 1}).Equals(&iceberg.ListType{ElementID: 1}))
+}
+
 func TestTypeStrings(t *testing.T) {
 	tests := []struct {
 		typ iceberg.Type
@@ -235,3 +355,63 @@ func TestTypeStrings(t *testing.T) {
 		assert.Equal(t, tt.str, tt.typ.String())
 	}
 }
+
+func TestPropertiesTypedGetters(t *testing.T) {
+	props := iceberg.Properties{
+		"write.target-file-size-bytes":  "134217728",
+		"commit.retry.num-retries":      "7",
+		"commit.retry.min-wait-ms":      "250",
+		"commit.manifest-merge.enabled": "false",
+		"write.format.default":          "parquet",
+		"some.timeout":                  "1m30s",
+	}
+
+	assert.Equal(t, 134217728, props.GetInt("write.target-file-size-bytes", 512*1024*1024))
+	assert.Equal(t, 7, props.GetInt("commit.retry.num-retries", 4))
+	assert.Equal(t, 250*time.Millisecond, props.GetDuration("commit.retry.min-wait-ms", 100*time.Millisecond))
+	assert.Equal(t, 90*time.Second, props.GetDuration("some.timeout", time.Second))
+	assert.False(t, props.GetBool("commit.manifest-merge.enabled", true))
+	assert.Equal(t, "parquet", props.GetString("write.format.default", "avro"))
+
+	// missing keys use the default
+	assert.Equal(t, 4, props.GetInt("missing", 4))
+	assert.True(t, props.GetBool("missing", true))
+	assert.Equal(t, time.Second, props.GetDuration("missing", time.Second))
+	assert.Equal(t, "avro", props.GetString("missing", "avro"))
+}
+
+func TestPropertiesInvalidValues(t *testing.T) {
+	props := iceberg.Properties{
+		"commit.retry.num-retries":      "many",
+		"commit.retry.max-wait-ms":      "soon",
+		"commit.manifest-merge.enabled": "maybe",
+	}
+
+	assert.Equal(t, 4, props.GetInt("commit.retry.num-retries", 4))
+	assert.Equal(t, time.Minute, props.GetDuration("commit.retry.max-wait-ms", time.Minute))
+	assert.True(t, props.GetBool("commit.manifest-merge.enabled", true))
+
+	strict := props.WithStrict()
+
+	i, err := strict.GetInt("commit.retry.num-retries", 4)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	assert.ErrorContains(t, err, "commit.retry.num-retries")
+	assert.Equal(t, 4, i)
+
+	d, err := strict.GetDuration("commit.retry.max-wait-ms", time.Minute)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	assert.Equal(t, time.Minute, d)
+
+	b, err := strict.GetBool("commit.manifest-merge.enabled", true)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	assert.True(t, b)
+
+	// missing keys are not an error in strict mode
+	i, err = strict.GetInt("missing", 4)
+	require.NoError(t, err)
+	assert.Equal(t, 4, i)
+
+	s, err := strict.GetString("missing", "avro")
+	require.NoError(t, err)
+	assert.Equal(t, "avro", s)
+}
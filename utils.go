@@ -23,6 +23,7 @@ import (
 	"hash/maphash"
 	"maps"
 	"runtime/debug"
+	"slices"
 	"strings"
 )
 
@@ -76,16 +77,21 @@ type structLike interface {
 	Set(pos int, val any)
 }
 
-type accessor struct {
+// Accessor retrieves a (possibly nested) field value from a row by
+// following a chain of struct positions. Use BuildAccessor to create
+// one for a given field ID.
+type Accessor struct {
 	pos   int
-	inner *accessor
+	inner *Accessor
 }
 
-func (a *accessor) String() string {
+func (a *Accessor) String() string {
 	return fmt.Sprintf("Accessor(position=%d, inner=%s)", a.pos, a.inner)
 }
 
-func (a *accessor) Get(s structLike) any {
+// Get returns the value of the field this accessor refers to, or nil
+// if the field or any struct containing it is null.
+func (a *Accessor) Get(s structLike) any {
 	val, inner := s.Get(a.pos), a
 	for val != nil && inner.inner != nil {
 		inner = inner.inner
@@ -104,6 +110,59 @@ type Set[E any] interface {
 	All(func(E) bool) bool
 }
 
+// NewSet returns a Set backed by a map for any comparable element type.
+// Literal values should continue to use the specialized set created when
+// building set predicates, as some literal types (e.g. BinaryLiteral) are
+// not comparable.
+func NewSet[E comparable](vals ...E) Set[E] {
+	s := make(mapSet[E], len(vals))
+	s.Add(vals...)
+
+	return s
+}
+
+type mapSet[E comparable] map[E]struct{}
+
+func (m mapSet[E]) Add(vals ...E) {
+	for _, v := range vals {
+		m[v] = struct{}{}
+	}
+}
+
+func (m mapSet[E]) Contains(v E) bool {
+	_, ok := m[v]
+
+	return ok
+}
+
+func (m mapSet[E]) Members() []E {
+	return slices.Collect(maps.Keys(m))
+}
+
+// Equals reports whether both sets contain exactly the same members,
+// regardless of the order in which they were inserted.
+func (m mapSet[E]) Equals(other Set[E]) bool {
+	if other == nil || m.Len() != other.Len() {
+		return false
+	}
+
+	return other.All(m.Contains)
+}
+
+func (m mapSet[E]) Len() int { return len(m) }
+
+// All returns true if fn returns true for every member of the set,
+// stopping at the first member for which it returns false.
+func (m mapSet[E]) All(fn func(E) bool) bool {
+	for k := range m {
+		if !fn(k) {
+			return false
+		}
+	}
+
+	return true
+}
+
 var lzseed = maphash.MakeSeed()
 
 type literalSet map[any]struct{ orig Literal }
@@ -173,7 +232,7 @@ func (l literalSet) Members() []Literal {
 func (l literalSet) Equals(other Set[Literal]) bool {
 	rhs, ok := other.(literalSet)
 	if !ok {
-		return false
+		return other != nil && l.Len() == other.Len() && other.All(l.Contains)
 	}
 
 	return maps.EqualFunc(l, rhs, func(v1, v2 struct{ orig Literal }) bool {
@@ -206,3 +265,103 @@ func (l literalSet) All(fn func(Literal) bool) bool {
 
 	return true
 }
+
+// sortedLiteralSet is a set of literals of a single type kept as a sorted
+// slice, with membership checked by binary search. It is used for set
+// predicates with many literals, where building a hash set up front is
+// more expensive than the lookups it saves.
+type sortedLiteralSet[T LiteralType] struct {
+	vals []Literal
+	cmp  Comparator[T]
+}
+
+func newSortedLiteralSet[T LiteralType](vals ...Literal) *sortedLiteralSet[T] {
+	s := &sortedLiteralSet[T]{cmp: getComparator[T]()}
+	s.Add(vals...)
+
+	return s
+}
+
+func (s *sortedLiteralSet[T]) compare(a, b Literal) int {
+	return s.cmp(a.(TypedLiteral[T]).Value(), b.(TypedLiteral[T]).Value())
+}
+
+// Add inserts the literals, which must be of type T, into the set.
+func (s *sortedLiteralSet[T]) Add(lits ...Literal) {
+	s.vals = append(s.vals, lits...)
+	slices.SortFunc(s.vals, s.compare)
+	s.vals = slices.CompactFunc(s.vals, func(a, b Literal) bool {
+		return s.compare(a, b) == 0
+	})
+}
+
+func (s *sortedLiteralSet[T]) Contains(lit Literal) bool {
+	v, ok := lit.(TypedLiteral[T])
+	if !ok {
+		return false
+	}
+
+	idx, found := slices.BinarySearchFunc(s.vals, v.Value(), func(e Literal, target T) int {
+		return s.cmp(e.(TypedLiteral[T]).Value(), target)
+	})
+
+	// the comparator may consider values equal (e.g. NaN) which the
+	// literals themselves don't, so confirm the match with Equals to keep
+	// the same semantics as literalSet
+	return found && s.vals[idx].Equals(lit)
+}
+
+func (s *sortedLiteralSet[T]) Members() []Literal { return slices.Clone(s.vals) }
+
+func (s *sortedLiteralSet[T]) Equals(other Set[Literal]) bool {
+	return other != nil && s.Len() == other.Len() && other.All(s.Contains)
+}
+
+func (s *sortedLiteralSet[T]) Len() int { return len(s.vals) }
+
+func (s *sortedLiteralSet[T]) All(fn func(Literal) bool) bool {
+	for _, v := range s.vals {
+		if !fn(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Union returns a new set containing every literal that is a member of
+// either a or b. Binary and fixed literals continue to be keyed by the
+// hash of their contents, with the original value retained for equality
+// checks.
+func Union(a, b Set[Literal]) Set[Literal] {
+	out := newLiteralSet(a.Members()...)
+	out.Add(b.Members()...)
+
+	return out
+}
+
+// Intersection returns a new set containing the literals that are members
+// of both a and b.
+func Intersection(a, b Set[Literal]) Set[Literal] {
+	out := newLiteralSet()
+	for _, v := range a.Members() {
+		if b.Contains(v) {
+			out.Add(v)
+		}
+	}
+
+	return out
+}
+
+// Difference returns a new set containing the literals that are members
+// of a but not of b.
+func Difference(a, b Set[Literal]) Set[Literal] {
+	out := newLiteralSet()
+	for _, v := range a.Members() {
+		if !b.Contains(v) {
+			out.Add(v)
+		}
+	}
+
+	return out
+}
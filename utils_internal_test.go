@@ -0,0 +1,134 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"hash/maphash"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiteralSetOperations(t *testing.T) {
+	a := newLiteralSet(NewLiteral(int32(1)), NewLiteral(int32(2)), NewLiteral(int32(3)))
+	b := newLiteralSet(NewLiteral(int32(3)), NewLiteral(int32(4)))
+
+	union := Union(a, b)
+	assert.Equal(t, 4, union.Len())
+	for _, v := range []int32{1, 2, 3, 4} {
+		assert.True(t, union.Contains(NewLiteral(v)))
+	}
+	assert.True(t, union.Equals(Union(a, b)))
+	assert.True(t, union.Equals(Union(b, a)))
+
+	inter := Intersection(a, b)
+	assert.Equal(t, 1, inter.Len())
+	assert.True(t, inter.Contains(NewLiteral(int32(3))))
+	assert.True(t, inter.Equals(Intersection(b, a)))
+
+	diff := Difference(a, b)
+	assert.Equal(t, 2, diff.Len())
+	assert.True(t, diff.Contains(NewLiteral(int32(1))))
+	assert.True(t, diff.Contains(NewLiteral(int32(2))))
+	assert.False(t, diff.Contains(NewLiteral(int32(3))))
+	assert.Zero(t, Difference(a, a).Len())
+}
+
+func TestLiteralSetOperationsBinaryHashCollision(t *testing.T) {
+	// simulate two distinct binary values whose hashes collide by storing
+	// the original "abc" bytes under the hash of "xyz"
+	stored, probe := BinaryLiteral("abc"), BinaryLiteral("xyz")
+	collided := literalSet{
+		maphash.Bytes(lzseed, probe): {orig: stored},
+	}
+
+	assert.False(t, collided.Contains(probe))
+
+	other := newLiteralSet(FixedLiteral("xyz"), BinaryLiteral("def"))
+
+	union := Union(collided, other)
+	assert.True(t, union.Contains(stored))
+	assert.False(t, union.Contains(probe))
+	assert.True(t, union.Contains(FixedLiteral("xyz")))
+	assert.True(t, union.Contains(BinaryLiteral("def")))
+	assert.True(t, union.Equals(Union(collided, other)))
+
+	assert.Zero(t, Intersection(collided, newLiteralSet(probe)).Len())
+
+	diff := Difference(collided, newLiteralSet(probe))
+	assert.Equal(t, 1, diff.Len())
+	assert.True(t, diff.Contains(stored))
+}
+
+func TestNewSet(t *testing.T) {
+	ints := NewSet(1, 2, 3, 2)
+	assert.Equal(t, 3, ints.Len())
+	assert.True(t, ints.Contains(2))
+	assert.False(t, ints.Contains(4))
+	assert.ElementsMatch(t, []int{1, 2, 3}, ints.Members())
+
+	ints.Add(4)
+	assert.True(t, ints.Contains(4))
+	assert.True(t, ints.Equals(NewSet(4, 3, 2, 1)))
+	assert.False(t, ints.Equals(NewSet(1, 2, 3)))
+	assert.False(t, ints.Equals(NewSet(1, 2, 3, 5)))
+
+	strs := NewSet("a", "b")
+	assert.True(t, strs.Equals(NewSet("b", "a")))
+	assert.False(t, strs.Equals(NewSet[string]()))
+	assert.True(t, NewSet[string]().Equals(NewSet[string]()))
+
+	calls := 0
+	assert.False(t, NewSet(1, 2, 3, 4, 5).All(func(int) bool {
+		calls++
+
+		return false
+	}))
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	assert.True(t, strs.All(func(s string) bool {
+		calls++
+
+		return s != ""
+	}))
+	assert.Equal(t, 2, calls)
+}
+
+func TestLiteralSetUUID(t *testing.T) {
+	a := uuid.MustParse("f79c3e09-677c-4bbd-a479-3f349cb785e7")
+	b := uuid.MustParse("0b1c2d3e-4f50-6172-8394-a5b6c7d8e9f0")
+
+	set := newLiteralSet(NewLiteral(a), NewLiteral(b), NewLiteral(a))
+	assert.Equal(t, 2, set.Len())
+
+	fromStr, err := NewLiteral(a.String()).To(PrimitiveTypes.UUID)
+	assert.NoError(t, err)
+	fromBytes, err := NewLiteral(b[:]).To(PrimitiveTypes.UUID)
+	assert.NoError(t, err)
+
+	assert.True(t, set.Contains(fromStr))
+	assert.True(t, set.Contains(fromBytes))
+	assert.False(t, set.Contains(NewLiteral(uuid.New())))
+	// fixed literals with the same bytes are a different value
+	assert.False(t, set.Contains(FixedLiteral(a[:])))
+
+	assert.True(t, set.Equals(newLiteralSet(fromStr, fromBytes)))
+	assert.ElementsMatch(t, []Literal{UUIDLiteral(a), UUIDLiteral(b)}, set.Members())
+}
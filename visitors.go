@@ -144,16 +144,46 @@ func VisitBoundPredicate[T any](e BoundPredicate, visitor BoundBooleanExprVisito
 	panic(fmt.Errorf("%w: unhandled bound predicate type: %s", ErrNotImplemented, e))
 }
 
+// DefaultInPredicateThreshold is the number of literals above which a bound
+// In or NotIn predicate keeps its literals in a sorted slice and checks
+// membership with a binary search instead of a hash set.
+const DefaultInPredicateThreshold = 200
+
+// BindOption configures the behavior of BindExpr.
+type BindOption func(*bindOptions)
+
+type bindOptions struct {
+	inPredicateThreshold int
+}
+
+// WithInPredicateThreshold overrides DefaultInPredicateThreshold for the
+// set predicates bound by BindExpr.
+func WithInPredicateThreshold(n int) BindOption {
+	return func(o *bindOptions) {
+		o.inPredicateThreshold = n
+	}
+}
+
 // BindExpr recursively binds each portion of an expression using the provided schema.
 // Because the expression can end up being simplified to just AlwaysTrue/AlwaysFalse,
 // this returns a BooleanExpression.
-func BindExpr(s *Schema, expr BooleanExpression, caseSensitive bool) (BooleanExpression, error) {
-	return VisitExpr(expr, &bindVisitor{schema: s, caseSensitive: caseSensitive})
+func BindExpr(s *Schema, expr BooleanExpression, caseSensitive bool, opts ...BindOption) (BooleanExpression, error) {
+	v := &bindVisitor{
+		schema:        s,
+		caseSensitive: caseSensitive,
+		opts:          bindOptions{inPredicateThreshold: DefaultInPredicateThreshold},
+	}
+	for _, opt := range opts {
+		opt(&v.opts)
+	}
+
+	return VisitExpr(expr, v)
 }
 
 type bindVisitor struct {
 	schema        *Schema
 	caseSensitive bool
+	opts          bindOptions
 }
 
 func (*bindVisitor) VisitTrue() BooleanExpression  { return AlwaysTrue{} }
@@ -171,7 +201,16 @@ func (*bindVisitor) VisitOr(left, right BooleanExpression) BooleanExpression {
 }
 
 func (b *bindVisitor) VisitUnbound(pred UnboundPredicate) BooleanExpression {
-	expr, err := pred.Bind(b.schema, b.caseSensitive)
+	var (
+		expr BooleanExpression
+		err  error
+	)
+
+	if usp, ok := pred.(*unboundSetPredicate); ok {
+		expr, err = usp.bind(b.schema, b.caseSensitive, b.opts.inPredicateThreshold)
+	} else {
+		expr, err = pred.Bind(b.schema, b.caseSensitive)
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -542,6 +542,60 @@ func TestExprEvaluator(t *testing.T) {
 	}
 }
 
+func TestEvaluateThreeValuedLogic(t *testing.T) {
+	z, x, str := iceberg.Reference("z"), iceberg.Reference("x"), iceberg.Reference("s")
+	nullZ := rowOf(7, 0, nil, nil, nil, nil)
+	setZ := rowOf(7, 0, 3, nil, nil, "abc")
+
+	tests := []struct {
+		expr            iceberg.BooleanExpression
+		nullRes, setRes bool
+	}{
+		{iceberg.IsNull(z), true, false},
+		{iceberg.NotNull(z), false, true},
+		{iceberg.NewNot(iceberg.IsNull(z)), false, true},
+		{iceberg.EqualTo(z, int32(3)), false, true},
+		{iceberg.NotEqualTo(z, int32(3)), false, false},
+		{iceberg.GreaterThan(z, int32(1)), false, true},
+		{iceberg.LessThan(z, int32(1)), false, false},
+		{iceberg.NewNot(iceberg.GreaterThan(z, int32(1))), false, false},
+		{iceberg.NewNot(iceberg.LessThan(z, int32(1))), false, true},
+		{iceberg.IsIn(z, int32(1), int32(3)), false, true},
+		{iceberg.NotIn(z, int32(1), int32(2)), false, true},
+		{iceberg.NewNot(iceberg.IsIn(z, int32(1), int32(2))), false, true},
+		{iceberg.StartsWith(str, "ab"), false, true},
+		{iceberg.NotStartsWith(str, "ab"), false, false},
+		// unknown OR true is true, unknown AND false is false
+		{iceberg.NewOr(iceberg.GreaterThan(z, int32(1)), iceberg.EqualTo(x, int32(7))), true, true},
+		{iceberg.NewAnd(iceberg.GreaterThan(z, int32(1)), iceberg.EqualTo(x, int32(7))), false, true},
+		{iceberg.NewNot(iceberg.NewAnd(iceberg.GreaterThan(z, int32(1)), iceberg.EqualTo(x, int32(8)))), true, true},
+		{iceberg.NewNot(iceberg.NewOr(iceberg.GreaterThan(z, int32(1)), iceberg.EqualTo(x, int32(8)))), false, false},
+		// comparisons on a field inside a null struct are unknown too
+		{iceberg.EqualTo(iceberg.Reference("s1.s2.s3.s4.i"), int32(7)), false, false},
+		{iceberg.NewNot(iceberg.EqualTo(iceberg.Reference("s1.s2.s3.s4.i"), int32(7))), false, false},
+		{iceberg.IsNull(iceberg.Reference("s1.s2.s3.s4.i")), true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr.String(), func(t *testing.T) {
+			bound, err := iceberg.BindExpr(testSchema, tt.expr, true)
+			require.NoError(t, err)
+
+			res, err := iceberg.Evaluate(bound, nullZ)
+			require.NoError(t, err)
+			assert.Equal(t, tt.nullRes, res, "z is null")
+
+			res, err = iceberg.Evaluate(bound, setZ)
+			require.NoError(t, err)
+			assert.Equal(t, tt.setRes, res, "z is 3")
+		})
+	}
+
+	// expressions must be bound before evaluating them
+	_, err := iceberg.Evaluate(iceberg.EqualTo(z, int32(3)), setZ)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
+
 func TestEvaluatorCmpTypes(t *testing.T) {
 	sc := iceberg.NewSchema(1,
 		iceberg.NestedField{ID: 1, Name: "a", Type: iceberg.PrimitiveTypes.Bool},